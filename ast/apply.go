@@ -0,0 +1,274 @@
+package ast
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// An ApplyFunc is invoked by Apply for each node n, even if n is nil, before
+// and/or after the node's children, using a Cursor describing the current
+// node and providing operations on it.
+//
+// The return value of ApplyFunc controls the syntax tree traversal. See Apply
+// for details.
+type ApplyFunc func(*Cursor) bool
+
+// Apply traverses a syntax tree recursively, starting with root, and calling
+// pre and post for each node as described below. Apply returns the syntax
+// tree, possibly modified.
+//
+// If pre is not nil, it is called for each node before the node's children
+// are traversed (pre-order). If pre returns false, no children are traversed,
+// and post is not called for that node.
+//
+// If post is not nil, and a prior call of pre didn't return false, post is
+// called for each node after its children are traversed (post-order). If post
+// returns false, traversal is terminated and Apply returns immediately.
+//
+// Only fields that refer to AST nodes are considered children; i.e., Loc and
+// primitive fields like Name and Operator are not. Children are traversed in
+// the order they're written in source, as they are by Walk.
+//
+// Children of the nodes that pre or post give to Cursor.Replace are
+// traversed, rather than those of the node they replace.
+func Apply(root Node, pre, post ApplyFunc) (result Node) {
+	parent := &struct{ Node }{root}
+
+	defer func() {
+		if r := recover(); r != nil && r != abort {
+			panic(r)
+		}
+
+		result = parent.Node
+	}()
+
+	a := &application{pre: pre, post: post}
+	a.apply(parent, "Node", nil, root)
+
+	return
+}
+
+var abort = new(int) // singleton, to signal termination of Apply
+
+// A Cursor describes a node encountered during Apply. Information about the
+// node and its parent is available from the Node, Parent, Name, and Index
+// methods.
+//
+// If p is a variable of type and value of the current parent node c.Parent(),
+// and f is the field identifier with name c.Name(), the following invariants
+// hold:
+//
+//	p.f            == c.Node()  if c.Index() <  0
+//	p.f[c.Index()] == c.Node()  if c.Index() >= 0
+//
+// The methods Replace, Delete, InsertBefore, and InsertAfter can be used to
+// change the AST without disrupting Apply.
+type Cursor struct {
+	parent Node
+	name   string
+	iter   *iterator // valid if non-nil
+	node   Node
+}
+
+// Node returns the current Node.
+func (c *Cursor) Node() Node { return c.node }
+
+// Parent returns the parent of the current Node.
+func (c *Cursor) Parent() Node { return c.parent }
+
+// Name returns the name of the parent Node field that contains the current
+// Node. If the parent is a *Program and the current Node is a statement,
+// c.Name() returns "Body".
+func (c *Cursor) Name() string { return c.name }
+
+// Index reports the index >= 0 of the current Node in the slice of Nodes that
+// contains it, or a value < 0 if the current Node is not part of a slice. The
+// index of the current node changes if InsertBefore is called while
+// processing the current node.
+func (c *Cursor) Index() int {
+	if c.iter != nil {
+		return c.iter.index
+	}
+
+	return -1
+}
+
+// field returns the current node's parent field value.
+func (c *Cursor) field() reflect.Value {
+	return reflect.Indirect(reflect.ValueOf(c.parent)).FieldByName(c.name)
+}
+
+// Replace replaces the current Node with n. The replacement node is not
+// walked by Apply, but its children are.
+func (c *Cursor) Replace(n Node) {
+	v := c.field()
+	if i := c.Index(); i >= 0 {
+		v = v.Index(i)
+	}
+
+	v.Set(value(n, v.Type()))
+
+	c.node = n
+}
+
+// Delete deletes the current Node from its containing slice. If the current
+// Node is not part of a slice, Delete panics.
+func (c *Cursor) Delete() {
+	i := c.Index()
+	if i < 0 {
+		panic(fmt.Sprintf("ast: Delete of %s, which isn't in a slice", c.name))
+	}
+
+	v := c.field()
+	l := v.Len()
+	reflect.Copy(v.Slice(i, l), v.Slice(i+1, l))
+	v.Index(l - 1).Set(reflect.Zero(v.Type().Elem()))
+	v.SetLen(l - 1)
+
+	c.iter.step--
+}
+
+// InsertAfter inserts n after the current Node in its containing slice. If the
+// current Node is not part of a slice, InsertAfter panics. Apply does not walk
+// n.
+func (c *Cursor) InsertAfter(n Node) {
+	i := c.Index()
+	if i < 0 {
+		panic(fmt.Sprintf("ast: InsertAfter of %s, which isn't in a slice", c.name))
+	}
+
+	v := c.field()
+	v.Set(reflect.Append(v, reflect.Zero(v.Type().Elem())))
+	l := v.Len()
+	reflect.Copy(v.Slice(i+2, l), v.Slice(i+1, l))
+	v.Index(i + 1).Set(value(n, v.Type().Elem()))
+
+	c.iter.step++
+}
+
+// InsertBefore inserts n before the current Node in its containing slice. If
+// the current Node is not part of a slice, InsertBefore panics. Apply will not
+// walk n.
+func (c *Cursor) InsertBefore(n Node) {
+	i := c.Index()
+	if i < 0 {
+		panic(fmt.Sprintf("ast: InsertBefore of %s, which isn't in a slice", c.name))
+	}
+
+	v := c.field()
+	v.Set(reflect.Append(v, reflect.Zero(v.Type().Elem())))
+	l := v.Len()
+	reflect.Copy(v.Slice(i+1, l), v.Slice(i, l))
+	v.Index(i).Set(value(n, v.Type().Elem()))
+
+	c.iter.index++
+}
+
+// value converts n to a value that can be stored in a field of type t, which
+// means the zero value if n is nil.
+func value(n Node, t reflect.Type) reflect.Value {
+	if n == nil {
+		return reflect.Zero(t)
+	}
+
+	return reflect.ValueOf(n)
+}
+
+// application carries all the shared data so we can pass it around cheaply.
+type application struct {
+	pre, post ApplyFunc
+	cursor    Cursor
+	iter      iterator
+}
+
+// An iterator controls iteration over a slice of nodes.
+type iterator struct {
+	index, step int
+}
+
+func (a *application) apply(parent Node, name string, iter *iterator, n Node) {
+	// Fields that hold pointers to concrete types give typed nils, which are
+	// made into plain nils so that they compare equal to nil.
+	if v := reflect.ValueOf(n); v.Kind() == reflect.Ptr && v.IsNil() {
+		n = nil
+	}
+
+	saved := a.cursor
+	a.cursor.parent = parent
+	a.cursor.name = name
+	a.cursor.iter = iter
+	a.cursor.node = n
+
+	if a.pre != nil && !a.pre(&a.cursor) {
+		a.cursor = saved
+		return
+	}
+
+	if n := a.cursor.node; n != nil {
+		a.children(n)
+	}
+
+	if a.post != nil && !a.post(&a.cursor) {
+		panic(abort)
+	}
+
+	a.cursor = saved
+}
+
+func (a *application) applyList(parent Node, name string) {
+	// avoid heap-allocating a new iterator for each applyList call; i.e.,
+	// reuse a.iter
+	saved := a.iter
+	a.iter.index = 0
+
+	for {
+		// must reload parent.name each time, since cursor modifications
+		// might change it
+		v := reflect.Indirect(reflect.ValueOf(parent)).FieldByName(name)
+		if a.iter.index >= v.Len() {
+			break
+		}
+
+		var x Node
+		if e := v.Index(a.iter.index); !e.IsNil() {
+			x = e.Interface().(Node)
+		}
+
+		a.iter.step = 1
+		a.apply(parent, name, &a.iter, x)
+		a.iter.index += a.iter.step
+	}
+
+	a.iter = saved
+}
+
+// applyInterleaved walks two lists whose elements are written alternately,
+// like the quasis and expressions of a TemplateLiteral, taking one from each
+// in turn. Each list keeps its own iterator, so the cursor can change either
+// of them.
+func (a *application) applyInterleaved(parent Node, first, second string) {
+	names := [2]string{first, second}
+	iters := [2]iterator{}
+
+	for more := true; more; {
+		more = false
+
+		for i, name := range names {
+			v := reflect.Indirect(reflect.ValueOf(parent)).FieldByName(name)
+			if iters[i].index >= v.Len() {
+				continue
+			}
+
+			var x Node
+			if e := v.Index(iters[i].index); !e.IsNil() {
+				x = e.Interface().(Node)
+			}
+
+			iters[i].step = 1
+			a.apply(parent, name, &iters[i], x)
+			iters[i].index += iters[i].step
+
+			more = true
+		}
+	}
+}
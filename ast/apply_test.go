@@ -0,0 +1,149 @@
+package ast
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApply(t *testing.T) {
+	a := assert.New(t)
+
+	// a; ; b;
+	p := &Program{
+		Body: []StatementOrModuleDeclaration{
+			&ExpressionStatement{Expression: &Identifier{Name: "a"}},
+			&EmptyStatement{},
+			&ExpressionStatement{Expression: &Identifier{Name: "b"}},
+		},
+	}
+
+	var names []string
+
+	r := Apply(p, func(c *Cursor) bool {
+		switch n := c.Node().(type) {
+		case *EmptyStatement:
+			c.Delete()
+		case *Identifier:
+			names = append(names, c.Name())
+
+			if n.Name == "a" {
+				c.Replace(&NumericLiteral{Value: 1})
+			}
+		case *ExpressionStatement:
+			if id, ok := n.Expression.(*Identifier); ok && id.Name == "b" {
+				c.InsertBefore(&DebuggerStatement{})
+				c.InsertAfter(&EmptyStatement{})
+				a.Equal(2, c.Index())
+			}
+		}
+
+		return true
+	}, nil)
+
+	a.Equal(p, r)
+	a.Equal([]string{"Expression", "Expression"}, names)
+	a.Equal([]StatementOrModuleDeclaration{
+		&ExpressionStatement{Expression: &NumericLiteral{Value: 1}},
+		&DebuggerStatement{},
+		&ExpressionStatement{Expression: &Identifier{Name: "b"}},
+		&EmptyStatement{},
+	}, p.Body)
+}
+
+func TestApplyRoot(t *testing.T) {
+	a := assert.New(t)
+
+	r := Apply(&Identifier{Name: "a"}, nil, func(c *Cursor) bool {
+		a.Equal("Node", c.Name())
+		c.Replace(&Identifier{Name: "b"})
+		return true
+	})
+
+	a.Equal(&Identifier{Name: "b"}, r)
+}
+
+func TestApplyStop(t *testing.T) {
+	a := assert.New(t)
+
+	// a + b
+	e := &BinaryExpression{Left: &Identifier{Name: "a"}, Right: &Identifier{Name: "b"}}
+
+	var seen []string
+	Apply(e, func(c *Cursor) bool {
+		if c.Node() == nil {
+			seen = append(seen, "nil")
+		}
+
+		return true
+	}, func(c *Cursor) bool {
+		if id, ok := c.Node().(*Identifier); ok {
+			seen = append(seen, id.Name)
+			return false
+		}
+
+		return true
+	})
+
+	a.Equal([]string{"a"}, seen)
+}
+
+func TestApplyNil(t *testing.T) {
+	a := assert.New(t)
+
+	var names []string
+	Apply(&ReturnStatement{}, func(c *Cursor) bool {
+		names = append(names, c.Name())
+		a.Equal(-1, c.Index())
+		return true
+	}, nil)
+
+	a.Equal([]string{"Node", "Argument"}, names)
+}
+
+func TestApplyTemplateLiteral(t *testing.T) {
+	a := assert.New(t)
+
+	// `a${b}c${d}e`
+	tl := &TemplateLiteral{
+		Quasis: []*TemplateElement{
+			{Raw: "a"},
+			{Raw: "c"},
+			{Raw: "e", Tail: true},
+		},
+		Expressions: []Expression{&Identifier{Name: "b"}, &Identifier{Name: "d"}},
+	}
+
+	var seen []string
+	Apply(tl, func(c *Cursor) bool {
+		switch n := c.Node().(type) {
+		case *TemplateElement:
+			seen = append(seen, n.Raw)
+		case *Identifier:
+			seen = append(seen, n.Name)
+
+			if n.Name == "b" {
+				c.Replace(&Identifier{Name: "x"})
+			}
+		}
+
+		return true
+	}, nil)
+
+	a.Equal([]string{"a", "b", "c", "d", "e"}, seen)
+	a.Equal([]Expression{&Identifier{Name: "x"}, &Identifier{Name: "d"}}, tl.Expressions)
+
+	var walked []string
+	Inspect(tl, func(n Node) bool {
+		switch n := n.(type) {
+		case *TemplateElement:
+			walked = append(walked, n.Raw)
+		case *Identifier:
+			walked = append(walked, n.Name)
+		}
+
+		return true
+	})
+
+	a.Equal([]string{"a", "x", "c", "d", "e"}, walked)
+}
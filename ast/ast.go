@@ -1,15 +1,19 @@
+// Code generated by ast/generator from spec.md; DO NOT EDIT.
+
 package ast
 
+import "encoding/json"
+
 type UnaryOperator string
 
 const (
-	UnaryOperatorMinus  = "-"
-	UnaryOperatorPlus   = "+"
-	UnaryOperatorBang   = "!"
-	UnaryOperatorTilde  = "~"
-	UnaryOperatorTypeof = "typeof"
-	UnaryOperatorVoid   = "void"
-	UnaryOperatorDelete = "delete"
+	UnaryOperatorMinus  UnaryOperator = "-"
+	UnaryOperatorPlus   UnaryOperator = "+"
+	UnaryOperatorBang   UnaryOperator = "!"
+	UnaryOperatorTilde  UnaryOperator = "~"
+	UnaryOperatorTypeof UnaryOperator = "typeof"
+	UnaryOperatorVoid   UnaryOperator = "void"
+	UnaryOperatorDelete UnaryOperator = "delete"
 )
 
 func (v UnaryOperator) Valid() bool {
@@ -19,8 +23,8 @@ func (v UnaryOperator) Valid() bool {
 type UpdateOperator string
 
 const (
-	UpdateOperatorIncrement = "++"
-	UpdateOperatorDecrement = "--"
+	UpdateOperatorIncrement UpdateOperator = "++"
+	UpdateOperatorDecrement UpdateOperator = "--"
 )
 
 func (v UpdateOperator) Valid() bool {
@@ -30,68 +34,87 @@ func (v UpdateOperator) Valid() bool {
 type BinaryOperator string
 
 const (
-	BinaryOperatorEqual            = "=="
-	BinaryOperatorNotEqual         = "!="
-	BinaryOperatorStrictEqual      = "==="
-	BinaryOperatorStrictNotEqual   = "!=="
-	BinaryOperatorLess             = "<"
-	BinaryOperatorLessOrEqual      = "<="
-	BinaryOperatorGreater          = ">"
-	BinaryOperatorGreaterOrEqual   = ">="
-	BinaryOperatorShiftLeft        = "<<"
-	BinaryOperatorShiftRight       = ">>"
-	BinaryOperatorShiftRightSigned = ">>>"
-	BinaryOperatorPlus             = "+"
-	BinaryOperatorMinus            = "-"
-	BinaryOperatorMultiply         = "*"
-	BinaryOperatorDivide           = "/"
-	BinaryOperatorModulo           = "%"
-	BinaryOperatorOr               = "|"
-	BinaryOperatorXor              = "^"
-	BinaryOperatorAnd              = "&"
-	BinaryOperatorIn               = "in"
-	BinaryOperatorInstanceof       = "instanceof"
+	BinaryOperatorEqual            BinaryOperator = "=="
+	BinaryOperatorNotEqual         BinaryOperator = "!="
+	BinaryOperatorStrictEqual      BinaryOperator = "==="
+	BinaryOperatorStrictNotEqual   BinaryOperator = "!=="
+	BinaryOperatorLess             BinaryOperator = "<"
+	BinaryOperatorLessOrEqual      BinaryOperator = "<="
+	BinaryOperatorGreater          BinaryOperator = ">"
+	BinaryOperatorGreaterOrEqual   BinaryOperator = ">="
+	BinaryOperatorShiftLeft        BinaryOperator = "<<"
+	BinaryOperatorShiftRight       BinaryOperator = ">>"
+	BinaryOperatorShiftRightSigned BinaryOperator = ">>>"
+	BinaryOperatorPlus             BinaryOperator = "+"
+	BinaryOperatorMinus            BinaryOperator = "-"
+	BinaryOperatorMultiply         BinaryOperator = "*"
+	BinaryOperatorDivide           BinaryOperator = "/"
+	BinaryOperatorModulo           BinaryOperator = "%"
+	BinaryOperatorOr               BinaryOperator = "|"
+	BinaryOperatorXor              BinaryOperator = "^"
+	BinaryOperatorAnd              BinaryOperator = "&"
+	BinaryOperatorIn               BinaryOperator = "in"
+	BinaryOperatorInstanceof       BinaryOperator = "instanceof"
+	BinaryOperatorExponent         BinaryOperator = "**"
 )
 
 func (v BinaryOperator) Valid() bool {
-	return v == BinaryOperatorEqual || v == BinaryOperatorNotEqual || v == BinaryOperatorStrictEqual || v == BinaryOperatorStrictNotEqual || v == BinaryOperatorLess || v == BinaryOperatorLessOrEqual || v == BinaryOperatorGreater || v == BinaryOperatorGreaterOrEqual || v == BinaryOperatorShiftLeft || v == BinaryOperatorShiftRight || v == BinaryOperatorShiftRightSigned || v == BinaryOperatorPlus || v == BinaryOperatorMinus || v == BinaryOperatorMultiply || v == BinaryOperatorDivide || v == BinaryOperatorModulo || v == BinaryOperatorOr || v == BinaryOperatorXor || v == BinaryOperatorAnd || v == BinaryOperatorIn || v == BinaryOperatorInstanceof
+	return v == BinaryOperatorEqual || v == BinaryOperatorNotEqual || v == BinaryOperatorStrictEqual || v == BinaryOperatorStrictNotEqual || v == BinaryOperatorLess || v == BinaryOperatorLessOrEqual || v == BinaryOperatorGreater || v == BinaryOperatorGreaterOrEqual || v == BinaryOperatorShiftLeft || v == BinaryOperatorShiftRight || v == BinaryOperatorShiftRightSigned || v == BinaryOperatorPlus || v == BinaryOperatorMinus || v == BinaryOperatorMultiply || v == BinaryOperatorDivide || v == BinaryOperatorModulo || v == BinaryOperatorOr || v == BinaryOperatorXor || v == BinaryOperatorAnd || v == BinaryOperatorIn || v == BinaryOperatorInstanceof || v == BinaryOperatorExponent
 }
 
 type AssignmentOperator string
 
 const (
-	AssignmentOperatorEquals           = "="
-	AssignmentOperatorAdd              = "+="
-	AssignmentOperatorSubtract         = "-="
-	AssignmentOperatorMultiply         = "*="
-	AssignmentOperatorDivide           = "/="
-	AssignmentOperatorModulo           = "%="
-	AssignmentOperatorShiftLeft        = "<<="
-	AssignmentOperatorShiftRight       = ">>="
-	AssignmentOperatorShiftRightSigned = ">>>="
-	AssignmentOperatorOr               = "|="
-	AssignmentOperatorXor              = "^="
-	AssignmentOperatorAnd              = "&="
+	AssignmentOperatorEquals           AssignmentOperator = "="
+	AssignmentOperatorAdd              AssignmentOperator = "+="
+	AssignmentOperatorSubtract         AssignmentOperator = "-="
+	AssignmentOperatorMultiply         AssignmentOperator = "*="
+	AssignmentOperatorDivide           AssignmentOperator = "/="
+	AssignmentOperatorModulo           AssignmentOperator = "%="
+	AssignmentOperatorShiftLeft        AssignmentOperator = "<<="
+	AssignmentOperatorShiftRight       AssignmentOperator = ">>="
+	AssignmentOperatorShiftRightSigned AssignmentOperator = ">>>="
+	AssignmentOperatorOr               AssignmentOperator = "|="
+	AssignmentOperatorXor              AssignmentOperator = "^="
+	AssignmentOperatorAnd              AssignmentOperator = "&="
+	AssignmentOperatorExponent         AssignmentOperator = "**="
+	AssignmentOperatorLogicalOr        AssignmentOperator = "||="
+	AssignmentOperatorLogicalAnd       AssignmentOperator = "&&="
+	AssignmentOperatorNullish          AssignmentOperator = "??="
 )
 
 func (v AssignmentOperator) Valid() bool {
-	return v == AssignmentOperatorEquals || v == AssignmentOperatorAdd || v == AssignmentOperatorSubtract || v == AssignmentOperatorMultiply || v == AssignmentOperatorDivide || v == AssignmentOperatorModulo || v == AssignmentOperatorShiftLeft || v == AssignmentOperatorShiftRight || v == AssignmentOperatorShiftRightSigned || v == AssignmentOperatorOr || v == AssignmentOperatorXor || v == AssignmentOperatorAnd
+	return v == AssignmentOperatorEquals || v == AssignmentOperatorAdd || v == AssignmentOperatorSubtract || v == AssignmentOperatorMultiply || v == AssignmentOperatorDivide || v == AssignmentOperatorModulo || v == AssignmentOperatorShiftLeft || v == AssignmentOperatorShiftRight || v == AssignmentOperatorShiftRightSigned || v == AssignmentOperatorOr || v == AssignmentOperatorXor || v == AssignmentOperatorAnd || v == AssignmentOperatorExponent || v == AssignmentOperatorLogicalOr || v == AssignmentOperatorLogicalAnd || v == AssignmentOperatorNullish
 }
 
 type LogicalOperator string
 
 const (
-	LogicalOperatorOr  = "||"
-	LogicalOperatorAnd = "&&"
+	LogicalOperatorOr      LogicalOperator = "||"
+	LogicalOperatorAnd     LogicalOperator = "&&"
+	LogicalOperatorNullish LogicalOperator = "??"
 )
 
-func (v LogicalOperator) Valid() bool { return v == LogicalOperatorOr || v == LogicalOperatorAnd }
+func (v LogicalOperator) Valid() bool {
+	return v == LogicalOperatorOr || v == LogicalOperatorAnd || v == LogicalOperatorNullish
+}
+
+type Node interface {
+	Base() *BaseNode
+}
 
-type Node struct {
-	Type string          `json:"type"`
-	Loc  *SourceLocation `json:"loc"`
+type BaseNode struct {
+	Type             string          `json:"type"`
+	Start            int             `json:"start"`
+	End              int             `json:"end"`
+	Loc              *SourceLocation `json:"loc"`
+	LeadingComments  []Comment       `json:"leadingComments,omitempty"`
+	TrailingComments []Comment       `json:"trailingComments,omitempty"`
+	InnerComments    []Comment       `json:"innerComments,omitempty"`
 }
 
+func (n *BaseNode) Base() *BaseNode { return n }
+
 type SourceLocation struct {
 	Source *string  `json:"source"`
 	Start  Position `json:"start"`
@@ -103,587 +126,8595 @@ type Position struct {
 	Column int `json:"column"`
 }
 
-type Identifier struct {
-	Expression
-	Pattern
-	Name string `json:"name"`
+type Comment struct {
+	Type  string          `json:"type"`
+	Value string          `json:"value"`
+	Start int             `json:"start"`
+	End   int             `json:"end"`
+	Loc   *SourceLocation `json:"loc"`
 }
 
-type Literal struct {
-	Expression
+type Span struct {
+	Start            int             `json:"start"`
+	End              int             `json:"end"`
+	Loc              *SourceLocation `json:"loc"`
+	LeadingComments  []Comment       `json:"leadingComments,omitempty"`
+	TrailingComments []Comment       `json:"trailingComments,omitempty"`
+	InnerComments    []Comment       `json:"innerComments,omitempty"`
 }
 
-type RegExpLiteral struct {
-	Literal
-	Pattern string `json:"pattern"`
-	Flags   string `json:"flags"`
+type Identifier struct {
+	BaseNode
+	Name string `json:"name"`
 }
 
-type NullLiteral struct {
-	Literal
+func (*Identifier) expressionNode()                    {}
+func (*Identifier) patternNode()                       {}
+func (*Identifier) isVariableDeclarationOrExpression() {}
+func (*Identifier) isBlockStatementOrExpression()      {}
+func (*Identifier) isExpressionOrSpreadElement()       {}
+func (*Identifier) isExpressionOrPrivateName()         {}
+func (*Identifier) isPatternOrExpression()             {}
+func (*Identifier) isExpressionOrSuper()               {}
+func (*Identifier) isIdentifierOrStringLiteral()       {}
+func (*Identifier) isDeclarationOrExpression()         {}
+
+func (n Identifier) MarshalJSON() ([]byte, error) {
+	type plain Identifier
+	v := plain(n)
+	v.Type = "Identifier"
+	return json.Marshal(v)
 }
 
-type StringLiteral struct {
-	Literal
-	Value string `json:"value"`
-}
+// NewIdentifier returns a new Identifier, or a *FieldError if one of the values
+// given can't go in it.
+func NewIdentifier(name string) (*Identifier, error) {
+	n := &Identifier{Name: name}
+	n.Type = "Identifier"
 
-type BooleanLiteral struct {
-	Literal
-	Value bool `json:"value"`
+	return n, nil
 }
 
-type NumericLiteral struct {
-	Literal
-	Value float64 `json:"value"`
+type PrivateName struct {
+	BaseNode
+	ID *Identifier `json:"id"`
 }
 
-type StatementOrModuleDeclaration interface {
-	IsStatementOrModuleDeclaration() bool
+func (*PrivateName) isExpressionOrPrivateName() {}
+
+func (n PrivateName) MarshalJSON() ([]byte, error) {
+	type plain PrivateName
+	v := plain(n)
+	v.Type = "PrivateName"
+	return json.Marshal(v)
 }
 
-func (Statement) IsStatementOrModuleDeclaration() bool         { return true }
-func (ModuleDeclaration) IsStatementOrModuleDeclaration() bool { return true }
+// NewPrivateName returns a new PrivateName, or a *FieldError if one of the values
+// given can't go in it.
+func NewPrivateName(id *Identifier) (*PrivateName, error) {
+	if missing(id) {
+		return nil, &FieldError{Type: "PrivateName", Field: "id", Value: nil}
+	}
 
-type Program struct {
-	Node
-	SourceType string                         `json:"sourceType"`
-	Body       []StatementOrModuleDeclaration `json:"body"`
-	Directives []Directive                    `json:"directives"`
-}
+	n := &PrivateName{ID: id}
+	n.Type = "PrivateName"
 
-type Function struct {
-	Node
-	ID        *Identifier    `json:"id"`
-	Params    []Pattern      `json:"params"`
-	Body      BlockStatement `json:"body"`
-	Generator bool           `json:"generator"`
-	Async     bool           `json:"async"`
+	return n, nil
 }
 
-type Statement struct {
-	Node
+type Literal interface {
+	Expression
+	literalNode()
 }
 
-type ExpressionStatement struct {
-	Statement
-	Expression Expression `json:"expression"`
+type Extra struct {
+	Raw      string      `json:"raw"`
+	RawValue interface{} `json:"rawValue"`
 }
 
-type BlockStatement struct {
-	Statement
-	Body       []Statement `json:"body"`
-	Directives []Directive `json:"directives"`
+type RegExpLiteral struct {
+	BaseNode
+	Pattern string `json:"pattern"`
+	Flags   string `json:"flags"`
+	Extra   *Extra `json:"extra"`
 }
 
-type EmptyStatement struct {
-	Statement
+func (*RegExpLiteral) literalNode()                       {}
+func (*RegExpLiteral) expressionNode()                    {}
+func (*RegExpLiteral) isVariableDeclarationOrExpression() {}
+func (*RegExpLiteral) isBlockStatementOrExpression()      {}
+func (*RegExpLiteral) isExpressionOrSpreadElement()       {}
+func (*RegExpLiteral) isExpressionOrPrivateName()         {}
+func (*RegExpLiteral) isPatternOrExpression()             {}
+func (*RegExpLiteral) isExpressionOrSuper()               {}
+func (*RegExpLiteral) isDeclarationOrExpression()         {}
+
+func (n RegExpLiteral) MarshalJSON() ([]byte, error) {
+	type plain RegExpLiteral
+	v := plain(n)
+	v.Type = "RegExpLiteral"
+	return json.Marshal(v)
 }
 
-type DebuggerStatement struct {
-	Statement
-}
+// NewRegExpLiteral returns a new RegExpLiteral, or a *FieldError if one of the values
+// given can't go in it.
+func NewRegExpLiteral(pattern string, flags string, extra *Extra) (*RegExpLiteral, error) {
+	n := &RegExpLiteral{Pattern: pattern, Flags: flags, Extra: extra}
+	n.Type = "RegExpLiteral"
 
-type WithStatement struct {
-	Statement
-	Object Expression `json:"object"`
-	Body   Statement  `json:"body"`
+	return n, nil
 }
 
-type ReturnStatement struct {
-	Statement
-	Argument *Expression `json:"argument"`
+type NullLiteral struct {
+	BaseNode
+	Extra *Extra `json:"extra"`
 }
 
-type LabeledStatement struct {
-	Statement
-	Label Identifier `json:"label"`
-	Body  Statement  `json:"body"`
+func (*NullLiteral) literalNode()                       {}
+func (*NullLiteral) expressionNode()                    {}
+func (*NullLiteral) isVariableDeclarationOrExpression() {}
+func (*NullLiteral) isBlockStatementOrExpression()      {}
+func (*NullLiteral) isExpressionOrSpreadElement()       {}
+func (*NullLiteral) isExpressionOrPrivateName()         {}
+func (*NullLiteral) isPatternOrExpression()             {}
+func (*NullLiteral) isExpressionOrSuper()               {}
+func (*NullLiteral) isDeclarationOrExpression()         {}
+
+func (n NullLiteral) MarshalJSON() ([]byte, error) {
+	type plain NullLiteral
+	v := plain(n)
+	v.Type = "NullLiteral"
+	return json.Marshal(v)
 }
 
-type BreakStatement struct {
-	Statement
-	Label *Identifier `json:"label"`
-}
+// NewNullLiteral returns a new NullLiteral, or a *FieldError if one of the values
+// given can't go in it.
+func NewNullLiteral(extra *Extra) (*NullLiteral, error) {
+	n := &NullLiteral{Extra: extra}
+	n.Type = "NullLiteral"
 
-type ContinueStatement struct {
-	Statement
-	Label *Identifier `json:"label"`
+	return n, nil
 }
 
-type IfStatement struct {
-	Statement
-	Test       Expression `json:"test"`
-	Consequent Statement  `json:"consequent"`
-	Alternate  *Statement `json:"alternate"`
+type StringLiteral struct {
+	BaseNode
+	Value string `json:"value"`
+	Extra *Extra `json:"extra"`
 }
 
-type SwitchStatement struct {
-	Statement
-	Discriminant Expression   `json:"discriminant"`
-	Cases        []SwitchCase `json:"cases"`
+func (*StringLiteral) literalNode()                       {}
+func (*StringLiteral) expressionNode()                    {}
+func (*StringLiteral) isVariableDeclarationOrExpression() {}
+func (*StringLiteral) isBlockStatementOrExpression()      {}
+func (*StringLiteral) isExpressionOrSpreadElement()       {}
+func (*StringLiteral) isExpressionOrPrivateName()         {}
+func (*StringLiteral) isPatternOrExpression()             {}
+func (*StringLiteral) isExpressionOrSuper()               {}
+func (*StringLiteral) isIdentifierOrStringLiteral()       {}
+func (*StringLiteral) isDeclarationOrExpression()         {}
+
+func (n StringLiteral) MarshalJSON() ([]byte, error) {
+	type plain StringLiteral
+	v := plain(n)
+	v.Type = "StringLiteral"
+	return json.Marshal(v)
 }
 
-type SwitchCase struct {
-	Node
-	Test       *Expression `json:"test"`
-	Consequent []Statement `json:"consequent"`
-}
+// NewStringLiteral returns a new StringLiteral, or a *FieldError if one of the values
+// given can't go in it.
+func NewStringLiteral(value string, extra *Extra) (*StringLiteral, error) {
+	n := &StringLiteral{Value: value, Extra: extra}
+	n.Type = "StringLiteral"
 
-type ThrowStatement struct {
-	Statement
-	Argument Expression `json:"argument"`
+	return n, nil
 }
 
-type TryStatement struct {
-	Statement
-	Block     BlockStatement  `json:"block"`
-	Handler   *CatchClause    `json:"handler"`
-	Finalizer *BlockStatement `json:"finalizer"`
+type BooleanLiteral struct {
+	BaseNode
+	Value bool   `json:"value"`
+	Extra *Extra `json:"extra"`
 }
 
-type CatchClause struct {
-	Node
-	Param Pattern        `json:"param"`
-	Body  BlockStatement `json:"body"`
+func (*BooleanLiteral) literalNode()                       {}
+func (*BooleanLiteral) expressionNode()                    {}
+func (*BooleanLiteral) isVariableDeclarationOrExpression() {}
+func (*BooleanLiteral) isBlockStatementOrExpression()      {}
+func (*BooleanLiteral) isExpressionOrSpreadElement()       {}
+func (*BooleanLiteral) isExpressionOrPrivateName()         {}
+func (*BooleanLiteral) isPatternOrExpression()             {}
+func (*BooleanLiteral) isExpressionOrSuper()               {}
+func (*BooleanLiteral) isDeclarationOrExpression()         {}
+
+func (n BooleanLiteral) MarshalJSON() ([]byte, error) {
+	type plain BooleanLiteral
+	v := plain(n)
+	v.Type = "BooleanLiteral"
+	return json.Marshal(v)
 }
 
-type WhileStatement struct {
-	Statement
-	Test Expression `json:"test"`
-	Body Statement  `json:"body"`
+// NewBooleanLiteral returns a new BooleanLiteral, or a *FieldError if one of the values
+// given can't go in it.
+func NewBooleanLiteral(value bool, extra *Extra) (*BooleanLiteral, error) {
+	n := &BooleanLiteral{Value: value, Extra: extra}
+	n.Type = "BooleanLiteral"
+
+	return n, nil
 }
 
-type DoWhileStatement struct {
-	Statement
-	Body Statement  `json:"body"`
-	Test Expression `json:"test"`
+type NumericLiteral struct {
+	BaseNode
+	Value float64 `json:"value"`
+	Extra *Extra  `json:"extra"`
 }
 
-type VariableDeclarationOrExpression interface {
-	IsVariableDeclarationOrExpression() bool
+func (*NumericLiteral) literalNode()                       {}
+func (*NumericLiteral) expressionNode()                    {}
+func (*NumericLiteral) isVariableDeclarationOrExpression() {}
+func (*NumericLiteral) isBlockStatementOrExpression()      {}
+func (*NumericLiteral) isExpressionOrSpreadElement()       {}
+func (*NumericLiteral) isExpressionOrPrivateName()         {}
+func (*NumericLiteral) isPatternOrExpression()             {}
+func (*NumericLiteral) isExpressionOrSuper()               {}
+func (*NumericLiteral) isDeclarationOrExpression()         {}
+
+func (n NumericLiteral) MarshalJSON() ([]byte, error) {
+	type plain NumericLiteral
+	v := plain(n)
+	v.Type = "NumericLiteral"
+	return json.Marshal(v)
 }
 
-func (VariableDeclaration) IsVariableDeclarationOrExpression() bool { return true }
-func (Expression) IsVariableDeclarationOrExpression() bool          { return true }
+// NewNumericLiteral returns a new NumericLiteral, or a *FieldError if one of the values
+// given can't go in it.
+func NewNumericLiteral(value float64, extra *Extra) (*NumericLiteral, error) {
+	n := &NumericLiteral{Value: value, Extra: extra}
+	n.Type = "NumericLiteral"
 
-type ForStatement struct {
-	Statement
-	Init   VariableDeclarationOrExpression `json:"init"`
-	Test   *Expression                     `json:"test"`
-	Update *Expression                     `json:"update"`
-	Body   Statement                       `json:"body"`
+	return n, nil
 }
 
-type ForInStatement struct {
-	Statement
-	Left  VariableDeclarationOrExpression `json:"left"`
-	Right Expression                      `json:"right"`
-	Body  Statement                       `json:"body"`
+type BigIntLiteral struct {
+	BaseNode
+	Value string `json:"value"`
+	Extra *Extra `json:"extra"`
 }
 
-type ForOfStatement struct {
-	ForInStatement
+func (*BigIntLiteral) literalNode()                       {}
+func (*BigIntLiteral) expressionNode()                    {}
+func (*BigIntLiteral) isVariableDeclarationOrExpression() {}
+func (*BigIntLiteral) isBlockStatementOrExpression()      {}
+func (*BigIntLiteral) isExpressionOrSpreadElement()       {}
+func (*BigIntLiteral) isExpressionOrPrivateName()         {}
+func (*BigIntLiteral) isPatternOrExpression()             {}
+func (*BigIntLiteral) isExpressionOrSuper()               {}
+func (*BigIntLiteral) isDeclarationOrExpression()         {}
+
+func (n BigIntLiteral) MarshalJSON() ([]byte, error) {
+	type plain BigIntLiteral
+	v := plain(n)
+	v.Type = "BigIntLiteral"
+	return json.Marshal(v)
 }
 
-type Declaration struct {
-	Statement
-}
+// NewBigIntLiteral returns a new BigIntLiteral, or a *FieldError if one of the values
+// given can't go in it.
+func NewBigIntLiteral(value string, extra *Extra) (*BigIntLiteral, error) {
+	n := &BigIntLiteral{Value: value, Extra: extra}
+	n.Type = "BigIntLiteral"
 
-type FunctionDeclaration struct {
-	Function
-	Declaration
-	ID Identifier `json:"id"`
+	return n, nil
 }
 
-type VariableDeclaration struct {
-	Declaration
-	Declarations []VariableDeclarator `json:"declarations"`
-	Kind         string               `json:"kind"`
+type StatementOrModuleDeclaration interface {
+	Node
+	isStatementOrModuleDeclaration()
 }
 
-type VariableDeclarator struct {
-	Node
-	ID   Pattern     `json:"id"`
-	Init *Expression `json:"init"`
+type Program struct {
+	BaseNode
+	SourceType string                         `json:"sourceType"`
+	Body       []StatementOrModuleDeclaration `json:"body"`
+	Directives []*Directive                   `json:"directives"`
 }
 
-type Decorator struct {
-	Node
-	Expression Expression `json:"expression"`
+func (n Program) MarshalJSON() ([]byte, error) {
+	type plain Program
+	v := plain(n)
+	v.Type = "Program"
+	if v.Body == nil {
+		v.Body = []StatementOrModuleDeclaration{}
+	}
+	if v.Directives == nil {
+		v.Directives = []*Directive{}
+	}
+	return json.Marshal(v)
 }
 
-type Directive struct {
-	Node
-	Value DirectiveLiteral `json:"value"`
+func (n *Program) UnmarshalJSON(b []byte) error {
+	type plain Program
+	var v struct {
+		*plain
+		Body []json.RawMessage `json:"body"`
+	}
+
+	v.plain = (*plain)(n)
+
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	if v.Body != nil {
+		n.Body = make([]StatementOrModuleDeclaration, len(v.Body))
+	}
+	for i, e := range v.Body {
+		x, err := decodeStatementOrModuleDeclaration(e)
+		if err != nil {
+			return err
+		}
+
+		n.Body[i] = x
+	}
+
+	return nil
 }
 
-type DirectiveLiteral struct {
-	StringLiteral
+// NewProgram returns a new Program, or a *FieldError if one of the values
+// given can't go in it.
+func NewProgram(sourceType string, body []StatementOrModuleDeclaration, directives []*Directive) (*Program, error) {
+	if sourceType != "script" && sourceType != "module" {
+		return nil, &FieldError{Type: "Program", Field: "sourceType", Value: sourceType}
+	}
+	for _, e := range body {
+		if missing(e) {
+			return nil, &FieldError{Type: "Program", Field: "body", Value: nil}
+		}
+	}
+	for _, e := range directives {
+		if missing(e) {
+			return nil, &FieldError{Type: "Program", Field: "directives", Value: nil}
+		}
+	}
+
+	n := &Program{SourceType: sourceType, Body: body, Directives: directives}
+	n.Type = "Program"
+
+	return n, nil
 }
 
-type Expression struct {
+type Function interface {
 	Node
+	functionNode()
 }
 
-type Super struct {
+type Statement interface {
 	Node
+	statementNode()
 }
 
-type ThisExpression struct {
-	Expression
+type ExpressionStatement struct {
+	BaseNode
+	Expression Expression `json:"expression"`
 }
 
-type BlockStatementOrExpression interface {
-	IsBlockStatementOrExpression() bool
+func (*ExpressionStatement) statementNode()                  {}
+func (*ExpressionStatement) isStatementOrModuleDeclaration() {}
+
+func (n ExpressionStatement) MarshalJSON() ([]byte, error) {
+	type plain ExpressionStatement
+	v := plain(n)
+	v.Type = "ExpressionStatement"
+	return json.Marshal(v)
 }
 
-func (BlockStatement) IsBlockStatementOrExpression() bool { return true }
-func (Expression) IsBlockStatementOrExpression() bool     { return true }
+func (n *ExpressionStatement) UnmarshalJSON(b []byte) error {
+	type plain ExpressionStatement
+	var v struct {
+		*plain
+		Expression json.RawMessage `json:"expression"`
+	}
 
-type ArrowFunctionExpression struct {
-	Function
-	Expression
-	Body         BlockStatementOrExpression `json:"body"`
-	IsExpression bool                       `json:"expression"`
+	v.plain = (*plain)(n)
+
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	if x, err := decodeExpression(v.Expression); err != nil {
+		return err
+	} else {
+		n.Expression = x
+	}
+
+	return nil
 }
 
-type YieldExpression struct {
-	Expression
-	Argument *Expression `json:"argument"`
-	Delegate bool        `json:"delegate"`
+// NewExpressionStatement returns a new ExpressionStatement, or a *FieldError if one of the values
+// given can't go in it.
+func NewExpressionStatement(expression Expression) (*ExpressionStatement, error) {
+	if missing(expression) {
+		return nil, &FieldError{Type: "ExpressionStatement", Field: "expression", Value: nil}
+	}
+
+	n := &ExpressionStatement{Expression: expression}
+	n.Type = "ExpressionStatement"
+
+	return n, nil
 }
 
-type AwaitExpression struct {
-	Expression
-	Argument *Expression `json:"argument"`
+type BlockStatement struct {
+	BaseNode
+	Body       []Statement  `json:"body"`
+	Directives []*Directive `json:"directives"`
 }
 
-type ExpressionOrSpreadElement interface {
-	IsExpressionOrSpreadElement() bool
+func (*BlockStatement) statementNode()                  {}
+func (*BlockStatement) isStatementOrModuleDeclaration() {}
+func (*BlockStatement) isBlockStatementOrExpression()   {}
+
+func (n BlockStatement) MarshalJSON() ([]byte, error) {
+	type plain BlockStatement
+	v := plain(n)
+	v.Type = "BlockStatement"
+	if v.Body == nil {
+		v.Body = []Statement{}
+	}
+	if v.Directives == nil {
+		v.Directives = []*Directive{}
+	}
+	return json.Marshal(v)
 }
 
-func (Expression) IsExpressionOrSpreadElement() bool    { return true }
-func (SpreadElement) IsExpressionOrSpreadElement() bool { return true }
+func (n *BlockStatement) UnmarshalJSON(b []byte) error {
+	type plain BlockStatement
+	var v struct {
+		*plain
+		Body []json.RawMessage `json:"body"`
+	}
 
-type ArrayExpression struct {
-	Expression
-	Elements []ExpressionOrSpreadElement `json:"elements"`
-}
+	v.plain = (*plain)(n)
 
-type ObjectPropertyOrObjectMethodOrSpreadProperty interface {
-	IsObjectPropertyOrObjectMethodOrSpreadProperty() bool
-}
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
 
-func (ObjectProperty) IsObjectPropertyOrObjectMethodOrSpreadProperty() bool { return true }
-func (ObjectMethod) IsObjectPropertyOrObjectMethodOrSpreadProperty() bool   { return true }
-func (SpreadProperty) IsObjectPropertyOrObjectMethodOrSpreadProperty() bool { return true }
+	if v.Body != nil {
+		n.Body = make([]Statement, len(v.Body))
+	}
+	for i, e := range v.Body {
+		x, err := decodeStatement(e)
+		if err != nil {
+			return err
+		}
 
-type ObjectExpression struct {
-	Expression
-	Properties []ObjectPropertyOrObjectMethodOrSpreadProperty `json:"properties"`
+		n.Body[i] = x
+	}
+
+	return nil
 }
 
-type ObjectMember struct {
-	Node
-	Key        Expression  `json:"key"`
-	Computed   bool        `json:"computed"`
-	Value      Expression  `json:"value"`
-	Decorators []Decorator `json:"decorators"`
+// NewBlockStatement returns a new BlockStatement, or a *FieldError if one of the values
+// given can't go in it.
+func NewBlockStatement(body []Statement, directives []*Directive) (*BlockStatement, error) {
+	for _, e := range body {
+		if missing(e) {
+			return nil, &FieldError{Type: "BlockStatement", Field: "body", Value: nil}
+		}
+	}
+	for _, e := range directives {
+		if missing(e) {
+			return nil, &FieldError{Type: "BlockStatement", Field: "directives", Value: nil}
+		}
+	}
+
+	n := &BlockStatement{Body: body, Directives: directives}
+	n.Type = "BlockStatement"
+
+	return n, nil
 }
 
-type ObjectProperty struct {
-	ObjectMember
-	Shorthand bool `json:"shorthand"`
+type EmptyStatement struct {
+	BaseNode
 }
 
-type ObjectMethod struct {
-	ObjectMember
-	Function
-	Kind string `json:"kind"`
+func (*EmptyStatement) statementNode()                  {}
+func (*EmptyStatement) isStatementOrModuleDeclaration() {}
+
+func (n EmptyStatement) MarshalJSON() ([]byte, error) {
+	type plain EmptyStatement
+	v := plain(n)
+	v.Type = "EmptyStatement"
+	return json.Marshal(v)
 }
 
-type RestProperty struct {
-	Node
-	Argument Expression `json:"argument"`
+// NewEmptyStatement returns a new EmptyStatement, or a *FieldError if one of the values
+// given can't go in it.
+func NewEmptyStatement() (*EmptyStatement, error) {
+	n := &EmptyStatement{}
+	n.Type = "EmptyStatement"
+
+	return n, nil
 }
 
-type SpreadProperty struct {
-	Node
-	Argument Expression `json:"argument"`
+type DebuggerStatement struct {
+	BaseNode
 }
 
-type FunctionExpression struct {
-	Function
-	Expression
+func (*DebuggerStatement) statementNode()                  {}
+func (*DebuggerStatement) isStatementOrModuleDeclaration() {}
+
+func (n DebuggerStatement) MarshalJSON() ([]byte, error) {
+	type plain DebuggerStatement
+	v := plain(n)
+	v.Type = "DebuggerStatement"
+	return json.Marshal(v)
 }
 
-type UnaryExpression struct {
-	Expression
-	Operator UnaryOperator `json:"operator"`
-	Prefix   bool          `json:"prefix"`
-	Argument Expression    `json:"argument"`
+// NewDebuggerStatement returns a new DebuggerStatement, or a *FieldError if one of the values
+// given can't go in it.
+func NewDebuggerStatement() (*DebuggerStatement, error) {
+	n := &DebuggerStatement{}
+	n.Type = "DebuggerStatement"
+
+	return n, nil
 }
 
-type UpdateExpression struct {
-	Expression
-	Operator UpdateOperator `json:"operator"`
-	Argument Expression     `json:"argument"`
-	Prefix   bool           `json:"prefix"`
+type WithStatement struct {
+	BaseNode
+	Object Expression `json:"object"`
+	Body   Statement  `json:"body"`
 }
 
-type BinaryExpression struct {
-	Expression
-	Operator BinaryOperator `json:"operator"`
-	Left     Expression     `json:"left"`
-	Right    Expression     `json:"right"`
+func (*WithStatement) statementNode()                  {}
+func (*WithStatement) isStatementOrModuleDeclaration() {}
+
+func (n WithStatement) MarshalJSON() ([]byte, error) {
+	type plain WithStatement
+	v := plain(n)
+	v.Type = "WithStatement"
+	return json.Marshal(v)
 }
 
-type PatternOrExpression interface {
-	IsPatternOrExpression() bool
+func (n *WithStatement) UnmarshalJSON(b []byte) error {
+	type plain WithStatement
+	var v struct {
+		*plain
+		Object json.RawMessage `json:"object"`
+		Body   json.RawMessage `json:"body"`
+	}
+
+	v.plain = (*plain)(n)
+
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	if x, err := decodeExpression(v.Object); err != nil {
+		return err
+	} else {
+		n.Object = x
+	}
+
+	if x, err := decodeStatement(v.Body); err != nil {
+		return err
+	} else {
+		n.Body = x
+	}
+
+	return nil
 }
 
-func (Pattern) IsPatternOrExpression() bool    { return true }
-func (Expression) IsPatternOrExpression() bool { return true }
+// NewWithStatement returns a new WithStatement, or a *FieldError if one of the values
+// given can't go in it.
+func NewWithStatement(object Expression, body Statement) (*WithStatement, error) {
+	if missing(object) {
+		return nil, &FieldError{Type: "WithStatement", Field: "object", Value: nil}
+	}
+	if missing(body) {
+		return nil, &FieldError{Type: "WithStatement", Field: "body", Value: nil}
+	}
 
-type AssignmentExpression struct {
-	Expression
-	Operator AssignmentOperator  `json:"operator"`
-	Left     PatternOrExpression `json:"left"`
-	Right    Expression          `json:"right"`
-}
+	n := &WithStatement{Object: object, Body: body}
+	n.Type = "WithStatement"
 
-type LogicalExpression struct {
-	Expression
-	Operator LogicalOperator `json:"operator"`
-	Left     Expression      `json:"left"`
-	Right    Expression      `json:"right"`
+	return n, nil
 }
 
-type SpreadElement struct {
-	Node
+type ReturnStatement struct {
+	BaseNode
 	Argument Expression `json:"argument"`
 }
 
-type ExpressionOrSuper interface {
-	IsExpressionOrSuper() bool
+func (*ReturnStatement) statementNode()                  {}
+func (*ReturnStatement) isStatementOrModuleDeclaration() {}
+
+func (n ReturnStatement) MarshalJSON() ([]byte, error) {
+	type plain ReturnStatement
+	v := plain(n)
+	v.Type = "ReturnStatement"
+	return json.Marshal(v)
 }
 
-func (Expression) IsExpressionOrSuper() bool { return true }
-func (Super) IsExpressionOrSuper() bool      { return true }
+func (n *ReturnStatement) UnmarshalJSON(b []byte) error {
+	type plain ReturnStatement
+	var v struct {
+		*plain
+		Argument json.RawMessage `json:"argument"`
+	}
 
-type MemberExpression struct {
-	Expression
-	Pattern
-	Object   ExpressionOrSuper `json:"object"`
-	Property Expression        `json:"property"`
-	Computed bool              `json:"computed"`
-}
+	v.plain = (*plain)(n)
 
-type BindExpression struct {
-	Expression
-	Object []*Expression `json:"object"`
-	Callee []Expression  `json:"callee"`
-}
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
 
-type ConditionalExpression struct {
-	Expression
-	Test       Expression `json:"test"`
-	Alternate  Expression `json:"alternate"`
-	Consequent Expression `json:"consequent"`
-}
+	if x, err := decodeExpression(v.Argument); err != nil {
+		return err
+	} else {
+		n.Argument = x
+	}
 
-type CallExpression struct {
-	Expression
-	Callee    ExpressionOrSuper           `json:"callee"`
-	Arguments []ExpressionOrSpreadElement `json:"arguments"`
+	return nil
 }
 
-type NewExpression struct {
-	CallExpression
-}
+// NewReturnStatement returns a new ReturnStatement, or a *FieldError if one of the values
+// given can't go in it.
+func NewReturnStatement(argument Expression) (*ReturnStatement, error) {
+	n := &ReturnStatement{Argument: argument}
+	n.Type = "ReturnStatement"
 
-type SequenceExpression struct {
-	Expression
-	Expressions []Expression `json:"expressions"`
+	return n, nil
 }
 
-type TemplateLiteral struct {
-	Expression
-	Quasis      []TemplateElement `json:"quasis"`
-	Expressions []Expression      `json:"expressions"`
+type LabeledStatement struct {
+	BaseNode
+	Label *Identifier `json:"label"`
+	Body  Statement   `json:"body"`
 }
 
-type TaggedTemplateExpression struct {
-	Expression
-	Tag   Expression      `json:"tag"`
-	Quasi TemplateLiteral `json:"quasi"`
-}
+func (*LabeledStatement) statementNode()                  {}
+func (*LabeledStatement) isStatementOrModuleDeclaration() {}
 
-type TemplateElement struct {
-	Node
-	Tail   bool   `json:"tail"`
-	Cooked string `json:"cooked"`
-	Raw    string `json:"raw"`
+func (n LabeledStatement) MarshalJSON() ([]byte, error) {
+	type plain LabeledStatement
+	v := plain(n)
+	v.Type = "LabeledStatement"
+	return json.Marshal(v)
 }
 
-type Pattern struct {
-	Node
-}
+func (n *LabeledStatement) UnmarshalJSON(b []byte) error {
+	type plain LabeledStatement
+	var v struct {
+		*plain
+		Body json.RawMessage `json:"body"`
+	}
 
-type AssignmentProperty struct {
-	ObjectProperty
-	Value Pattern `json:"value"`
-}
+	v.plain = (*plain)(n)
 
-type AssignmentPropertyOrRestProperty interface {
-	IsAssignmentPropertyOrRestProperty() bool
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	if x, err := decodeStatement(v.Body); err != nil {
+		return err
+	} else {
+		n.Body = x
+	}
+
+	return nil
 }
 
-func (AssignmentProperty) IsAssignmentPropertyOrRestProperty() bool { return true }
-func (RestProperty) IsAssignmentPropertyOrRestProperty() bool       { return true }
+// NewLabeledStatement returns a new LabeledStatement, or a *FieldError if one of the values
+// given can't go in it.
+func NewLabeledStatement(label *Identifier, body Statement) (*LabeledStatement, error) {
+	if missing(label) {
+		return nil, &FieldError{Type: "LabeledStatement", Field: "label", Value: nil}
+	}
+	if missing(body) {
+		return nil, &FieldError{Type: "LabeledStatement", Field: "body", Value: nil}
+	}
 
-type ObjectPattern struct {
-	Pattern
-	Properties []AssignmentPropertyOrRestProperty `json:"properties"`
+	n := &LabeledStatement{Label: label, Body: body}
+	n.Type = "LabeledStatement"
+
+	return n, nil
 }
 
-type ArrayPattern struct {
-	Pattern
-	Elements []*Pattern `json:"elements"`
+type BreakStatement struct {
+	BaseNode
+	Label *Identifier `json:"label"`
 }
 
-type RestElement struct {
-	Pattern
-	Argument Pattern `json:"argument"`
+func (*BreakStatement) statementNode()                  {}
+func (*BreakStatement) isStatementOrModuleDeclaration() {}
+
+func (n BreakStatement) MarshalJSON() ([]byte, error) {
+	type plain BreakStatement
+	v := plain(n)
+	v.Type = "BreakStatement"
+	return json.Marshal(v)
+}
+
+// NewBreakStatement returns a new BreakStatement, or a *FieldError if one of the values
+// given can't go in it.
+func NewBreakStatement(label *Identifier) (*BreakStatement, error) {
+	n := &BreakStatement{Label: label}
+	n.Type = "BreakStatement"
+
+	return n, nil
+}
+
+type ContinueStatement struct {
+	BaseNode
+	Label *Identifier `json:"label"`
+}
+
+func (*ContinueStatement) statementNode()                  {}
+func (*ContinueStatement) isStatementOrModuleDeclaration() {}
+
+func (n ContinueStatement) MarshalJSON() ([]byte, error) {
+	type plain ContinueStatement
+	v := plain(n)
+	v.Type = "ContinueStatement"
+	return json.Marshal(v)
+}
+
+// NewContinueStatement returns a new ContinueStatement, or a *FieldError if one of the values
+// given can't go in it.
+func NewContinueStatement(label *Identifier) (*ContinueStatement, error) {
+	n := &ContinueStatement{Label: label}
+	n.Type = "ContinueStatement"
+
+	return n, nil
+}
+
+type IfStatement struct {
+	BaseNode
+	Test       Expression `json:"test"`
+	Consequent Statement  `json:"consequent"`
+	Alternate  Statement  `json:"alternate"`
+}
+
+func (*IfStatement) statementNode()                  {}
+func (*IfStatement) isStatementOrModuleDeclaration() {}
+
+func (n IfStatement) MarshalJSON() ([]byte, error) {
+	type plain IfStatement
+	v := plain(n)
+	v.Type = "IfStatement"
+	return json.Marshal(v)
+}
+
+func (n *IfStatement) UnmarshalJSON(b []byte) error {
+	type plain IfStatement
+	var v struct {
+		*plain
+		Test       json.RawMessage `json:"test"`
+		Consequent json.RawMessage `json:"consequent"`
+		Alternate  json.RawMessage `json:"alternate"`
+	}
+
+	v.plain = (*plain)(n)
+
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	if x, err := decodeExpression(v.Test); err != nil {
+		return err
+	} else {
+		n.Test = x
+	}
+
+	if x, err := decodeStatement(v.Consequent); err != nil {
+		return err
+	} else {
+		n.Consequent = x
+	}
+
+	if x, err := decodeStatement(v.Alternate); err != nil {
+		return err
+	} else {
+		n.Alternate = x
+	}
+
+	return nil
+}
+
+// NewIfStatement returns a new IfStatement, or a *FieldError if one of the values
+// given can't go in it.
+func NewIfStatement(test Expression, consequent Statement, alternate Statement) (*IfStatement, error) {
+	if missing(test) {
+		return nil, &FieldError{Type: "IfStatement", Field: "test", Value: nil}
+	}
+	if missing(consequent) {
+		return nil, &FieldError{Type: "IfStatement", Field: "consequent", Value: nil}
+	}
+
+	n := &IfStatement{Test: test, Consequent: consequent, Alternate: alternate}
+	n.Type = "IfStatement"
+
+	return n, nil
+}
+
+type SwitchStatement struct {
+	BaseNode
+	Discriminant Expression    `json:"discriminant"`
+	Cases        []*SwitchCase `json:"cases"`
+}
+
+func (*SwitchStatement) statementNode()                  {}
+func (*SwitchStatement) isStatementOrModuleDeclaration() {}
+
+func (n SwitchStatement) MarshalJSON() ([]byte, error) {
+	type plain SwitchStatement
+	v := plain(n)
+	v.Type = "SwitchStatement"
+	if v.Cases == nil {
+		v.Cases = []*SwitchCase{}
+	}
+	return json.Marshal(v)
+}
+
+func (n *SwitchStatement) UnmarshalJSON(b []byte) error {
+	type plain SwitchStatement
+	var v struct {
+		*plain
+		Discriminant json.RawMessage `json:"discriminant"`
+	}
+
+	v.plain = (*plain)(n)
+
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	if x, err := decodeExpression(v.Discriminant); err != nil {
+		return err
+	} else {
+		n.Discriminant = x
+	}
+
+	return nil
+}
+
+// NewSwitchStatement returns a new SwitchStatement, or a *FieldError if one of the values
+// given can't go in it.
+func NewSwitchStatement(discriminant Expression, cases []*SwitchCase) (*SwitchStatement, error) {
+	if missing(discriminant) {
+		return nil, &FieldError{Type: "SwitchStatement", Field: "discriminant", Value: nil}
+	}
+	for _, e := range cases {
+		if missing(e) {
+			return nil, &FieldError{Type: "SwitchStatement", Field: "cases", Value: nil}
+		}
+	}
+
+	n := &SwitchStatement{Discriminant: discriminant, Cases: cases}
+	n.Type = "SwitchStatement"
+
+	return n, nil
+}
+
+type SwitchCase struct {
+	BaseNode
+	Test       Expression  `json:"test"`
+	Consequent []Statement `json:"consequent"`
+}
+
+func (n SwitchCase) MarshalJSON() ([]byte, error) {
+	type plain SwitchCase
+	v := plain(n)
+	v.Type = "SwitchCase"
+	if v.Consequent == nil {
+		v.Consequent = []Statement{}
+	}
+	return json.Marshal(v)
+}
+
+func (n *SwitchCase) UnmarshalJSON(b []byte) error {
+	type plain SwitchCase
+	var v struct {
+		*plain
+		Test       json.RawMessage   `json:"test"`
+		Consequent []json.RawMessage `json:"consequent"`
+	}
+
+	v.plain = (*plain)(n)
+
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	if x, err := decodeExpression(v.Test); err != nil {
+		return err
+	} else {
+		n.Test = x
+	}
+
+	if v.Consequent != nil {
+		n.Consequent = make([]Statement, len(v.Consequent))
+	}
+	for i, e := range v.Consequent {
+		x, err := decodeStatement(e)
+		if err != nil {
+			return err
+		}
+
+		n.Consequent[i] = x
+	}
+
+	return nil
+}
+
+// NewSwitchCase returns a new SwitchCase, or a *FieldError if one of the values
+// given can't go in it.
+func NewSwitchCase(test Expression, consequent []Statement) (*SwitchCase, error) {
+	for _, e := range consequent {
+		if missing(e) {
+			return nil, &FieldError{Type: "SwitchCase", Field: "consequent", Value: nil}
+		}
+	}
+
+	n := &SwitchCase{Test: test, Consequent: consequent}
+	n.Type = "SwitchCase"
+
+	return n, nil
+}
+
+type ThrowStatement struct {
+	BaseNode
+	Argument Expression `json:"argument"`
+}
+
+func (*ThrowStatement) statementNode()                  {}
+func (*ThrowStatement) isStatementOrModuleDeclaration() {}
+
+func (n ThrowStatement) MarshalJSON() ([]byte, error) {
+	type plain ThrowStatement
+	v := plain(n)
+	v.Type = "ThrowStatement"
+	return json.Marshal(v)
+}
+
+func (n *ThrowStatement) UnmarshalJSON(b []byte) error {
+	type plain ThrowStatement
+	var v struct {
+		*plain
+		Argument json.RawMessage `json:"argument"`
+	}
+
+	v.plain = (*plain)(n)
+
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	if x, err := decodeExpression(v.Argument); err != nil {
+		return err
+	} else {
+		n.Argument = x
+	}
+
+	return nil
+}
+
+// NewThrowStatement returns a new ThrowStatement, or a *FieldError if one of the values
+// given can't go in it.
+func NewThrowStatement(argument Expression) (*ThrowStatement, error) {
+	if missing(argument) {
+		return nil, &FieldError{Type: "ThrowStatement", Field: "argument", Value: nil}
+	}
+
+	n := &ThrowStatement{Argument: argument}
+	n.Type = "ThrowStatement"
+
+	return n, nil
+}
+
+type TryStatement struct {
+	BaseNode
+	Block     *BlockStatement `json:"block"`
+	Handler   *CatchClause    `json:"handler"`
+	Finalizer *BlockStatement `json:"finalizer"`
+}
+
+func (*TryStatement) statementNode()                  {}
+func (*TryStatement) isStatementOrModuleDeclaration() {}
+
+func (n TryStatement) MarshalJSON() ([]byte, error) {
+	type plain TryStatement
+	v := plain(n)
+	v.Type = "TryStatement"
+	return json.Marshal(v)
+}
+
+// NewTryStatement returns a new TryStatement, or a *FieldError if one of the values
+// given can't go in it.
+func NewTryStatement(block *BlockStatement, handler *CatchClause, finalizer *BlockStatement) (*TryStatement, error) {
+	if missing(block) {
+		return nil, &FieldError{Type: "TryStatement", Field: "block", Value: nil}
+	}
+
+	n := &TryStatement{Block: block, Handler: handler, Finalizer: finalizer}
+	n.Type = "TryStatement"
+
+	return n, nil
+}
+
+type CatchClause struct {
+	BaseNode
+	Param Pattern         `json:"param"`
+	Body  *BlockStatement `json:"body"`
+}
+
+func (n CatchClause) MarshalJSON() ([]byte, error) {
+	type plain CatchClause
+	v := plain(n)
+	v.Type = "CatchClause"
+	return json.Marshal(v)
+}
+
+func (n *CatchClause) UnmarshalJSON(b []byte) error {
+	type plain CatchClause
+	var v struct {
+		*plain
+		Param json.RawMessage `json:"param"`
+	}
+
+	v.plain = (*plain)(n)
+
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	if x, err := decodePattern(v.Param); err != nil {
+		return err
+	} else {
+		n.Param = x
+	}
+
+	return nil
+}
+
+// NewCatchClause returns a new CatchClause, or a *FieldError if one of the values
+// given can't go in it.
+func NewCatchClause(param Pattern, body *BlockStatement) (*CatchClause, error) {
+	if missing(param) {
+		return nil, &FieldError{Type: "CatchClause", Field: "param", Value: nil}
+	}
+	if missing(body) {
+		return nil, &FieldError{Type: "CatchClause", Field: "body", Value: nil}
+	}
+
+	n := &CatchClause{Param: param, Body: body}
+	n.Type = "CatchClause"
+
+	return n, nil
+}
+
+type WhileStatement struct {
+	BaseNode
+	Test Expression `json:"test"`
+	Body Statement  `json:"body"`
+}
+
+func (*WhileStatement) statementNode()                  {}
+func (*WhileStatement) isStatementOrModuleDeclaration() {}
+
+func (n WhileStatement) MarshalJSON() ([]byte, error) {
+	type plain WhileStatement
+	v := plain(n)
+	v.Type = "WhileStatement"
+	return json.Marshal(v)
+}
+
+func (n *WhileStatement) UnmarshalJSON(b []byte) error {
+	type plain WhileStatement
+	var v struct {
+		*plain
+		Test json.RawMessage `json:"test"`
+		Body json.RawMessage `json:"body"`
+	}
+
+	v.plain = (*plain)(n)
+
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	if x, err := decodeExpression(v.Test); err != nil {
+		return err
+	} else {
+		n.Test = x
+	}
+
+	if x, err := decodeStatement(v.Body); err != nil {
+		return err
+	} else {
+		n.Body = x
+	}
+
+	return nil
+}
+
+// NewWhileStatement returns a new WhileStatement, or a *FieldError if one of the values
+// given can't go in it.
+func NewWhileStatement(test Expression, body Statement) (*WhileStatement, error) {
+	if missing(test) {
+		return nil, &FieldError{Type: "WhileStatement", Field: "test", Value: nil}
+	}
+	if missing(body) {
+		return nil, &FieldError{Type: "WhileStatement", Field: "body", Value: nil}
+	}
+
+	n := &WhileStatement{Test: test, Body: body}
+	n.Type = "WhileStatement"
+
+	return n, nil
+}
+
+type DoWhileStatement struct {
+	BaseNode
+	Body Statement  `json:"body"`
+	Test Expression `json:"test"`
+}
+
+func (*DoWhileStatement) statementNode()                  {}
+func (*DoWhileStatement) isStatementOrModuleDeclaration() {}
+
+func (n DoWhileStatement) MarshalJSON() ([]byte, error) {
+	type plain DoWhileStatement
+	v := plain(n)
+	v.Type = "DoWhileStatement"
+	return json.Marshal(v)
+}
+
+func (n *DoWhileStatement) UnmarshalJSON(b []byte) error {
+	type plain DoWhileStatement
+	var v struct {
+		*plain
+		Body json.RawMessage `json:"body"`
+		Test json.RawMessage `json:"test"`
+	}
+
+	v.plain = (*plain)(n)
+
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	if x, err := decodeStatement(v.Body); err != nil {
+		return err
+	} else {
+		n.Body = x
+	}
+
+	if x, err := decodeExpression(v.Test); err != nil {
+		return err
+	} else {
+		n.Test = x
+	}
+
+	return nil
+}
+
+// NewDoWhileStatement returns a new DoWhileStatement, or a *FieldError if one of the values
+// given can't go in it.
+func NewDoWhileStatement(body Statement, test Expression) (*DoWhileStatement, error) {
+	if missing(body) {
+		return nil, &FieldError{Type: "DoWhileStatement", Field: "body", Value: nil}
+	}
+	if missing(test) {
+		return nil, &FieldError{Type: "DoWhileStatement", Field: "test", Value: nil}
+	}
+
+	n := &DoWhileStatement{Body: body, Test: test}
+	n.Type = "DoWhileStatement"
+
+	return n, nil
+}
+
+type VariableDeclarationOrExpression interface {
+	Node
+	isVariableDeclarationOrExpression()
+}
+
+type ForStatement struct {
+	BaseNode
+	Init   VariableDeclarationOrExpression `json:"init"`
+	Test   Expression                      `json:"test"`
+	Update Expression                      `json:"update"`
+	Body   Statement                       `json:"body"`
+}
+
+func (*ForStatement) statementNode()                  {}
+func (*ForStatement) isStatementOrModuleDeclaration() {}
+
+func (n ForStatement) MarshalJSON() ([]byte, error) {
+	type plain ForStatement
+	v := plain(n)
+	v.Type = "ForStatement"
+	return json.Marshal(v)
+}
+
+func (n *ForStatement) UnmarshalJSON(b []byte) error {
+	type plain ForStatement
+	var v struct {
+		*plain
+		Init   json.RawMessage `json:"init"`
+		Test   json.RawMessage `json:"test"`
+		Update json.RawMessage `json:"update"`
+		Body   json.RawMessage `json:"body"`
+	}
+
+	v.plain = (*plain)(n)
+
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	if x, err := decodeVariableDeclarationOrExpression(v.Init); err != nil {
+		return err
+	} else {
+		n.Init = x
+	}
+
+	if x, err := decodeExpression(v.Test); err != nil {
+		return err
+	} else {
+		n.Test = x
+	}
+
+	if x, err := decodeExpression(v.Update); err != nil {
+		return err
+	} else {
+		n.Update = x
+	}
+
+	if x, err := decodeStatement(v.Body); err != nil {
+		return err
+	} else {
+		n.Body = x
+	}
+
+	return nil
+}
+
+// NewForStatement returns a new ForStatement, or a *FieldError if one of the values
+// given can't go in it.
+func NewForStatement(init VariableDeclarationOrExpression, test Expression, update Expression, body Statement) (*ForStatement, error) {
+	if missing(body) {
+		return nil, &FieldError{Type: "ForStatement", Field: "body", Value: nil}
+	}
+
+	n := &ForStatement{Init: init, Test: test, Update: update, Body: body}
+	n.Type = "ForStatement"
+
+	return n, nil
+}
+
+type ForInStatement struct {
+	BaseNode
+	Left  VariableDeclarationOrExpression `json:"left"`
+	Right Expression                      `json:"right"`
+	Body  Statement                       `json:"body"`
+}
+
+func (*ForInStatement) statementNode()                  {}
+func (*ForInStatement) isStatementOrModuleDeclaration() {}
+
+func (n ForInStatement) MarshalJSON() ([]byte, error) {
+	type plain ForInStatement
+	v := plain(n)
+	v.Type = "ForInStatement"
+	return json.Marshal(v)
+}
+
+func (n *ForInStatement) UnmarshalJSON(b []byte) error {
+	type plain ForInStatement
+	var v struct {
+		*plain
+		Left  json.RawMessage `json:"left"`
+		Right json.RawMessage `json:"right"`
+		Body  json.RawMessage `json:"body"`
+	}
+
+	v.plain = (*plain)(n)
+
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	if x, err := decodeVariableDeclarationOrExpression(v.Left); err != nil {
+		return err
+	} else {
+		n.Left = x
+	}
+
+	if x, err := decodeExpression(v.Right); err != nil {
+		return err
+	} else {
+		n.Right = x
+	}
+
+	if x, err := decodeStatement(v.Body); err != nil {
+		return err
+	} else {
+		n.Body = x
+	}
+
+	return nil
+}
+
+// NewForInStatement returns a new ForInStatement, or a *FieldError if one of the values
+// given can't go in it.
+func NewForInStatement(left VariableDeclarationOrExpression, right Expression, body Statement) (*ForInStatement, error) {
+	if missing(left) {
+		return nil, &FieldError{Type: "ForInStatement", Field: "left", Value: nil}
+	}
+	if missing(right) {
+		return nil, &FieldError{Type: "ForInStatement", Field: "right", Value: nil}
+	}
+	if missing(body) {
+		return nil, &FieldError{Type: "ForInStatement", Field: "body", Value: nil}
+	}
+
+	n := &ForInStatement{Left: left, Right: right, Body: body}
+	n.Type = "ForInStatement"
+
+	return n, nil
+}
+
+type ForOfStatement struct {
+	BaseNode
+	Left  VariableDeclarationOrExpression `json:"left"`
+	Right Expression                      `json:"right"`
+	Body  Statement                       `json:"body"`
+}
+
+func (*ForOfStatement) statementNode()                  {}
+func (*ForOfStatement) isStatementOrModuleDeclaration() {}
+
+func (n ForOfStatement) MarshalJSON() ([]byte, error) {
+	type plain ForOfStatement
+	v := plain(n)
+	v.Type = "ForOfStatement"
+	return json.Marshal(v)
+}
+
+func (n *ForOfStatement) UnmarshalJSON(b []byte) error {
+	type plain ForOfStatement
+	var v struct {
+		*plain
+		Left  json.RawMessage `json:"left"`
+		Right json.RawMessage `json:"right"`
+		Body  json.RawMessage `json:"body"`
+	}
+
+	v.plain = (*plain)(n)
+
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	if x, err := decodeVariableDeclarationOrExpression(v.Left); err != nil {
+		return err
+	} else {
+		n.Left = x
+	}
+
+	if x, err := decodeExpression(v.Right); err != nil {
+		return err
+	} else {
+		n.Right = x
+	}
+
+	if x, err := decodeStatement(v.Body); err != nil {
+		return err
+	} else {
+		n.Body = x
+	}
+
+	return nil
+}
+
+// NewForOfStatement returns a new ForOfStatement, or a *FieldError if one of the values
+// given can't go in it.
+func NewForOfStatement(left VariableDeclarationOrExpression, right Expression, body Statement) (*ForOfStatement, error) {
+	if missing(left) {
+		return nil, &FieldError{Type: "ForOfStatement", Field: "left", Value: nil}
+	}
+	if missing(right) {
+		return nil, &FieldError{Type: "ForOfStatement", Field: "right", Value: nil}
+	}
+	if missing(body) {
+		return nil, &FieldError{Type: "ForOfStatement", Field: "body", Value: nil}
+	}
+
+	n := &ForOfStatement{Left: left, Right: right, Body: body}
+	n.Type = "ForOfStatement"
+
+	return n, nil
+}
+
+type Declaration interface {
+	Statement
+	declarationNode()
+}
+
+type FunctionDeclaration struct {
+	BaseNode
+	ID        *Identifier     `json:"id"`
+	Params    []Pattern       `json:"params"`
+	Body      *BlockStatement `json:"body"`
+	Generator bool            `json:"generator"`
+	Async     bool            `json:"async"`
+}
+
+func (*FunctionDeclaration) functionNode()                   {}
+func (*FunctionDeclaration) declarationNode()                {}
+func (*FunctionDeclaration) statementNode()                  {}
+func (*FunctionDeclaration) isStatementOrModuleDeclaration() {}
+func (*FunctionDeclaration) isDeclarationOrExpression()      {}
+
+func (n FunctionDeclaration) MarshalJSON() ([]byte, error) {
+	type plain FunctionDeclaration
+	v := plain(n)
+	v.Type = "FunctionDeclaration"
+	if v.Params == nil {
+		v.Params = []Pattern{}
+	}
+	return json.Marshal(v)
+}
+
+func (n *FunctionDeclaration) UnmarshalJSON(b []byte) error {
+	type plain FunctionDeclaration
+	var v struct {
+		*plain
+		Params []json.RawMessage `json:"params"`
+	}
+
+	v.plain = (*plain)(n)
+
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	if v.Params != nil {
+		n.Params = make([]Pattern, len(v.Params))
+	}
+	for i, e := range v.Params {
+		x, err := decodePattern(e)
+		if err != nil {
+			return err
+		}
+
+		n.Params[i] = x
+	}
+
+	return nil
+}
+
+// NewFunctionDeclaration returns a new FunctionDeclaration, or a *FieldError if one of the values
+// given can't go in it.
+func NewFunctionDeclaration(id *Identifier, params []Pattern, body *BlockStatement, generator bool, async bool) (*FunctionDeclaration, error) {
+	if missing(id) {
+		return nil, &FieldError{Type: "FunctionDeclaration", Field: "id", Value: nil}
+	}
+	for _, e := range params {
+		if missing(e) {
+			return nil, &FieldError{Type: "FunctionDeclaration", Field: "params", Value: nil}
+		}
+	}
+	if missing(body) {
+		return nil, &FieldError{Type: "FunctionDeclaration", Field: "body", Value: nil}
+	}
+
+	n := &FunctionDeclaration{ID: id, Params: params, Body: body, Generator: generator, Async: async}
+	n.Type = "FunctionDeclaration"
+
+	return n, nil
+}
+
+type VariableDeclaration struct {
+	BaseNode
+	Declarations []*VariableDeclarator `json:"declarations"`
+	Kind         string                `json:"kind"`
+}
+
+func (*VariableDeclaration) declarationNode()                   {}
+func (*VariableDeclaration) statementNode()                     {}
+func (*VariableDeclaration) isStatementOrModuleDeclaration()    {}
+func (*VariableDeclaration) isVariableDeclarationOrExpression() {}
+func (*VariableDeclaration) isDeclarationOrExpression()         {}
+
+func (n VariableDeclaration) MarshalJSON() ([]byte, error) {
+	type plain VariableDeclaration
+	v := plain(n)
+	v.Type = "VariableDeclaration"
+	if v.Declarations == nil {
+		v.Declarations = []*VariableDeclarator{}
+	}
+	return json.Marshal(v)
+}
+
+// NewVariableDeclaration returns a new VariableDeclaration, or a *FieldError if one of the values
+// given can't go in it.
+func NewVariableDeclaration(declarations []*VariableDeclarator, kind string) (*VariableDeclaration, error) {
+	for _, e := range declarations {
+		if missing(e) {
+			return nil, &FieldError{Type: "VariableDeclaration", Field: "declarations", Value: nil}
+		}
+	}
+	if kind != "var" && kind != "let" && kind != "const" {
+		return nil, &FieldError{Type: "VariableDeclaration", Field: "kind", Value: kind}
+	}
+
+	n := &VariableDeclaration{Declarations: declarations, Kind: kind}
+	n.Type = "VariableDeclaration"
+
+	return n, nil
+}
+
+type VariableDeclarator struct {
+	BaseNode
+	ID   Pattern    `json:"id"`
+	Init Expression `json:"init"`
+}
+
+func (n VariableDeclarator) MarshalJSON() ([]byte, error) {
+	type plain VariableDeclarator
+	v := plain(n)
+	v.Type = "VariableDeclarator"
+	return json.Marshal(v)
+}
+
+func (n *VariableDeclarator) UnmarshalJSON(b []byte) error {
+	type plain VariableDeclarator
+	var v struct {
+		*plain
+		ID   json.RawMessage `json:"id"`
+		Init json.RawMessage `json:"init"`
+	}
+
+	v.plain = (*plain)(n)
+
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	if x, err := decodePattern(v.ID); err != nil {
+		return err
+	} else {
+		n.ID = x
+	}
+
+	if x, err := decodeExpression(v.Init); err != nil {
+		return err
+	} else {
+		n.Init = x
+	}
+
+	return nil
+}
+
+// NewVariableDeclarator returns a new VariableDeclarator, or a *FieldError if one of the values
+// given can't go in it.
+func NewVariableDeclarator(id Pattern, init Expression) (*VariableDeclarator, error) {
+	if missing(id) {
+		return nil, &FieldError{Type: "VariableDeclarator", Field: "id", Value: nil}
+	}
+
+	n := &VariableDeclarator{ID: id, Init: init}
+	n.Type = "VariableDeclarator"
+
+	return n, nil
+}
+
+type Decorator struct {
+	BaseNode
+	Expression Expression `json:"expression"`
+}
+
+func (n Decorator) MarshalJSON() ([]byte, error) {
+	type plain Decorator
+	v := plain(n)
+	v.Type = "Decorator"
+	return json.Marshal(v)
+}
+
+func (n *Decorator) UnmarshalJSON(b []byte) error {
+	type plain Decorator
+	var v struct {
+		*plain
+		Expression json.RawMessage `json:"expression"`
+	}
+
+	v.plain = (*plain)(n)
+
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	if x, err := decodeExpression(v.Expression); err != nil {
+		return err
+	} else {
+		n.Expression = x
+	}
+
+	return nil
+}
+
+// NewDecorator returns a new Decorator, or a *FieldError if one of the values
+// given can't go in it.
+func NewDecorator(expression Expression) (*Decorator, error) {
+	if missing(expression) {
+		return nil, &FieldError{Type: "Decorator", Field: "expression", Value: nil}
+	}
+
+	n := &Decorator{Expression: expression}
+	n.Type = "Decorator"
+
+	return n, nil
+}
+
+type Directive struct {
+	BaseNode
+	Value *DirectiveLiteral `json:"value"`
+}
+
+func (n Directive) MarshalJSON() ([]byte, error) {
+	type plain Directive
+	v := plain(n)
+	v.Type = "Directive"
+	return json.Marshal(v)
+}
+
+// NewDirective returns a new Directive, or a *FieldError if one of the values
+// given can't go in it.
+func NewDirective(value *DirectiveLiteral) (*Directive, error) {
+	if missing(value) {
+		return nil, &FieldError{Type: "Directive", Field: "value", Value: nil}
+	}
+
+	n := &Directive{Value: value}
+	n.Type = "Directive"
+
+	return n, nil
+}
+
+type DirectiveLiteral struct {
+	BaseNode
+	Value string `json:"value"`
+	Extra *Extra `json:"extra"`
+}
+
+func (*DirectiveLiteral) literalNode()                       {}
+func (*DirectiveLiteral) expressionNode()                    {}
+func (*DirectiveLiteral) isVariableDeclarationOrExpression() {}
+func (*DirectiveLiteral) isBlockStatementOrExpression()      {}
+func (*DirectiveLiteral) isExpressionOrSpreadElement()       {}
+func (*DirectiveLiteral) isExpressionOrPrivateName()         {}
+func (*DirectiveLiteral) isPatternOrExpression()             {}
+func (*DirectiveLiteral) isExpressionOrSuper()               {}
+func (*DirectiveLiteral) isIdentifierOrStringLiteral()       {}
+func (*DirectiveLiteral) isDeclarationOrExpression()         {}
+
+func (n DirectiveLiteral) MarshalJSON() ([]byte, error) {
+	type plain DirectiveLiteral
+	v := plain(n)
+	v.Type = "DirectiveLiteral"
+	return json.Marshal(v)
+}
+
+// NewDirectiveLiteral returns a new DirectiveLiteral, or a *FieldError if one of the values
+// given can't go in it.
+func NewDirectiveLiteral(value string, extra *Extra) (*DirectiveLiteral, error) {
+	n := &DirectiveLiteral{Value: value, Extra: extra}
+	n.Type = "DirectiveLiteral"
+
+	return n, nil
+}
+
+type Expression interface {
+	Node
+	expressionNode()
+}
+
+type Super struct {
+	BaseNode
+}
+
+func (*Super) isExpressionOrSuper() {}
+
+func (n Super) MarshalJSON() ([]byte, error) {
+	type plain Super
+	v := plain(n)
+	v.Type = "Super"
+	return json.Marshal(v)
+}
+
+// NewSuper returns a new Super, or a *FieldError if one of the values
+// given can't go in it.
+func NewSuper() (*Super, error) {
+	n := &Super{}
+	n.Type = "Super"
+
+	return n, nil
+}
+
+type ThisExpression struct {
+	BaseNode
+}
+
+func (*ThisExpression) expressionNode()                    {}
+func (*ThisExpression) isVariableDeclarationOrExpression() {}
+func (*ThisExpression) isBlockStatementOrExpression()      {}
+func (*ThisExpression) isExpressionOrSpreadElement()       {}
+func (*ThisExpression) isExpressionOrPrivateName()         {}
+func (*ThisExpression) isPatternOrExpression()             {}
+func (*ThisExpression) isExpressionOrSuper()               {}
+func (*ThisExpression) isDeclarationOrExpression()         {}
+
+func (n ThisExpression) MarshalJSON() ([]byte, error) {
+	type plain ThisExpression
+	v := plain(n)
+	v.Type = "ThisExpression"
+	return json.Marshal(v)
+}
+
+// NewThisExpression returns a new ThisExpression, or a *FieldError if one of the values
+// given can't go in it.
+func NewThisExpression() (*ThisExpression, error) {
+	n := &ThisExpression{}
+	n.Type = "ThisExpression"
+
+	return n, nil
+}
+
+type BlockStatementOrExpression interface {
+	Node
+	isBlockStatementOrExpression()
+}
+
+type ArrowFunctionExpression struct {
+	BaseNode
+	ID           *Identifier                `json:"id"`
+	Params       []Pattern                  `json:"params"`
+	Body         BlockStatementOrExpression `json:"body"`
+	Generator    bool                       `json:"generator"`
+	Async        bool                       `json:"async"`
+	IsExpression bool                       `json:"expression"`
+}
+
+func (*ArrowFunctionExpression) functionNode()                      {}
+func (*ArrowFunctionExpression) expressionNode()                    {}
+func (*ArrowFunctionExpression) isVariableDeclarationOrExpression() {}
+func (*ArrowFunctionExpression) isBlockStatementOrExpression()      {}
+func (*ArrowFunctionExpression) isExpressionOrSpreadElement()       {}
+func (*ArrowFunctionExpression) isExpressionOrPrivateName()         {}
+func (*ArrowFunctionExpression) isPatternOrExpression()             {}
+func (*ArrowFunctionExpression) isExpressionOrSuper()               {}
+func (*ArrowFunctionExpression) isDeclarationOrExpression()         {}
+
+func (n ArrowFunctionExpression) MarshalJSON() ([]byte, error) {
+	type plain ArrowFunctionExpression
+	v := plain(n)
+	v.Type = "ArrowFunctionExpression"
+	if v.Params == nil {
+		v.Params = []Pattern{}
+	}
+	return json.Marshal(v)
+}
+
+func (n *ArrowFunctionExpression) UnmarshalJSON(b []byte) error {
+	type plain ArrowFunctionExpression
+	var v struct {
+		*plain
+		Params []json.RawMessage `json:"params"`
+		Body   json.RawMessage   `json:"body"`
+	}
+
+	v.plain = (*plain)(n)
+
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	if v.Params != nil {
+		n.Params = make([]Pattern, len(v.Params))
+	}
+	for i, e := range v.Params {
+		x, err := decodePattern(e)
+		if err != nil {
+			return err
+		}
+
+		n.Params[i] = x
+	}
+
+	if x, err := decodeBlockStatementOrExpression(v.Body); err != nil {
+		return err
+	} else {
+		n.Body = x
+	}
+
+	return nil
+}
+
+// NewArrowFunctionExpression returns a new ArrowFunctionExpression, or a *FieldError if one of the values
+// given can't go in it.
+func NewArrowFunctionExpression(id *Identifier, params []Pattern, body BlockStatementOrExpression, generator bool, async bool, isExpression bool) (*ArrowFunctionExpression, error) {
+	for _, e := range params {
+		if missing(e) {
+			return nil, &FieldError{Type: "ArrowFunctionExpression", Field: "params", Value: nil}
+		}
+	}
+	if missing(body) {
+		return nil, &FieldError{Type: "ArrowFunctionExpression", Field: "body", Value: nil}
+	}
+
+	n := &ArrowFunctionExpression{ID: id, Params: params, Body: body, Generator: generator, Async: async, IsExpression: isExpression}
+	n.Type = "ArrowFunctionExpression"
+
+	return n, nil
+}
+
+type YieldExpression struct {
+	BaseNode
+	Argument Expression `json:"argument"`
+	Delegate bool       `json:"delegate"`
+}
+
+func (*YieldExpression) expressionNode()                    {}
+func (*YieldExpression) isVariableDeclarationOrExpression() {}
+func (*YieldExpression) isBlockStatementOrExpression()      {}
+func (*YieldExpression) isExpressionOrSpreadElement()       {}
+func (*YieldExpression) isExpressionOrPrivateName()         {}
+func (*YieldExpression) isPatternOrExpression()             {}
+func (*YieldExpression) isExpressionOrSuper()               {}
+func (*YieldExpression) isDeclarationOrExpression()         {}
+
+func (n YieldExpression) MarshalJSON() ([]byte, error) {
+	type plain YieldExpression
+	v := plain(n)
+	v.Type = "YieldExpression"
+	return json.Marshal(v)
+}
+
+func (n *YieldExpression) UnmarshalJSON(b []byte) error {
+	type plain YieldExpression
+	var v struct {
+		*plain
+		Argument json.RawMessage `json:"argument"`
+	}
+
+	v.plain = (*plain)(n)
+
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	if x, err := decodeExpression(v.Argument); err != nil {
+		return err
+	} else {
+		n.Argument = x
+	}
+
+	return nil
+}
+
+// NewYieldExpression returns a new YieldExpression, or a *FieldError if one of the values
+// given can't go in it.
+func NewYieldExpression(argument Expression, delegate bool) (*YieldExpression, error) {
+	n := &YieldExpression{Argument: argument, Delegate: delegate}
+	n.Type = "YieldExpression"
+
+	return n, nil
+}
+
+type AwaitExpression struct {
+	BaseNode
+	Argument Expression `json:"argument"`
+}
+
+func (*AwaitExpression) expressionNode()                    {}
+func (*AwaitExpression) isVariableDeclarationOrExpression() {}
+func (*AwaitExpression) isBlockStatementOrExpression()      {}
+func (*AwaitExpression) isExpressionOrSpreadElement()       {}
+func (*AwaitExpression) isExpressionOrPrivateName()         {}
+func (*AwaitExpression) isPatternOrExpression()             {}
+func (*AwaitExpression) isExpressionOrSuper()               {}
+func (*AwaitExpression) isDeclarationOrExpression()         {}
+
+func (n AwaitExpression) MarshalJSON() ([]byte, error) {
+	type plain AwaitExpression
+	v := plain(n)
+	v.Type = "AwaitExpression"
+	return json.Marshal(v)
+}
+
+func (n *AwaitExpression) UnmarshalJSON(b []byte) error {
+	type plain AwaitExpression
+	var v struct {
+		*plain
+		Argument json.RawMessage `json:"argument"`
+	}
+
+	v.plain = (*plain)(n)
+
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	if x, err := decodeExpression(v.Argument); err != nil {
+		return err
+	} else {
+		n.Argument = x
+	}
+
+	return nil
+}
+
+// NewAwaitExpression returns a new AwaitExpression, or a *FieldError if one of the values
+// given can't go in it.
+func NewAwaitExpression(argument Expression) (*AwaitExpression, error) {
+	n := &AwaitExpression{Argument: argument}
+	n.Type = "AwaitExpression"
+
+	return n, nil
+}
+
+type ExpressionOrSpreadElement interface {
+	Node
+	isExpressionOrSpreadElement()
+}
+
+type ArrayExpression struct {
+	BaseNode
+	Elements []ExpressionOrSpreadElement `json:"elements"`
+}
+
+func (*ArrayExpression) expressionNode()                    {}
+func (*ArrayExpression) isVariableDeclarationOrExpression() {}
+func (*ArrayExpression) isBlockStatementOrExpression()      {}
+func (*ArrayExpression) isExpressionOrSpreadElement()       {}
+func (*ArrayExpression) isExpressionOrPrivateName()         {}
+func (*ArrayExpression) isPatternOrExpression()             {}
+func (*ArrayExpression) isExpressionOrSuper()               {}
+func (*ArrayExpression) isDeclarationOrExpression()         {}
+
+func (n ArrayExpression) MarshalJSON() ([]byte, error) {
+	type plain ArrayExpression
+	v := plain(n)
+	v.Type = "ArrayExpression"
+	if v.Elements == nil {
+		v.Elements = []ExpressionOrSpreadElement{}
+	}
+	return json.Marshal(v)
+}
+
+func (n *ArrayExpression) UnmarshalJSON(b []byte) error {
+	type plain ArrayExpression
+	var v struct {
+		*plain
+		Elements []json.RawMessage `json:"elements"`
+	}
+
+	v.plain = (*plain)(n)
+
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	if v.Elements != nil {
+		n.Elements = make([]ExpressionOrSpreadElement, len(v.Elements))
+	}
+	for i, e := range v.Elements {
+		x, err := decodeExpressionOrSpreadElement(e)
+		if err != nil {
+			return err
+		}
+
+		n.Elements[i] = x
+	}
+
+	return nil
+}
+
+// NewArrayExpression returns a new ArrayExpression, or a *FieldError if one of the values
+// given can't go in it.
+func NewArrayExpression(elements []ExpressionOrSpreadElement) (*ArrayExpression, error) {
+	n := &ArrayExpression{Elements: elements}
+	n.Type = "ArrayExpression"
+
+	return n, nil
+}
+
+type ObjectPropertyOrObjectMethodOrSpreadProperty interface {
+	Node
+	isObjectPropertyOrObjectMethodOrSpreadProperty()
+}
+
+type ObjectExpression struct {
+	BaseNode
+	Properties []ObjectPropertyOrObjectMethodOrSpreadProperty `json:"properties"`
+}
+
+func (*ObjectExpression) expressionNode()                    {}
+func (*ObjectExpression) isVariableDeclarationOrExpression() {}
+func (*ObjectExpression) isBlockStatementOrExpression()      {}
+func (*ObjectExpression) isExpressionOrSpreadElement()       {}
+func (*ObjectExpression) isExpressionOrPrivateName()         {}
+func (*ObjectExpression) isPatternOrExpression()             {}
+func (*ObjectExpression) isExpressionOrSuper()               {}
+func (*ObjectExpression) isDeclarationOrExpression()         {}
+
+func (n ObjectExpression) MarshalJSON() ([]byte, error) {
+	type plain ObjectExpression
+	v := plain(n)
+	v.Type = "ObjectExpression"
+	if v.Properties == nil {
+		v.Properties = []ObjectPropertyOrObjectMethodOrSpreadProperty{}
+	}
+	return json.Marshal(v)
+}
+
+func (n *ObjectExpression) UnmarshalJSON(b []byte) error {
+	type plain ObjectExpression
+	var v struct {
+		*plain
+		Properties []json.RawMessage `json:"properties"`
+	}
+
+	v.plain = (*plain)(n)
+
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	if v.Properties != nil {
+		n.Properties = make([]ObjectPropertyOrObjectMethodOrSpreadProperty, len(v.Properties))
+	}
+	for i, e := range v.Properties {
+		x, err := decodeObjectPropertyOrObjectMethodOrSpreadProperty(e)
+		if err != nil {
+			return err
+		}
+
+		n.Properties[i] = x
+	}
+
+	return nil
+}
+
+// NewObjectExpression returns a new ObjectExpression, or a *FieldError if one of the values
+// given can't go in it.
+func NewObjectExpression(properties []ObjectPropertyOrObjectMethodOrSpreadProperty) (*ObjectExpression, error) {
+	for _, e := range properties {
+		if missing(e) {
+			return nil, &FieldError{Type: "ObjectExpression", Field: "properties", Value: nil}
+		}
+	}
+
+	n := &ObjectExpression{Properties: properties}
+	n.Type = "ObjectExpression"
+
+	return n, nil
+}
+
+type ObjectMember interface {
+	Node
+	objectMemberNode()
+}
+
+type ObjectProperty struct {
+	BaseNode
+	Key        Expression   `json:"key"`
+	Computed   bool         `json:"computed"`
+	Value      Expression   `json:"value"`
+	Decorators []*Decorator `json:"decorators"`
+	Shorthand  bool         `json:"shorthand"`
+}
+
+func (*ObjectProperty) objectMemberNode()                               {}
+func (*ObjectProperty) isObjectPropertyOrObjectMethodOrSpreadProperty() {}
+
+func (n ObjectProperty) MarshalJSON() ([]byte, error) {
+	type plain ObjectProperty
+	v := plain(n)
+	v.Type = "ObjectProperty"
+	if v.Decorators == nil {
+		v.Decorators = []*Decorator{}
+	}
+	return json.Marshal(v)
+}
+
+func (n *ObjectProperty) UnmarshalJSON(b []byte) error {
+	type plain ObjectProperty
+	var v struct {
+		*plain
+		Key   json.RawMessage `json:"key"`
+		Value json.RawMessage `json:"value"`
+	}
+
+	v.plain = (*plain)(n)
+
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	if x, err := decodeExpression(v.Key); err != nil {
+		return err
+	} else {
+		n.Key = x
+	}
+
+	if x, err := decodeExpression(v.Value); err != nil {
+		return err
+	} else {
+		n.Value = x
+	}
+
+	return nil
+}
+
+// NewObjectProperty returns a new ObjectProperty, or a *FieldError if one of the values
+// given can't go in it.
+func NewObjectProperty(key Expression, computed bool, value Expression, decorators []*Decorator, shorthand bool) (*ObjectProperty, error) {
+	if missing(key) {
+		return nil, &FieldError{Type: "ObjectProperty", Field: "key", Value: nil}
+	}
+	if missing(value) {
+		return nil, &FieldError{Type: "ObjectProperty", Field: "value", Value: nil}
+	}
+	for _, e := range decorators {
+		if missing(e) {
+			return nil, &FieldError{Type: "ObjectProperty", Field: "decorators", Value: nil}
+		}
+	}
+
+	n := &ObjectProperty{Key: key, Computed: computed, Value: value, Decorators: decorators, Shorthand: shorthand}
+	n.Type = "ObjectProperty"
+
+	return n, nil
+}
+
+type ObjectMethod struct {
+	BaseNode
+	Key          Expression      `json:"key"`
+	Computed     bool            `json:"computed"`
+	Value        Expression      `json:"value"`
+	Decorators   []*Decorator    `json:"decorators"`
+	ID           *Identifier     `json:"id"`
+	Params       []Pattern       `json:"params"`
+	Body         *BlockStatement `json:"body"`
+	Generator    bool            `json:"generator"`
+	Async        bool            `json:"async"`
+	Kind         string          `json:"kind"`
+	FunctionSpan *Span           `json:"functionSpan"`
+}
+
+func (*ObjectMethod) objectMemberNode()                               {}
+func (*ObjectMethod) functionNode()                                   {}
+func (*ObjectMethod) isObjectPropertyOrObjectMethodOrSpreadProperty() {}
+
+func (n ObjectMethod) MarshalJSON() ([]byte, error) {
+	type plain ObjectMethod
+	v := plain(n)
+	v.Type = "ObjectMethod"
+	if v.Decorators == nil {
+		v.Decorators = []*Decorator{}
+	}
+	if v.Params == nil {
+		v.Params = []Pattern{}
+	}
+	return json.Marshal(v)
+}
+
+func (n *ObjectMethod) UnmarshalJSON(b []byte) error {
+	type plain ObjectMethod
+	var v struct {
+		*plain
+		Key    json.RawMessage   `json:"key"`
+		Value  json.RawMessage   `json:"value"`
+		Params []json.RawMessage `json:"params"`
+	}
+
+	v.plain = (*plain)(n)
+
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	if x, err := decodeExpression(v.Key); err != nil {
+		return err
+	} else {
+		n.Key = x
+	}
+
+	if x, err := decodeExpression(v.Value); err != nil {
+		return err
+	} else {
+		n.Value = x
+	}
+
+	if v.Params != nil {
+		n.Params = make([]Pattern, len(v.Params))
+	}
+	for i, e := range v.Params {
+		x, err := decodePattern(e)
+		if err != nil {
+			return err
+		}
+
+		n.Params[i] = x
+	}
+
+	return nil
+}
+
+// NewObjectMethod returns a new ObjectMethod, or a *FieldError if one of the values
+// given can't go in it.
+func NewObjectMethod(key Expression, computed bool, value Expression, decorators []*Decorator, id *Identifier, params []Pattern, body *BlockStatement, generator bool, async bool, kind string, functionSpan *Span) (*ObjectMethod, error) {
+	if missing(key) {
+		return nil, &FieldError{Type: "ObjectMethod", Field: "key", Value: nil}
+	}
+	if missing(value) {
+		return nil, &FieldError{Type: "ObjectMethod", Field: "value", Value: nil}
+	}
+	for _, e := range decorators {
+		if missing(e) {
+			return nil, &FieldError{Type: "ObjectMethod", Field: "decorators", Value: nil}
+		}
+	}
+	for _, e := range params {
+		if missing(e) {
+			return nil, &FieldError{Type: "ObjectMethod", Field: "params", Value: nil}
+		}
+	}
+	if missing(body) {
+		return nil, &FieldError{Type: "ObjectMethod", Field: "body", Value: nil}
+	}
+	if kind != "get" && kind != "set" && kind != "method" {
+		return nil, &FieldError{Type: "ObjectMethod", Field: "kind", Value: kind}
+	}
+
+	n := &ObjectMethod{Key: key, Computed: computed, Value: value, Decorators: decorators, ID: id, Params: params, Body: body, Generator: generator, Async: async, Kind: kind, FunctionSpan: functionSpan}
+	n.Type = "ObjectMethod"
+
+	return n, nil
+}
+
+type RestProperty struct {
+	BaseNode
+	Argument Expression `json:"argument"`
+}
+
+func (*RestProperty) isAssignmentPropertyOrRestProperty() {}
+
+func (n RestProperty) MarshalJSON() ([]byte, error) {
+	type plain RestProperty
+	v := plain(n)
+	v.Type = "RestProperty"
+	return json.Marshal(v)
+}
+
+func (n *RestProperty) UnmarshalJSON(b []byte) error {
+	type plain RestProperty
+	var v struct {
+		*plain
+		Argument json.RawMessage `json:"argument"`
+	}
+
+	v.plain = (*plain)(n)
+
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	if x, err := decodeExpression(v.Argument); err != nil {
+		return err
+	} else {
+		n.Argument = x
+	}
+
+	return nil
+}
+
+// NewRestProperty returns a new RestProperty, or a *FieldError if one of the values
+// given can't go in it.
+func NewRestProperty(argument Expression) (*RestProperty, error) {
+	if missing(argument) {
+		return nil, &FieldError{Type: "RestProperty", Field: "argument", Value: nil}
+	}
+
+	n := &RestProperty{Argument: argument}
+	n.Type = "RestProperty"
+
+	return n, nil
+}
+
+type SpreadProperty struct {
+	BaseNode
+	Argument Expression `json:"argument"`
+}
+
+func (*SpreadProperty) isObjectPropertyOrObjectMethodOrSpreadProperty() {}
+
+func (n SpreadProperty) MarshalJSON() ([]byte, error) {
+	type plain SpreadProperty
+	v := plain(n)
+	v.Type = "SpreadProperty"
+	return json.Marshal(v)
+}
+
+func (n *SpreadProperty) UnmarshalJSON(b []byte) error {
+	type plain SpreadProperty
+	var v struct {
+		*plain
+		Argument json.RawMessage `json:"argument"`
+	}
+
+	v.plain = (*plain)(n)
+
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	if x, err := decodeExpression(v.Argument); err != nil {
+		return err
+	} else {
+		n.Argument = x
+	}
+
+	return nil
+}
+
+// NewSpreadProperty returns a new SpreadProperty, or a *FieldError if one of the values
+// given can't go in it.
+func NewSpreadProperty(argument Expression) (*SpreadProperty, error) {
+	if missing(argument) {
+		return nil, &FieldError{Type: "SpreadProperty", Field: "argument", Value: nil}
+	}
+
+	n := &SpreadProperty{Argument: argument}
+	n.Type = "SpreadProperty"
+
+	return n, nil
+}
+
+type FunctionExpression struct {
+	BaseNode
+	ID        *Identifier     `json:"id"`
+	Params    []Pattern       `json:"params"`
+	Body      *BlockStatement `json:"body"`
+	Generator bool            `json:"generator"`
+	Async     bool            `json:"async"`
+}
+
+func (*FunctionExpression) functionNode()                      {}
+func (*FunctionExpression) expressionNode()                    {}
+func (*FunctionExpression) isVariableDeclarationOrExpression() {}
+func (*FunctionExpression) isBlockStatementOrExpression()      {}
+func (*FunctionExpression) isExpressionOrSpreadElement()       {}
+func (*FunctionExpression) isExpressionOrPrivateName()         {}
+func (*FunctionExpression) isPatternOrExpression()             {}
+func (*FunctionExpression) isExpressionOrSuper()               {}
+func (*FunctionExpression) isDeclarationOrExpression()         {}
+
+func (n FunctionExpression) MarshalJSON() ([]byte, error) {
+	type plain FunctionExpression
+	v := plain(n)
+	v.Type = "FunctionExpression"
+	if v.Params == nil {
+		v.Params = []Pattern{}
+	}
+	return json.Marshal(v)
+}
+
+func (n *FunctionExpression) UnmarshalJSON(b []byte) error {
+	type plain FunctionExpression
+	var v struct {
+		*plain
+		Params []json.RawMessage `json:"params"`
+	}
+
+	v.plain = (*plain)(n)
+
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	if v.Params != nil {
+		n.Params = make([]Pattern, len(v.Params))
+	}
+	for i, e := range v.Params {
+		x, err := decodePattern(e)
+		if err != nil {
+			return err
+		}
+
+		n.Params[i] = x
+	}
+
+	return nil
+}
+
+// NewFunctionExpression returns a new FunctionExpression, or a *FieldError if one of the values
+// given can't go in it.
+func NewFunctionExpression(id *Identifier, params []Pattern, body *BlockStatement, generator bool, async bool) (*FunctionExpression, error) {
+	for _, e := range params {
+		if missing(e) {
+			return nil, &FieldError{Type: "FunctionExpression", Field: "params", Value: nil}
+		}
+	}
+	if missing(body) {
+		return nil, &FieldError{Type: "FunctionExpression", Field: "body", Value: nil}
+	}
+
+	n := &FunctionExpression{ID: id, Params: params, Body: body, Generator: generator, Async: async}
+	n.Type = "FunctionExpression"
+
+	return n, nil
+}
+
+type UnaryExpression struct {
+	BaseNode
+	Operator UnaryOperator `json:"operator"`
+	Prefix   bool          `json:"prefix"`
+	Argument Expression    `json:"argument"`
+}
+
+func (*UnaryExpression) expressionNode()                    {}
+func (*UnaryExpression) isVariableDeclarationOrExpression() {}
+func (*UnaryExpression) isBlockStatementOrExpression()      {}
+func (*UnaryExpression) isExpressionOrSpreadElement()       {}
+func (*UnaryExpression) isExpressionOrPrivateName()         {}
+func (*UnaryExpression) isPatternOrExpression()             {}
+func (*UnaryExpression) isExpressionOrSuper()               {}
+func (*UnaryExpression) isDeclarationOrExpression()         {}
+
+func (n UnaryExpression) MarshalJSON() ([]byte, error) {
+	type plain UnaryExpression
+	v := plain(n)
+	v.Type = "UnaryExpression"
+	return json.Marshal(v)
+}
+
+func (n *UnaryExpression) UnmarshalJSON(b []byte) error {
+	type plain UnaryExpression
+	var v struct {
+		*plain
+		Argument json.RawMessage `json:"argument"`
+	}
+
+	v.plain = (*plain)(n)
+
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	if x, err := decodeExpression(v.Argument); err != nil {
+		return err
+	} else {
+		n.Argument = x
+	}
+
+	return nil
+}
+
+// NewUnaryExpression returns a new UnaryExpression, or a *FieldError if one of the values
+// given can't go in it.
+func NewUnaryExpression(operator UnaryOperator, prefix bool, argument Expression) (*UnaryExpression, error) {
+	if !operator.Valid() {
+		return nil, &FieldError{Type: "UnaryExpression", Field: "operator", Value: operator}
+	}
+	if missing(argument) {
+		return nil, &FieldError{Type: "UnaryExpression", Field: "argument", Value: nil}
+	}
+
+	n := &UnaryExpression{Operator: operator, Prefix: prefix, Argument: argument}
+	n.Type = "UnaryExpression"
+
+	return n, nil
+}
+
+type UpdateExpression struct {
+	BaseNode
+	Operator UpdateOperator `json:"operator"`
+	Argument Expression     `json:"argument"`
+	Prefix   bool           `json:"prefix"`
+}
+
+func (*UpdateExpression) expressionNode()                    {}
+func (*UpdateExpression) isVariableDeclarationOrExpression() {}
+func (*UpdateExpression) isBlockStatementOrExpression()      {}
+func (*UpdateExpression) isExpressionOrSpreadElement()       {}
+func (*UpdateExpression) isExpressionOrPrivateName()         {}
+func (*UpdateExpression) isPatternOrExpression()             {}
+func (*UpdateExpression) isExpressionOrSuper()               {}
+func (*UpdateExpression) isDeclarationOrExpression()         {}
+
+func (n UpdateExpression) MarshalJSON() ([]byte, error) {
+	type plain UpdateExpression
+	v := plain(n)
+	v.Type = "UpdateExpression"
+	return json.Marshal(v)
+}
+
+func (n *UpdateExpression) UnmarshalJSON(b []byte) error {
+	type plain UpdateExpression
+	var v struct {
+		*plain
+		Argument json.RawMessage `json:"argument"`
+	}
+
+	v.plain = (*plain)(n)
+
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	if x, err := decodeExpression(v.Argument); err != nil {
+		return err
+	} else {
+		n.Argument = x
+	}
+
+	return nil
+}
+
+// NewUpdateExpression returns a new UpdateExpression, or a *FieldError if one of the values
+// given can't go in it.
+func NewUpdateExpression(operator UpdateOperator, argument Expression, prefix bool) (*UpdateExpression, error) {
+	if !operator.Valid() {
+		return nil, &FieldError{Type: "UpdateExpression", Field: "operator", Value: operator}
+	}
+	if missing(argument) {
+		return nil, &FieldError{Type: "UpdateExpression", Field: "argument", Value: nil}
+	}
+
+	n := &UpdateExpression{Operator: operator, Argument: argument, Prefix: prefix}
+	n.Type = "UpdateExpression"
+
+	return n, nil
+}
+
+type ExpressionOrPrivateName interface {
+	Node
+	isExpressionOrPrivateName()
+}
+
+type BinaryExpression struct {
+	BaseNode
+	Operator BinaryOperator          `json:"operator"`
+	Left     ExpressionOrPrivateName `json:"left"`
+	Right    Expression              `json:"right"`
+}
+
+func (*BinaryExpression) expressionNode()                    {}
+func (*BinaryExpression) isVariableDeclarationOrExpression() {}
+func (*BinaryExpression) isBlockStatementOrExpression()      {}
+func (*BinaryExpression) isExpressionOrSpreadElement()       {}
+func (*BinaryExpression) isExpressionOrPrivateName()         {}
+func (*BinaryExpression) isPatternOrExpression()             {}
+func (*BinaryExpression) isExpressionOrSuper()               {}
+func (*BinaryExpression) isDeclarationOrExpression()         {}
+
+func (n BinaryExpression) MarshalJSON() ([]byte, error) {
+	type plain BinaryExpression
+	v := plain(n)
+	v.Type = "BinaryExpression"
+	return json.Marshal(v)
+}
+
+func (n *BinaryExpression) UnmarshalJSON(b []byte) error {
+	type plain BinaryExpression
+	var v struct {
+		*plain
+		Left  json.RawMessage `json:"left"`
+		Right json.RawMessage `json:"right"`
+	}
+
+	v.plain = (*plain)(n)
+
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	if x, err := decodeExpressionOrPrivateName(v.Left); err != nil {
+		return err
+	} else {
+		n.Left = x
+	}
+
+	if x, err := decodeExpression(v.Right); err != nil {
+		return err
+	} else {
+		n.Right = x
+	}
+
+	return nil
+}
+
+// NewBinaryExpression returns a new BinaryExpression, or a *FieldError if one of the values
+// given can't go in it.
+func NewBinaryExpression(operator BinaryOperator, left ExpressionOrPrivateName, right Expression) (*BinaryExpression, error) {
+	if !operator.Valid() {
+		return nil, &FieldError{Type: "BinaryExpression", Field: "operator", Value: operator}
+	}
+	if missing(left) {
+		return nil, &FieldError{Type: "BinaryExpression", Field: "left", Value: nil}
+	}
+	if missing(right) {
+		return nil, &FieldError{Type: "BinaryExpression", Field: "right", Value: nil}
+	}
+
+	n := &BinaryExpression{Operator: operator, Left: left, Right: right}
+	n.Type = "BinaryExpression"
+
+	return n, nil
+}
+
+type PatternOrExpression interface {
+	Node
+	isPatternOrExpression()
+}
+
+type AssignmentExpression struct {
+	BaseNode
+	Operator AssignmentOperator  `json:"operator"`
+	Left     PatternOrExpression `json:"left"`
+	Right    Expression          `json:"right"`
+}
+
+func (*AssignmentExpression) expressionNode()                    {}
+func (*AssignmentExpression) isVariableDeclarationOrExpression() {}
+func (*AssignmentExpression) isBlockStatementOrExpression()      {}
+func (*AssignmentExpression) isExpressionOrSpreadElement()       {}
+func (*AssignmentExpression) isExpressionOrPrivateName()         {}
+func (*AssignmentExpression) isPatternOrExpression()             {}
+func (*AssignmentExpression) isExpressionOrSuper()               {}
+func (*AssignmentExpression) isDeclarationOrExpression()         {}
+
+func (n AssignmentExpression) MarshalJSON() ([]byte, error) {
+	type plain AssignmentExpression
+	v := plain(n)
+	v.Type = "AssignmentExpression"
+	return json.Marshal(v)
+}
+
+func (n *AssignmentExpression) UnmarshalJSON(b []byte) error {
+	type plain AssignmentExpression
+	var v struct {
+		*plain
+		Left  json.RawMessage `json:"left"`
+		Right json.RawMessage `json:"right"`
+	}
+
+	v.plain = (*plain)(n)
+
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	if x, err := decodePatternOrExpression(v.Left); err != nil {
+		return err
+	} else {
+		n.Left = x
+	}
+
+	if x, err := decodeExpression(v.Right); err != nil {
+		return err
+	} else {
+		n.Right = x
+	}
+
+	return nil
+}
+
+// NewAssignmentExpression returns a new AssignmentExpression, or a *FieldError if one of the values
+// given can't go in it.
+func NewAssignmentExpression(operator AssignmentOperator, left PatternOrExpression, right Expression) (*AssignmentExpression, error) {
+	if !operator.Valid() {
+		return nil, &FieldError{Type: "AssignmentExpression", Field: "operator", Value: operator}
+	}
+	if missing(left) {
+		return nil, &FieldError{Type: "AssignmentExpression", Field: "left", Value: nil}
+	}
+	if missing(right) {
+		return nil, &FieldError{Type: "AssignmentExpression", Field: "right", Value: nil}
+	}
+
+	n := &AssignmentExpression{Operator: operator, Left: left, Right: right}
+	n.Type = "AssignmentExpression"
+
+	return n, nil
+}
+
+type LogicalExpression struct {
+	BaseNode
+	Operator LogicalOperator `json:"operator"`
+	Left     Expression      `json:"left"`
+	Right    Expression      `json:"right"`
+}
+
+func (*LogicalExpression) expressionNode()                    {}
+func (*LogicalExpression) isVariableDeclarationOrExpression() {}
+func (*LogicalExpression) isBlockStatementOrExpression()      {}
+func (*LogicalExpression) isExpressionOrSpreadElement()       {}
+func (*LogicalExpression) isExpressionOrPrivateName()         {}
+func (*LogicalExpression) isPatternOrExpression()             {}
+func (*LogicalExpression) isExpressionOrSuper()               {}
+func (*LogicalExpression) isDeclarationOrExpression()         {}
+
+func (n LogicalExpression) MarshalJSON() ([]byte, error) {
+	type plain LogicalExpression
+	v := plain(n)
+	v.Type = "LogicalExpression"
+	return json.Marshal(v)
+}
+
+func (n *LogicalExpression) UnmarshalJSON(b []byte) error {
+	type plain LogicalExpression
+	var v struct {
+		*plain
+		Left  json.RawMessage `json:"left"`
+		Right json.RawMessage `json:"right"`
+	}
+
+	v.plain = (*plain)(n)
+
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	if x, err := decodeExpression(v.Left); err != nil {
+		return err
+	} else {
+		n.Left = x
+	}
+
+	if x, err := decodeExpression(v.Right); err != nil {
+		return err
+	} else {
+		n.Right = x
+	}
+
+	return nil
+}
+
+// NewLogicalExpression returns a new LogicalExpression, or a *FieldError if one of the values
+// given can't go in it.
+func NewLogicalExpression(operator LogicalOperator, left Expression, right Expression) (*LogicalExpression, error) {
+	if !operator.Valid() {
+		return nil, &FieldError{Type: "LogicalExpression", Field: "operator", Value: operator}
+	}
+	if missing(left) {
+		return nil, &FieldError{Type: "LogicalExpression", Field: "left", Value: nil}
+	}
+	if missing(right) {
+		return nil, &FieldError{Type: "LogicalExpression", Field: "right", Value: nil}
+	}
+
+	n := &LogicalExpression{Operator: operator, Left: left, Right: right}
+	n.Type = "LogicalExpression"
+
+	return n, nil
+}
+
+type SpreadElement struct {
+	BaseNode
+	Argument Expression `json:"argument"`
+}
+
+func (*SpreadElement) isExpressionOrSpreadElement() {}
+
+func (n SpreadElement) MarshalJSON() ([]byte, error) {
+	type plain SpreadElement
+	v := plain(n)
+	v.Type = "SpreadElement"
+	return json.Marshal(v)
+}
+
+func (n *SpreadElement) UnmarshalJSON(b []byte) error {
+	type plain SpreadElement
+	var v struct {
+		*plain
+		Argument json.RawMessage `json:"argument"`
+	}
+
+	v.plain = (*plain)(n)
+
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	if x, err := decodeExpression(v.Argument); err != nil {
+		return err
+	} else {
+		n.Argument = x
+	}
+
+	return nil
+}
+
+// NewSpreadElement returns a new SpreadElement, or a *FieldError if one of the values
+// given can't go in it.
+func NewSpreadElement(argument Expression) (*SpreadElement, error) {
+	if missing(argument) {
+		return nil, &FieldError{Type: "SpreadElement", Field: "argument", Value: nil}
+	}
+
+	n := &SpreadElement{Argument: argument}
+	n.Type = "SpreadElement"
+
+	return n, nil
+}
+
+type ExpressionOrSuper interface {
+	Node
+	isExpressionOrSuper()
+}
+
+type MemberExpression struct {
+	BaseNode
+	Object   ExpressionOrSuper       `json:"object"`
+	Property ExpressionOrPrivateName `json:"property"`
+	Computed bool                    `json:"computed"`
+}
+
+func (*MemberExpression) expressionNode()                    {}
+func (*MemberExpression) patternNode()                       {}
+func (*MemberExpression) isVariableDeclarationOrExpression() {}
+func (*MemberExpression) isBlockStatementOrExpression()      {}
+func (*MemberExpression) isExpressionOrSpreadElement()       {}
+func (*MemberExpression) isExpressionOrPrivateName()         {}
+func (*MemberExpression) isPatternOrExpression()             {}
+func (*MemberExpression) isExpressionOrSuper()               {}
+func (*MemberExpression) isDeclarationOrExpression()         {}
+
+func (n MemberExpression) MarshalJSON() ([]byte, error) {
+	type plain MemberExpression
+	v := plain(n)
+	v.Type = "MemberExpression"
+	return json.Marshal(v)
+}
+
+func (n *MemberExpression) UnmarshalJSON(b []byte) error {
+	type plain MemberExpression
+	var v struct {
+		*plain
+		Object   json.RawMessage `json:"object"`
+		Property json.RawMessage `json:"property"`
+	}
+
+	v.plain = (*plain)(n)
+
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	if x, err := decodeExpressionOrSuper(v.Object); err != nil {
+		return err
+	} else {
+		n.Object = x
+	}
+
+	if x, err := decodeExpressionOrPrivateName(v.Property); err != nil {
+		return err
+	} else {
+		n.Property = x
+	}
+
+	return nil
+}
+
+// NewMemberExpression returns a new MemberExpression, or a *FieldError if one of the values
+// given can't go in it.
+func NewMemberExpression(object ExpressionOrSuper, property ExpressionOrPrivateName, computed bool) (*MemberExpression, error) {
+	if missing(object) {
+		return nil, &FieldError{Type: "MemberExpression", Field: "object", Value: nil}
+	}
+	if missing(property) {
+		return nil, &FieldError{Type: "MemberExpression", Field: "property", Value: nil}
+	}
+
+	n := &MemberExpression{Object: object, Property: property, Computed: computed}
+	n.Type = "MemberExpression"
+
+	return n, nil
+}
+
+type OptionalMemberExpression struct {
+	BaseNode
+	Object    Expression              `json:"object"`
+	Property  ExpressionOrPrivateName `json:"property"`
+	Computed  bool                    `json:"computed"`
+	Optional  bool                    `json:"optional"`
+	ChainSpan *Span                   `json:"chainSpan"`
+}
+
+func (*OptionalMemberExpression) expressionNode()                    {}
+func (*OptionalMemberExpression) isVariableDeclarationOrExpression() {}
+func (*OptionalMemberExpression) isBlockStatementOrExpression()      {}
+func (*OptionalMemberExpression) isExpressionOrSpreadElement()       {}
+func (*OptionalMemberExpression) isExpressionOrPrivateName()         {}
+func (*OptionalMemberExpression) isPatternOrExpression()             {}
+func (*OptionalMemberExpression) isExpressionOrSuper()               {}
+func (*OptionalMemberExpression) isDeclarationOrExpression()         {}
+
+func (n OptionalMemberExpression) MarshalJSON() ([]byte, error) {
+	type plain OptionalMemberExpression
+	v := plain(n)
+	v.Type = "OptionalMemberExpression"
+	return json.Marshal(v)
+}
+
+func (n *OptionalMemberExpression) UnmarshalJSON(b []byte) error {
+	type plain OptionalMemberExpression
+	var v struct {
+		*plain
+		Object   json.RawMessage `json:"object"`
+		Property json.RawMessage `json:"property"`
+	}
+
+	v.plain = (*plain)(n)
+
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	if x, err := decodeExpression(v.Object); err != nil {
+		return err
+	} else {
+		n.Object = x
+	}
+
+	if x, err := decodeExpressionOrPrivateName(v.Property); err != nil {
+		return err
+	} else {
+		n.Property = x
+	}
+
+	return nil
+}
+
+// NewOptionalMemberExpression returns a new OptionalMemberExpression, or a *FieldError if one of the values
+// given can't go in it.
+func NewOptionalMemberExpression(object Expression, property ExpressionOrPrivateName, computed bool, optional bool, chainSpan *Span) (*OptionalMemberExpression, error) {
+	if missing(object) {
+		return nil, &FieldError{Type: "OptionalMemberExpression", Field: "object", Value: nil}
+	}
+	if missing(property) {
+		return nil, &FieldError{Type: "OptionalMemberExpression", Field: "property", Value: nil}
+	}
+
+	n := &OptionalMemberExpression{Object: object, Property: property, Computed: computed, Optional: optional, ChainSpan: chainSpan}
+	n.Type = "OptionalMemberExpression"
+
+	return n, nil
+}
+
+type BindExpression struct {
+	BaseNode
+	Object []Expression `json:"object"`
+	Callee []Expression `json:"callee"`
+}
+
+func (*BindExpression) expressionNode()                    {}
+func (*BindExpression) isVariableDeclarationOrExpression() {}
+func (*BindExpression) isBlockStatementOrExpression()      {}
+func (*BindExpression) isExpressionOrSpreadElement()       {}
+func (*BindExpression) isExpressionOrPrivateName()         {}
+func (*BindExpression) isPatternOrExpression()             {}
+func (*BindExpression) isExpressionOrSuper()               {}
+func (*BindExpression) isDeclarationOrExpression()         {}
+
+func (n BindExpression) MarshalJSON() ([]byte, error) {
+	type plain BindExpression
+	v := plain(n)
+	v.Type = "BindExpression"
+	if v.Object == nil {
+		v.Object = []Expression{}
+	}
+	if v.Callee == nil {
+		v.Callee = []Expression{}
+	}
+	return json.Marshal(v)
+}
+
+func (n *BindExpression) UnmarshalJSON(b []byte) error {
+	type plain BindExpression
+	var v struct {
+		*plain
+		Object []json.RawMessage `json:"object"`
+		Callee []json.RawMessage `json:"callee"`
+	}
+
+	v.plain = (*plain)(n)
+
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	if v.Object != nil {
+		n.Object = make([]Expression, len(v.Object))
+	}
+	for i, e := range v.Object {
+		x, err := decodeExpression(e)
+		if err != nil {
+			return err
+		}
+
+		n.Object[i] = x
+	}
+
+	if v.Callee != nil {
+		n.Callee = make([]Expression, len(v.Callee))
+	}
+	for i, e := range v.Callee {
+		x, err := decodeExpression(e)
+		if err != nil {
+			return err
+		}
+
+		n.Callee[i] = x
+	}
+
+	return nil
+}
+
+// NewBindExpression returns a new BindExpression, or a *FieldError if one of the values
+// given can't go in it.
+func NewBindExpression(object []Expression, callee []Expression) (*BindExpression, error) {
+	for _, e := range callee {
+		if missing(e) {
+			return nil, &FieldError{Type: "BindExpression", Field: "callee", Value: nil}
+		}
+	}
+
+	n := &BindExpression{Object: object, Callee: callee}
+	n.Type = "BindExpression"
+
+	return n, nil
+}
+
+type ConditionalExpression struct {
+	BaseNode
+	Test       Expression `json:"test"`
+	Alternate  Expression `json:"alternate"`
+	Consequent Expression `json:"consequent"`
+}
+
+func (*ConditionalExpression) expressionNode()                    {}
+func (*ConditionalExpression) isVariableDeclarationOrExpression() {}
+func (*ConditionalExpression) isBlockStatementOrExpression()      {}
+func (*ConditionalExpression) isExpressionOrSpreadElement()       {}
+func (*ConditionalExpression) isExpressionOrPrivateName()         {}
+func (*ConditionalExpression) isPatternOrExpression()             {}
+func (*ConditionalExpression) isExpressionOrSuper()               {}
+func (*ConditionalExpression) isDeclarationOrExpression()         {}
+
+func (n ConditionalExpression) MarshalJSON() ([]byte, error) {
+	type plain ConditionalExpression
+	v := plain(n)
+	v.Type = "ConditionalExpression"
+	return json.Marshal(v)
+}
+
+func (n *ConditionalExpression) UnmarshalJSON(b []byte) error {
+	type plain ConditionalExpression
+	var v struct {
+		*plain
+		Test       json.RawMessage `json:"test"`
+		Alternate  json.RawMessage `json:"alternate"`
+		Consequent json.RawMessage `json:"consequent"`
+	}
+
+	v.plain = (*plain)(n)
+
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	if x, err := decodeExpression(v.Test); err != nil {
+		return err
+	} else {
+		n.Test = x
+	}
+
+	if x, err := decodeExpression(v.Alternate); err != nil {
+		return err
+	} else {
+		n.Alternate = x
+	}
+
+	if x, err := decodeExpression(v.Consequent); err != nil {
+		return err
+	} else {
+		n.Consequent = x
+	}
+
+	return nil
+}
+
+// NewConditionalExpression returns a new ConditionalExpression, or a *FieldError if one of the values
+// given can't go in it.
+func NewConditionalExpression(test Expression, alternate Expression, consequent Expression) (*ConditionalExpression, error) {
+	if missing(test) {
+		return nil, &FieldError{Type: "ConditionalExpression", Field: "test", Value: nil}
+	}
+	if missing(alternate) {
+		return nil, &FieldError{Type: "ConditionalExpression", Field: "alternate", Value: nil}
+	}
+	if missing(consequent) {
+		return nil, &FieldError{Type: "ConditionalExpression", Field: "consequent", Value: nil}
+	}
+
+	n := &ConditionalExpression{Test: test, Alternate: alternate, Consequent: consequent}
+	n.Type = "ConditionalExpression"
+
+	return n, nil
+}
+
+type CallExpression struct {
+	BaseNode
+	Callee    ExpressionOrSuper           `json:"callee"`
+	Arguments []ExpressionOrSpreadElement `json:"arguments"`
+}
+
+func (*CallExpression) expressionNode()                    {}
+func (*CallExpression) isVariableDeclarationOrExpression() {}
+func (*CallExpression) isBlockStatementOrExpression()      {}
+func (*CallExpression) isExpressionOrSpreadElement()       {}
+func (*CallExpression) isExpressionOrPrivateName()         {}
+func (*CallExpression) isPatternOrExpression()             {}
+func (*CallExpression) isExpressionOrSuper()               {}
+func (*CallExpression) isDeclarationOrExpression()         {}
+
+func (n CallExpression) MarshalJSON() ([]byte, error) {
+	type plain CallExpression
+	v := plain(n)
+	v.Type = "CallExpression"
+	if v.Arguments == nil {
+		v.Arguments = []ExpressionOrSpreadElement{}
+	}
+	return json.Marshal(v)
+}
+
+func (n *CallExpression) UnmarshalJSON(b []byte) error {
+	type plain CallExpression
+	var v struct {
+		*plain
+		Callee    json.RawMessage   `json:"callee"`
+		Arguments []json.RawMessage `json:"arguments"`
+	}
+
+	v.plain = (*plain)(n)
+
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	if x, err := decodeExpressionOrSuper(v.Callee); err != nil {
+		return err
+	} else {
+		n.Callee = x
+	}
+
+	if v.Arguments != nil {
+		n.Arguments = make([]ExpressionOrSpreadElement, len(v.Arguments))
+	}
+	for i, e := range v.Arguments {
+		x, err := decodeExpressionOrSpreadElement(e)
+		if err != nil {
+			return err
+		}
+
+		n.Arguments[i] = x
+	}
+
+	return nil
+}
+
+// NewCallExpression returns a new CallExpression, or a *FieldError if one of the values
+// given can't go in it.
+func NewCallExpression(callee ExpressionOrSuper, arguments []ExpressionOrSpreadElement) (*CallExpression, error) {
+	if missing(callee) {
+		return nil, &FieldError{Type: "CallExpression", Field: "callee", Value: nil}
+	}
+	for _, e := range arguments {
+		if missing(e) {
+			return nil, &FieldError{Type: "CallExpression", Field: "arguments", Value: nil}
+		}
+	}
+
+	n := &CallExpression{Callee: callee, Arguments: arguments}
+	n.Type = "CallExpression"
+
+	return n, nil
+}
+
+type OptionalCallExpression struct {
+	BaseNode
+	Callee    Expression                  `json:"callee"`
+	Arguments []ExpressionOrSpreadElement `json:"arguments"`
+	Optional  bool                        `json:"optional"`
+	ChainSpan *Span                       `json:"chainSpan"`
+}
+
+func (*OptionalCallExpression) expressionNode()                    {}
+func (*OptionalCallExpression) isVariableDeclarationOrExpression() {}
+func (*OptionalCallExpression) isBlockStatementOrExpression()      {}
+func (*OptionalCallExpression) isExpressionOrSpreadElement()       {}
+func (*OptionalCallExpression) isExpressionOrPrivateName()         {}
+func (*OptionalCallExpression) isPatternOrExpression()             {}
+func (*OptionalCallExpression) isExpressionOrSuper()               {}
+func (*OptionalCallExpression) isDeclarationOrExpression()         {}
+
+func (n OptionalCallExpression) MarshalJSON() ([]byte, error) {
+	type plain OptionalCallExpression
+	v := plain(n)
+	v.Type = "OptionalCallExpression"
+	if v.Arguments == nil {
+		v.Arguments = []ExpressionOrSpreadElement{}
+	}
+	return json.Marshal(v)
+}
+
+func (n *OptionalCallExpression) UnmarshalJSON(b []byte) error {
+	type plain OptionalCallExpression
+	var v struct {
+		*plain
+		Callee    json.RawMessage   `json:"callee"`
+		Arguments []json.RawMessage `json:"arguments"`
+	}
+
+	v.plain = (*plain)(n)
+
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	if x, err := decodeExpression(v.Callee); err != nil {
+		return err
+	} else {
+		n.Callee = x
+	}
+
+	if v.Arguments != nil {
+		n.Arguments = make([]ExpressionOrSpreadElement, len(v.Arguments))
+	}
+	for i, e := range v.Arguments {
+		x, err := decodeExpressionOrSpreadElement(e)
+		if err != nil {
+			return err
+		}
+
+		n.Arguments[i] = x
+	}
+
+	return nil
+}
+
+// NewOptionalCallExpression returns a new OptionalCallExpression, or a *FieldError if one of the values
+// given can't go in it.
+func NewOptionalCallExpression(callee Expression, arguments []ExpressionOrSpreadElement, optional bool, chainSpan *Span) (*OptionalCallExpression, error) {
+	if missing(callee) {
+		return nil, &FieldError{Type: "OptionalCallExpression", Field: "callee", Value: nil}
+	}
+	for _, e := range arguments {
+		if missing(e) {
+			return nil, &FieldError{Type: "OptionalCallExpression", Field: "arguments", Value: nil}
+		}
+	}
+
+	n := &OptionalCallExpression{Callee: callee, Arguments: arguments, Optional: optional, ChainSpan: chainSpan}
+	n.Type = "OptionalCallExpression"
+
+	return n, nil
+}
+
+type NewExpression struct {
+	BaseNode
+	Callee    ExpressionOrSuper           `json:"callee"`
+	Arguments []ExpressionOrSpreadElement `json:"arguments"`
+}
+
+func (*NewExpression) expressionNode()                    {}
+func (*NewExpression) isVariableDeclarationOrExpression() {}
+func (*NewExpression) isBlockStatementOrExpression()      {}
+func (*NewExpression) isExpressionOrSpreadElement()       {}
+func (*NewExpression) isExpressionOrPrivateName()         {}
+func (*NewExpression) isPatternOrExpression()             {}
+func (*NewExpression) isExpressionOrSuper()               {}
+func (*NewExpression) isDeclarationOrExpression()         {}
+
+func (n NewExpression) MarshalJSON() ([]byte, error) {
+	type plain NewExpression
+	v := plain(n)
+	v.Type = "NewExpression"
+	if v.Arguments == nil {
+		v.Arguments = []ExpressionOrSpreadElement{}
+	}
+	return json.Marshal(v)
+}
+
+func (n *NewExpression) UnmarshalJSON(b []byte) error {
+	type plain NewExpression
+	var v struct {
+		*plain
+		Callee    json.RawMessage   `json:"callee"`
+		Arguments []json.RawMessage `json:"arguments"`
+	}
+
+	v.plain = (*plain)(n)
+
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	if x, err := decodeExpressionOrSuper(v.Callee); err != nil {
+		return err
+	} else {
+		n.Callee = x
+	}
+
+	if v.Arguments != nil {
+		n.Arguments = make([]ExpressionOrSpreadElement, len(v.Arguments))
+	}
+	for i, e := range v.Arguments {
+		x, err := decodeExpressionOrSpreadElement(e)
+		if err != nil {
+			return err
+		}
+
+		n.Arguments[i] = x
+	}
+
+	return nil
+}
+
+// NewNewExpression returns a new NewExpression, or a *FieldError if one of the values
+// given can't go in it.
+func NewNewExpression(callee ExpressionOrSuper, arguments []ExpressionOrSpreadElement) (*NewExpression, error) {
+	if missing(callee) {
+		return nil, &FieldError{Type: "NewExpression", Field: "callee", Value: nil}
+	}
+	for _, e := range arguments {
+		if missing(e) {
+			return nil, &FieldError{Type: "NewExpression", Field: "arguments", Value: nil}
+		}
+	}
+
+	n := &NewExpression{Callee: callee, Arguments: arguments}
+	n.Type = "NewExpression"
+
+	return n, nil
+}
+
+type SequenceExpression struct {
+	BaseNode
+	Expressions []Expression `json:"expressions"`
+}
+
+func (*SequenceExpression) expressionNode()                    {}
+func (*SequenceExpression) isVariableDeclarationOrExpression() {}
+func (*SequenceExpression) isBlockStatementOrExpression()      {}
+func (*SequenceExpression) isExpressionOrSpreadElement()       {}
+func (*SequenceExpression) isExpressionOrPrivateName()         {}
+func (*SequenceExpression) isPatternOrExpression()             {}
+func (*SequenceExpression) isExpressionOrSuper()               {}
+func (*SequenceExpression) isDeclarationOrExpression()         {}
+
+func (n SequenceExpression) MarshalJSON() ([]byte, error) {
+	type plain SequenceExpression
+	v := plain(n)
+	v.Type = "SequenceExpression"
+	if v.Expressions == nil {
+		v.Expressions = []Expression{}
+	}
+	return json.Marshal(v)
+}
+
+func (n *SequenceExpression) UnmarshalJSON(b []byte) error {
+	type plain SequenceExpression
+	var v struct {
+		*plain
+		Expressions []json.RawMessage `json:"expressions"`
+	}
+
+	v.plain = (*plain)(n)
+
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	if v.Expressions != nil {
+		n.Expressions = make([]Expression, len(v.Expressions))
+	}
+	for i, e := range v.Expressions {
+		x, err := decodeExpression(e)
+		if err != nil {
+			return err
+		}
+
+		n.Expressions[i] = x
+	}
+
+	return nil
+}
+
+// NewSequenceExpression returns a new SequenceExpression, or a *FieldError if one of the values
+// given can't go in it.
+func NewSequenceExpression(expressions []Expression) (*SequenceExpression, error) {
+	for _, e := range expressions {
+		if missing(e) {
+			return nil, &FieldError{Type: "SequenceExpression", Field: "expressions", Value: nil}
+		}
+	}
+
+	n := &SequenceExpression{Expressions: expressions}
+	n.Type = "SequenceExpression"
+
+	return n, nil
+}
+
+type ImportExpression struct {
+	BaseNode
+	Source  Expression `json:"source"`
+	Options Expression `json:"options"`
+}
+
+func (*ImportExpression) expressionNode()                    {}
+func (*ImportExpression) isVariableDeclarationOrExpression() {}
+func (*ImportExpression) isBlockStatementOrExpression()      {}
+func (*ImportExpression) isExpressionOrSpreadElement()       {}
+func (*ImportExpression) isExpressionOrPrivateName()         {}
+func (*ImportExpression) isPatternOrExpression()             {}
+func (*ImportExpression) isExpressionOrSuper()               {}
+func (*ImportExpression) isDeclarationOrExpression()         {}
+
+func (n ImportExpression) MarshalJSON() ([]byte, error) {
+	type plain ImportExpression
+	v := plain(n)
+	v.Type = "ImportExpression"
+	return json.Marshal(v)
+}
+
+func (n *ImportExpression) UnmarshalJSON(b []byte) error {
+	type plain ImportExpression
+	var v struct {
+		*plain
+		Source  json.RawMessage `json:"source"`
+		Options json.RawMessage `json:"options"`
+	}
+
+	v.plain = (*plain)(n)
+
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	if x, err := decodeExpression(v.Source); err != nil {
+		return err
+	} else {
+		n.Source = x
+	}
+
+	if x, err := decodeExpression(v.Options); err != nil {
+		return err
+	} else {
+		n.Options = x
+	}
+
+	return nil
+}
+
+// NewImportExpression returns a new ImportExpression, or a *FieldError if one of the values
+// given can't go in it.
+func NewImportExpression(source Expression, options Expression) (*ImportExpression, error) {
+	if missing(source) {
+		return nil, &FieldError{Type: "ImportExpression", Field: "source", Value: nil}
+	}
+
+	n := &ImportExpression{Source: source, Options: options}
+	n.Type = "ImportExpression"
+
+	return n, nil
+}
+
+type TemplateLiteral struct {
+	BaseNode
+	Quasis      []*TemplateElement `json:"quasis"`
+	Expressions []Expression       `json:"expressions"`
+}
+
+func (*TemplateLiteral) expressionNode()                    {}
+func (*TemplateLiteral) isVariableDeclarationOrExpression() {}
+func (*TemplateLiteral) isBlockStatementOrExpression()      {}
+func (*TemplateLiteral) isExpressionOrSpreadElement()       {}
+func (*TemplateLiteral) isExpressionOrPrivateName()         {}
+func (*TemplateLiteral) isPatternOrExpression()             {}
+func (*TemplateLiteral) isExpressionOrSuper()               {}
+func (*TemplateLiteral) isDeclarationOrExpression()         {}
+
+func (n TemplateLiteral) MarshalJSON() ([]byte, error) {
+	type plain TemplateLiteral
+	v := plain(n)
+	v.Type = "TemplateLiteral"
+	if v.Quasis == nil {
+		v.Quasis = []*TemplateElement{}
+	}
+	if v.Expressions == nil {
+		v.Expressions = []Expression{}
+	}
+	return json.Marshal(v)
+}
+
+func (n *TemplateLiteral) UnmarshalJSON(b []byte) error {
+	type plain TemplateLiteral
+	var v struct {
+		*plain
+		Expressions []json.RawMessage `json:"expressions"`
+	}
+
+	v.plain = (*plain)(n)
+
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	if v.Expressions != nil {
+		n.Expressions = make([]Expression, len(v.Expressions))
+	}
+	for i, e := range v.Expressions {
+		x, err := decodeExpression(e)
+		if err != nil {
+			return err
+		}
+
+		n.Expressions[i] = x
+	}
+
+	return nil
+}
+
+// NewTemplateLiteral returns a new TemplateLiteral, or a *FieldError if one of the values
+// given can't go in it.
+func NewTemplateLiteral(quasis []*TemplateElement, expressions []Expression) (*TemplateLiteral, error) {
+	for _, e := range quasis {
+		if missing(e) {
+			return nil, &FieldError{Type: "TemplateLiteral", Field: "quasis", Value: nil}
+		}
+	}
+	for _, e := range expressions {
+		if missing(e) {
+			return nil, &FieldError{Type: "TemplateLiteral", Field: "expressions", Value: nil}
+		}
+	}
+
+	n := &TemplateLiteral{Quasis: quasis, Expressions: expressions}
+	n.Type = "TemplateLiteral"
+
+	return n, nil
+}
+
+type TaggedTemplateExpression struct {
+	BaseNode
+	Tag   Expression       `json:"tag"`
+	Quasi *TemplateLiteral `json:"quasi"`
+}
+
+func (*TaggedTemplateExpression) expressionNode()                    {}
+func (*TaggedTemplateExpression) isVariableDeclarationOrExpression() {}
+func (*TaggedTemplateExpression) isBlockStatementOrExpression()      {}
+func (*TaggedTemplateExpression) isExpressionOrSpreadElement()       {}
+func (*TaggedTemplateExpression) isExpressionOrPrivateName()         {}
+func (*TaggedTemplateExpression) isPatternOrExpression()             {}
+func (*TaggedTemplateExpression) isExpressionOrSuper()               {}
+func (*TaggedTemplateExpression) isDeclarationOrExpression()         {}
+
+func (n TaggedTemplateExpression) MarshalJSON() ([]byte, error) {
+	type plain TaggedTemplateExpression
+	v := plain(n)
+	v.Type = "TaggedTemplateExpression"
+	return json.Marshal(v)
+}
+
+func (n *TaggedTemplateExpression) UnmarshalJSON(b []byte) error {
+	type plain TaggedTemplateExpression
+	var v struct {
+		*plain
+		Tag json.RawMessage `json:"tag"`
+	}
+
+	v.plain = (*plain)(n)
+
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	if x, err := decodeExpression(v.Tag); err != nil {
+		return err
+	} else {
+		n.Tag = x
+	}
+
+	return nil
+}
+
+// NewTaggedTemplateExpression returns a new TaggedTemplateExpression, or a *FieldError if one of the values
+// given can't go in it.
+func NewTaggedTemplateExpression(tag Expression, quasi *TemplateLiteral) (*TaggedTemplateExpression, error) {
+	if missing(tag) {
+		return nil, &FieldError{Type: "TaggedTemplateExpression", Field: "tag", Value: nil}
+	}
+	if missing(quasi) {
+		return nil, &FieldError{Type: "TaggedTemplateExpression", Field: "quasi", Value: nil}
+	}
+
+	n := &TaggedTemplateExpression{Tag: tag, Quasi: quasi}
+	n.Type = "TaggedTemplateExpression"
+
+	return n, nil
+}
+
+type TemplateElement struct {
+	BaseNode
+	Tail   bool    `json:"tail"`
+	Cooked *string `json:"cooked"`
+	Raw    string  `json:"raw"`
+}
+
+func (n TemplateElement) MarshalJSON() ([]byte, error) {
+	type plain TemplateElement
+	v := plain(n)
+	v.Type = "TemplateElement"
+	return json.Marshal(v)
+}
+
+// NewTemplateElement returns a new TemplateElement, or a *FieldError if one of the values
+// given can't go in it.
+func NewTemplateElement(tail bool, cooked *string, raw string) (*TemplateElement, error) {
+	n := &TemplateElement{Tail: tail, Cooked: cooked, Raw: raw}
+	n.Type = "TemplateElement"
+
+	return n, nil
+}
+
+type Pattern interface {
+	Node
+	patternNode()
+}
+
+type AssignmentProperty struct {
+	BaseNode
+	Key        Expression   `json:"key"`
+	Computed   bool         `json:"computed"`
+	Value      Pattern      `json:"value"`
+	Decorators []*Decorator `json:"decorators"`
+	Shorthand  bool         `json:"shorthand"`
+}
+
+func (*AssignmentProperty) objectMemberNode()                               {}
+func (*AssignmentProperty) isObjectPropertyOrObjectMethodOrSpreadProperty() {}
+func (*AssignmentProperty) isAssignmentPropertyOrRestProperty()             {}
+
+func (n AssignmentProperty) MarshalJSON() ([]byte, error) {
+	type plain AssignmentProperty
+	v := plain(n)
+	v.Type = "ObjectProperty"
+	if v.Decorators == nil {
+		v.Decorators = []*Decorator{}
+	}
+	return json.Marshal(v)
+}
+
+func (n *AssignmentProperty) UnmarshalJSON(b []byte) error {
+	type plain AssignmentProperty
+	var v struct {
+		*plain
+		Key   json.RawMessage `json:"key"`
+		Value json.RawMessage `json:"value"`
+	}
+
+	v.plain = (*plain)(n)
+
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	if x, err := decodeExpression(v.Key); err != nil {
+		return err
+	} else {
+		n.Key = x
+	}
+
+	if x, err := decodePattern(v.Value); err != nil {
+		return err
+	} else {
+		n.Value = x
+	}
+
+	return nil
+}
+
+// NewAssignmentProperty returns a new AssignmentProperty, or a *FieldError if one of the values
+// given can't go in it.
+func NewAssignmentProperty(key Expression, computed bool, value Pattern, decorators []*Decorator, shorthand bool) (*AssignmentProperty, error) {
+	if missing(key) {
+		return nil, &FieldError{Type: "ObjectProperty", Field: "key", Value: nil}
+	}
+	if missing(value) {
+		return nil, &FieldError{Type: "ObjectProperty", Field: "value", Value: nil}
+	}
+	for _, e := range decorators {
+		if missing(e) {
+			return nil, &FieldError{Type: "ObjectProperty", Field: "decorators", Value: nil}
+		}
+	}
+
+	n := &AssignmentProperty{Key: key, Computed: computed, Value: value, Decorators: decorators, Shorthand: shorthand}
+	n.Type = "ObjectProperty"
+
+	return n, nil
+}
+
+type AssignmentPropertyOrRestProperty interface {
+	Node
+	isAssignmentPropertyOrRestProperty()
+}
+
+type ObjectPattern struct {
+	BaseNode
+	Properties []AssignmentPropertyOrRestProperty `json:"properties"`
+}
+
+func (*ObjectPattern) patternNode()           {}
+func (*ObjectPattern) isPatternOrExpression() {}
+
+func (n ObjectPattern) MarshalJSON() ([]byte, error) {
+	type plain ObjectPattern
+	v := plain(n)
+	v.Type = "ObjectPattern"
+	if v.Properties == nil {
+		v.Properties = []AssignmentPropertyOrRestProperty{}
+	}
+	return json.Marshal(v)
+}
+
+func (n *ObjectPattern) UnmarshalJSON(b []byte) error {
+	type plain ObjectPattern
+	var v struct {
+		*plain
+		Properties []json.RawMessage `json:"properties"`
+	}
+
+	v.plain = (*plain)(n)
+
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	if v.Properties != nil {
+		n.Properties = make([]AssignmentPropertyOrRestProperty, len(v.Properties))
+	}
+	for i, e := range v.Properties {
+		x, err := decodeAssignmentPropertyOrRestProperty(e)
+		if err != nil {
+			return err
+		}
+
+		n.Properties[i] = x
+	}
+
+	return nil
+}
+
+// NewObjectPattern returns a new ObjectPattern, or a *FieldError if one of the values
+// given can't go in it.
+func NewObjectPattern(properties []AssignmentPropertyOrRestProperty) (*ObjectPattern, error) {
+	for _, e := range properties {
+		if missing(e) {
+			return nil, &FieldError{Type: "ObjectPattern", Field: "properties", Value: nil}
+		}
+	}
+
+	n := &ObjectPattern{Properties: properties}
+	n.Type = "ObjectPattern"
+
+	return n, nil
+}
+
+type ArrayPattern struct {
+	BaseNode
+	Elements []Pattern `json:"elements"`
+}
+
+func (*ArrayPattern) patternNode()           {}
+func (*ArrayPattern) isPatternOrExpression() {}
+
+func (n ArrayPattern) MarshalJSON() ([]byte, error) {
+	type plain ArrayPattern
+	v := plain(n)
+	v.Type = "ArrayPattern"
+	if v.Elements == nil {
+		v.Elements = []Pattern{}
+	}
+	return json.Marshal(v)
+}
+
+func (n *ArrayPattern) UnmarshalJSON(b []byte) error {
+	type plain ArrayPattern
+	var v struct {
+		*plain
+		Elements []json.RawMessage `json:"elements"`
+	}
+
+	v.plain = (*plain)(n)
+
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	if v.Elements != nil {
+		n.Elements = make([]Pattern, len(v.Elements))
+	}
+	for i, e := range v.Elements {
+		x, err := decodePattern(e)
+		if err != nil {
+			return err
+		}
+
+		n.Elements[i] = x
+	}
+
+	return nil
+}
+
+// NewArrayPattern returns a new ArrayPattern, or a *FieldError if one of the values
+// given can't go in it.
+func NewArrayPattern(elements []Pattern) (*ArrayPattern, error) {
+	n := &ArrayPattern{Elements: elements}
+	n.Type = "ArrayPattern"
+
+	return n, nil
+}
+
+type RestElement struct {
+	BaseNode
+	Argument Pattern `json:"argument"`
+}
+
+func (*RestElement) patternNode()           {}
+func (*RestElement) isPatternOrExpression() {}
+
+func (n RestElement) MarshalJSON() ([]byte, error) {
+	type plain RestElement
+	v := plain(n)
+	v.Type = "RestElement"
+	return json.Marshal(v)
+}
+
+func (n *RestElement) UnmarshalJSON(b []byte) error {
+	type plain RestElement
+	var v struct {
+		*plain
+		Argument json.RawMessage `json:"argument"`
+	}
+
+	v.plain = (*plain)(n)
+
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	if x, err := decodePattern(v.Argument); err != nil {
+		return err
+	} else {
+		n.Argument = x
+	}
+
+	return nil
+}
+
+// NewRestElement returns a new RestElement, or a *FieldError if one of the values
+// given can't go in it.
+func NewRestElement(argument Pattern) (*RestElement, error) {
+	if missing(argument) {
+		return nil, &FieldError{Type: "RestElement", Field: "argument", Value: nil}
+	}
+
+	n := &RestElement{Argument: argument}
+	n.Type = "RestElement"
+
+	return n, nil
 }
 
 type AssignmentPattern struct {
-	Pattern
+	BaseNode
 	Left  Pattern    `json:"left"`
 	Right Expression `json:"right"`
 }
 
-type Class struct {
-	Node
-	ID         *Identifier `json:"id"`
-	SuperClass *Expression `json:"superClass"`
-	Body       ClassBody   `json:"body"`
-	Decorators []Decorator `json:"decorators"`
+func (*AssignmentPattern) patternNode()           {}
+func (*AssignmentPattern) isPatternOrExpression() {}
+
+func (n AssignmentPattern) MarshalJSON() ([]byte, error) {
+	type plain AssignmentPattern
+	v := plain(n)
+	v.Type = "AssignmentPattern"
+	return json.Marshal(v)
+}
+
+func (n *AssignmentPattern) UnmarshalJSON(b []byte) error {
+	type plain AssignmentPattern
+	var v struct {
+		*plain
+		Left  json.RawMessage `json:"left"`
+		Right json.RawMessage `json:"right"`
+	}
+
+	v.plain = (*plain)(n)
+
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	if x, err := decodePattern(v.Left); err != nil {
+		return err
+	} else {
+		n.Left = x
+	}
+
+	if x, err := decodeExpression(v.Right); err != nil {
+		return err
+	} else {
+		n.Right = x
+	}
+
+	return nil
+}
+
+// NewAssignmentPattern returns a new AssignmentPattern, or a *FieldError if one of the values
+// given can't go in it.
+func NewAssignmentPattern(left Pattern, right Expression) (*AssignmentPattern, error) {
+	if missing(left) {
+		return nil, &FieldError{Type: "AssignmentPattern", Field: "left", Value: nil}
+	}
+	if missing(right) {
+		return nil, &FieldError{Type: "AssignmentPattern", Field: "right", Value: nil}
+	}
+
+	n := &AssignmentPattern{Left: left, Right: right}
+	n.Type = "AssignmentPattern"
+
+	return n, nil
+}
+
+type Class interface {
+	Node
+	classNode()
+}
+
+type ClassMethodOrClassPrivateMethodOrClassPropertyOrClassPrivatePropertyOrStaticBlock interface {
+	Node
+	isClassMethodOrClassPrivateMethodOrClassPropertyOrClassPrivatePropertyOrStaticBlock()
+}
+
+type ClassBody struct {
+	BaseNode
+	Body []ClassMethodOrClassPrivateMethodOrClassPropertyOrClassPrivatePropertyOrStaticBlock `json:"body"`
+}
+
+func (n ClassBody) MarshalJSON() ([]byte, error) {
+	type plain ClassBody
+	v := plain(n)
+	v.Type = "ClassBody"
+	if v.Body == nil {
+		v.Body = []ClassMethodOrClassPrivateMethodOrClassPropertyOrClassPrivatePropertyOrStaticBlock{}
+	}
+	return json.Marshal(v)
+}
+
+func (n *ClassBody) UnmarshalJSON(b []byte) error {
+	type plain ClassBody
+	var v struct {
+		*plain
+		Body []json.RawMessage `json:"body"`
+	}
+
+	v.plain = (*plain)(n)
+
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	if v.Body != nil {
+		n.Body = make([]ClassMethodOrClassPrivateMethodOrClassPropertyOrClassPrivatePropertyOrStaticBlock, len(v.Body))
+	}
+	for i, e := range v.Body {
+		x, err := decodeClassMethodOrClassPrivateMethodOrClassPropertyOrClassPrivatePropertyOrStaticBlock(e)
+		if err != nil {
+			return err
+		}
+
+		n.Body[i] = x
+	}
+
+	return nil
+}
+
+// NewClassBody returns a new ClassBody, or a *FieldError if one of the values
+// given can't go in it.
+func NewClassBody(body []ClassMethodOrClassPrivateMethodOrClassPropertyOrClassPrivatePropertyOrStaticBlock) (*ClassBody, error) {
+	for _, e := range body {
+		if missing(e) {
+			return nil, &FieldError{Type: "ClassBody", Field: "body", Value: nil}
+		}
+	}
+
+	n := &ClassBody{Body: body}
+	n.Type = "ClassBody"
+
+	return n, nil
 }
 
-type ClassMethodOrClassProperty interface {
-	IsClassMethodOrClassProperty() bool
+type ClassMethod struct {
+	BaseNode
+	Key        Expression          `json:"key"`
+	Value      *FunctionExpression `json:"value"`
+	Kind       string              `json:"kind"`
+	Computed   bool                `json:"computed"`
+	Static     bool                `json:"static"`
+	Decorators []*Decorator        `json:"decorators"`
 }
 
-func (ClassMethod) IsClassMethodOrClassProperty() bool   { return true }
-func (ClassProperty) IsClassMethodOrClassProperty() bool { return true }
+func (*ClassMethod) isClassMethodOrClassPrivateMethodOrClassPropertyOrClassPrivatePropertyOrStaticBlock() {
+}
 
-type ClassBody struct {
-	Node
-	Body []ClassMethodOrClassProperty `json:"body"`
+func (n ClassMethod) MarshalJSON() ([]byte, error) {
+	type plain ClassMethod
+	v := plain(n)
+	v.Type = "ClassMethod"
+	if v.Decorators == nil {
+		v.Decorators = []*Decorator{}
+	}
+	return json.Marshal(v)
 }
 
-type ClassMethod struct {
-	Node
-	Key        Expression         `json:"key"`
-	Value      FunctionExpression `json:"value"`
-	Kind       string             `json:"kind"`
-	Computed   bool               `json:"computed"`
-	Static     bool               `json:"static"`
-	Decorators []Decorator        `json:"decorators"`
+func (n *ClassMethod) UnmarshalJSON(b []byte) error {
+	type plain ClassMethod
+	var v struct {
+		*plain
+		Key json.RawMessage `json:"key"`
+	}
+
+	v.plain = (*plain)(n)
+
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	if x, err := decodeExpression(v.Key); err != nil {
+		return err
+	} else {
+		n.Key = x
+	}
+
+	return nil
+}
+
+// NewClassMethod returns a new ClassMethod, or a *FieldError if one of the values
+// given can't go in it.
+func NewClassMethod(key Expression, value *FunctionExpression, kind string, computed bool, static bool, decorators []*Decorator) (*ClassMethod, error) {
+	if missing(key) {
+		return nil, &FieldError{Type: "ClassMethod", Field: "key", Value: nil}
+	}
+	if missing(value) {
+		return nil, &FieldError{Type: "ClassMethod", Field: "value", Value: nil}
+	}
+	if kind != "constructor" && kind != "method" && kind != "get" && kind != "set" {
+		return nil, &FieldError{Type: "ClassMethod", Field: "kind", Value: kind}
+	}
+	for _, e := range decorators {
+		if missing(e) {
+			return nil, &FieldError{Type: "ClassMethod", Field: "decorators", Value: nil}
+		}
+	}
+
+	n := &ClassMethod{Key: key, Value: value, Kind: kind, Computed: computed, Static: static, Decorators: decorators}
+	n.Type = "ClassMethod"
+
+	return n, nil
+}
+
+type ClassPrivateMethod struct {
+	BaseNode
+	Key        *PrivateName        `json:"key"`
+	Value      *FunctionExpression `json:"value"`
+	Kind       string              `json:"kind"`
+	Static     bool                `json:"static"`
+	Decorators []*Decorator        `json:"decorators"`
+}
+
+func (*ClassPrivateMethod) isClassMethodOrClassPrivateMethodOrClassPropertyOrClassPrivatePropertyOrStaticBlock() {
+}
+
+func (n ClassPrivateMethod) MarshalJSON() ([]byte, error) {
+	type plain ClassPrivateMethod
+	v := plain(n)
+	v.Type = "ClassPrivateMethod"
+	if v.Decorators == nil {
+		v.Decorators = []*Decorator{}
+	}
+	return json.Marshal(v)
+}
+
+// NewClassPrivateMethod returns a new ClassPrivateMethod, or a *FieldError if one of the values
+// given can't go in it.
+func NewClassPrivateMethod(key *PrivateName, value *FunctionExpression, kind string, static bool, decorators []*Decorator) (*ClassPrivateMethod, error) {
+	if missing(key) {
+		return nil, &FieldError{Type: "ClassPrivateMethod", Field: "key", Value: nil}
+	}
+	if missing(value) {
+		return nil, &FieldError{Type: "ClassPrivateMethod", Field: "value", Value: nil}
+	}
+	if kind != "method" && kind != "get" && kind != "set" {
+		return nil, &FieldError{Type: "ClassPrivateMethod", Field: "kind", Value: kind}
+	}
+	for _, e := range decorators {
+		if missing(e) {
+			return nil, &FieldError{Type: "ClassPrivateMethod", Field: "decorators", Value: nil}
+		}
+	}
+
+	n := &ClassPrivateMethod{Key: key, Value: value, Kind: kind, Static: static, Decorators: decorators}
+	n.Type = "ClassPrivateMethod"
+
+	return n, nil
 }
 
 type ClassProperty struct {
-	Node
-	Key   Identifier `json:"key"`
-	Value Expression `json:"value"`
+	BaseNode
+	Key        Expression   `json:"key"`
+	Value      Expression   `json:"value"`
+	Computed   bool         `json:"computed"`
+	Static     bool         `json:"static"`
+	Decorators []*Decorator `json:"decorators"`
+}
+
+func (*ClassProperty) isClassMethodOrClassPrivateMethodOrClassPropertyOrClassPrivatePropertyOrStaticBlock() {
+}
+
+func (n ClassProperty) MarshalJSON() ([]byte, error) {
+	type plain ClassProperty
+	v := plain(n)
+	v.Type = "ClassProperty"
+	if v.Decorators == nil {
+		v.Decorators = []*Decorator{}
+	}
+	return json.Marshal(v)
+}
+
+func (n *ClassProperty) UnmarshalJSON(b []byte) error {
+	type plain ClassProperty
+	var v struct {
+		*plain
+		Key   json.RawMessage `json:"key"`
+		Value json.RawMessage `json:"value"`
+	}
+
+	v.plain = (*plain)(n)
+
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	if x, err := decodeExpression(v.Key); err != nil {
+		return err
+	} else {
+		n.Key = x
+	}
+
+	if x, err := decodeExpression(v.Value); err != nil {
+		return err
+	} else {
+		n.Value = x
+	}
+
+	return nil
+}
+
+// NewClassProperty returns a new ClassProperty, or a *FieldError if one of the values
+// given can't go in it.
+func NewClassProperty(key Expression, value Expression, computed bool, static bool, decorators []*Decorator) (*ClassProperty, error) {
+	if missing(key) {
+		return nil, &FieldError{Type: "ClassProperty", Field: "key", Value: nil}
+	}
+	if missing(value) {
+		return nil, &FieldError{Type: "ClassProperty", Field: "value", Value: nil}
+	}
+	for _, e := range decorators {
+		if missing(e) {
+			return nil, &FieldError{Type: "ClassProperty", Field: "decorators", Value: nil}
+		}
+	}
+
+	n := &ClassProperty{Key: key, Value: value, Computed: computed, Static: static, Decorators: decorators}
+	n.Type = "ClassProperty"
+
+	return n, nil
+}
+
+type ClassPrivateProperty struct {
+	BaseNode
+	Key        *PrivateName `json:"key"`
+	Value      Expression   `json:"value"`
+	Static     bool         `json:"static"`
+	Decorators []*Decorator `json:"decorators"`
+}
+
+func (*ClassPrivateProperty) isClassMethodOrClassPrivateMethodOrClassPropertyOrClassPrivatePropertyOrStaticBlock() {
+}
+
+func (n ClassPrivateProperty) MarshalJSON() ([]byte, error) {
+	type plain ClassPrivateProperty
+	v := plain(n)
+	v.Type = "ClassPrivateProperty"
+	if v.Decorators == nil {
+		v.Decorators = []*Decorator{}
+	}
+	return json.Marshal(v)
+}
+
+func (n *ClassPrivateProperty) UnmarshalJSON(b []byte) error {
+	type plain ClassPrivateProperty
+	var v struct {
+		*plain
+		Value json.RawMessage `json:"value"`
+	}
+
+	v.plain = (*plain)(n)
+
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	if x, err := decodeExpression(v.Value); err != nil {
+		return err
+	} else {
+		n.Value = x
+	}
+
+	return nil
+}
+
+// NewClassPrivateProperty returns a new ClassPrivateProperty, or a *FieldError if one of the values
+// given can't go in it.
+func NewClassPrivateProperty(key *PrivateName, value Expression, static bool, decorators []*Decorator) (*ClassPrivateProperty, error) {
+	if missing(key) {
+		return nil, &FieldError{Type: "ClassPrivateProperty", Field: "key", Value: nil}
+	}
+	for _, e := range decorators {
+		if missing(e) {
+			return nil, &FieldError{Type: "ClassPrivateProperty", Field: "decorators", Value: nil}
+		}
+	}
+
+	n := &ClassPrivateProperty{Key: key, Value: value, Static: static, Decorators: decorators}
+	n.Type = "ClassPrivateProperty"
+
+	return n, nil
+}
+
+type StaticBlock struct {
+	BaseNode
+	Body []Statement `json:"body"`
+}
+
+func (*StaticBlock) isClassMethodOrClassPrivateMethodOrClassPropertyOrClassPrivatePropertyOrStaticBlock() {
+}
+
+func (n StaticBlock) MarshalJSON() ([]byte, error) {
+	type plain StaticBlock
+	v := plain(n)
+	v.Type = "StaticBlock"
+	if v.Body == nil {
+		v.Body = []Statement{}
+	}
+	return json.Marshal(v)
+}
+
+func (n *StaticBlock) UnmarshalJSON(b []byte) error {
+	type plain StaticBlock
+	var v struct {
+		*plain
+		Body []json.RawMessage `json:"body"`
+	}
+
+	v.plain = (*plain)(n)
+
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	if v.Body != nil {
+		n.Body = make([]Statement, len(v.Body))
+	}
+	for i, e := range v.Body {
+		x, err := decodeStatement(e)
+		if err != nil {
+			return err
+		}
+
+		n.Body[i] = x
+	}
+
+	return nil
+}
+
+// NewStaticBlock returns a new StaticBlock, or a *FieldError if one of the values
+// given can't go in it.
+func NewStaticBlock(body []Statement) (*StaticBlock, error) {
+	for _, e := range body {
+		if missing(e) {
+			return nil, &FieldError{Type: "StaticBlock", Field: "body", Value: nil}
+		}
+	}
+
+	n := &StaticBlock{Body: body}
+	n.Type = "StaticBlock"
+
+	return n, nil
 }
 
 type ClassDeclaration struct {
-	Class
-	Declaration
-	ID Identifier `json:"id"`
+	BaseNode
+	ID         *Identifier  `json:"id"`
+	SuperClass Expression   `json:"superClass"`
+	Body       *ClassBody   `json:"body"`
+	Decorators []*Decorator `json:"decorators"`
+}
+
+func (*ClassDeclaration) classNode()                      {}
+func (*ClassDeclaration) declarationNode()                {}
+func (*ClassDeclaration) statementNode()                  {}
+func (*ClassDeclaration) isStatementOrModuleDeclaration() {}
+func (*ClassDeclaration) isDeclarationOrExpression()      {}
+
+func (n ClassDeclaration) MarshalJSON() ([]byte, error) {
+	type plain ClassDeclaration
+	v := plain(n)
+	v.Type = "ClassDeclaration"
+	if v.Decorators == nil {
+		v.Decorators = []*Decorator{}
+	}
+	return json.Marshal(v)
+}
+
+func (n *ClassDeclaration) UnmarshalJSON(b []byte) error {
+	type plain ClassDeclaration
+	var v struct {
+		*plain
+		SuperClass json.RawMessage `json:"superClass"`
+	}
+
+	v.plain = (*plain)(n)
+
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	if x, err := decodeExpression(v.SuperClass); err != nil {
+		return err
+	} else {
+		n.SuperClass = x
+	}
+
+	return nil
+}
+
+// NewClassDeclaration returns a new ClassDeclaration, or a *FieldError if one of the values
+// given can't go in it.
+func NewClassDeclaration(id *Identifier, superClass Expression, body *ClassBody, decorators []*Decorator) (*ClassDeclaration, error) {
+	if missing(id) {
+		return nil, &FieldError{Type: "ClassDeclaration", Field: "id", Value: nil}
+	}
+	if missing(body) {
+		return nil, &FieldError{Type: "ClassDeclaration", Field: "body", Value: nil}
+	}
+	for _, e := range decorators {
+		if missing(e) {
+			return nil, &FieldError{Type: "ClassDeclaration", Field: "decorators", Value: nil}
+		}
+	}
+
+	n := &ClassDeclaration{ID: id, SuperClass: superClass, Body: body, Decorators: decorators}
+	n.Type = "ClassDeclaration"
+
+	return n, nil
 }
 
 type ClassExpression struct {
-	Class
-	Expression
+	BaseNode
+	ID         *Identifier  `json:"id"`
+	SuperClass Expression   `json:"superClass"`
+	Body       *ClassBody   `json:"body"`
+	Decorators []*Decorator `json:"decorators"`
+}
+
+func (*ClassExpression) classNode()                         {}
+func (*ClassExpression) expressionNode()                    {}
+func (*ClassExpression) isVariableDeclarationOrExpression() {}
+func (*ClassExpression) isBlockStatementOrExpression()      {}
+func (*ClassExpression) isExpressionOrSpreadElement()       {}
+func (*ClassExpression) isExpressionOrPrivateName()         {}
+func (*ClassExpression) isPatternOrExpression()             {}
+func (*ClassExpression) isExpressionOrSuper()               {}
+func (*ClassExpression) isDeclarationOrExpression()         {}
+
+func (n ClassExpression) MarshalJSON() ([]byte, error) {
+	type plain ClassExpression
+	v := plain(n)
+	v.Type = "ClassExpression"
+	if v.Decorators == nil {
+		v.Decorators = []*Decorator{}
+	}
+	return json.Marshal(v)
+}
+
+func (n *ClassExpression) UnmarshalJSON(b []byte) error {
+	type plain ClassExpression
+	var v struct {
+		*plain
+		SuperClass json.RawMessage `json:"superClass"`
+	}
+
+	v.plain = (*plain)(n)
+
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	if x, err := decodeExpression(v.SuperClass); err != nil {
+		return err
+	} else {
+		n.SuperClass = x
+	}
+
+	return nil
+}
+
+// NewClassExpression returns a new ClassExpression, or a *FieldError if one of the values
+// given can't go in it.
+func NewClassExpression(id *Identifier, superClass Expression, body *ClassBody, decorators []*Decorator) (*ClassExpression, error) {
+	if missing(body) {
+		return nil, &FieldError{Type: "ClassExpression", Field: "body", Value: nil}
+	}
+	for _, e := range decorators {
+		if missing(e) {
+			return nil, &FieldError{Type: "ClassExpression", Field: "decorators", Value: nil}
+		}
+	}
+
+	n := &ClassExpression{ID: id, SuperClass: superClass, Body: body, Decorators: decorators}
+	n.Type = "ClassExpression"
+
+	return n, nil
 }
 
 type MetaProperty struct {
-	Expression
-	Meta     Identifier `json:"meta"`
-	Property Identifier `json:"property"`
+	BaseNode
+	Meta     *Identifier `json:"meta"`
+	Property *Identifier `json:"property"`
+}
+
+func (*MetaProperty) expressionNode()                    {}
+func (*MetaProperty) isVariableDeclarationOrExpression() {}
+func (*MetaProperty) isBlockStatementOrExpression()      {}
+func (*MetaProperty) isExpressionOrSpreadElement()       {}
+func (*MetaProperty) isExpressionOrPrivateName()         {}
+func (*MetaProperty) isPatternOrExpression()             {}
+func (*MetaProperty) isExpressionOrSuper()               {}
+func (*MetaProperty) isDeclarationOrExpression()         {}
+
+func (n MetaProperty) MarshalJSON() ([]byte, error) {
+	type plain MetaProperty
+	v := plain(n)
+	v.Type = "MetaProperty"
+	return json.Marshal(v)
+}
+
+// NewMetaProperty returns a new MetaProperty, or a *FieldError if one of the values
+// given can't go in it.
+func NewMetaProperty(meta *Identifier, property *Identifier) (*MetaProperty, error) {
+	if missing(meta) {
+		return nil, &FieldError{Type: "MetaProperty", Field: "meta", Value: nil}
+	}
+	if missing(property) {
+		return nil, &FieldError{Type: "MetaProperty", Field: "property", Value: nil}
+	}
+
+	n := &MetaProperty{Meta: meta, Property: property}
+	n.Type = "MetaProperty"
+
+	return n, nil
 }
 
-type ModuleDeclaration struct {
+type ModuleDeclaration interface {
 	Node
+	moduleDeclarationNode()
 }
 
-type ModuleSpecifier struct {
+type ModuleSpecifier interface {
 	Node
-	Local Identifier `json:"local"`
+	moduleSpecifierNode()
 }
 
 type ImportSpecifierOrImportDefaultSpecifierOrImportNamespaceSpecifier interface {
-	IsImportSpecifierOrImportDefaultSpecifierOrImportNamespaceSpecifier() bool
+	Node
+	isImportSpecifierOrImportDefaultSpecifierOrImportNamespaceSpecifier()
 }
 
-func (ImportSpecifier) IsImportSpecifierOrImportDefaultSpecifierOrImportNamespaceSpecifier() bool {
-	return true
+type ImportDeclaration struct {
+	BaseNode
+	Specifiers []ImportSpecifierOrImportDefaultSpecifierOrImportNamespaceSpecifier `json:"specifiers"`
+	Source     Literal                                                             `json:"source"`
+	Attributes []*ImportAttribute                                                  `json:"attributes"`
 }
-func (ImportDefaultSpecifier) IsImportSpecifierOrImportDefaultSpecifierOrImportNamespaceSpecifier() bool {
-	return true
+
+func (*ImportDeclaration) moduleDeclarationNode()          {}
+func (*ImportDeclaration) isStatementOrModuleDeclaration() {}
+
+func (n ImportDeclaration) MarshalJSON() ([]byte, error) {
+	type plain ImportDeclaration
+	v := plain(n)
+	v.Type = "ImportDeclaration"
+	if v.Specifiers == nil {
+		v.Specifiers = []ImportSpecifierOrImportDefaultSpecifierOrImportNamespaceSpecifier{}
+	}
+	if v.Attributes == nil {
+		v.Attributes = []*ImportAttribute{}
+	}
+	return json.Marshal(v)
 }
-func (ImportNamespaceSpecifier) IsImportSpecifierOrImportDefaultSpecifierOrImportNamespaceSpecifier() bool {
-	return true
+
+func (n *ImportDeclaration) UnmarshalJSON(b []byte) error {
+	type plain ImportDeclaration
+	var v struct {
+		*plain
+		Specifiers []json.RawMessage `json:"specifiers"`
+		Source     json.RawMessage   `json:"source"`
+	}
+
+	v.plain = (*plain)(n)
+
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	if v.Specifiers != nil {
+		n.Specifiers = make([]ImportSpecifierOrImportDefaultSpecifierOrImportNamespaceSpecifier, len(v.Specifiers))
+	}
+	for i, e := range v.Specifiers {
+		x, err := decodeImportSpecifierOrImportDefaultSpecifierOrImportNamespaceSpecifier(e)
+		if err != nil {
+			return err
+		}
+
+		n.Specifiers[i] = x
+	}
+
+	if x, err := decodeLiteral(v.Source); err != nil {
+		return err
+	} else {
+		n.Source = x
+	}
+
+	return nil
 }
 
-type ImportDeclaration struct {
-	ModuleDeclaration
-	Specifiers []ImportSpecifierOrImportDefaultSpecifierOrImportNamespaceSpecifier `json:"specifiers"`
-	Source     Literal                                                             `json:"source"`
+// NewImportDeclaration returns a new ImportDeclaration, or a *FieldError if one of the values
+// given can't go in it.
+func NewImportDeclaration(specifiers []ImportSpecifierOrImportDefaultSpecifierOrImportNamespaceSpecifier, source Literal, attributes []*ImportAttribute) (*ImportDeclaration, error) {
+	for _, e := range specifiers {
+		if missing(e) {
+			return nil, &FieldError{Type: "ImportDeclaration", Field: "specifiers", Value: nil}
+		}
+	}
+	if missing(source) {
+		return nil, &FieldError{Type: "ImportDeclaration", Field: "source", Value: nil}
+	}
+	for _, e := range attributes {
+		if missing(e) {
+			return nil, &FieldError{Type: "ImportDeclaration", Field: "attributes", Value: nil}
+		}
+	}
+
+	n := &ImportDeclaration{Specifiers: specifiers, Source: source, Attributes: attributes}
+	n.Type = "ImportDeclaration"
+
+	return n, nil
 }
 
 type ImportSpecifier struct {
-	ModuleSpecifier
-	Imported Identifier `json:"imported"`
+	BaseNode
+	Local    *Identifier `json:"local"`
+	Imported *Identifier `json:"imported"`
+}
+
+func (*ImportSpecifier) moduleSpecifierNode()                                                 {}
+func (*ImportSpecifier) isImportSpecifierOrImportDefaultSpecifierOrImportNamespaceSpecifier() {}
+
+func (n ImportSpecifier) MarshalJSON() ([]byte, error) {
+	type plain ImportSpecifier
+	v := plain(n)
+	v.Type = "ImportSpecifier"
+	return json.Marshal(v)
+}
+
+// NewImportSpecifier returns a new ImportSpecifier, or a *FieldError if one of the values
+// given can't go in it.
+func NewImportSpecifier(local *Identifier, imported *Identifier) (*ImportSpecifier, error) {
+	if missing(local) {
+		return nil, &FieldError{Type: "ImportSpecifier", Field: "local", Value: nil}
+	}
+	if missing(imported) {
+		return nil, &FieldError{Type: "ImportSpecifier", Field: "imported", Value: nil}
+	}
+
+	n := &ImportSpecifier{Local: local, Imported: imported}
+	n.Type = "ImportSpecifier"
+
+	return n, nil
 }
 
 type ImportDefaultSpecifier struct {
-	ModuleSpecifier
+	BaseNode
+	Local *Identifier `json:"local"`
+}
+
+func (*ImportDefaultSpecifier) moduleSpecifierNode() {}
+func (*ImportDefaultSpecifier) isImportSpecifierOrImportDefaultSpecifierOrImportNamespaceSpecifier() {
+}
+
+func (n ImportDefaultSpecifier) MarshalJSON() ([]byte, error) {
+	type plain ImportDefaultSpecifier
+	v := plain(n)
+	v.Type = "ImportDefaultSpecifier"
+	return json.Marshal(v)
+}
+
+// NewImportDefaultSpecifier returns a new ImportDefaultSpecifier, or a *FieldError if one of the values
+// given can't go in it.
+func NewImportDefaultSpecifier(local *Identifier) (*ImportDefaultSpecifier, error) {
+	if missing(local) {
+		return nil, &FieldError{Type: "ImportDefaultSpecifier", Field: "local", Value: nil}
+	}
+
+	n := &ImportDefaultSpecifier{Local: local}
+	n.Type = "ImportDefaultSpecifier"
+
+	return n, nil
 }
 
 type ImportNamespaceSpecifier struct {
-	ModuleSpecifier
+	BaseNode
+	Local *Identifier `json:"local"`
+}
+
+func (*ImportNamespaceSpecifier) moduleSpecifierNode() {}
+func (*ImportNamespaceSpecifier) isImportSpecifierOrImportDefaultSpecifierOrImportNamespaceSpecifier() {
+}
+
+func (n ImportNamespaceSpecifier) MarshalJSON() ([]byte, error) {
+	type plain ImportNamespaceSpecifier
+	v := plain(n)
+	v.Type = "ImportNamespaceSpecifier"
+	return json.Marshal(v)
+}
+
+// NewImportNamespaceSpecifier returns a new ImportNamespaceSpecifier, or a *FieldError if one of the values
+// given can't go in it.
+func NewImportNamespaceSpecifier(local *Identifier) (*ImportNamespaceSpecifier, error) {
+	if missing(local) {
+		return nil, &FieldError{Type: "ImportNamespaceSpecifier", Field: "local", Value: nil}
+	}
+
+	n := &ImportNamespaceSpecifier{Local: local}
+	n.Type = "ImportNamespaceSpecifier"
+
+	return n, nil
+}
+
+type IdentifierOrStringLiteral interface {
+	Node
+	isIdentifierOrStringLiteral()
+}
+
+type ImportAttribute struct {
+	BaseNode
+	Key   IdentifierOrStringLiteral `json:"key"`
+	Value *StringLiteral            `json:"value"`
+}
+
+func (n ImportAttribute) MarshalJSON() ([]byte, error) {
+	type plain ImportAttribute
+	v := plain(n)
+	v.Type = "ImportAttribute"
+	return json.Marshal(v)
+}
+
+func (n *ImportAttribute) UnmarshalJSON(b []byte) error {
+	type plain ImportAttribute
+	var v struct {
+		*plain
+		Key json.RawMessage `json:"key"`
+	}
+
+	v.plain = (*plain)(n)
+
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	if x, err := decodeIdentifierOrStringLiteral(v.Key); err != nil {
+		return err
+	} else {
+		n.Key = x
+	}
+
+	return nil
+}
+
+// NewImportAttribute returns a new ImportAttribute, or a *FieldError if one of the values
+// given can't go in it.
+func NewImportAttribute(key IdentifierOrStringLiteral, value *StringLiteral) (*ImportAttribute, error) {
+	if missing(key) {
+		return nil, &FieldError{Type: "ImportAttribute", Field: "key", Value: nil}
+	}
+	if missing(value) {
+		return nil, &FieldError{Type: "ImportAttribute", Field: "value", Value: nil}
+	}
+
+	n := &ImportAttribute{Key: key, Value: value}
+	n.Type = "ImportAttribute"
+
+	return n, nil
 }
 
 type ExportNamedDeclaration struct {
-	ModuleDeclaration
-	Declaration *Declaration      `json:"declaration"`
-	Specifiers  []ExportSpecifier `json:"specifiers"`
-	Source      *Literal          `json:"source"`
+	BaseNode
+	Declaration Declaration        `json:"declaration"`
+	Specifiers  []*ExportSpecifier `json:"specifiers"`
+	Source      Literal            `json:"source"`
+	Attributes  []*ImportAttribute `json:"attributes"`
+}
+
+func (*ExportNamedDeclaration) moduleDeclarationNode()          {}
+func (*ExportNamedDeclaration) isStatementOrModuleDeclaration() {}
+
+func (n ExportNamedDeclaration) MarshalJSON() ([]byte, error) {
+	type plain ExportNamedDeclaration
+	v := plain(n)
+	v.Type = "ExportNamedDeclaration"
+	if v.Specifiers == nil {
+		v.Specifiers = []*ExportSpecifier{}
+	}
+	if v.Attributes == nil {
+		v.Attributes = []*ImportAttribute{}
+	}
+	return json.Marshal(v)
+}
+
+func (n *ExportNamedDeclaration) UnmarshalJSON(b []byte) error {
+	type plain ExportNamedDeclaration
+	var v struct {
+		*plain
+		Declaration json.RawMessage `json:"declaration"`
+		Source      json.RawMessage `json:"source"`
+	}
+
+	v.plain = (*plain)(n)
+
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	if x, err := decodeDeclaration(v.Declaration); err != nil {
+		return err
+	} else {
+		n.Declaration = x
+	}
+
+	if x, err := decodeLiteral(v.Source); err != nil {
+		return err
+	} else {
+		n.Source = x
+	}
+
+	return nil
+}
+
+// NewExportNamedDeclaration returns a new ExportNamedDeclaration, or a *FieldError if one of the values
+// given can't go in it.
+func NewExportNamedDeclaration(declaration Declaration, specifiers []*ExportSpecifier, source Literal, attributes []*ImportAttribute) (*ExportNamedDeclaration, error) {
+	for _, e := range specifiers {
+		if missing(e) {
+			return nil, &FieldError{Type: "ExportNamedDeclaration", Field: "specifiers", Value: nil}
+		}
+	}
+	for _, e := range attributes {
+		if missing(e) {
+			return nil, &FieldError{Type: "ExportNamedDeclaration", Field: "attributes", Value: nil}
+		}
+	}
+
+	n := &ExportNamedDeclaration{Declaration: declaration, Specifiers: specifiers, Source: source, Attributes: attributes}
+	n.Type = "ExportNamedDeclaration"
+
+	return n, nil
 }
 
 type ExportSpecifier struct {
-	ModuleSpecifier
-	Exported Identifier `json:"exported"`
+	BaseNode
+	Local    *Identifier `json:"local"`
+	Exported *Identifier `json:"exported"`
 }
 
-type DeclarationOrExpression interface {
-	IsDeclarationOrExpression() bool
+func (*ExportSpecifier) moduleSpecifierNode() {}
+
+func (n ExportSpecifier) MarshalJSON() ([]byte, error) {
+	type plain ExportSpecifier
+	v := plain(n)
+	v.Type = "ExportSpecifier"
+	return json.Marshal(v)
 }
 
-func (Declaration) IsDeclarationOrExpression() bool { return true }
-func (Expression) IsDeclarationOrExpression() bool  { return true }
+// NewExportSpecifier returns a new ExportSpecifier, or a *FieldError if one of the values
+// given can't go in it.
+func NewExportSpecifier(local *Identifier, exported *Identifier) (*ExportSpecifier, error) {
+	if missing(local) {
+		return nil, &FieldError{Type: "ExportSpecifier", Field: "local", Value: nil}
+	}
+	if missing(exported) {
+		return nil, &FieldError{Type: "ExportSpecifier", Field: "exported", Value: nil}
+	}
+
+	n := &ExportSpecifier{Local: local, Exported: exported}
+	n.Type = "ExportSpecifier"
+
+	return n, nil
+}
+
+type DeclarationOrExpression interface {
+	Node
+	isDeclarationOrExpression()
+}
 
 type ExportDefaultDeclaration struct {
-	ModuleDeclaration
+	BaseNode
 	Declaration DeclarationOrExpression `json:"declaration"`
 }
 
+func (*ExportDefaultDeclaration) moduleDeclarationNode()          {}
+func (*ExportDefaultDeclaration) isStatementOrModuleDeclaration() {}
+
+func (n ExportDefaultDeclaration) MarshalJSON() ([]byte, error) {
+	type plain ExportDefaultDeclaration
+	v := plain(n)
+	v.Type = "ExportDefaultDeclaration"
+	return json.Marshal(v)
+}
+
+func (n *ExportDefaultDeclaration) UnmarshalJSON(b []byte) error {
+	type plain ExportDefaultDeclaration
+	var v struct {
+		*plain
+		Declaration json.RawMessage `json:"declaration"`
+	}
+
+	v.plain = (*plain)(n)
+
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	if x, err := decodeDeclarationOrExpression(v.Declaration); err != nil {
+		return err
+	} else {
+		n.Declaration = x
+	}
+
+	return nil
+}
+
+// NewExportDefaultDeclaration returns a new ExportDefaultDeclaration, or a *FieldError if one of the values
+// given can't go in it.
+func NewExportDefaultDeclaration(declaration DeclarationOrExpression) (*ExportDefaultDeclaration, error) {
+	if missing(declaration) {
+		return nil, &FieldError{Type: "ExportDefaultDeclaration", Field: "declaration", Value: nil}
+	}
+
+	n := &ExportDefaultDeclaration{Declaration: declaration}
+	n.Type = "ExportDefaultDeclaration"
+
+	return n, nil
+}
+
 type ExportAllDeclaration struct {
-	ModuleDeclaration
-	Source Literal `json:"source"`
+	BaseNode
+	Source     Literal            `json:"source"`
+	Attributes []*ImportAttribute `json:"attributes"`
+}
+
+func (*ExportAllDeclaration) moduleDeclarationNode()          {}
+func (*ExportAllDeclaration) isStatementOrModuleDeclaration() {}
+
+func (n ExportAllDeclaration) MarshalJSON() ([]byte, error) {
+	type plain ExportAllDeclaration
+	v := plain(n)
+	v.Type = "ExportAllDeclaration"
+	if v.Attributes == nil {
+		v.Attributes = []*ImportAttribute{}
+	}
+	return json.Marshal(v)
+}
+
+func (n *ExportAllDeclaration) UnmarshalJSON(b []byte) error {
+	type plain ExportAllDeclaration
+	var v struct {
+		*plain
+		Source json.RawMessage `json:"source"`
+	}
+
+	v.plain = (*plain)(n)
+
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	if x, err := decodeLiteral(v.Source); err != nil {
+		return err
+	} else {
+		n.Source = x
+	}
+
+	return nil
+}
+
+// NewExportAllDeclaration returns a new ExportAllDeclaration, or a *FieldError if one of the values
+// given can't go in it.
+func NewExportAllDeclaration(source Literal, attributes []*ImportAttribute) (*ExportAllDeclaration, error) {
+	if missing(source) {
+		return nil, &FieldError{Type: "ExportAllDeclaration", Field: "source", Value: nil}
+	}
+	for _, e := range attributes {
+		if missing(e) {
+			return nil, &FieldError{Type: "ExportAllDeclaration", Field: "attributes", Value: nil}
+		}
+	}
+
+	n := &ExportAllDeclaration{Source: source, Attributes: attributes}
+	n.Type = "ExportAllDeclaration"
+
+	return n, nil
+}
+
+// nodeTypes has the concrete types for each value of the type field. Some
+// values have more than one, and the first one that fits is used.
+var nodeTypes = map[string][]func() Node{
+	"Identifier":               {func() Node { return &Identifier{} }},
+	"PrivateName":              {func() Node { return &PrivateName{} }},
+	"RegExpLiteral":            {func() Node { return &RegExpLiteral{} }},
+	"NullLiteral":              {func() Node { return &NullLiteral{} }},
+	"StringLiteral":            {func() Node { return &StringLiteral{} }},
+	"BooleanLiteral":           {func() Node { return &BooleanLiteral{} }},
+	"NumericLiteral":           {func() Node { return &NumericLiteral{} }},
+	"BigIntLiteral":            {func() Node { return &BigIntLiteral{} }},
+	"Program":                  {func() Node { return &Program{} }},
+	"ExpressionStatement":      {func() Node { return &ExpressionStatement{} }},
+	"BlockStatement":           {func() Node { return &BlockStatement{} }},
+	"EmptyStatement":           {func() Node { return &EmptyStatement{} }},
+	"DebuggerStatement":        {func() Node { return &DebuggerStatement{} }},
+	"WithStatement":            {func() Node { return &WithStatement{} }},
+	"ReturnStatement":          {func() Node { return &ReturnStatement{} }},
+	"LabeledStatement":         {func() Node { return &LabeledStatement{} }},
+	"BreakStatement":           {func() Node { return &BreakStatement{} }},
+	"ContinueStatement":        {func() Node { return &ContinueStatement{} }},
+	"IfStatement":              {func() Node { return &IfStatement{} }},
+	"SwitchStatement":          {func() Node { return &SwitchStatement{} }},
+	"SwitchCase":               {func() Node { return &SwitchCase{} }},
+	"ThrowStatement":           {func() Node { return &ThrowStatement{} }},
+	"TryStatement":             {func() Node { return &TryStatement{} }},
+	"CatchClause":              {func() Node { return &CatchClause{} }},
+	"WhileStatement":           {func() Node { return &WhileStatement{} }},
+	"DoWhileStatement":         {func() Node { return &DoWhileStatement{} }},
+	"ForStatement":             {func() Node { return &ForStatement{} }},
+	"ForInStatement":           {func() Node { return &ForInStatement{} }},
+	"ForOfStatement":           {func() Node { return &ForOfStatement{} }},
+	"FunctionDeclaration":      {func() Node { return &FunctionDeclaration{} }},
+	"VariableDeclaration":      {func() Node { return &VariableDeclaration{} }},
+	"VariableDeclarator":       {func() Node { return &VariableDeclarator{} }},
+	"Decorator":                {func() Node { return &Decorator{} }},
+	"Directive":                {func() Node { return &Directive{} }},
+	"DirectiveLiteral":         {func() Node { return &DirectiveLiteral{} }},
+	"Super":                    {func() Node { return &Super{} }},
+	"ThisExpression":           {func() Node { return &ThisExpression{} }},
+	"ArrowFunctionExpression":  {func() Node { return &ArrowFunctionExpression{} }},
+	"YieldExpression":          {func() Node { return &YieldExpression{} }},
+	"AwaitExpression":          {func() Node { return &AwaitExpression{} }},
+	"ArrayExpression":          {func() Node { return &ArrayExpression{} }},
+	"ObjectExpression":         {func() Node { return &ObjectExpression{} }},
+	"ObjectProperty":           {func() Node { return &ObjectProperty{} }, func() Node { return &AssignmentProperty{} }},
+	"ObjectMethod":             {func() Node { return &ObjectMethod{} }},
+	"RestProperty":             {func() Node { return &RestProperty{} }},
+	"SpreadProperty":           {func() Node { return &SpreadProperty{} }},
+	"FunctionExpression":       {func() Node { return &FunctionExpression{} }},
+	"UnaryExpression":          {func() Node { return &UnaryExpression{} }},
+	"UpdateExpression":         {func() Node { return &UpdateExpression{} }},
+	"BinaryExpression":         {func() Node { return &BinaryExpression{} }},
+	"AssignmentExpression":     {func() Node { return &AssignmentExpression{} }},
+	"LogicalExpression":        {func() Node { return &LogicalExpression{} }},
+	"SpreadElement":            {func() Node { return &SpreadElement{} }},
+	"MemberExpression":         {func() Node { return &MemberExpression{} }},
+	"OptionalMemberExpression": {func() Node { return &OptionalMemberExpression{} }},
+	"BindExpression":           {func() Node { return &BindExpression{} }},
+	"ConditionalExpression":    {func() Node { return &ConditionalExpression{} }},
+	"CallExpression":           {func() Node { return &CallExpression{} }},
+	"OptionalCallExpression":   {func() Node { return &OptionalCallExpression{} }},
+	"NewExpression":            {func() Node { return &NewExpression{} }},
+	"SequenceExpression":       {func() Node { return &SequenceExpression{} }},
+	"ImportExpression":         {func() Node { return &ImportExpression{} }},
+	"TemplateLiteral":          {func() Node { return &TemplateLiteral{} }},
+	"TaggedTemplateExpression": {func() Node { return &TaggedTemplateExpression{} }},
+	"TemplateElement":          {func() Node { return &TemplateElement{} }},
+	"ObjectPattern":            {func() Node { return &ObjectPattern{} }},
+	"ArrayPattern":             {func() Node { return &ArrayPattern{} }},
+	"RestElement":              {func() Node { return &RestElement{} }},
+	"AssignmentPattern":        {func() Node { return &AssignmentPattern{} }},
+	"ClassBody":                {func() Node { return &ClassBody{} }},
+	"ClassMethod":              {func() Node { return &ClassMethod{} }},
+	"ClassPrivateMethod":       {func() Node { return &ClassPrivateMethod{} }},
+	"ClassProperty":            {func() Node { return &ClassProperty{} }},
+	"ClassPrivateProperty":     {func() Node { return &ClassPrivateProperty{} }},
+	"StaticBlock":              {func() Node { return &StaticBlock{} }},
+	"ClassDeclaration":         {func() Node { return &ClassDeclaration{} }},
+	"ClassExpression":          {func() Node { return &ClassExpression{} }},
+	"MetaProperty":             {func() Node { return &MetaProperty{} }},
+	"ImportDeclaration":        {func() Node { return &ImportDeclaration{} }},
+	"ImportSpecifier":          {func() Node { return &ImportSpecifier{} }},
+	"ImportDefaultSpecifier":   {func() Node { return &ImportDefaultSpecifier{} }},
+	"ImportNamespaceSpecifier": {func() Node { return &ImportNamespaceSpecifier{} }},
+	"ImportAttribute":          {func() Node { return &ImportAttribute{} }},
+	"ExportNamedDeclaration":   {func() Node { return &ExportNamedDeclaration{} }},
+	"ExportSpecifier":          {func() Node { return &ExportSpecifier{} }},
+	"ExportDefaultDeclaration": {func() Node { return &ExportDefaultDeclaration{} }},
+	"ExportAllDeclaration":     {func() Node { return &ExportAllDeclaration{} }},
+}
+
+func decodeLiteral(b []byte) (Literal, error) {
+	v, err := decodeNode(b, func(n Node) bool { _, ok := n.(Literal); return ok })
+	if v == nil || err != nil {
+		return nil, err
+	}
+
+	return v.(Literal), nil
+}
+
+func decodeStatement(b []byte) (Statement, error) {
+	v, err := decodeNode(b, func(n Node) bool { _, ok := n.(Statement); return ok })
+	if v == nil || err != nil {
+		return nil, err
+	}
+
+	return v.(Statement), nil
+}
+
+func decodeDeclaration(b []byte) (Declaration, error) {
+	v, err := decodeNode(b, func(n Node) bool { _, ok := n.(Declaration); return ok })
+	if v == nil || err != nil {
+		return nil, err
+	}
+
+	return v.(Declaration), nil
+}
+
+func decodeExpression(b []byte) (Expression, error) {
+	v, err := decodeNode(b, func(n Node) bool { _, ok := n.(Expression); return ok })
+	if v == nil || err != nil {
+		return nil, err
+	}
+
+	return v.(Expression), nil
+}
+
+func decodePattern(b []byte) (Pattern, error) {
+	v, err := decodeNode(b, func(n Node) bool { _, ok := n.(Pattern); return ok })
+	if v == nil || err != nil {
+		return nil, err
+	}
+
+	return v.(Pattern), nil
+}
+
+func decodeStatementOrModuleDeclaration(b []byte) (StatementOrModuleDeclaration, error) {
+	v, err := decodeNode(b, func(n Node) bool { _, ok := n.(StatementOrModuleDeclaration); return ok })
+	if v == nil || err != nil {
+		return nil, err
+	}
+
+	return v.(StatementOrModuleDeclaration), nil
+}
+
+func decodeVariableDeclarationOrExpression(b []byte) (VariableDeclarationOrExpression, error) {
+	v, err := decodeNode(b, func(n Node) bool { _, ok := n.(VariableDeclarationOrExpression); return ok })
+	if v == nil || err != nil {
+		return nil, err
+	}
+
+	return v.(VariableDeclarationOrExpression), nil
+}
+
+func decodeBlockStatementOrExpression(b []byte) (BlockStatementOrExpression, error) {
+	v, err := decodeNode(b, func(n Node) bool { _, ok := n.(BlockStatementOrExpression); return ok })
+	if v == nil || err != nil {
+		return nil, err
+	}
+
+	return v.(BlockStatementOrExpression), nil
+}
+
+func decodeExpressionOrSpreadElement(b []byte) (ExpressionOrSpreadElement, error) {
+	v, err := decodeNode(b, func(n Node) bool { _, ok := n.(ExpressionOrSpreadElement); return ok })
+	if v == nil || err != nil {
+		return nil, err
+	}
+
+	return v.(ExpressionOrSpreadElement), nil
+}
+
+func decodeObjectPropertyOrObjectMethodOrSpreadProperty(b []byte) (ObjectPropertyOrObjectMethodOrSpreadProperty, error) {
+	v, err := decodeNode(b, func(n Node) bool { _, ok := n.(ObjectPropertyOrObjectMethodOrSpreadProperty); return ok })
+	if v == nil || err != nil {
+		return nil, err
+	}
+
+	return v.(ObjectPropertyOrObjectMethodOrSpreadProperty), nil
+}
+
+func decodeExpressionOrPrivateName(b []byte) (ExpressionOrPrivateName, error) {
+	v, err := decodeNode(b, func(n Node) bool { _, ok := n.(ExpressionOrPrivateName); return ok })
+	if v == nil || err != nil {
+		return nil, err
+	}
+
+	return v.(ExpressionOrPrivateName), nil
+}
+
+func decodePatternOrExpression(b []byte) (PatternOrExpression, error) {
+	v, err := decodeNode(b, func(n Node) bool { _, ok := n.(PatternOrExpression); return ok })
+	if v == nil || err != nil {
+		return nil, err
+	}
+
+	return v.(PatternOrExpression), nil
+}
+
+func decodeExpressionOrSuper(b []byte) (ExpressionOrSuper, error) {
+	v, err := decodeNode(b, func(n Node) bool { _, ok := n.(ExpressionOrSuper); return ok })
+	if v == nil || err != nil {
+		return nil, err
+	}
+
+	return v.(ExpressionOrSuper), nil
+}
+
+func decodeAssignmentPropertyOrRestProperty(b []byte) (AssignmentPropertyOrRestProperty, error) {
+	v, err := decodeNode(b, func(n Node) bool { _, ok := n.(AssignmentPropertyOrRestProperty); return ok })
+	if v == nil || err != nil {
+		return nil, err
+	}
+
+	return v.(AssignmentPropertyOrRestProperty), nil
+}
+
+func decodeClassMethodOrClassPrivateMethodOrClassPropertyOrClassPrivatePropertyOrStaticBlock(b []byte) (ClassMethodOrClassPrivateMethodOrClassPropertyOrClassPrivatePropertyOrStaticBlock, error) {
+	v, err := decodeNode(b, func(n Node) bool {
+		_, ok := n.(ClassMethodOrClassPrivateMethodOrClassPropertyOrClassPrivatePropertyOrStaticBlock)
+		return ok
+	})
+	if v == nil || err != nil {
+		return nil, err
+	}
+
+	return v.(ClassMethodOrClassPrivateMethodOrClassPropertyOrClassPrivatePropertyOrStaticBlock), nil
+}
+
+func decodeImportSpecifierOrImportDefaultSpecifierOrImportNamespaceSpecifier(b []byte) (ImportSpecifierOrImportDefaultSpecifierOrImportNamespaceSpecifier, error) {
+	v, err := decodeNode(b, func(n Node) bool {
+		_, ok := n.(ImportSpecifierOrImportDefaultSpecifierOrImportNamespaceSpecifier)
+		return ok
+	})
+	if v == nil || err != nil {
+		return nil, err
+	}
+
+	return v.(ImportSpecifierOrImportDefaultSpecifierOrImportNamespaceSpecifier), nil
+}
+
+func decodeIdentifierOrStringLiteral(b []byte) (IdentifierOrStringLiteral, error) {
+	v, err := decodeNode(b, func(n Node) bool { _, ok := n.(IdentifierOrStringLiteral); return ok })
+	if v == nil || err != nil {
+		return nil, err
+	}
+
+	return v.(IdentifierOrStringLiteral), nil
+}
+
+func decodeDeclarationOrExpression(b []byte) (DeclarationOrExpression, error) {
+	v, err := decodeNode(b, func(n Node) bool { _, ok := n.(DeclarationOrExpression); return ok })
+	if v == nil || err != nil {
+		return nil, err
+	}
+
+	return v.(DeclarationOrExpression), nil
+}
+
+func (*Unknown) literalNode()                                    {}
+func (*Unknown) functionNode()                                   {}
+func (*Unknown) statementNode()                                  {}
+func (*Unknown) declarationNode()                                {}
+func (*Unknown) expressionNode()                                 {}
+func (*Unknown) objectMemberNode()                               {}
+func (*Unknown) patternNode()                                    {}
+func (*Unknown) classNode()                                      {}
+func (*Unknown) moduleDeclarationNode()                          {}
+func (*Unknown) moduleSpecifierNode()                            {}
+func (*Unknown) isStatementOrModuleDeclaration()                 {}
+func (*Unknown) isVariableDeclarationOrExpression()              {}
+func (*Unknown) isBlockStatementOrExpression()                   {}
+func (*Unknown) isExpressionOrSpreadElement()                    {}
+func (*Unknown) isObjectPropertyOrObjectMethodOrSpreadProperty() {}
+func (*Unknown) isExpressionOrPrivateName()                      {}
+func (*Unknown) isPatternOrExpression()                          {}
+func (*Unknown) isExpressionOrSuper()                            {}
+func (*Unknown) isAssignmentPropertyOrRestProperty()             {}
+func (*Unknown) isClassMethodOrClassPrivateMethodOrClassPropertyOrClassPrivatePropertyOrStaticBlock() {
+}
+func (*Unknown) isImportSpecifierOrImportDefaultSpecifierOrImportNamespaceSpecifier() {}
+func (*Unknown) isIdentifierOrStringLiteral()                                         {}
+func (*Unknown) isDeclarationOrExpression()                                           {}
+
+// A Visitor's Visit method is invoked for each node encountered by Walk.
+// If the result visitor w is not nil, Walk visits each of the children
+// of node with the visitor w, followed by a call of w.Visit(nil).
+type Visitor interface {
+	Visit(node Node) (w Visitor)
+}
+
+// Walk traverses an AST in depth-first order, visiting the children of
+// each node in the order they're written in source. It starts by calling
+// v.Visit(node); node must not be nil.
+func Walk(v Visitor, node Node) {
+	if v = v.Visit(node); v == nil {
+		return
+	}
+
+	switch n := node.(type) {
+	case *PrivateName:
+		if n.ID != nil {
+			Walk(v, n.ID)
+		}
+	case *Program:
+		for _, e := range n.Directives {
+			if e != nil {
+				Walk(v, e)
+			}
+		}
+		for _, e := range n.Body {
+			if e != nil {
+				Walk(v, e)
+			}
+		}
+	case *ExpressionStatement:
+		if n.Expression != nil {
+			Walk(v, n.Expression)
+		}
+	case *BlockStatement:
+		for _, e := range n.Directives {
+			if e != nil {
+				Walk(v, e)
+			}
+		}
+		for _, e := range n.Body {
+			if e != nil {
+				Walk(v, e)
+			}
+		}
+	case *WithStatement:
+		if n.Object != nil {
+			Walk(v, n.Object)
+		}
+		if n.Body != nil {
+			Walk(v, n.Body)
+		}
+	case *ReturnStatement:
+		if n.Argument != nil {
+			Walk(v, n.Argument)
+		}
+	case *LabeledStatement:
+		if n.Label != nil {
+			Walk(v, n.Label)
+		}
+		if n.Body != nil {
+			Walk(v, n.Body)
+		}
+	case *BreakStatement:
+		if n.Label != nil {
+			Walk(v, n.Label)
+		}
+	case *ContinueStatement:
+		if n.Label != nil {
+			Walk(v, n.Label)
+		}
+	case *IfStatement:
+		if n.Test != nil {
+			Walk(v, n.Test)
+		}
+		if n.Consequent != nil {
+			Walk(v, n.Consequent)
+		}
+		if n.Alternate != nil {
+			Walk(v, n.Alternate)
+		}
+	case *SwitchStatement:
+		if n.Discriminant != nil {
+			Walk(v, n.Discriminant)
+		}
+		for _, e := range n.Cases {
+			if e != nil {
+				Walk(v, e)
+			}
+		}
+	case *SwitchCase:
+		if n.Test != nil {
+			Walk(v, n.Test)
+		}
+		for _, e := range n.Consequent {
+			if e != nil {
+				Walk(v, e)
+			}
+		}
+	case *ThrowStatement:
+		if n.Argument != nil {
+			Walk(v, n.Argument)
+		}
+	case *TryStatement:
+		if n.Block != nil {
+			Walk(v, n.Block)
+		}
+		if n.Handler != nil {
+			Walk(v, n.Handler)
+		}
+		if n.Finalizer != nil {
+			Walk(v, n.Finalizer)
+		}
+	case *CatchClause:
+		if n.Param != nil {
+			Walk(v, n.Param)
+		}
+		if n.Body != nil {
+			Walk(v, n.Body)
+		}
+	case *WhileStatement:
+		if n.Test != nil {
+			Walk(v, n.Test)
+		}
+		if n.Body != nil {
+			Walk(v, n.Body)
+		}
+	case *DoWhileStatement:
+		if n.Body != nil {
+			Walk(v, n.Body)
+		}
+		if n.Test != nil {
+			Walk(v, n.Test)
+		}
+	case *ForStatement:
+		if n.Init != nil {
+			Walk(v, n.Init)
+		}
+		if n.Test != nil {
+			Walk(v, n.Test)
+		}
+		if n.Update != nil {
+			Walk(v, n.Update)
+		}
+		if n.Body != nil {
+			Walk(v, n.Body)
+		}
+	case *ForInStatement:
+		if n.Left != nil {
+			Walk(v, n.Left)
+		}
+		if n.Right != nil {
+			Walk(v, n.Right)
+		}
+		if n.Body != nil {
+			Walk(v, n.Body)
+		}
+	case *ForOfStatement:
+		if n.Left != nil {
+			Walk(v, n.Left)
+		}
+		if n.Right != nil {
+			Walk(v, n.Right)
+		}
+		if n.Body != nil {
+			Walk(v, n.Body)
+		}
+	case *FunctionDeclaration:
+		if n.ID != nil {
+			Walk(v, n.ID)
+		}
+		for _, e := range n.Params {
+			if e != nil {
+				Walk(v, e)
+			}
+		}
+		if n.Body != nil {
+			Walk(v, n.Body)
+		}
+	case *VariableDeclaration:
+		for _, e := range n.Declarations {
+			if e != nil {
+				Walk(v, e)
+			}
+		}
+	case *VariableDeclarator:
+		if n.ID != nil {
+			Walk(v, n.ID)
+		}
+		if n.Init != nil {
+			Walk(v, n.Init)
+		}
+	case *Decorator:
+		if n.Expression != nil {
+			Walk(v, n.Expression)
+		}
+	case *Directive:
+		if n.Value != nil {
+			Walk(v, n.Value)
+		}
+	case *ArrowFunctionExpression:
+		if n.ID != nil {
+			Walk(v, n.ID)
+		}
+		for _, e := range n.Params {
+			if e != nil {
+				Walk(v, e)
+			}
+		}
+		if n.Body != nil {
+			Walk(v, n.Body)
+		}
+	case *YieldExpression:
+		if n.Argument != nil {
+			Walk(v, n.Argument)
+		}
+	case *AwaitExpression:
+		if n.Argument != nil {
+			Walk(v, n.Argument)
+		}
+	case *ArrayExpression:
+		for _, e := range n.Elements {
+			if e != nil {
+				Walk(v, e)
+			}
+		}
+	case *ObjectExpression:
+		for _, e := range n.Properties {
+			if e != nil {
+				Walk(v, e)
+			}
+		}
+	case *ObjectProperty:
+		for _, e := range n.Decorators {
+			if e != nil {
+				Walk(v, e)
+			}
+		}
+		if n.Key != nil {
+			Walk(v, n.Key)
+		}
+		if n.Value != nil {
+			Walk(v, n.Value)
+		}
+	case *ObjectMethod:
+		for _, e := range n.Decorators {
+			if e != nil {
+				Walk(v, e)
+			}
+		}
+		if n.Key != nil {
+			Walk(v, n.Key)
+		}
+		if n.Value != nil {
+			Walk(v, n.Value)
+		}
+		if n.ID != nil {
+			Walk(v, n.ID)
+		}
+		for _, e := range n.Params {
+			if e != nil {
+				Walk(v, e)
+			}
+		}
+		if n.Body != nil {
+			Walk(v, n.Body)
+		}
+	case *RestProperty:
+		if n.Argument != nil {
+			Walk(v, n.Argument)
+		}
+	case *SpreadProperty:
+		if n.Argument != nil {
+			Walk(v, n.Argument)
+		}
+	case *FunctionExpression:
+		if n.ID != nil {
+			Walk(v, n.ID)
+		}
+		for _, e := range n.Params {
+			if e != nil {
+				Walk(v, e)
+			}
+		}
+		if n.Body != nil {
+			Walk(v, n.Body)
+		}
+	case *UnaryExpression:
+		if n.Argument != nil {
+			Walk(v, n.Argument)
+		}
+	case *UpdateExpression:
+		if n.Argument != nil {
+			Walk(v, n.Argument)
+		}
+	case *BinaryExpression:
+		if n.Left != nil {
+			Walk(v, n.Left)
+		}
+		if n.Right != nil {
+			Walk(v, n.Right)
+		}
+	case *AssignmentExpression:
+		if n.Left != nil {
+			Walk(v, n.Left)
+		}
+		if n.Right != nil {
+			Walk(v, n.Right)
+		}
+	case *LogicalExpression:
+		if n.Left != nil {
+			Walk(v, n.Left)
+		}
+		if n.Right != nil {
+			Walk(v, n.Right)
+		}
+	case *SpreadElement:
+		if n.Argument != nil {
+			Walk(v, n.Argument)
+		}
+	case *MemberExpression:
+		if n.Object != nil {
+			Walk(v, n.Object)
+		}
+		if n.Property != nil {
+			Walk(v, n.Property)
+		}
+	case *OptionalMemberExpression:
+		if n.Object != nil {
+			Walk(v, n.Object)
+		}
+		if n.Property != nil {
+			Walk(v, n.Property)
+		}
+	case *BindExpression:
+		for _, e := range n.Object {
+			if e != nil {
+				Walk(v, e)
+			}
+		}
+		for _, e := range n.Callee {
+			if e != nil {
+				Walk(v, e)
+			}
+		}
+	case *ConditionalExpression:
+		if n.Test != nil {
+			Walk(v, n.Test)
+		}
+		if n.Consequent != nil {
+			Walk(v, n.Consequent)
+		}
+		if n.Alternate != nil {
+			Walk(v, n.Alternate)
+		}
+	case *CallExpression:
+		if n.Callee != nil {
+			Walk(v, n.Callee)
+		}
+		for _, e := range n.Arguments {
+			if e != nil {
+				Walk(v, e)
+			}
+		}
+	case *OptionalCallExpression:
+		if n.Callee != nil {
+			Walk(v, n.Callee)
+		}
+		for _, e := range n.Arguments {
+			if e != nil {
+				Walk(v, e)
+			}
+		}
+	case *NewExpression:
+		if n.Callee != nil {
+			Walk(v, n.Callee)
+		}
+		for _, e := range n.Arguments {
+			if e != nil {
+				Walk(v, e)
+			}
+		}
+	case *SequenceExpression:
+		for _, e := range n.Expressions {
+			if e != nil {
+				Walk(v, e)
+			}
+		}
+	case *ImportExpression:
+		if n.Source != nil {
+			Walk(v, n.Source)
+		}
+		if n.Options != nil {
+			Walk(v, n.Options)
+		}
+	case *TemplateLiteral:
+		for i, e := range n.Quasis {
+			if e != nil {
+				Walk(v, e)
+			}
+			if i < len(n.Expressions) && n.Expressions[i] != nil {
+				Walk(v, n.Expressions[i])
+			}
+		}
+	case *TaggedTemplateExpression:
+		if n.Tag != nil {
+			Walk(v, n.Tag)
+		}
+		if n.Quasi != nil {
+			Walk(v, n.Quasi)
+		}
+	case *AssignmentProperty:
+		for _, e := range n.Decorators {
+			if e != nil {
+				Walk(v, e)
+			}
+		}
+		if n.Key != nil {
+			Walk(v, n.Key)
+		}
+		if n.Value != nil {
+			Walk(v, n.Value)
+		}
+	case *ObjectPattern:
+		for _, e := range n.Properties {
+			if e != nil {
+				Walk(v, e)
+			}
+		}
+	case *ArrayPattern:
+		for _, e := range n.Elements {
+			if e != nil {
+				Walk(v, e)
+			}
+		}
+	case *RestElement:
+		if n.Argument != nil {
+			Walk(v, n.Argument)
+		}
+	case *AssignmentPattern:
+		if n.Left != nil {
+			Walk(v, n.Left)
+		}
+		if n.Right != nil {
+			Walk(v, n.Right)
+		}
+	case *ClassBody:
+		for _, e := range n.Body {
+			if e != nil {
+				Walk(v, e)
+			}
+		}
+	case *ClassMethod:
+		for _, e := range n.Decorators {
+			if e != nil {
+				Walk(v, e)
+			}
+		}
+		if n.Key != nil {
+			Walk(v, n.Key)
+		}
+		if n.Value != nil {
+			Walk(v, n.Value)
+		}
+	case *ClassPrivateMethod:
+		for _, e := range n.Decorators {
+			if e != nil {
+				Walk(v, e)
+			}
+		}
+		if n.Key != nil {
+			Walk(v, n.Key)
+		}
+		if n.Value != nil {
+			Walk(v, n.Value)
+		}
+	case *ClassProperty:
+		for _, e := range n.Decorators {
+			if e != nil {
+				Walk(v, e)
+			}
+		}
+		if n.Key != nil {
+			Walk(v, n.Key)
+		}
+		if n.Value != nil {
+			Walk(v, n.Value)
+		}
+	case *ClassPrivateProperty:
+		for _, e := range n.Decorators {
+			if e != nil {
+				Walk(v, e)
+			}
+		}
+		if n.Key != nil {
+			Walk(v, n.Key)
+		}
+		if n.Value != nil {
+			Walk(v, n.Value)
+		}
+	case *StaticBlock:
+		for _, e := range n.Body {
+			if e != nil {
+				Walk(v, e)
+			}
+		}
+	case *ClassDeclaration:
+		for _, e := range n.Decorators {
+			if e != nil {
+				Walk(v, e)
+			}
+		}
+		if n.ID != nil {
+			Walk(v, n.ID)
+		}
+		if n.SuperClass != nil {
+			Walk(v, n.SuperClass)
+		}
+		if n.Body != nil {
+			Walk(v, n.Body)
+		}
+	case *ClassExpression:
+		for _, e := range n.Decorators {
+			if e != nil {
+				Walk(v, e)
+			}
+		}
+		if n.ID != nil {
+			Walk(v, n.ID)
+		}
+		if n.SuperClass != nil {
+			Walk(v, n.SuperClass)
+		}
+		if n.Body != nil {
+			Walk(v, n.Body)
+		}
+	case *MetaProperty:
+		if n.Meta != nil {
+			Walk(v, n.Meta)
+		}
+		if n.Property != nil {
+			Walk(v, n.Property)
+		}
+	case *ImportDeclaration:
+		for _, e := range n.Specifiers {
+			if e != nil {
+				Walk(v, e)
+			}
+		}
+		if n.Source != nil {
+			Walk(v, n.Source)
+		}
+		for _, e := range n.Attributes {
+			if e != nil {
+				Walk(v, e)
+			}
+		}
+	case *ImportSpecifier:
+		if n.Imported != nil {
+			Walk(v, n.Imported)
+		}
+		if n.Local != nil {
+			Walk(v, n.Local)
+		}
+	case *ImportDefaultSpecifier:
+		if n.Local != nil {
+			Walk(v, n.Local)
+		}
+	case *ImportNamespaceSpecifier:
+		if n.Local != nil {
+			Walk(v, n.Local)
+		}
+	case *ImportAttribute:
+		if n.Key != nil {
+			Walk(v, n.Key)
+		}
+		if n.Value != nil {
+			Walk(v, n.Value)
+		}
+	case *ExportNamedDeclaration:
+		if n.Declaration != nil {
+			Walk(v, n.Declaration)
+		}
+		for _, e := range n.Specifiers {
+			if e != nil {
+				Walk(v, e)
+			}
+		}
+		if n.Source != nil {
+			Walk(v, n.Source)
+		}
+		for _, e := range n.Attributes {
+			if e != nil {
+				Walk(v, e)
+			}
+		}
+	case *ExportSpecifier:
+		if n.Local != nil {
+			Walk(v, n.Local)
+		}
+		if n.Exported != nil {
+			Walk(v, n.Exported)
+		}
+	case *ExportDefaultDeclaration:
+		if n.Declaration != nil {
+			Walk(v, n.Declaration)
+		}
+	case *ExportAllDeclaration:
+		if n.Source != nil {
+			Walk(v, n.Source)
+		}
+		for _, e := range n.Attributes {
+			if e != nil {
+				Walk(v, e)
+			}
+		}
+	}
+
+	v.Visit(nil)
+}
+
+type inspector func(Node) bool
+
+func (f inspector) Visit(node Node) Visitor {
+	if f(node) {
+		return f
+	}
+
+	return nil
+}
+
+// Inspect traverses an AST in depth-first order: It starts by calling
+// f(node); node must not be nil. If f returns true, Inspect invokes f
+// recursively for each of the non-nil children of node, followed by a
+// call of f(nil).
+func Inspect(node Node, f func(Node) bool) {
+	Walk(inspector(f), node)
+}
+
+func (a *application) children(node Node) {
+	switch n := node.(type) {
+	case *PrivateName:
+		a.apply(n, "ID", nil, n.ID)
+	case *Program:
+		a.applyList(n, "Directives")
+		a.applyList(n, "Body")
+	case *ExpressionStatement:
+		a.apply(n, "Expression", nil, n.Expression)
+	case *BlockStatement:
+		a.applyList(n, "Directives")
+		a.applyList(n, "Body")
+	case *WithStatement:
+		a.apply(n, "Object", nil, n.Object)
+		a.apply(n, "Body", nil, n.Body)
+	case *ReturnStatement:
+		a.apply(n, "Argument", nil, n.Argument)
+	case *LabeledStatement:
+		a.apply(n, "Label", nil, n.Label)
+		a.apply(n, "Body", nil, n.Body)
+	case *BreakStatement:
+		a.apply(n, "Label", nil, n.Label)
+	case *ContinueStatement:
+		a.apply(n, "Label", nil, n.Label)
+	case *IfStatement:
+		a.apply(n, "Test", nil, n.Test)
+		a.apply(n, "Consequent", nil, n.Consequent)
+		a.apply(n, "Alternate", nil, n.Alternate)
+	case *SwitchStatement:
+		a.apply(n, "Discriminant", nil, n.Discriminant)
+		a.applyList(n, "Cases")
+	case *SwitchCase:
+		a.apply(n, "Test", nil, n.Test)
+		a.applyList(n, "Consequent")
+	case *ThrowStatement:
+		a.apply(n, "Argument", nil, n.Argument)
+	case *TryStatement:
+		a.apply(n, "Block", nil, n.Block)
+		a.apply(n, "Handler", nil, n.Handler)
+		a.apply(n, "Finalizer", nil, n.Finalizer)
+	case *CatchClause:
+		a.apply(n, "Param", nil, n.Param)
+		a.apply(n, "Body", nil, n.Body)
+	case *WhileStatement:
+		a.apply(n, "Test", nil, n.Test)
+		a.apply(n, "Body", nil, n.Body)
+	case *DoWhileStatement:
+		a.apply(n, "Body", nil, n.Body)
+		a.apply(n, "Test", nil, n.Test)
+	case *ForStatement:
+		a.apply(n, "Init", nil, n.Init)
+		a.apply(n, "Test", nil, n.Test)
+		a.apply(n, "Update", nil, n.Update)
+		a.apply(n, "Body", nil, n.Body)
+	case *ForInStatement:
+		a.apply(n, "Left", nil, n.Left)
+		a.apply(n, "Right", nil, n.Right)
+		a.apply(n, "Body", nil, n.Body)
+	case *ForOfStatement:
+		a.apply(n, "Left", nil, n.Left)
+		a.apply(n, "Right", nil, n.Right)
+		a.apply(n, "Body", nil, n.Body)
+	case *FunctionDeclaration:
+		a.apply(n, "ID", nil, n.ID)
+		a.applyList(n, "Params")
+		a.apply(n, "Body", nil, n.Body)
+	case *VariableDeclaration:
+		a.applyList(n, "Declarations")
+	case *VariableDeclarator:
+		a.apply(n, "ID", nil, n.ID)
+		a.apply(n, "Init", nil, n.Init)
+	case *Decorator:
+		a.apply(n, "Expression", nil, n.Expression)
+	case *Directive:
+		a.apply(n, "Value", nil, n.Value)
+	case *ArrowFunctionExpression:
+		a.apply(n, "ID", nil, n.ID)
+		a.applyList(n, "Params")
+		a.apply(n, "Body", nil, n.Body)
+	case *YieldExpression:
+		a.apply(n, "Argument", nil, n.Argument)
+	case *AwaitExpression:
+		a.apply(n, "Argument", nil, n.Argument)
+	case *ArrayExpression:
+		a.applyList(n, "Elements")
+	case *ObjectExpression:
+		a.applyList(n, "Properties")
+	case *ObjectProperty:
+		a.applyList(n, "Decorators")
+		a.apply(n, "Key", nil, n.Key)
+		a.apply(n, "Value", nil, n.Value)
+	case *ObjectMethod:
+		a.applyList(n, "Decorators")
+		a.apply(n, "Key", nil, n.Key)
+		a.apply(n, "Value", nil, n.Value)
+		a.apply(n, "ID", nil, n.ID)
+		a.applyList(n, "Params")
+		a.apply(n, "Body", nil, n.Body)
+	case *RestProperty:
+		a.apply(n, "Argument", nil, n.Argument)
+	case *SpreadProperty:
+		a.apply(n, "Argument", nil, n.Argument)
+	case *FunctionExpression:
+		a.apply(n, "ID", nil, n.ID)
+		a.applyList(n, "Params")
+		a.apply(n, "Body", nil, n.Body)
+	case *UnaryExpression:
+		a.apply(n, "Argument", nil, n.Argument)
+	case *UpdateExpression:
+		a.apply(n, "Argument", nil, n.Argument)
+	case *BinaryExpression:
+		a.apply(n, "Left", nil, n.Left)
+		a.apply(n, "Right", nil, n.Right)
+	case *AssignmentExpression:
+		a.apply(n, "Left", nil, n.Left)
+		a.apply(n, "Right", nil, n.Right)
+	case *LogicalExpression:
+		a.apply(n, "Left", nil, n.Left)
+		a.apply(n, "Right", nil, n.Right)
+	case *SpreadElement:
+		a.apply(n, "Argument", nil, n.Argument)
+	case *MemberExpression:
+		a.apply(n, "Object", nil, n.Object)
+		a.apply(n, "Property", nil, n.Property)
+	case *OptionalMemberExpression:
+		a.apply(n, "Object", nil, n.Object)
+		a.apply(n, "Property", nil, n.Property)
+	case *BindExpression:
+		a.applyList(n, "Object")
+		a.applyList(n, "Callee")
+	case *ConditionalExpression:
+		a.apply(n, "Test", nil, n.Test)
+		a.apply(n, "Consequent", nil, n.Consequent)
+		a.apply(n, "Alternate", nil, n.Alternate)
+	case *CallExpression:
+		a.apply(n, "Callee", nil, n.Callee)
+		a.applyList(n, "Arguments")
+	case *OptionalCallExpression:
+		a.apply(n, "Callee", nil, n.Callee)
+		a.applyList(n, "Arguments")
+	case *NewExpression:
+		a.apply(n, "Callee", nil, n.Callee)
+		a.applyList(n, "Arguments")
+	case *SequenceExpression:
+		a.applyList(n, "Expressions")
+	case *ImportExpression:
+		a.apply(n, "Source", nil, n.Source)
+		a.apply(n, "Options", nil, n.Options)
+	case *TemplateLiteral:
+		a.applyInterleaved(n, "Quasis", "Expressions")
+	case *TaggedTemplateExpression:
+		a.apply(n, "Tag", nil, n.Tag)
+		a.apply(n, "Quasi", nil, n.Quasi)
+	case *AssignmentProperty:
+		a.applyList(n, "Decorators")
+		a.apply(n, "Key", nil, n.Key)
+		a.apply(n, "Value", nil, n.Value)
+	case *ObjectPattern:
+		a.applyList(n, "Properties")
+	case *ArrayPattern:
+		a.applyList(n, "Elements")
+	case *RestElement:
+		a.apply(n, "Argument", nil, n.Argument)
+	case *AssignmentPattern:
+		a.apply(n, "Left", nil, n.Left)
+		a.apply(n, "Right", nil, n.Right)
+	case *ClassBody:
+		a.applyList(n, "Body")
+	case *ClassMethod:
+		a.applyList(n, "Decorators")
+		a.apply(n, "Key", nil, n.Key)
+		a.apply(n, "Value", nil, n.Value)
+	case *ClassPrivateMethod:
+		a.applyList(n, "Decorators")
+		a.apply(n, "Key", nil, n.Key)
+		a.apply(n, "Value", nil, n.Value)
+	case *ClassProperty:
+		a.applyList(n, "Decorators")
+		a.apply(n, "Key", nil, n.Key)
+		a.apply(n, "Value", nil, n.Value)
+	case *ClassPrivateProperty:
+		a.applyList(n, "Decorators")
+		a.apply(n, "Key", nil, n.Key)
+		a.apply(n, "Value", nil, n.Value)
+	case *StaticBlock:
+		a.applyList(n, "Body")
+	case *ClassDeclaration:
+		a.applyList(n, "Decorators")
+		a.apply(n, "ID", nil, n.ID)
+		a.apply(n, "SuperClass", nil, n.SuperClass)
+		a.apply(n, "Body", nil, n.Body)
+	case *ClassExpression:
+		a.applyList(n, "Decorators")
+		a.apply(n, "ID", nil, n.ID)
+		a.apply(n, "SuperClass", nil, n.SuperClass)
+		a.apply(n, "Body", nil, n.Body)
+	case *MetaProperty:
+		a.apply(n, "Meta", nil, n.Meta)
+		a.apply(n, "Property", nil, n.Property)
+	case *ImportDeclaration:
+		a.applyList(n, "Specifiers")
+		a.apply(n, "Source", nil, n.Source)
+		a.applyList(n, "Attributes")
+	case *ImportSpecifier:
+		a.apply(n, "Imported", nil, n.Imported)
+		a.apply(n, "Local", nil, n.Local)
+	case *ImportDefaultSpecifier:
+		a.apply(n, "Local", nil, n.Local)
+	case *ImportNamespaceSpecifier:
+		a.apply(n, "Local", nil, n.Local)
+	case *ImportAttribute:
+		a.apply(n, "Key", nil, n.Key)
+		a.apply(n, "Value", nil, n.Value)
+	case *ExportNamedDeclaration:
+		a.apply(n, "Declaration", nil, n.Declaration)
+		a.applyList(n, "Specifiers")
+		a.apply(n, "Source", nil, n.Source)
+		a.applyList(n, "Attributes")
+	case *ExportSpecifier:
+		a.apply(n, "Local", nil, n.Local)
+		a.apply(n, "Exported", nil, n.Exported)
+	case *ExportDefaultDeclaration:
+		a.apply(n, "Declaration", nil, n.Declaration)
+	case *ExportAllDeclaration:
+		a.apply(n, "Source", nil, n.Source)
+		a.applyList(n, "Attributes")
+	}
+}
+
+func (d *differ) fields(path string, a, b Node) {
+	switch a := a.(type) {
+	case *Identifier:
+		b := b.(*Identifier)
+		d.value(field(path, "name"), a.Name, b.Name)
+	case *PrivateName:
+		b := b.(*PrivateName)
+		d.node(field(path, "id"), a.ID, b.ID)
+	case *RegExpLiteral:
+		b := b.(*RegExpLiteral)
+		d.value(field(path, "pattern"), a.Pattern, b.Pattern)
+		d.value(field(path, "flags"), a.Flags, b.Flags)
+		if !d.IgnoreRaw {
+			d.value(field(path, "extra"), a.Extra, b.Extra)
+		}
+	case *NullLiteral:
+		b := b.(*NullLiteral)
+		if !d.IgnoreRaw {
+			d.value(field(path, "extra"), a.Extra, b.Extra)
+		}
+	case *StringLiteral:
+		b := b.(*StringLiteral)
+		d.value(field(path, "value"), a.Value, b.Value)
+		if !d.IgnoreRaw {
+			d.value(field(path, "extra"), a.Extra, b.Extra)
+		}
+	case *BooleanLiteral:
+		b := b.(*BooleanLiteral)
+		d.value(field(path, "value"), a.Value, b.Value)
+		if !d.IgnoreRaw {
+			d.value(field(path, "extra"), a.Extra, b.Extra)
+		}
+	case *NumericLiteral:
+		b := b.(*NumericLiteral)
+		d.value(field(path, "value"), a.Value, b.Value)
+		if !d.IgnoreRaw {
+			d.value(field(path, "extra"), a.Extra, b.Extra)
+		}
+	case *BigIntLiteral:
+		b := b.(*BigIntLiteral)
+		d.value(field(path, "value"), a.Value, b.Value)
+		if !d.IgnoreRaw {
+			d.value(field(path, "extra"), a.Extra, b.Extra)
+		}
+	case *Program:
+		b := b.(*Program)
+		d.value(field(path, "sourceType"), a.SourceType, b.SourceType)
+		d.length(field(path, "body"), len(a.Body), len(b.Body))
+		for i := 0; i < len(a.Body) && i < len(b.Body); i++ {
+			d.node(index(field(path, "body"), i), a.Body[i], b.Body[i])
+		}
+		d.length(field(path, "directives"), len(a.Directives), len(b.Directives))
+		for i := 0; i < len(a.Directives) && i < len(b.Directives); i++ {
+			d.node(index(field(path, "directives"), i), a.Directives[i], b.Directives[i])
+		}
+	case *ExpressionStatement:
+		b := b.(*ExpressionStatement)
+		d.node(field(path, "expression"), a.Expression, b.Expression)
+	case *BlockStatement:
+		b := b.(*BlockStatement)
+		d.length(field(path, "body"), len(a.Body), len(b.Body))
+		for i := 0; i < len(a.Body) && i < len(b.Body); i++ {
+			d.node(index(field(path, "body"), i), a.Body[i], b.Body[i])
+		}
+		d.length(field(path, "directives"), len(a.Directives), len(b.Directives))
+		for i := 0; i < len(a.Directives) && i < len(b.Directives); i++ {
+			d.node(index(field(path, "directives"), i), a.Directives[i], b.Directives[i])
+		}
+	case *WithStatement:
+		b := b.(*WithStatement)
+		d.node(field(path, "object"), a.Object, b.Object)
+		d.node(field(path, "body"), a.Body, b.Body)
+	case *ReturnStatement:
+		b := b.(*ReturnStatement)
+		d.node(field(path, "argument"), a.Argument, b.Argument)
+	case *LabeledStatement:
+		b := b.(*LabeledStatement)
+		d.node(field(path, "label"), a.Label, b.Label)
+		d.node(field(path, "body"), a.Body, b.Body)
+	case *BreakStatement:
+		b := b.(*BreakStatement)
+		d.node(field(path, "label"), a.Label, b.Label)
+	case *ContinueStatement:
+		b := b.(*ContinueStatement)
+		d.node(field(path, "label"), a.Label, b.Label)
+	case *IfStatement:
+		b := b.(*IfStatement)
+		d.node(field(path, "test"), a.Test, b.Test)
+		d.node(field(path, "consequent"), a.Consequent, b.Consequent)
+		d.node(field(path, "alternate"), a.Alternate, b.Alternate)
+	case *SwitchStatement:
+		b := b.(*SwitchStatement)
+		d.node(field(path, "discriminant"), a.Discriminant, b.Discriminant)
+		d.length(field(path, "cases"), len(a.Cases), len(b.Cases))
+		for i := 0; i < len(a.Cases) && i < len(b.Cases); i++ {
+			d.node(index(field(path, "cases"), i), a.Cases[i], b.Cases[i])
+		}
+	case *SwitchCase:
+		b := b.(*SwitchCase)
+		d.node(field(path, "test"), a.Test, b.Test)
+		d.length(field(path, "consequent"), len(a.Consequent), len(b.Consequent))
+		for i := 0; i < len(a.Consequent) && i < len(b.Consequent); i++ {
+			d.node(index(field(path, "consequent"), i), a.Consequent[i], b.Consequent[i])
+		}
+	case *ThrowStatement:
+		b := b.(*ThrowStatement)
+		d.node(field(path, "argument"), a.Argument, b.Argument)
+	case *TryStatement:
+		b := b.(*TryStatement)
+		d.node(field(path, "block"), a.Block, b.Block)
+		d.node(field(path, "handler"), a.Handler, b.Handler)
+		d.node(field(path, "finalizer"), a.Finalizer, b.Finalizer)
+	case *CatchClause:
+		b := b.(*CatchClause)
+		d.node(field(path, "param"), a.Param, b.Param)
+		d.node(field(path, "body"), a.Body, b.Body)
+	case *WhileStatement:
+		b := b.(*WhileStatement)
+		d.node(field(path, "test"), a.Test, b.Test)
+		d.node(field(path, "body"), a.Body, b.Body)
+	case *DoWhileStatement:
+		b := b.(*DoWhileStatement)
+		d.node(field(path, "body"), a.Body, b.Body)
+		d.node(field(path, "test"), a.Test, b.Test)
+	case *ForStatement:
+		b := b.(*ForStatement)
+		d.node(field(path, "init"), a.Init, b.Init)
+		d.node(field(path, "test"), a.Test, b.Test)
+		d.node(field(path, "update"), a.Update, b.Update)
+		d.node(field(path, "body"), a.Body, b.Body)
+	case *ForInStatement:
+		b := b.(*ForInStatement)
+		d.node(field(path, "left"), a.Left, b.Left)
+		d.node(field(path, "right"), a.Right, b.Right)
+		d.node(field(path, "body"), a.Body, b.Body)
+	case *ForOfStatement:
+		b := b.(*ForOfStatement)
+		d.node(field(path, "left"), a.Left, b.Left)
+		d.node(field(path, "right"), a.Right, b.Right)
+		d.node(field(path, "body"), a.Body, b.Body)
+	case *FunctionDeclaration:
+		b := b.(*FunctionDeclaration)
+		d.node(field(path, "id"), a.ID, b.ID)
+		d.length(field(path, "params"), len(a.Params), len(b.Params))
+		for i := 0; i < len(a.Params) && i < len(b.Params); i++ {
+			d.node(index(field(path, "params"), i), a.Params[i], b.Params[i])
+		}
+		d.node(field(path, "body"), a.Body, b.Body)
+		d.value(field(path, "generator"), a.Generator, b.Generator)
+		d.value(field(path, "async"), a.Async, b.Async)
+	case *VariableDeclaration:
+		b := b.(*VariableDeclaration)
+		d.length(field(path, "declarations"), len(a.Declarations), len(b.Declarations))
+		for i := 0; i < len(a.Declarations) && i < len(b.Declarations); i++ {
+			d.node(index(field(path, "declarations"), i), a.Declarations[i], b.Declarations[i])
+		}
+		d.value(field(path, "kind"), a.Kind, b.Kind)
+	case *VariableDeclarator:
+		b := b.(*VariableDeclarator)
+		d.node(field(path, "id"), a.ID, b.ID)
+		d.node(field(path, "init"), a.Init, b.Init)
+	case *Decorator:
+		b := b.(*Decorator)
+		d.node(field(path, "expression"), a.Expression, b.Expression)
+	case *Directive:
+		b := b.(*Directive)
+		d.node(field(path, "value"), a.Value, b.Value)
+	case *DirectiveLiteral:
+		b := b.(*DirectiveLiteral)
+		d.value(field(path, "value"), a.Value, b.Value)
+		if !d.IgnoreRaw {
+			d.value(field(path, "extra"), a.Extra, b.Extra)
+		}
+	case *ArrowFunctionExpression:
+		b := b.(*ArrowFunctionExpression)
+		d.node(field(path, "id"), a.ID, b.ID)
+		d.length(field(path, "params"), len(a.Params), len(b.Params))
+		for i := 0; i < len(a.Params) && i < len(b.Params); i++ {
+			d.node(index(field(path, "params"), i), a.Params[i], b.Params[i])
+		}
+		d.node(field(path, "body"), a.Body, b.Body)
+		d.value(field(path, "generator"), a.Generator, b.Generator)
+		d.value(field(path, "async"), a.Async, b.Async)
+		d.value(field(path, "expression"), a.IsExpression, b.IsExpression)
+	case *YieldExpression:
+		b := b.(*YieldExpression)
+		d.node(field(path, "argument"), a.Argument, b.Argument)
+		d.value(field(path, "delegate"), a.Delegate, b.Delegate)
+	case *AwaitExpression:
+		b := b.(*AwaitExpression)
+		d.node(field(path, "argument"), a.Argument, b.Argument)
+	case *ArrayExpression:
+		b := b.(*ArrayExpression)
+		d.length(field(path, "elements"), len(a.Elements), len(b.Elements))
+		for i := 0; i < len(a.Elements) && i < len(b.Elements); i++ {
+			d.node(index(field(path, "elements"), i), a.Elements[i], b.Elements[i])
+		}
+	case *ObjectExpression:
+		b := b.(*ObjectExpression)
+		d.length(field(path, "properties"), len(a.Properties), len(b.Properties))
+		for i := 0; i < len(a.Properties) && i < len(b.Properties); i++ {
+			d.node(index(field(path, "properties"), i), a.Properties[i], b.Properties[i])
+		}
+	case *ObjectProperty:
+		b := b.(*ObjectProperty)
+		d.node(field(path, "key"), a.Key, b.Key)
+		d.value(field(path, "computed"), a.Computed, b.Computed)
+		d.node(field(path, "value"), a.Value, b.Value)
+		d.length(field(path, "decorators"), len(a.Decorators), len(b.Decorators))
+		for i := 0; i < len(a.Decorators) && i < len(b.Decorators); i++ {
+			d.node(index(field(path, "decorators"), i), a.Decorators[i], b.Decorators[i])
+		}
+		d.value(field(path, "shorthand"), a.Shorthand, b.Shorthand)
+	case *ObjectMethod:
+		b := b.(*ObjectMethod)
+		d.node(field(path, "key"), a.Key, b.Key)
+		d.value(field(path, "computed"), a.Computed, b.Computed)
+		d.node(field(path, "value"), a.Value, b.Value)
+		d.length(field(path, "decorators"), len(a.Decorators), len(b.Decorators))
+		for i := 0; i < len(a.Decorators) && i < len(b.Decorators); i++ {
+			d.node(index(field(path, "decorators"), i), a.Decorators[i], b.Decorators[i])
+		}
+		d.node(field(path, "id"), a.ID, b.ID)
+		d.length(field(path, "params"), len(a.Params), len(b.Params))
+		for i := 0; i < len(a.Params) && i < len(b.Params); i++ {
+			d.node(index(field(path, "params"), i), a.Params[i], b.Params[i])
+		}
+		d.node(field(path, "body"), a.Body, b.Body)
+		d.value(field(path, "generator"), a.Generator, b.Generator)
+		d.value(field(path, "async"), a.Async, b.Async)
+		d.value(field(path, "kind"), a.Kind, b.Kind)
+		d.span(field(path, "functionSpan"), a.FunctionSpan, b.FunctionSpan)
+	case *RestProperty:
+		b := b.(*RestProperty)
+		d.node(field(path, "argument"), a.Argument, b.Argument)
+	case *SpreadProperty:
+		b := b.(*SpreadProperty)
+		d.node(field(path, "argument"), a.Argument, b.Argument)
+	case *FunctionExpression:
+		b := b.(*FunctionExpression)
+		d.node(field(path, "id"), a.ID, b.ID)
+		d.length(field(path, "params"), len(a.Params), len(b.Params))
+		for i := 0; i < len(a.Params) && i < len(b.Params); i++ {
+			d.node(index(field(path, "params"), i), a.Params[i], b.Params[i])
+		}
+		d.node(field(path, "body"), a.Body, b.Body)
+		d.value(field(path, "generator"), a.Generator, b.Generator)
+		d.value(field(path, "async"), a.Async, b.Async)
+	case *UnaryExpression:
+		b := b.(*UnaryExpression)
+		d.value(field(path, "operator"), a.Operator, b.Operator)
+		d.value(field(path, "prefix"), a.Prefix, b.Prefix)
+		d.node(field(path, "argument"), a.Argument, b.Argument)
+	case *UpdateExpression:
+		b := b.(*UpdateExpression)
+		d.value(field(path, "operator"), a.Operator, b.Operator)
+		d.node(field(path, "argument"), a.Argument, b.Argument)
+		d.value(field(path, "prefix"), a.Prefix, b.Prefix)
+	case *BinaryExpression:
+		b := b.(*BinaryExpression)
+		d.value(field(path, "operator"), a.Operator, b.Operator)
+		d.node(field(path, "left"), a.Left, b.Left)
+		d.node(field(path, "right"), a.Right, b.Right)
+	case *AssignmentExpression:
+		b := b.(*AssignmentExpression)
+		d.value(field(path, "operator"), a.Operator, b.Operator)
+		d.node(field(path, "left"), a.Left, b.Left)
+		d.node(field(path, "right"), a.Right, b.Right)
+	case *LogicalExpression:
+		b := b.(*LogicalExpression)
+		d.value(field(path, "operator"), a.Operator, b.Operator)
+		d.node(field(path, "left"), a.Left, b.Left)
+		d.node(field(path, "right"), a.Right, b.Right)
+	case *SpreadElement:
+		b := b.(*SpreadElement)
+		d.node(field(path, "argument"), a.Argument, b.Argument)
+	case *MemberExpression:
+		b := b.(*MemberExpression)
+		d.node(field(path, "object"), a.Object, b.Object)
+		d.node(field(path, "property"), a.Property, b.Property)
+		d.value(field(path, "computed"), a.Computed, b.Computed)
+	case *OptionalMemberExpression:
+		b := b.(*OptionalMemberExpression)
+		d.node(field(path, "object"), a.Object, b.Object)
+		d.node(field(path, "property"), a.Property, b.Property)
+		d.value(field(path, "computed"), a.Computed, b.Computed)
+		d.value(field(path, "optional"), a.Optional, b.Optional)
+		d.span(field(path, "chainSpan"), a.ChainSpan, b.ChainSpan)
+	case *BindExpression:
+		b := b.(*BindExpression)
+		d.length(field(path, "object"), len(a.Object), len(b.Object))
+		for i := 0; i < len(a.Object) && i < len(b.Object); i++ {
+			d.node(index(field(path, "object"), i), a.Object[i], b.Object[i])
+		}
+		d.length(field(path, "callee"), len(a.Callee), len(b.Callee))
+		for i := 0; i < len(a.Callee) && i < len(b.Callee); i++ {
+			d.node(index(field(path, "callee"), i), a.Callee[i], b.Callee[i])
+		}
+	case *ConditionalExpression:
+		b := b.(*ConditionalExpression)
+		d.node(field(path, "test"), a.Test, b.Test)
+		d.node(field(path, "alternate"), a.Alternate, b.Alternate)
+		d.node(field(path, "consequent"), a.Consequent, b.Consequent)
+	case *CallExpression:
+		b := b.(*CallExpression)
+		d.node(field(path, "callee"), a.Callee, b.Callee)
+		d.length(field(path, "arguments"), len(a.Arguments), len(b.Arguments))
+		for i := 0; i < len(a.Arguments) && i < len(b.Arguments); i++ {
+			d.node(index(field(path, "arguments"), i), a.Arguments[i], b.Arguments[i])
+		}
+	case *OptionalCallExpression:
+		b := b.(*OptionalCallExpression)
+		d.node(field(path, "callee"), a.Callee, b.Callee)
+		d.length(field(path, "arguments"), len(a.Arguments), len(b.Arguments))
+		for i := 0; i < len(a.Arguments) && i < len(b.Arguments); i++ {
+			d.node(index(field(path, "arguments"), i), a.Arguments[i], b.Arguments[i])
+		}
+		d.value(field(path, "optional"), a.Optional, b.Optional)
+		d.span(field(path, "chainSpan"), a.ChainSpan, b.ChainSpan)
+	case *NewExpression:
+		b := b.(*NewExpression)
+		d.node(field(path, "callee"), a.Callee, b.Callee)
+		d.length(field(path, "arguments"), len(a.Arguments), len(b.Arguments))
+		for i := 0; i < len(a.Arguments) && i < len(b.Arguments); i++ {
+			d.node(index(field(path, "arguments"), i), a.Arguments[i], b.Arguments[i])
+		}
+	case *SequenceExpression:
+		b := b.(*SequenceExpression)
+		d.length(field(path, "expressions"), len(a.Expressions), len(b.Expressions))
+		for i := 0; i < len(a.Expressions) && i < len(b.Expressions); i++ {
+			d.node(index(field(path, "expressions"), i), a.Expressions[i], b.Expressions[i])
+		}
+	case *ImportExpression:
+		b := b.(*ImportExpression)
+		d.node(field(path, "source"), a.Source, b.Source)
+		d.node(field(path, "options"), a.Options, b.Options)
+	case *TemplateLiteral:
+		b := b.(*TemplateLiteral)
+		d.length(field(path, "quasis"), len(a.Quasis), len(b.Quasis))
+		for i := 0; i < len(a.Quasis) && i < len(b.Quasis); i++ {
+			d.node(index(field(path, "quasis"), i), a.Quasis[i], b.Quasis[i])
+		}
+		d.length(field(path, "expressions"), len(a.Expressions), len(b.Expressions))
+		for i := 0; i < len(a.Expressions) && i < len(b.Expressions); i++ {
+			d.node(index(field(path, "expressions"), i), a.Expressions[i], b.Expressions[i])
+		}
+	case *TaggedTemplateExpression:
+		b := b.(*TaggedTemplateExpression)
+		d.node(field(path, "tag"), a.Tag, b.Tag)
+		d.node(field(path, "quasi"), a.Quasi, b.Quasi)
+	case *TemplateElement:
+		b := b.(*TemplateElement)
+		d.value(field(path, "tail"), a.Tail, b.Tail)
+		d.value(field(path, "cooked"), a.Cooked, b.Cooked)
+		d.value(field(path, "raw"), a.Raw, b.Raw)
+	case *AssignmentProperty:
+		b := b.(*AssignmentProperty)
+		d.node(field(path, "key"), a.Key, b.Key)
+		d.value(field(path, "computed"), a.Computed, b.Computed)
+		d.node(field(path, "value"), a.Value, b.Value)
+		d.length(field(path, "decorators"), len(a.Decorators), len(b.Decorators))
+		for i := 0; i < len(a.Decorators) && i < len(b.Decorators); i++ {
+			d.node(index(field(path, "decorators"), i), a.Decorators[i], b.Decorators[i])
+		}
+		d.value(field(path, "shorthand"), a.Shorthand, b.Shorthand)
+	case *ObjectPattern:
+		b := b.(*ObjectPattern)
+		d.length(field(path, "properties"), len(a.Properties), len(b.Properties))
+		for i := 0; i < len(a.Properties) && i < len(b.Properties); i++ {
+			d.node(index(field(path, "properties"), i), a.Properties[i], b.Properties[i])
+		}
+	case *ArrayPattern:
+		b := b.(*ArrayPattern)
+		d.length(field(path, "elements"), len(a.Elements), len(b.Elements))
+		for i := 0; i < len(a.Elements) && i < len(b.Elements); i++ {
+			d.node(index(field(path, "elements"), i), a.Elements[i], b.Elements[i])
+		}
+	case *RestElement:
+		b := b.(*RestElement)
+		d.node(field(path, "argument"), a.Argument, b.Argument)
+	case *AssignmentPattern:
+		b := b.(*AssignmentPattern)
+		d.node(field(path, "left"), a.Left, b.Left)
+		d.node(field(path, "right"), a.Right, b.Right)
+	case *ClassBody:
+		b := b.(*ClassBody)
+		d.length(field(path, "body"), len(a.Body), len(b.Body))
+		for i := 0; i < len(a.Body) && i < len(b.Body); i++ {
+			d.node(index(field(path, "body"), i), a.Body[i], b.Body[i])
+		}
+	case *ClassMethod:
+		b := b.(*ClassMethod)
+		d.node(field(path, "key"), a.Key, b.Key)
+		d.node(field(path, "value"), a.Value, b.Value)
+		d.value(field(path, "kind"), a.Kind, b.Kind)
+		d.value(field(path, "computed"), a.Computed, b.Computed)
+		d.value(field(path, "static"), a.Static, b.Static)
+		d.length(field(path, "decorators"), len(a.Decorators), len(b.Decorators))
+		for i := 0; i < len(a.Decorators) && i < len(b.Decorators); i++ {
+			d.node(index(field(path, "decorators"), i), a.Decorators[i], b.Decorators[i])
+		}
+	case *ClassPrivateMethod:
+		b := b.(*ClassPrivateMethod)
+		d.node(field(path, "key"), a.Key, b.Key)
+		d.node(field(path, "value"), a.Value, b.Value)
+		d.value(field(path, "kind"), a.Kind, b.Kind)
+		d.value(field(path, "static"), a.Static, b.Static)
+		d.length(field(path, "decorators"), len(a.Decorators), len(b.Decorators))
+		for i := 0; i < len(a.Decorators) && i < len(b.Decorators); i++ {
+			d.node(index(field(path, "decorators"), i), a.Decorators[i], b.Decorators[i])
+		}
+	case *ClassProperty:
+		b := b.(*ClassProperty)
+		d.node(field(path, "key"), a.Key, b.Key)
+		d.node(field(path, "value"), a.Value, b.Value)
+		d.value(field(path, "computed"), a.Computed, b.Computed)
+		d.value(field(path, "static"), a.Static, b.Static)
+		d.length(field(path, "decorators"), len(a.Decorators), len(b.Decorators))
+		for i := 0; i < len(a.Decorators) && i < len(b.Decorators); i++ {
+			d.node(index(field(path, "decorators"), i), a.Decorators[i], b.Decorators[i])
+		}
+	case *ClassPrivateProperty:
+		b := b.(*ClassPrivateProperty)
+		d.node(field(path, "key"), a.Key, b.Key)
+		d.node(field(path, "value"), a.Value, b.Value)
+		d.value(field(path, "static"), a.Static, b.Static)
+		d.length(field(path, "decorators"), len(a.Decorators), len(b.Decorators))
+		for i := 0; i < len(a.Decorators) && i < len(b.Decorators); i++ {
+			d.node(index(field(path, "decorators"), i), a.Decorators[i], b.Decorators[i])
+		}
+	case *StaticBlock:
+		b := b.(*StaticBlock)
+		d.length(field(path, "body"), len(a.Body), len(b.Body))
+		for i := 0; i < len(a.Body) && i < len(b.Body); i++ {
+			d.node(index(field(path, "body"), i), a.Body[i], b.Body[i])
+		}
+	case *ClassDeclaration:
+		b := b.(*ClassDeclaration)
+		d.node(field(path, "id"), a.ID, b.ID)
+		d.node(field(path, "superClass"), a.SuperClass, b.SuperClass)
+		d.node(field(path, "body"), a.Body, b.Body)
+		d.length(field(path, "decorators"), len(a.Decorators), len(b.Decorators))
+		for i := 0; i < len(a.Decorators) && i < len(b.Decorators); i++ {
+			d.node(index(field(path, "decorators"), i), a.Decorators[i], b.Decorators[i])
+		}
+	case *ClassExpression:
+		b := b.(*ClassExpression)
+		d.node(field(path, "id"), a.ID, b.ID)
+		d.node(field(path, "superClass"), a.SuperClass, b.SuperClass)
+		d.node(field(path, "body"), a.Body, b.Body)
+		d.length(field(path, "decorators"), len(a.Decorators), len(b.Decorators))
+		for i := 0; i < len(a.Decorators) && i < len(b.Decorators); i++ {
+			d.node(index(field(path, "decorators"), i), a.Decorators[i], b.Decorators[i])
+		}
+	case *MetaProperty:
+		b := b.(*MetaProperty)
+		d.node(field(path, "meta"), a.Meta, b.Meta)
+		d.node(field(path, "property"), a.Property, b.Property)
+	case *ImportDeclaration:
+		b := b.(*ImportDeclaration)
+		d.length(field(path, "specifiers"), len(a.Specifiers), len(b.Specifiers))
+		for i := 0; i < len(a.Specifiers) && i < len(b.Specifiers); i++ {
+			d.node(index(field(path, "specifiers"), i), a.Specifiers[i], b.Specifiers[i])
+		}
+		d.node(field(path, "source"), a.Source, b.Source)
+		d.length(field(path, "attributes"), len(a.Attributes), len(b.Attributes))
+		for i := 0; i < len(a.Attributes) && i < len(b.Attributes); i++ {
+			d.node(index(field(path, "attributes"), i), a.Attributes[i], b.Attributes[i])
+		}
+	case *ImportSpecifier:
+		b := b.(*ImportSpecifier)
+		d.node(field(path, "local"), a.Local, b.Local)
+		d.node(field(path, "imported"), a.Imported, b.Imported)
+	case *ImportDefaultSpecifier:
+		b := b.(*ImportDefaultSpecifier)
+		d.node(field(path, "local"), a.Local, b.Local)
+	case *ImportNamespaceSpecifier:
+		b := b.(*ImportNamespaceSpecifier)
+		d.node(field(path, "local"), a.Local, b.Local)
+	case *ImportAttribute:
+		b := b.(*ImportAttribute)
+		d.node(field(path, "key"), a.Key, b.Key)
+		d.node(field(path, "value"), a.Value, b.Value)
+	case *ExportNamedDeclaration:
+		b := b.(*ExportNamedDeclaration)
+		d.node(field(path, "declaration"), a.Declaration, b.Declaration)
+		d.length(field(path, "specifiers"), len(a.Specifiers), len(b.Specifiers))
+		for i := 0; i < len(a.Specifiers) && i < len(b.Specifiers); i++ {
+			d.node(index(field(path, "specifiers"), i), a.Specifiers[i], b.Specifiers[i])
+		}
+		d.node(field(path, "source"), a.Source, b.Source)
+		d.length(field(path, "attributes"), len(a.Attributes), len(b.Attributes))
+		for i := 0; i < len(a.Attributes) && i < len(b.Attributes); i++ {
+			d.node(index(field(path, "attributes"), i), a.Attributes[i], b.Attributes[i])
+		}
+	case *ExportSpecifier:
+		b := b.(*ExportSpecifier)
+		d.node(field(path, "local"), a.Local, b.Local)
+		d.node(field(path, "exported"), a.Exported, b.Exported)
+	case *ExportDefaultDeclaration:
+		b := b.(*ExportDefaultDeclaration)
+		d.node(field(path, "declaration"), a.Declaration, b.Declaration)
+	case *ExportAllDeclaration:
+		b := b.(*ExportAllDeclaration)
+		d.node(field(path, "source"), a.Source, b.Source)
+		d.length(field(path, "attributes"), len(a.Attributes), len(b.Attributes))
+		for i := 0; i < len(a.Attributes) && i < len(b.Attributes); i++ {
+			d.node(index(field(path, "attributes"), i), a.Attributes[i], b.Attributes[i])
+		}
+	}
+}
+
+// Clone returns a deep copy of a tree, which shares nothing with the
+// original. Each node has the same concrete type as the one it copies.
+func Clone(node Node) Node {
+	switch n := node.(type) {
+	case *Unknown:
+		if n == nil {
+			return n
+		}
+
+		c := *n
+		c.BaseNode = n.BaseNode.clone()
+		c.Raw = append(json.RawMessage(nil), n.Raw...)
+		return &c
+	case *Identifier:
+		if n == nil {
+			return n
+		}
+
+		c := *n
+		c.BaseNode = n.BaseNode.clone()
+		return &c
+	case *PrivateName:
+		if n == nil {
+			return n
+		}
+
+		c := *n
+		c.BaseNode = n.BaseNode.clone()
+		if n.ID != nil {
+			c.ID = Clone(n.ID).(*Identifier)
+		}
+		return &c
+	case *RegExpLiteral:
+		if n == nil {
+			return n
+		}
+
+		c := *n
+		c.BaseNode = n.BaseNode.clone()
+		if n.Extra != nil {
+			v := *n.Extra
+			c.Extra = &v
+		}
+		return &c
+	case *NullLiteral:
+		if n == nil {
+			return n
+		}
+
+		c := *n
+		c.BaseNode = n.BaseNode.clone()
+		if n.Extra != nil {
+			v := *n.Extra
+			c.Extra = &v
+		}
+		return &c
+	case *StringLiteral:
+		if n == nil {
+			return n
+		}
+
+		c := *n
+		c.BaseNode = n.BaseNode.clone()
+		if n.Extra != nil {
+			v := *n.Extra
+			c.Extra = &v
+		}
+		return &c
+	case *BooleanLiteral:
+		if n == nil {
+			return n
+		}
+
+		c := *n
+		c.BaseNode = n.BaseNode.clone()
+		if n.Extra != nil {
+			v := *n.Extra
+			c.Extra = &v
+		}
+		return &c
+	case *NumericLiteral:
+		if n == nil {
+			return n
+		}
+
+		c := *n
+		c.BaseNode = n.BaseNode.clone()
+		if n.Extra != nil {
+			v := *n.Extra
+			c.Extra = &v
+		}
+		return &c
+	case *BigIntLiteral:
+		if n == nil {
+			return n
+		}
+
+		c := *n
+		c.BaseNode = n.BaseNode.clone()
+		if n.Extra != nil {
+			v := *n.Extra
+			c.Extra = &v
+		}
+		return &c
+	case *Program:
+		if n == nil {
+			return n
+		}
+
+		c := *n
+		c.BaseNode = n.BaseNode.clone()
+		if n.Body != nil {
+			c.Body = make([]StatementOrModuleDeclaration, len(n.Body))
+			for i, e := range n.Body {
+				if e != nil {
+					c.Body[i] = Clone(e).(StatementOrModuleDeclaration)
+				}
+			}
+		}
+		if n.Directives != nil {
+			c.Directives = make([]*Directive, len(n.Directives))
+			for i, e := range n.Directives {
+				if e != nil {
+					c.Directives[i] = Clone(e).(*Directive)
+				}
+			}
+		}
+		return &c
+	case *ExpressionStatement:
+		if n == nil {
+			return n
+		}
+
+		c := *n
+		c.BaseNode = n.BaseNode.clone()
+		if n.Expression != nil {
+			c.Expression = Clone(n.Expression).(Expression)
+		}
+		return &c
+	case *BlockStatement:
+		if n == nil {
+			return n
+		}
+
+		c := *n
+		c.BaseNode = n.BaseNode.clone()
+		if n.Body != nil {
+			c.Body = make([]Statement, len(n.Body))
+			for i, e := range n.Body {
+				if e != nil {
+					c.Body[i] = Clone(e).(Statement)
+				}
+			}
+		}
+		if n.Directives != nil {
+			c.Directives = make([]*Directive, len(n.Directives))
+			for i, e := range n.Directives {
+				if e != nil {
+					c.Directives[i] = Clone(e).(*Directive)
+				}
+			}
+		}
+		return &c
+	case *EmptyStatement:
+		if n == nil {
+			return n
+		}
+
+		c := *n
+		c.BaseNode = n.BaseNode.clone()
+		return &c
+	case *DebuggerStatement:
+		if n == nil {
+			return n
+		}
+
+		c := *n
+		c.BaseNode = n.BaseNode.clone()
+		return &c
+	case *WithStatement:
+		if n == nil {
+			return n
+		}
+
+		c := *n
+		c.BaseNode = n.BaseNode.clone()
+		if n.Object != nil {
+			c.Object = Clone(n.Object).(Expression)
+		}
+		if n.Body != nil {
+			c.Body = Clone(n.Body).(Statement)
+		}
+		return &c
+	case *ReturnStatement:
+		if n == nil {
+			return n
+		}
+
+		c := *n
+		c.BaseNode = n.BaseNode.clone()
+		if n.Argument != nil {
+			c.Argument = Clone(n.Argument).(Expression)
+		}
+		return &c
+	case *LabeledStatement:
+		if n == nil {
+			return n
+		}
+
+		c := *n
+		c.BaseNode = n.BaseNode.clone()
+		if n.Label != nil {
+			c.Label = Clone(n.Label).(*Identifier)
+		}
+		if n.Body != nil {
+			c.Body = Clone(n.Body).(Statement)
+		}
+		return &c
+	case *BreakStatement:
+		if n == nil {
+			return n
+		}
+
+		c := *n
+		c.BaseNode = n.BaseNode.clone()
+		if n.Label != nil {
+			c.Label = Clone(n.Label).(*Identifier)
+		}
+		return &c
+	case *ContinueStatement:
+		if n == nil {
+			return n
+		}
+
+		c := *n
+		c.BaseNode = n.BaseNode.clone()
+		if n.Label != nil {
+			c.Label = Clone(n.Label).(*Identifier)
+		}
+		return &c
+	case *IfStatement:
+		if n == nil {
+			return n
+		}
+
+		c := *n
+		c.BaseNode = n.BaseNode.clone()
+		if n.Test != nil {
+			c.Test = Clone(n.Test).(Expression)
+		}
+		if n.Consequent != nil {
+			c.Consequent = Clone(n.Consequent).(Statement)
+		}
+		if n.Alternate != nil {
+			c.Alternate = Clone(n.Alternate).(Statement)
+		}
+		return &c
+	case *SwitchStatement:
+		if n == nil {
+			return n
+		}
+
+		c := *n
+		c.BaseNode = n.BaseNode.clone()
+		if n.Discriminant != nil {
+			c.Discriminant = Clone(n.Discriminant).(Expression)
+		}
+		if n.Cases != nil {
+			c.Cases = make([]*SwitchCase, len(n.Cases))
+			for i, e := range n.Cases {
+				if e != nil {
+					c.Cases[i] = Clone(e).(*SwitchCase)
+				}
+			}
+		}
+		return &c
+	case *SwitchCase:
+		if n == nil {
+			return n
+		}
+
+		c := *n
+		c.BaseNode = n.BaseNode.clone()
+		if n.Test != nil {
+			c.Test = Clone(n.Test).(Expression)
+		}
+		if n.Consequent != nil {
+			c.Consequent = make([]Statement, len(n.Consequent))
+			for i, e := range n.Consequent {
+				if e != nil {
+					c.Consequent[i] = Clone(e).(Statement)
+				}
+			}
+		}
+		return &c
+	case *ThrowStatement:
+		if n == nil {
+			return n
+		}
+
+		c := *n
+		c.BaseNode = n.BaseNode.clone()
+		if n.Argument != nil {
+			c.Argument = Clone(n.Argument).(Expression)
+		}
+		return &c
+	case *TryStatement:
+		if n == nil {
+			return n
+		}
+
+		c := *n
+		c.BaseNode = n.BaseNode.clone()
+		if n.Block != nil {
+			c.Block = Clone(n.Block).(*BlockStatement)
+		}
+		if n.Handler != nil {
+			c.Handler = Clone(n.Handler).(*CatchClause)
+		}
+		if n.Finalizer != nil {
+			c.Finalizer = Clone(n.Finalizer).(*BlockStatement)
+		}
+		return &c
+	case *CatchClause:
+		if n == nil {
+			return n
+		}
+
+		c := *n
+		c.BaseNode = n.BaseNode.clone()
+		if n.Param != nil {
+			c.Param = Clone(n.Param).(Pattern)
+		}
+		if n.Body != nil {
+			c.Body = Clone(n.Body).(*BlockStatement)
+		}
+		return &c
+	case *WhileStatement:
+		if n == nil {
+			return n
+		}
+
+		c := *n
+		c.BaseNode = n.BaseNode.clone()
+		if n.Test != nil {
+			c.Test = Clone(n.Test).(Expression)
+		}
+		if n.Body != nil {
+			c.Body = Clone(n.Body).(Statement)
+		}
+		return &c
+	case *DoWhileStatement:
+		if n == nil {
+			return n
+		}
+
+		c := *n
+		c.BaseNode = n.BaseNode.clone()
+		if n.Body != nil {
+			c.Body = Clone(n.Body).(Statement)
+		}
+		if n.Test != nil {
+			c.Test = Clone(n.Test).(Expression)
+		}
+		return &c
+	case *ForStatement:
+		if n == nil {
+			return n
+		}
+
+		c := *n
+		c.BaseNode = n.BaseNode.clone()
+		if n.Init != nil {
+			c.Init = Clone(n.Init).(VariableDeclarationOrExpression)
+		}
+		if n.Test != nil {
+			c.Test = Clone(n.Test).(Expression)
+		}
+		if n.Update != nil {
+			c.Update = Clone(n.Update).(Expression)
+		}
+		if n.Body != nil {
+			c.Body = Clone(n.Body).(Statement)
+		}
+		return &c
+	case *ForInStatement:
+		if n == nil {
+			return n
+		}
+
+		c := *n
+		c.BaseNode = n.BaseNode.clone()
+		if n.Left != nil {
+			c.Left = Clone(n.Left).(VariableDeclarationOrExpression)
+		}
+		if n.Right != nil {
+			c.Right = Clone(n.Right).(Expression)
+		}
+		if n.Body != nil {
+			c.Body = Clone(n.Body).(Statement)
+		}
+		return &c
+	case *ForOfStatement:
+		if n == nil {
+			return n
+		}
+
+		c := *n
+		c.BaseNode = n.BaseNode.clone()
+		if n.Left != nil {
+			c.Left = Clone(n.Left).(VariableDeclarationOrExpression)
+		}
+		if n.Right != nil {
+			c.Right = Clone(n.Right).(Expression)
+		}
+		if n.Body != nil {
+			c.Body = Clone(n.Body).(Statement)
+		}
+		return &c
+	case *FunctionDeclaration:
+		if n == nil {
+			return n
+		}
+
+		c := *n
+		c.BaseNode = n.BaseNode.clone()
+		if n.ID != nil {
+			c.ID = Clone(n.ID).(*Identifier)
+		}
+		if n.Params != nil {
+			c.Params = make([]Pattern, len(n.Params))
+			for i, e := range n.Params {
+				if e != nil {
+					c.Params[i] = Clone(e).(Pattern)
+				}
+			}
+		}
+		if n.Body != nil {
+			c.Body = Clone(n.Body).(*BlockStatement)
+		}
+		return &c
+	case *VariableDeclaration:
+		if n == nil {
+			return n
+		}
+
+		c := *n
+		c.BaseNode = n.BaseNode.clone()
+		if n.Declarations != nil {
+			c.Declarations = make([]*VariableDeclarator, len(n.Declarations))
+			for i, e := range n.Declarations {
+				if e != nil {
+					c.Declarations[i] = Clone(e).(*VariableDeclarator)
+				}
+			}
+		}
+		return &c
+	case *VariableDeclarator:
+		if n == nil {
+			return n
+		}
+
+		c := *n
+		c.BaseNode = n.BaseNode.clone()
+		if n.ID != nil {
+			c.ID = Clone(n.ID).(Pattern)
+		}
+		if n.Init != nil {
+			c.Init = Clone(n.Init).(Expression)
+		}
+		return &c
+	case *Decorator:
+		if n == nil {
+			return n
+		}
+
+		c := *n
+		c.BaseNode = n.BaseNode.clone()
+		if n.Expression != nil {
+			c.Expression = Clone(n.Expression).(Expression)
+		}
+		return &c
+	case *Directive:
+		if n == nil {
+			return n
+		}
+
+		c := *n
+		c.BaseNode = n.BaseNode.clone()
+		if n.Value != nil {
+			c.Value = Clone(n.Value).(*DirectiveLiteral)
+		}
+		return &c
+	case *DirectiveLiteral:
+		if n == nil {
+			return n
+		}
+
+		c := *n
+		c.BaseNode = n.BaseNode.clone()
+		if n.Extra != nil {
+			v := *n.Extra
+			c.Extra = &v
+		}
+		return &c
+	case *Super:
+		if n == nil {
+			return n
+		}
+
+		c := *n
+		c.BaseNode = n.BaseNode.clone()
+		return &c
+	case *ThisExpression:
+		if n == nil {
+			return n
+		}
+
+		c := *n
+		c.BaseNode = n.BaseNode.clone()
+		return &c
+	case *ArrowFunctionExpression:
+		if n == nil {
+			return n
+		}
+
+		c := *n
+		c.BaseNode = n.BaseNode.clone()
+		if n.ID != nil {
+			c.ID = Clone(n.ID).(*Identifier)
+		}
+		if n.Params != nil {
+			c.Params = make([]Pattern, len(n.Params))
+			for i, e := range n.Params {
+				if e != nil {
+					c.Params[i] = Clone(e).(Pattern)
+				}
+			}
+		}
+		if n.Body != nil {
+			c.Body = Clone(n.Body).(BlockStatementOrExpression)
+		}
+		return &c
+	case *YieldExpression:
+		if n == nil {
+			return n
+		}
+
+		c := *n
+		c.BaseNode = n.BaseNode.clone()
+		if n.Argument != nil {
+			c.Argument = Clone(n.Argument).(Expression)
+		}
+		return &c
+	case *AwaitExpression:
+		if n == nil {
+			return n
+		}
+
+		c := *n
+		c.BaseNode = n.BaseNode.clone()
+		if n.Argument != nil {
+			c.Argument = Clone(n.Argument).(Expression)
+		}
+		return &c
+	case *ArrayExpression:
+		if n == nil {
+			return n
+		}
+
+		c := *n
+		c.BaseNode = n.BaseNode.clone()
+		if n.Elements != nil {
+			c.Elements = make([]ExpressionOrSpreadElement, len(n.Elements))
+			for i, e := range n.Elements {
+				if e != nil {
+					c.Elements[i] = Clone(e).(ExpressionOrSpreadElement)
+				}
+			}
+		}
+		return &c
+	case *ObjectExpression:
+		if n == nil {
+			return n
+		}
+
+		c := *n
+		c.BaseNode = n.BaseNode.clone()
+		if n.Properties != nil {
+			c.Properties = make([]ObjectPropertyOrObjectMethodOrSpreadProperty, len(n.Properties))
+			for i, e := range n.Properties {
+				if e != nil {
+					c.Properties[i] = Clone(e).(ObjectPropertyOrObjectMethodOrSpreadProperty)
+				}
+			}
+		}
+		return &c
+	case *ObjectProperty:
+		if n == nil {
+			return n
+		}
+
+		c := *n
+		c.BaseNode = n.BaseNode.clone()
+		if n.Key != nil {
+			c.Key = Clone(n.Key).(Expression)
+		}
+		if n.Value != nil {
+			c.Value = Clone(n.Value).(Expression)
+		}
+		if n.Decorators != nil {
+			c.Decorators = make([]*Decorator, len(n.Decorators))
+			for i, e := range n.Decorators {
+				if e != nil {
+					c.Decorators[i] = Clone(e).(*Decorator)
+				}
+			}
+		}
+		return &c
+	case *ObjectMethod:
+		if n == nil {
+			return n
+		}
+
+		c := *n
+		c.BaseNode = n.BaseNode.clone()
+		if n.Key != nil {
+			c.Key = Clone(n.Key).(Expression)
+		}
+		if n.Value != nil {
+			c.Value = Clone(n.Value).(Expression)
+		}
+		if n.Decorators != nil {
+			c.Decorators = make([]*Decorator, len(n.Decorators))
+			for i, e := range n.Decorators {
+				if e != nil {
+					c.Decorators[i] = Clone(e).(*Decorator)
+				}
+			}
+		}
+		if n.ID != nil {
+			c.ID = Clone(n.ID).(*Identifier)
+		}
+		if n.Params != nil {
+			c.Params = make([]Pattern, len(n.Params))
+			for i, e := range n.Params {
+				if e != nil {
+					c.Params[i] = Clone(e).(Pattern)
+				}
+			}
+		}
+		if n.Body != nil {
+			c.Body = Clone(n.Body).(*BlockStatement)
+		}
+		c.FunctionSpan = n.FunctionSpan.clone()
+		return &c
+	case *RestProperty:
+		if n == nil {
+			return n
+		}
+
+		c := *n
+		c.BaseNode = n.BaseNode.clone()
+		if n.Argument != nil {
+			c.Argument = Clone(n.Argument).(Expression)
+		}
+		return &c
+	case *SpreadProperty:
+		if n == nil {
+			return n
+		}
+
+		c := *n
+		c.BaseNode = n.BaseNode.clone()
+		if n.Argument != nil {
+			c.Argument = Clone(n.Argument).(Expression)
+		}
+		return &c
+	case *FunctionExpression:
+		if n == nil {
+			return n
+		}
+
+		c := *n
+		c.BaseNode = n.BaseNode.clone()
+		if n.ID != nil {
+			c.ID = Clone(n.ID).(*Identifier)
+		}
+		if n.Params != nil {
+			c.Params = make([]Pattern, len(n.Params))
+			for i, e := range n.Params {
+				if e != nil {
+					c.Params[i] = Clone(e).(Pattern)
+				}
+			}
+		}
+		if n.Body != nil {
+			c.Body = Clone(n.Body).(*BlockStatement)
+		}
+		return &c
+	case *UnaryExpression:
+		if n == nil {
+			return n
+		}
+
+		c := *n
+		c.BaseNode = n.BaseNode.clone()
+		if n.Argument != nil {
+			c.Argument = Clone(n.Argument).(Expression)
+		}
+		return &c
+	case *UpdateExpression:
+		if n == nil {
+			return n
+		}
+
+		c := *n
+		c.BaseNode = n.BaseNode.clone()
+		if n.Argument != nil {
+			c.Argument = Clone(n.Argument).(Expression)
+		}
+		return &c
+	case *BinaryExpression:
+		if n == nil {
+			return n
+		}
+
+		c := *n
+		c.BaseNode = n.BaseNode.clone()
+		if n.Left != nil {
+			c.Left = Clone(n.Left).(ExpressionOrPrivateName)
+		}
+		if n.Right != nil {
+			c.Right = Clone(n.Right).(Expression)
+		}
+		return &c
+	case *AssignmentExpression:
+		if n == nil {
+			return n
+		}
+
+		c := *n
+		c.BaseNode = n.BaseNode.clone()
+		if n.Left != nil {
+			c.Left = Clone(n.Left).(PatternOrExpression)
+		}
+		if n.Right != nil {
+			c.Right = Clone(n.Right).(Expression)
+		}
+		return &c
+	case *LogicalExpression:
+		if n == nil {
+			return n
+		}
+
+		c := *n
+		c.BaseNode = n.BaseNode.clone()
+		if n.Left != nil {
+			c.Left = Clone(n.Left).(Expression)
+		}
+		if n.Right != nil {
+			c.Right = Clone(n.Right).(Expression)
+		}
+		return &c
+	case *SpreadElement:
+		if n == nil {
+			return n
+		}
+
+		c := *n
+		c.BaseNode = n.BaseNode.clone()
+		if n.Argument != nil {
+			c.Argument = Clone(n.Argument).(Expression)
+		}
+		return &c
+	case *MemberExpression:
+		if n == nil {
+			return n
+		}
+
+		c := *n
+		c.BaseNode = n.BaseNode.clone()
+		if n.Object != nil {
+			c.Object = Clone(n.Object).(ExpressionOrSuper)
+		}
+		if n.Property != nil {
+			c.Property = Clone(n.Property).(ExpressionOrPrivateName)
+		}
+		return &c
+	case *OptionalMemberExpression:
+		if n == nil {
+			return n
+		}
+
+		c := *n
+		c.BaseNode = n.BaseNode.clone()
+		if n.Object != nil {
+			c.Object = Clone(n.Object).(Expression)
+		}
+		if n.Property != nil {
+			c.Property = Clone(n.Property).(ExpressionOrPrivateName)
+		}
+		c.ChainSpan = n.ChainSpan.clone()
+		return &c
+	case *BindExpression:
+		if n == nil {
+			return n
+		}
+
+		c := *n
+		c.BaseNode = n.BaseNode.clone()
+		if n.Object != nil {
+			c.Object = make([]Expression, len(n.Object))
+			for i, e := range n.Object {
+				if e != nil {
+					c.Object[i] = Clone(e).(Expression)
+				}
+			}
+		}
+		if n.Callee != nil {
+			c.Callee = make([]Expression, len(n.Callee))
+			for i, e := range n.Callee {
+				if e != nil {
+					c.Callee[i] = Clone(e).(Expression)
+				}
+			}
+		}
+		return &c
+	case *ConditionalExpression:
+		if n == nil {
+			return n
+		}
+
+		c := *n
+		c.BaseNode = n.BaseNode.clone()
+		if n.Test != nil {
+			c.Test = Clone(n.Test).(Expression)
+		}
+		if n.Alternate != nil {
+			c.Alternate = Clone(n.Alternate).(Expression)
+		}
+		if n.Consequent != nil {
+			c.Consequent = Clone(n.Consequent).(Expression)
+		}
+		return &c
+	case *CallExpression:
+		if n == nil {
+			return n
+		}
+
+		c := *n
+		c.BaseNode = n.BaseNode.clone()
+		if n.Callee != nil {
+			c.Callee = Clone(n.Callee).(ExpressionOrSuper)
+		}
+		if n.Arguments != nil {
+			c.Arguments = make([]ExpressionOrSpreadElement, len(n.Arguments))
+			for i, e := range n.Arguments {
+				if e != nil {
+					c.Arguments[i] = Clone(e).(ExpressionOrSpreadElement)
+				}
+			}
+		}
+		return &c
+	case *OptionalCallExpression:
+		if n == nil {
+			return n
+		}
+
+		c := *n
+		c.BaseNode = n.BaseNode.clone()
+		if n.Callee != nil {
+			c.Callee = Clone(n.Callee).(Expression)
+		}
+		if n.Arguments != nil {
+			c.Arguments = make([]ExpressionOrSpreadElement, len(n.Arguments))
+			for i, e := range n.Arguments {
+				if e != nil {
+					c.Arguments[i] = Clone(e).(ExpressionOrSpreadElement)
+				}
+			}
+		}
+		c.ChainSpan = n.ChainSpan.clone()
+		return &c
+	case *NewExpression:
+		if n == nil {
+			return n
+		}
+
+		c := *n
+		c.BaseNode = n.BaseNode.clone()
+		if n.Callee != nil {
+			c.Callee = Clone(n.Callee).(ExpressionOrSuper)
+		}
+		if n.Arguments != nil {
+			c.Arguments = make([]ExpressionOrSpreadElement, len(n.Arguments))
+			for i, e := range n.Arguments {
+				if e != nil {
+					c.Arguments[i] = Clone(e).(ExpressionOrSpreadElement)
+				}
+			}
+		}
+		return &c
+	case *SequenceExpression:
+		if n == nil {
+			return n
+		}
+
+		c := *n
+		c.BaseNode = n.BaseNode.clone()
+		if n.Expressions != nil {
+			c.Expressions = make([]Expression, len(n.Expressions))
+			for i, e := range n.Expressions {
+				if e != nil {
+					c.Expressions[i] = Clone(e).(Expression)
+				}
+			}
+		}
+		return &c
+	case *ImportExpression:
+		if n == nil {
+			return n
+		}
+
+		c := *n
+		c.BaseNode = n.BaseNode.clone()
+		if n.Source != nil {
+			c.Source = Clone(n.Source).(Expression)
+		}
+		if n.Options != nil {
+			c.Options = Clone(n.Options).(Expression)
+		}
+		return &c
+	case *TemplateLiteral:
+		if n == nil {
+			return n
+		}
+
+		c := *n
+		c.BaseNode = n.BaseNode.clone()
+		if n.Quasis != nil {
+			c.Quasis = make([]*TemplateElement, len(n.Quasis))
+			for i, e := range n.Quasis {
+				if e != nil {
+					c.Quasis[i] = Clone(e).(*TemplateElement)
+				}
+			}
+		}
+		if n.Expressions != nil {
+			c.Expressions = make([]Expression, len(n.Expressions))
+			for i, e := range n.Expressions {
+				if e != nil {
+					c.Expressions[i] = Clone(e).(Expression)
+				}
+			}
+		}
+		return &c
+	case *TaggedTemplateExpression:
+		if n == nil {
+			return n
+		}
+
+		c := *n
+		c.BaseNode = n.BaseNode.clone()
+		if n.Tag != nil {
+			c.Tag = Clone(n.Tag).(Expression)
+		}
+		if n.Quasi != nil {
+			c.Quasi = Clone(n.Quasi).(*TemplateLiteral)
+		}
+		return &c
+	case *TemplateElement:
+		if n == nil {
+			return n
+		}
+
+		c := *n
+		c.BaseNode = n.BaseNode.clone()
+		if n.Cooked != nil {
+			v := *n.Cooked
+			c.Cooked = &v
+		}
+		return &c
+	case *AssignmentProperty:
+		if n == nil {
+			return n
+		}
+
+		c := *n
+		c.BaseNode = n.BaseNode.clone()
+		if n.Key != nil {
+			c.Key = Clone(n.Key).(Expression)
+		}
+		if n.Value != nil {
+			c.Value = Clone(n.Value).(Pattern)
+		}
+		if n.Decorators != nil {
+			c.Decorators = make([]*Decorator, len(n.Decorators))
+			for i, e := range n.Decorators {
+				if e != nil {
+					c.Decorators[i] = Clone(e).(*Decorator)
+				}
+			}
+		}
+		return &c
+	case *ObjectPattern:
+		if n == nil {
+			return n
+		}
+
+		c := *n
+		c.BaseNode = n.BaseNode.clone()
+		if n.Properties != nil {
+			c.Properties = make([]AssignmentPropertyOrRestProperty, len(n.Properties))
+			for i, e := range n.Properties {
+				if e != nil {
+					c.Properties[i] = Clone(e).(AssignmentPropertyOrRestProperty)
+				}
+			}
+		}
+		return &c
+	case *ArrayPattern:
+		if n == nil {
+			return n
+		}
+
+		c := *n
+		c.BaseNode = n.BaseNode.clone()
+		if n.Elements != nil {
+			c.Elements = make([]Pattern, len(n.Elements))
+			for i, e := range n.Elements {
+				if e != nil {
+					c.Elements[i] = Clone(e).(Pattern)
+				}
+			}
+		}
+		return &c
+	case *RestElement:
+		if n == nil {
+			return n
+		}
+
+		c := *n
+		c.BaseNode = n.BaseNode.clone()
+		if n.Argument != nil {
+			c.Argument = Clone(n.Argument).(Pattern)
+		}
+		return &c
+	case *AssignmentPattern:
+		if n == nil {
+			return n
+		}
+
+		c := *n
+		c.BaseNode = n.BaseNode.clone()
+		if n.Left != nil {
+			c.Left = Clone(n.Left).(Pattern)
+		}
+		if n.Right != nil {
+			c.Right = Clone(n.Right).(Expression)
+		}
+		return &c
+	case *ClassBody:
+		if n == nil {
+			return n
+		}
+
+		c := *n
+		c.BaseNode = n.BaseNode.clone()
+		if n.Body != nil {
+			c.Body = make([]ClassMethodOrClassPrivateMethodOrClassPropertyOrClassPrivatePropertyOrStaticBlock, len(n.Body))
+			for i, e := range n.Body {
+				if e != nil {
+					c.Body[i] = Clone(e).(ClassMethodOrClassPrivateMethodOrClassPropertyOrClassPrivatePropertyOrStaticBlock)
+				}
+			}
+		}
+		return &c
+	case *ClassMethod:
+		if n == nil {
+			return n
+		}
+
+		c := *n
+		c.BaseNode = n.BaseNode.clone()
+		if n.Key != nil {
+			c.Key = Clone(n.Key).(Expression)
+		}
+		if n.Value != nil {
+			c.Value = Clone(n.Value).(*FunctionExpression)
+		}
+		if n.Decorators != nil {
+			c.Decorators = make([]*Decorator, len(n.Decorators))
+			for i, e := range n.Decorators {
+				if e != nil {
+					c.Decorators[i] = Clone(e).(*Decorator)
+				}
+			}
+		}
+		return &c
+	case *ClassPrivateMethod:
+		if n == nil {
+			return n
+		}
+
+		c := *n
+		c.BaseNode = n.BaseNode.clone()
+		if n.Key != nil {
+			c.Key = Clone(n.Key).(*PrivateName)
+		}
+		if n.Value != nil {
+			c.Value = Clone(n.Value).(*FunctionExpression)
+		}
+		if n.Decorators != nil {
+			c.Decorators = make([]*Decorator, len(n.Decorators))
+			for i, e := range n.Decorators {
+				if e != nil {
+					c.Decorators[i] = Clone(e).(*Decorator)
+				}
+			}
+		}
+		return &c
+	case *ClassProperty:
+		if n == nil {
+			return n
+		}
+
+		c := *n
+		c.BaseNode = n.BaseNode.clone()
+		if n.Key != nil {
+			c.Key = Clone(n.Key).(Expression)
+		}
+		if n.Value != nil {
+			c.Value = Clone(n.Value).(Expression)
+		}
+		if n.Decorators != nil {
+			c.Decorators = make([]*Decorator, len(n.Decorators))
+			for i, e := range n.Decorators {
+				if e != nil {
+					c.Decorators[i] = Clone(e).(*Decorator)
+				}
+			}
+		}
+		return &c
+	case *ClassPrivateProperty:
+		if n == nil {
+			return n
+		}
+
+		c := *n
+		c.BaseNode = n.BaseNode.clone()
+		if n.Key != nil {
+			c.Key = Clone(n.Key).(*PrivateName)
+		}
+		if n.Value != nil {
+			c.Value = Clone(n.Value).(Expression)
+		}
+		if n.Decorators != nil {
+			c.Decorators = make([]*Decorator, len(n.Decorators))
+			for i, e := range n.Decorators {
+				if e != nil {
+					c.Decorators[i] = Clone(e).(*Decorator)
+				}
+			}
+		}
+		return &c
+	case *StaticBlock:
+		if n == nil {
+			return n
+		}
+
+		c := *n
+		c.BaseNode = n.BaseNode.clone()
+		if n.Body != nil {
+			c.Body = make([]Statement, len(n.Body))
+			for i, e := range n.Body {
+				if e != nil {
+					c.Body[i] = Clone(e).(Statement)
+				}
+			}
+		}
+		return &c
+	case *ClassDeclaration:
+		if n == nil {
+			return n
+		}
+
+		c := *n
+		c.BaseNode = n.BaseNode.clone()
+		if n.ID != nil {
+			c.ID = Clone(n.ID).(*Identifier)
+		}
+		if n.SuperClass != nil {
+			c.SuperClass = Clone(n.SuperClass).(Expression)
+		}
+		if n.Body != nil {
+			c.Body = Clone(n.Body).(*ClassBody)
+		}
+		if n.Decorators != nil {
+			c.Decorators = make([]*Decorator, len(n.Decorators))
+			for i, e := range n.Decorators {
+				if e != nil {
+					c.Decorators[i] = Clone(e).(*Decorator)
+				}
+			}
+		}
+		return &c
+	case *ClassExpression:
+		if n == nil {
+			return n
+		}
+
+		c := *n
+		c.BaseNode = n.BaseNode.clone()
+		if n.ID != nil {
+			c.ID = Clone(n.ID).(*Identifier)
+		}
+		if n.SuperClass != nil {
+			c.SuperClass = Clone(n.SuperClass).(Expression)
+		}
+		if n.Body != nil {
+			c.Body = Clone(n.Body).(*ClassBody)
+		}
+		if n.Decorators != nil {
+			c.Decorators = make([]*Decorator, len(n.Decorators))
+			for i, e := range n.Decorators {
+				if e != nil {
+					c.Decorators[i] = Clone(e).(*Decorator)
+				}
+			}
+		}
+		return &c
+	case *MetaProperty:
+		if n == nil {
+			return n
+		}
+
+		c := *n
+		c.BaseNode = n.BaseNode.clone()
+		if n.Meta != nil {
+			c.Meta = Clone(n.Meta).(*Identifier)
+		}
+		if n.Property != nil {
+			c.Property = Clone(n.Property).(*Identifier)
+		}
+		return &c
+	case *ImportDeclaration:
+		if n == nil {
+			return n
+		}
+
+		c := *n
+		c.BaseNode = n.BaseNode.clone()
+		if n.Specifiers != nil {
+			c.Specifiers = make([]ImportSpecifierOrImportDefaultSpecifierOrImportNamespaceSpecifier, len(n.Specifiers))
+			for i, e := range n.Specifiers {
+				if e != nil {
+					c.Specifiers[i] = Clone(e).(ImportSpecifierOrImportDefaultSpecifierOrImportNamespaceSpecifier)
+				}
+			}
+		}
+		if n.Source != nil {
+			c.Source = Clone(n.Source).(Literal)
+		}
+		if n.Attributes != nil {
+			c.Attributes = make([]*ImportAttribute, len(n.Attributes))
+			for i, e := range n.Attributes {
+				if e != nil {
+					c.Attributes[i] = Clone(e).(*ImportAttribute)
+				}
+			}
+		}
+		return &c
+	case *ImportSpecifier:
+		if n == nil {
+			return n
+		}
+
+		c := *n
+		c.BaseNode = n.BaseNode.clone()
+		if n.Local != nil {
+			c.Local = Clone(n.Local).(*Identifier)
+		}
+		if n.Imported != nil {
+			c.Imported = Clone(n.Imported).(*Identifier)
+		}
+		return &c
+	case *ImportDefaultSpecifier:
+		if n == nil {
+			return n
+		}
+
+		c := *n
+		c.BaseNode = n.BaseNode.clone()
+		if n.Local != nil {
+			c.Local = Clone(n.Local).(*Identifier)
+		}
+		return &c
+	case *ImportNamespaceSpecifier:
+		if n == nil {
+			return n
+		}
+
+		c := *n
+		c.BaseNode = n.BaseNode.clone()
+		if n.Local != nil {
+			c.Local = Clone(n.Local).(*Identifier)
+		}
+		return &c
+	case *ImportAttribute:
+		if n == nil {
+			return n
+		}
+
+		c := *n
+		c.BaseNode = n.BaseNode.clone()
+		if n.Key != nil {
+			c.Key = Clone(n.Key).(IdentifierOrStringLiteral)
+		}
+		if n.Value != nil {
+			c.Value = Clone(n.Value).(*StringLiteral)
+		}
+		return &c
+	case *ExportNamedDeclaration:
+		if n == nil {
+			return n
+		}
+
+		c := *n
+		c.BaseNode = n.BaseNode.clone()
+		if n.Declaration != nil {
+			c.Declaration = Clone(n.Declaration).(Declaration)
+		}
+		if n.Specifiers != nil {
+			c.Specifiers = make([]*ExportSpecifier, len(n.Specifiers))
+			for i, e := range n.Specifiers {
+				if e != nil {
+					c.Specifiers[i] = Clone(e).(*ExportSpecifier)
+				}
+			}
+		}
+		if n.Source != nil {
+			c.Source = Clone(n.Source).(Literal)
+		}
+		if n.Attributes != nil {
+			c.Attributes = make([]*ImportAttribute, len(n.Attributes))
+			for i, e := range n.Attributes {
+				if e != nil {
+					c.Attributes[i] = Clone(e).(*ImportAttribute)
+				}
+			}
+		}
+		return &c
+	case *ExportSpecifier:
+		if n == nil {
+			return n
+		}
+
+		c := *n
+		c.BaseNode = n.BaseNode.clone()
+		if n.Local != nil {
+			c.Local = Clone(n.Local).(*Identifier)
+		}
+		if n.Exported != nil {
+			c.Exported = Clone(n.Exported).(*Identifier)
+		}
+		return &c
+	case *ExportDefaultDeclaration:
+		if n == nil {
+			return n
+		}
+
+		c := *n
+		c.BaseNode = n.BaseNode.clone()
+		if n.Declaration != nil {
+			c.Declaration = Clone(n.Declaration).(DeclarationOrExpression)
+		}
+		return &c
+	case *ExportAllDeclaration:
+		if n == nil {
+			return n
+		}
+
+		c := *n
+		c.BaseNode = n.BaseNode.clone()
+		if n.Source != nil {
+			c.Source = Clone(n.Source).(Literal)
+		}
+		if n.Attributes != nil {
+			c.Attributes = make([]*ImportAttribute, len(n.Attributes))
+			for i, e := range n.Attributes {
+				if e != nil {
+					c.Attributes[i] = Clone(e).(*ImportAttribute)
+				}
+			}
+		}
+		return &c
+	}
+
+	return node
+}
+func (v *validator) fields(path string, node Node) {
+	switch n := node.(type) {
+	case *PrivateName:
+		if missing(n.ID) {
+			v.add(path, &FieldError{Type: "PrivateName", Field: "id", Value: nil})
+		}
+	case *Program:
+		if n.SourceType != "script" && n.SourceType != "module" {
+			v.add(path, &FieldError{Type: "Program", Field: "sourceType", Value: n.SourceType})
+		}
+		for _, e := range n.Body {
+			if missing(e) {
+				v.add(path, &FieldError{Type: "Program", Field: "body", Value: nil})
+			}
+		}
+		for _, e := range n.Directives {
+			if missing(e) {
+				v.add(path, &FieldError{Type: "Program", Field: "directives", Value: nil})
+			}
+		}
+	case *ExpressionStatement:
+		if missing(n.Expression) {
+			v.add(path, &FieldError{Type: "ExpressionStatement", Field: "expression", Value: nil})
+		}
+	case *BlockStatement:
+		for _, e := range n.Body {
+			if missing(e) {
+				v.add(path, &FieldError{Type: "BlockStatement", Field: "body", Value: nil})
+			}
+		}
+		for _, e := range n.Directives {
+			if missing(e) {
+				v.add(path, &FieldError{Type: "BlockStatement", Field: "directives", Value: nil})
+			}
+		}
+	case *WithStatement:
+		if missing(n.Object) {
+			v.add(path, &FieldError{Type: "WithStatement", Field: "object", Value: nil})
+		}
+		if missing(n.Body) {
+			v.add(path, &FieldError{Type: "WithStatement", Field: "body", Value: nil})
+		}
+	case *LabeledStatement:
+		if missing(n.Label) {
+			v.add(path, &FieldError{Type: "LabeledStatement", Field: "label", Value: nil})
+		}
+		if missing(n.Body) {
+			v.add(path, &FieldError{Type: "LabeledStatement", Field: "body", Value: nil})
+		}
+	case *IfStatement:
+		if missing(n.Test) {
+			v.add(path, &FieldError{Type: "IfStatement", Field: "test", Value: nil})
+		}
+		if missing(n.Consequent) {
+			v.add(path, &FieldError{Type: "IfStatement", Field: "consequent", Value: nil})
+		}
+	case *SwitchStatement:
+		if missing(n.Discriminant) {
+			v.add(path, &FieldError{Type: "SwitchStatement", Field: "discriminant", Value: nil})
+		}
+		for _, e := range n.Cases {
+			if missing(e) {
+				v.add(path, &FieldError{Type: "SwitchStatement", Field: "cases", Value: nil})
+			}
+		}
+	case *SwitchCase:
+		for _, e := range n.Consequent {
+			if missing(e) {
+				v.add(path, &FieldError{Type: "SwitchCase", Field: "consequent", Value: nil})
+			}
+		}
+	case *ThrowStatement:
+		if missing(n.Argument) {
+			v.add(path, &FieldError{Type: "ThrowStatement", Field: "argument", Value: nil})
+		}
+	case *TryStatement:
+		if missing(n.Block) {
+			v.add(path, &FieldError{Type: "TryStatement", Field: "block", Value: nil})
+		}
+	case *CatchClause:
+		if missing(n.Param) {
+			v.add(path, &FieldError{Type: "CatchClause", Field: "param", Value: nil})
+		}
+		if missing(n.Body) {
+			v.add(path, &FieldError{Type: "CatchClause", Field: "body", Value: nil})
+		}
+	case *WhileStatement:
+		if missing(n.Test) {
+			v.add(path, &FieldError{Type: "WhileStatement", Field: "test", Value: nil})
+		}
+		if missing(n.Body) {
+			v.add(path, &FieldError{Type: "WhileStatement", Field: "body", Value: nil})
+		}
+	case *DoWhileStatement:
+		if missing(n.Body) {
+			v.add(path, &FieldError{Type: "DoWhileStatement", Field: "body", Value: nil})
+		}
+		if missing(n.Test) {
+			v.add(path, &FieldError{Type: "DoWhileStatement", Field: "test", Value: nil})
+		}
+	case *ForStatement:
+		if missing(n.Body) {
+			v.add(path, &FieldError{Type: "ForStatement", Field: "body", Value: nil})
+		}
+	case *ForInStatement:
+		if missing(n.Left) {
+			v.add(path, &FieldError{Type: "ForInStatement", Field: "left", Value: nil})
+		}
+		if missing(n.Right) {
+			v.add(path, &FieldError{Type: "ForInStatement", Field: "right", Value: nil})
+		}
+		if missing(n.Body) {
+			v.add(path, &FieldError{Type: "ForInStatement", Field: "body", Value: nil})
+		}
+	case *ForOfStatement:
+		if missing(n.Left) {
+			v.add(path, &FieldError{Type: "ForOfStatement", Field: "left", Value: nil})
+		}
+		if missing(n.Right) {
+			v.add(path, &FieldError{Type: "ForOfStatement", Field: "right", Value: nil})
+		}
+		if missing(n.Body) {
+			v.add(path, &FieldError{Type: "ForOfStatement", Field: "body", Value: nil})
+		}
+	case *FunctionDeclaration:
+		if missing(n.ID) {
+			v.add(path, &FieldError{Type: "FunctionDeclaration", Field: "id", Value: nil})
+		}
+		for _, e := range n.Params {
+			if missing(e) {
+				v.add(path, &FieldError{Type: "FunctionDeclaration", Field: "params", Value: nil})
+			}
+		}
+		if missing(n.Body) {
+			v.add(path, &FieldError{Type: "FunctionDeclaration", Field: "body", Value: nil})
+		}
+	case *VariableDeclaration:
+		for _, e := range n.Declarations {
+			if missing(e) {
+				v.add(path, &FieldError{Type: "VariableDeclaration", Field: "declarations", Value: nil})
+			}
+		}
+		if n.Kind != "var" && n.Kind != "let" && n.Kind != "const" {
+			v.add(path, &FieldError{Type: "VariableDeclaration", Field: "kind", Value: n.Kind})
+		}
+	case *VariableDeclarator:
+		if missing(n.ID) {
+			v.add(path, &FieldError{Type: "VariableDeclarator", Field: "id", Value: nil})
+		}
+	case *Decorator:
+		if missing(n.Expression) {
+			v.add(path, &FieldError{Type: "Decorator", Field: "expression", Value: nil})
+		}
+	case *Directive:
+		if missing(n.Value) {
+			v.add(path, &FieldError{Type: "Directive", Field: "value", Value: nil})
+		}
+	case *ArrowFunctionExpression:
+		for _, e := range n.Params {
+			if missing(e) {
+				v.add(path, &FieldError{Type: "ArrowFunctionExpression", Field: "params", Value: nil})
+			}
+		}
+		if missing(n.Body) {
+			v.add(path, &FieldError{Type: "ArrowFunctionExpression", Field: "body", Value: nil})
+		}
+	case *ObjectExpression:
+		for _, e := range n.Properties {
+			if missing(e) {
+				v.add(path, &FieldError{Type: "ObjectExpression", Field: "properties", Value: nil})
+			}
+		}
+	case *ObjectProperty:
+		if missing(n.Key) {
+			v.add(path, &FieldError{Type: "ObjectProperty", Field: "key", Value: nil})
+		}
+		if missing(n.Value) {
+			v.add(path, &FieldError{Type: "ObjectProperty", Field: "value", Value: nil})
+		}
+		for _, e := range n.Decorators {
+			if missing(e) {
+				v.add(path, &FieldError{Type: "ObjectProperty", Field: "decorators", Value: nil})
+			}
+		}
+	case *ObjectMethod:
+		if missing(n.Key) {
+			v.add(path, &FieldError{Type: "ObjectMethod", Field: "key", Value: nil})
+		}
+		if missing(n.Value) {
+			v.add(path, &FieldError{Type: "ObjectMethod", Field: "value", Value: nil})
+		}
+		for _, e := range n.Decorators {
+			if missing(e) {
+				v.add(path, &FieldError{Type: "ObjectMethod", Field: "decorators", Value: nil})
+			}
+		}
+		for _, e := range n.Params {
+			if missing(e) {
+				v.add(path, &FieldError{Type: "ObjectMethod", Field: "params", Value: nil})
+			}
+		}
+		if missing(n.Body) {
+			v.add(path, &FieldError{Type: "ObjectMethod", Field: "body", Value: nil})
+		}
+		if n.Kind != "get" && n.Kind != "set" && n.Kind != "method" {
+			v.add(path, &FieldError{Type: "ObjectMethod", Field: "kind", Value: n.Kind})
+		}
+	case *RestProperty:
+		if missing(n.Argument) {
+			v.add(path, &FieldError{Type: "RestProperty", Field: "argument", Value: nil})
+		}
+	case *SpreadProperty:
+		if missing(n.Argument) {
+			v.add(path, &FieldError{Type: "SpreadProperty", Field: "argument", Value: nil})
+		}
+	case *FunctionExpression:
+		for _, e := range n.Params {
+			if missing(e) {
+				v.add(path, &FieldError{Type: "FunctionExpression", Field: "params", Value: nil})
+			}
+		}
+		if missing(n.Body) {
+			v.add(path, &FieldError{Type: "FunctionExpression", Field: "body", Value: nil})
+		}
+	case *UnaryExpression:
+		if !n.Operator.Valid() {
+			v.add(path, &FieldError{Type: "UnaryExpression", Field: "operator", Value: n.Operator})
+		}
+		if missing(n.Argument) {
+			v.add(path, &FieldError{Type: "UnaryExpression", Field: "argument", Value: nil})
+		}
+	case *UpdateExpression:
+		if !n.Operator.Valid() {
+			v.add(path, &FieldError{Type: "UpdateExpression", Field: "operator", Value: n.Operator})
+		}
+		if missing(n.Argument) {
+			v.add(path, &FieldError{Type: "UpdateExpression", Field: "argument", Value: nil})
+		}
+	case *BinaryExpression:
+		if !n.Operator.Valid() {
+			v.add(path, &FieldError{Type: "BinaryExpression", Field: "operator", Value: n.Operator})
+		}
+		if missing(n.Left) {
+			v.add(path, &FieldError{Type: "BinaryExpression", Field: "left", Value: nil})
+		}
+		if missing(n.Right) {
+			v.add(path, &FieldError{Type: "BinaryExpression", Field: "right", Value: nil})
+		}
+	case *AssignmentExpression:
+		if !n.Operator.Valid() {
+			v.add(path, &FieldError{Type: "AssignmentExpression", Field: "operator", Value: n.Operator})
+		}
+		if missing(n.Left) {
+			v.add(path, &FieldError{Type: "AssignmentExpression", Field: "left", Value: nil})
+		}
+		if missing(n.Right) {
+			v.add(path, &FieldError{Type: "AssignmentExpression", Field: "right", Value: nil})
+		}
+	case *LogicalExpression:
+		if !n.Operator.Valid() {
+			v.add(path, &FieldError{Type: "LogicalExpression", Field: "operator", Value: n.Operator})
+		}
+		if missing(n.Left) {
+			v.add(path, &FieldError{Type: "LogicalExpression", Field: "left", Value: nil})
+		}
+		if missing(n.Right) {
+			v.add(path, &FieldError{Type: "LogicalExpression", Field: "right", Value: nil})
+		}
+	case *SpreadElement:
+		if missing(n.Argument) {
+			v.add(path, &FieldError{Type: "SpreadElement", Field: "argument", Value: nil})
+		}
+	case *MemberExpression:
+		if missing(n.Object) {
+			v.add(path, &FieldError{Type: "MemberExpression", Field: "object", Value: nil})
+		}
+		if missing(n.Property) {
+			v.add(path, &FieldError{Type: "MemberExpression", Field: "property", Value: nil})
+		}
+	case *OptionalMemberExpression:
+		if missing(n.Object) {
+			v.add(path, &FieldError{Type: "OptionalMemberExpression", Field: "object", Value: nil})
+		}
+		if missing(n.Property) {
+			v.add(path, &FieldError{Type: "OptionalMemberExpression", Field: "property", Value: nil})
+		}
+	case *BindExpression:
+		for _, e := range n.Callee {
+			if missing(e) {
+				v.add(path, &FieldError{Type: "BindExpression", Field: "callee", Value: nil})
+			}
+		}
+	case *ConditionalExpression:
+		if missing(n.Test) {
+			v.add(path, &FieldError{Type: "ConditionalExpression", Field: "test", Value: nil})
+		}
+		if missing(n.Alternate) {
+			v.add(path, &FieldError{Type: "ConditionalExpression", Field: "alternate", Value: nil})
+		}
+		if missing(n.Consequent) {
+			v.add(path, &FieldError{Type: "ConditionalExpression", Field: "consequent", Value: nil})
+		}
+	case *CallExpression:
+		if missing(n.Callee) {
+			v.add(path, &FieldError{Type: "CallExpression", Field: "callee", Value: nil})
+		}
+		for _, e := range n.Arguments {
+			if missing(e) {
+				v.add(path, &FieldError{Type: "CallExpression", Field: "arguments", Value: nil})
+			}
+		}
+	case *OptionalCallExpression:
+		if missing(n.Callee) {
+			v.add(path, &FieldError{Type: "OptionalCallExpression", Field: "callee", Value: nil})
+		}
+		for _, e := range n.Arguments {
+			if missing(e) {
+				v.add(path, &FieldError{Type: "OptionalCallExpression", Field: "arguments", Value: nil})
+			}
+		}
+	case *NewExpression:
+		if missing(n.Callee) {
+			v.add(path, &FieldError{Type: "NewExpression", Field: "callee", Value: nil})
+		}
+		for _, e := range n.Arguments {
+			if missing(e) {
+				v.add(path, &FieldError{Type: "NewExpression", Field: "arguments", Value: nil})
+			}
+		}
+	case *SequenceExpression:
+		for _, e := range n.Expressions {
+			if missing(e) {
+				v.add(path, &FieldError{Type: "SequenceExpression", Field: "expressions", Value: nil})
+			}
+		}
+	case *ImportExpression:
+		if missing(n.Source) {
+			v.add(path, &FieldError{Type: "ImportExpression", Field: "source", Value: nil})
+		}
+	case *TemplateLiteral:
+		for _, e := range n.Quasis {
+			if missing(e) {
+				v.add(path, &FieldError{Type: "TemplateLiteral", Field: "quasis", Value: nil})
+			}
+		}
+		for _, e := range n.Expressions {
+			if missing(e) {
+				v.add(path, &FieldError{Type: "TemplateLiteral", Field: "expressions", Value: nil})
+			}
+		}
+	case *TaggedTemplateExpression:
+		if missing(n.Tag) {
+			v.add(path, &FieldError{Type: "TaggedTemplateExpression", Field: "tag", Value: nil})
+		}
+		if missing(n.Quasi) {
+			v.add(path, &FieldError{Type: "TaggedTemplateExpression", Field: "quasi", Value: nil})
+		}
+	case *AssignmentProperty:
+		if missing(n.Key) {
+			v.add(path, &FieldError{Type: "ObjectProperty", Field: "key", Value: nil})
+		}
+		if missing(n.Value) {
+			v.add(path, &FieldError{Type: "ObjectProperty", Field: "value", Value: nil})
+		}
+		for _, e := range n.Decorators {
+			if missing(e) {
+				v.add(path, &FieldError{Type: "ObjectProperty", Field: "decorators", Value: nil})
+			}
+		}
+	case *ObjectPattern:
+		for _, e := range n.Properties {
+			if missing(e) {
+				v.add(path, &FieldError{Type: "ObjectPattern", Field: "properties", Value: nil})
+			}
+		}
+	case *RestElement:
+		if missing(n.Argument) {
+			v.add(path, &FieldError{Type: "RestElement", Field: "argument", Value: nil})
+		}
+	case *AssignmentPattern:
+		if missing(n.Left) {
+			v.add(path, &FieldError{Type: "AssignmentPattern", Field: "left", Value: nil})
+		}
+		if missing(n.Right) {
+			v.add(path, &FieldError{Type: "AssignmentPattern", Field: "right", Value: nil})
+		}
+	case *ClassBody:
+		for _, e := range n.Body {
+			if missing(e) {
+				v.add(path, &FieldError{Type: "ClassBody", Field: "body", Value: nil})
+			}
+		}
+	case *ClassMethod:
+		if missing(n.Key) {
+			v.add(path, &FieldError{Type: "ClassMethod", Field: "key", Value: nil})
+		}
+		if missing(n.Value) {
+			v.add(path, &FieldError{Type: "ClassMethod", Field: "value", Value: nil})
+		}
+		if n.Kind != "constructor" && n.Kind != "method" && n.Kind != "get" && n.Kind != "set" {
+			v.add(path, &FieldError{Type: "ClassMethod", Field: "kind", Value: n.Kind})
+		}
+		for _, e := range n.Decorators {
+			if missing(e) {
+				v.add(path, &FieldError{Type: "ClassMethod", Field: "decorators", Value: nil})
+			}
+		}
+	case *ClassPrivateMethod:
+		if missing(n.Key) {
+			v.add(path, &FieldError{Type: "ClassPrivateMethod", Field: "key", Value: nil})
+		}
+		if missing(n.Value) {
+			v.add(path, &FieldError{Type: "ClassPrivateMethod", Field: "value", Value: nil})
+		}
+		if n.Kind != "method" && n.Kind != "get" && n.Kind != "set" {
+			v.add(path, &FieldError{Type: "ClassPrivateMethod", Field: "kind", Value: n.Kind})
+		}
+		for _, e := range n.Decorators {
+			if missing(e) {
+				v.add(path, &FieldError{Type: "ClassPrivateMethod", Field: "decorators", Value: nil})
+			}
+		}
+	case *ClassProperty:
+		if missing(n.Key) {
+			v.add(path, &FieldError{Type: "ClassProperty", Field: "key", Value: nil})
+		}
+		if missing(n.Value) {
+			v.add(path, &FieldError{Type: "ClassProperty", Field: "value", Value: nil})
+		}
+		for _, e := range n.Decorators {
+			if missing(e) {
+				v.add(path, &FieldError{Type: "ClassProperty", Field: "decorators", Value: nil})
+			}
+		}
+	case *ClassPrivateProperty:
+		if missing(n.Key) {
+			v.add(path, &FieldError{Type: "ClassPrivateProperty", Field: "key", Value: nil})
+		}
+		for _, e := range n.Decorators {
+			if missing(e) {
+				v.add(path, &FieldError{Type: "ClassPrivateProperty", Field: "decorators", Value: nil})
+			}
+		}
+	case *StaticBlock:
+		for _, e := range n.Body {
+			if missing(e) {
+				v.add(path, &FieldError{Type: "StaticBlock", Field: "body", Value: nil})
+			}
+		}
+	case *ClassDeclaration:
+		if missing(n.ID) {
+			v.add(path, &FieldError{Type: "ClassDeclaration", Field: "id", Value: nil})
+		}
+		if missing(n.Body) {
+			v.add(path, &FieldError{Type: "ClassDeclaration", Field: "body", Value: nil})
+		}
+		for _, e := range n.Decorators {
+			if missing(e) {
+				v.add(path, &FieldError{Type: "ClassDeclaration", Field: "decorators", Value: nil})
+			}
+		}
+	case *ClassExpression:
+		if missing(n.Body) {
+			v.add(path, &FieldError{Type: "ClassExpression", Field: "body", Value: nil})
+		}
+		for _, e := range n.Decorators {
+			if missing(e) {
+				v.add(path, &FieldError{Type: "ClassExpression", Field: "decorators", Value: nil})
+			}
+		}
+	case *MetaProperty:
+		if missing(n.Meta) {
+			v.add(path, &FieldError{Type: "MetaProperty", Field: "meta", Value: nil})
+		}
+		if missing(n.Property) {
+			v.add(path, &FieldError{Type: "MetaProperty", Field: "property", Value: nil})
+		}
+	case *ImportDeclaration:
+		for _, e := range n.Specifiers {
+			if missing(e) {
+				v.add(path, &FieldError{Type: "ImportDeclaration", Field: "specifiers", Value: nil})
+			}
+		}
+		if missing(n.Source) {
+			v.add(path, &FieldError{Type: "ImportDeclaration", Field: "source", Value: nil})
+		}
+		for _, e := range n.Attributes {
+			if missing(e) {
+				v.add(path, &FieldError{Type: "ImportDeclaration", Field: "attributes", Value: nil})
+			}
+		}
+	case *ImportSpecifier:
+		if missing(n.Local) {
+			v.add(path, &FieldError{Type: "ImportSpecifier", Field: "local", Value: nil})
+		}
+		if missing(n.Imported) {
+			v.add(path, &FieldError{Type: "ImportSpecifier", Field: "imported", Value: nil})
+		}
+	case *ImportDefaultSpecifier:
+		if missing(n.Local) {
+			v.add(path, &FieldError{Type: "ImportDefaultSpecifier", Field: "local", Value: nil})
+		}
+	case *ImportNamespaceSpecifier:
+		if missing(n.Local) {
+			v.add(path, &FieldError{Type: "ImportNamespaceSpecifier", Field: "local", Value: nil})
+		}
+	case *ImportAttribute:
+		if missing(n.Key) {
+			v.add(path, &FieldError{Type: "ImportAttribute", Field: "key", Value: nil})
+		}
+		if missing(n.Value) {
+			v.add(path, &FieldError{Type: "ImportAttribute", Field: "value", Value: nil})
+		}
+	case *ExportNamedDeclaration:
+		for _, e := range n.Specifiers {
+			if missing(e) {
+				v.add(path, &FieldError{Type: "ExportNamedDeclaration", Field: "specifiers", Value: nil})
+			}
+		}
+		for _, e := range n.Attributes {
+			if missing(e) {
+				v.add(path, &FieldError{Type: "ExportNamedDeclaration", Field: "attributes", Value: nil})
+			}
+		}
+	case *ExportSpecifier:
+		if missing(n.Local) {
+			v.add(path, &FieldError{Type: "ExportSpecifier", Field: "local", Value: nil})
+		}
+		if missing(n.Exported) {
+			v.add(path, &FieldError{Type: "ExportSpecifier", Field: "exported", Value: nil})
+		}
+	case *ExportDefaultDeclaration:
+		if missing(n.Declaration) {
+			v.add(path, &FieldError{Type: "ExportDefaultDeclaration", Field: "declaration", Value: nil})
+		}
+	case *ExportAllDeclaration:
+		if missing(n.Source) {
+			v.add(path, &FieldError{Type: "ExportAllDeclaration", Field: "source", Value: nil})
+		}
+		for _, e := range n.Attributes {
+			if missing(e) {
+				v.add(path, &FieldError{Type: "ExportAllDeclaration", Field: "attributes", Value: nil})
+			}
+		}
+	}
 }
@@ -0,0 +1,245 @@
+package ast
+
+import (
+	"encoding/json"
+	"flag"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var update = flag.Bool("update", false, "Rewrite the golden files in testdata")
+
+func golden(t *testing.T, name string, n Node) {
+	a := assert.New(t)
+
+	b, err := json.MarshalIndent(n, "", "  ")
+	if !a.NoError(err) {
+		return
+	}
+
+	b = append(b, '\n')
+
+	p := filepath.Join("testdata", name+".json")
+
+	if *update {
+		a.NoError(ioutil.WriteFile(p, b, 0644))
+		return
+	}
+
+	expected, err := ioutil.ReadFile(p)
+	if a.NoError(err) {
+		a.Equal(string(expected), string(b))
+	}
+}
+
+func TestMarshalProgram(t *testing.T) {
+	// var a = 1; a + b;
+	golden(t, "program", &Program{
+		SourceType: "script",
+		Body: []StatementOrModuleDeclaration{
+			&VariableDeclaration{
+				Kind: "var",
+				Declarations: []*VariableDeclarator{
+					{ID: &Identifier{Name: "a"}, Init: &NumericLiteral{Value: 1}},
+				},
+			},
+			&ExpressionStatement{
+				Expression: &BinaryExpression{
+					Operator: BinaryOperatorPlus,
+					Left:     &Identifier{Name: "a"},
+					Right:    &Identifier{Name: "b"},
+				},
+			},
+		},
+	})
+}
+
+func TestMarshalFunction(t *testing.T) {
+	// function f() { if (x) return; }
+	golden(t, "function", &FunctionDeclaration{
+		ID: &Identifier{Name: "f"},
+		Body: &BlockStatement{
+			Body: []Statement{
+				&IfStatement{
+					Test:       &Identifier{Name: "x"},
+					Consequent: &ReturnStatement{},
+				},
+			},
+		},
+	})
+}
+
+func TestMarshalModule(t *testing.T) {
+	// import a, * as b from "c"; export { a }; [a, , ...b];
+	golden(t, "module", &Program{
+		SourceType: "module",
+		Body: []StatementOrModuleDeclaration{
+			&ImportDeclaration{
+				Specifiers: []ImportSpecifierOrImportDefaultSpecifierOrImportNamespaceSpecifier{
+					&ImportDefaultSpecifier{Local: &Identifier{Name: "a"}},
+					&ImportNamespaceSpecifier{Local: &Identifier{Name: "b"}},
+				},
+				Source: &StringLiteral{Value: "c"},
+			},
+			&ExportNamedDeclaration{
+				Specifiers: []*ExportSpecifier{
+					{Local: &Identifier{Name: "a"}, Exported: &Identifier{Name: "a"}},
+				},
+			},
+			&ExpressionStatement{
+				Expression: &ArrayExpression{
+					Elements: []ExpressionOrSpreadElement{
+						&Identifier{Name: "a"},
+						nil,
+						&SpreadElement{Argument: &Identifier{Name: "b"}},
+					},
+				},
+			},
+		},
+	})
+}
+
+func TestMarshalInheritedType(t *testing.T) {
+	// for (x of new C()) ({ a: b } = x);
+	golden(t, "inherited", &ForOfStatement{
+		Left:  &Identifier{Name: "x"},
+		Right: &NewExpression{Callee: &Identifier{Name: "C"}},
+		Body: &ExpressionStatement{
+			Expression: &AssignmentExpression{
+				Operator: AssignmentOperatorEquals,
+				Left: &ObjectPattern{
+					Properties: []AssignmentPropertyOrRestProperty{
+						&AssignmentProperty{Key: &Identifier{Name: "a"}, Value: &Identifier{Name: "b"}},
+					},
+				},
+				Right: &Identifier{Name: "x"},
+			},
+		},
+	})
+}
+
+func TestMarshalOverridesType(t *testing.T) {
+	a := assert.New(t)
+
+	n := Identifier{BaseNode: BaseNode{Type: "Wrong"}, Name: "a"}
+
+	b, err := json.Marshal(n)
+	a.NoError(err)
+	a.JSONEq(`{"type":"Identifier","start":0,"end":0,"loc":null,"name":"a"}`, string(b))
+}
+
+func TestUnmarshalGolden(t *testing.T) {
+	a := assert.New(t)
+
+	for _, name := range []string{"program", "function", "module", "inherited"} {
+		b, err := ioutil.ReadFile(filepath.Join("testdata", name+".json"))
+		if !a.NoError(err) {
+			continue
+		}
+
+		n, err := UnmarshalNode(b)
+		if !a.NoError(err, name) {
+			continue
+		}
+
+		c, err := json.MarshalIndent(n, "", "  ")
+		if a.NoError(err, name) {
+			a.Equal(string(b), string(c)+"\n", name)
+		}
+	}
+}
+
+func TestUnmarshalPicksType(t *testing.T) {
+	a := assert.New(t)
+
+	n, err := UnmarshalNode([]byte(`{"type":"ObjectPattern","properties":[{"type":"ObjectProperty","key":{"type":"Identifier","name":"a"},"value":{"type":"Identifier","name":"b"}}]}`))
+	a.NoError(err)
+
+	if p, ok := n.(*ObjectPattern); a.True(ok) && a.Len(p.Properties, 1) {
+		a.IsType(&AssignmentProperty{}, p.Properties[0])
+	}
+
+	_, err = UnmarshalNode([]byte(`{"type":"ExpressionStatement","expression":{"type":"BlockStatement","body":[]}}`))
+	a.Error(err)
+}
+
+func TestUnmarshalUnknown(t *testing.T) {
+	a := assert.New(t)
+
+	in := `{"type":"ExpressionStatement","start":0,"end":15,"loc":null,"expression":{"type":"JSXElement","loc":null,"children":[]}}`
+
+	n, err := UnmarshalNode([]byte(in))
+	a.NoError(err)
+
+	if s, ok := n.(*ExpressionStatement); a.True(ok) {
+		if u, ok := s.Expression.(*Unknown); a.True(ok) {
+			a.Equal("JSXElement", u.Type)
+		}
+	}
+
+	b, err := json.Marshal(n)
+	a.NoError(err)
+	a.JSONEq(in, string(b))
+}
+
+func TestInspect(t *testing.T) {
+	a := assert.New(t)
+
+	// "use strict"; @d class C {} a ? `x${b}y${c}` : d;
+	p := &Program{
+		Directives: []*Directive{{Value: &DirectiveLiteral{Value: "use strict"}}},
+		Body: []StatementOrModuleDeclaration{
+			&ClassDeclaration{
+				Decorators: []*Decorator{{Expression: &Identifier{Name: "d"}}},
+				ID:         &Identifier{Name: "C"},
+				Body:       &ClassBody{},
+			},
+			&ExpressionStatement{
+				Expression: &ConditionalExpression{
+					Test: &Identifier{Name: "a"},
+					Consequent: &TemplateLiteral{
+						Quasis: []*TemplateElement{
+							{Raw: "x"},
+							{Raw: "y"},
+							{Raw: "", Tail: true},
+						},
+						Expressions: []Expression{&Identifier{Name: "b"}, &Identifier{Name: "c"}},
+					},
+					Alternate: &Identifier{Name: "d"},
+				},
+			},
+		},
+	}
+
+	var names []string
+	Inspect(p, func(n Node) bool {
+		switch n := n.(type) {
+		case *Identifier:
+			names = append(names, n.Name)
+		case *TemplateElement:
+			names = append(names, "`"+n.Raw)
+		case *DirectiveLiteral:
+			names = append(names, n.Value)
+		}
+
+		return true
+	})
+
+	a.Equal([]string{"use strict", "d", "C", "a", "`x", "b", "`y", "c", "`", "d"}, names)
+
+	count := 0
+	Inspect(p, func(n Node) bool {
+		if n != nil {
+			count++
+		}
+
+		_, ok := n.(*ClassDeclaration)
+
+		return !ok
+	})
+
+	a.Equal(14, count)
+}
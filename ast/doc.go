@@ -0,0 +1,8 @@
+// Package ast declares the types used to represent syntax trees for
+// JavaScript, following the Babylon AST specification in generator/spec.md.
+//
+// Most of the package, in ast.go, is generated from that specification. The
+// rest of it is written by hand.
+package ast
+
+//go:generate go run ./generator -o ast.go generator/spec.md
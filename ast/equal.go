@@ -0,0 +1,270 @@
+package ast
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+)
+
+// Options change what Equal and Diff pay attention to.
+type Options struct {
+	IgnoreLoc      bool // ignore the Loc, Start and End of each node and comment
+	IgnoreComments bool // ignore comments attached to nodes
+	IgnoreRaw      bool // ignore the source text of literals, in Extra
+}
+
+// A Difference is a place where two trees don't match. Path is written like
+// a JavaScript expression, starting from the roots, like
+// "body[0].expression.operator", and uses the JSON names of fields. A and B
+// are the values found in each tree. For a node of the wrong type, they're
+// the names of the types, and for lists of different lengths, Path ends in
+// ".length" and they're the lengths.
+type Difference struct {
+	Path string
+	A, B interface{}
+}
+
+func (d Difference) String() string {
+	if d.Path == "" {
+		return fmt.Sprintf("%s != %s", describe(d.A), describe(d.B))
+	}
+
+	return fmt.Sprintf("%s: %s != %s", d.Path, describe(d.A), describe(d.B))
+}
+
+func describe(v interface{}) string {
+	switch v := v.(type) {
+	case nil:
+		return "null"
+	case *SourceLocation:
+		if v == nil {
+			return "null"
+		}
+
+		return fmt.Sprintf("%d:%d-%d:%d", v.Start.Line, v.Start.Column, v.End.Line, v.End.Column)
+	}
+
+	switch rv := reflect.ValueOf(v); rv.Kind() {
+	case reflect.String:
+		return strconv.Quote(rv.String())
+	case reflect.Ptr:
+		if rv.IsNil() {
+			return "null"
+		}
+
+		return fmt.Sprintf("%+v", rv.Elem().Interface())
+	}
+
+	return fmt.Sprintf("%v", v)
+}
+
+// Equal reports whether two trees are the same, node for node.
+func Equal(a, b Node, opts Options) bool {
+	d := &differ{Options: opts, first: true}
+	d.node("", a, b)
+
+	return len(d.diffs) == 0
+}
+
+// Diff returns every difference between two trees, in the order they're
+// found in a walk through both. It returns nil if they're the same.
+func Diff(a, b Node) []Difference {
+	return Options{}.Diff(a, b)
+}
+
+// Diff is like the Diff function, but ignores what the options say to.
+func (o Options) Diff(a, b Node) []Difference {
+	d := &differ{Options: o}
+	d.node("", a, b)
+
+	return d.diffs
+}
+
+// differ walks two trees at once, collecting the differences between them.
+type differ struct {
+	Options
+
+	first bool // stop at the first difference, for Equal
+	diffs []Difference
+}
+
+func (d *differ) add(path string, a, b interface{}) {
+	if d.first && len(d.diffs) > 0 {
+		return
+	}
+
+	d.diffs = append(d.diffs, Difference{Path: path, A: a, B: b})
+}
+
+func (d *differ) done() bool {
+	return d.first && len(d.diffs) > 0
+}
+
+func (d *differ) value(path string, a, b interface{}) {
+	if !reflect.DeepEqual(a, b) {
+		d.add(path, a, b)
+	}
+}
+
+func (d *differ) length(path string, a, b int) {
+	if a != b {
+		d.add(path+".length", a, b)
+	}
+}
+
+func (d *differ) node(path string, a, b Node) {
+	if d.done() {
+		return
+	}
+
+	switch ma, mb := missing(a), missing(b); {
+	case ma && mb:
+		return
+	case ma:
+		d.add(path, nil, typeName(b))
+		return
+	case mb:
+		d.add(path, typeName(a), nil)
+		return
+	}
+
+	if reflect.TypeOf(a) != reflect.TypeOf(b) {
+		d.add(path, typeName(a), typeName(b))
+		return
+	}
+
+	ba, bb := a.Base(), b.Base()
+
+	if !d.IgnoreLoc {
+		d.value(field(path, "start"), ba.Start, bb.Start)
+		d.value(field(path, "end"), ba.End, bb.End)
+		d.value(field(path, "loc"), ba.Loc, bb.Loc)
+	}
+
+	if !d.IgnoreComments {
+		d.comments(field(path, "leadingComments"), ba.LeadingComments, bb.LeadingComments)
+		d.comments(field(path, "trailingComments"), ba.TrailingComments, bb.TrailingComments)
+		d.comments(field(path, "innerComments"), ba.InnerComments, bb.InnerComments)
+	}
+
+	if u, ok := a.(*Unknown); ok {
+		d.value(field(path, "raw"), string(u.Raw), string(b.(*Unknown).Raw))
+		return
+	}
+
+	d.fields(path, a, b)
+}
+
+// typeName returns the type of a node, which the Type field doesn't hold
+// until the node has been through JSON.
+func typeName(n Node) string {
+	if u, ok := n.(*Unknown); ok {
+		return u.Type
+	}
+
+	return reflect.TypeOf(n).Elem().Name()
+}
+
+func (d *differ) comments(path string, a, b []Comment) {
+	d.length(path, len(a), len(b))
+
+	for i := 0; i < len(a) && i < len(b); i++ {
+		p := index(path, i)
+
+		d.value(field(p, "type"), a[i].Type, b[i].Type)
+		d.value(field(p, "value"), a[i].Value, b[i].Value)
+
+		if !d.IgnoreLoc {
+			d.value(field(p, "start"), a[i].Start, b[i].Start)
+			d.value(field(p, "end"), a[i].End, b[i].End)
+			d.value(field(p, "loc"), a[i].Loc, b[i].Loc)
+		}
+	}
+}
+
+// span compares the Spans of two nodes, which are only positions and
+// comments, so a missing one is the same as one with nothing in it.
+func (d *differ) span(path string, a, b *Span) {
+	if a == nil {
+		a = &Span{}
+	}
+	if b == nil {
+		b = &Span{}
+	}
+
+	if !d.IgnoreLoc {
+		d.value(field(path, "start"), a.Start, b.Start)
+		d.value(field(path, "end"), a.End, b.End)
+		d.value(field(path, "loc"), a.Loc, b.Loc)
+	}
+
+	if !d.IgnoreComments {
+		d.comments(field(path, "leadingComments"), a.LeadingComments, b.LeadingComments)
+		d.comments(field(path, "trailingComments"), a.TrailingComments, b.TrailingComments)
+		d.comments(field(path, "innerComments"), a.InnerComments, b.InnerComments)
+	}
+}
+
+func field(path, name string) string {
+	if path == "" {
+		return name
+	}
+
+	return path + "." + name
+}
+
+func index(path string, i int) string {
+	return path + "[" + strconv.Itoa(i) + "]"
+}
+
+// clone copies the parts of a BaseNode that point to shared memory.
+func (n BaseNode) clone() BaseNode {
+	n.Loc = n.Loc.clone()
+	n.LeadingComments = cloneComments(n.LeadingComments)
+	n.TrailingComments = cloneComments(n.TrailingComments)
+	n.InnerComments = cloneComments(n.InnerComments)
+
+	return n
+}
+
+func (s *Span) clone() *Span {
+	if s == nil {
+		return nil
+	}
+
+	c := *s
+	c.Loc = s.Loc.clone()
+	c.LeadingComments = cloneComments(s.LeadingComments)
+	c.TrailingComments = cloneComments(s.TrailingComments)
+	c.InnerComments = cloneComments(s.InnerComments)
+
+	return &c
+}
+
+func cloneComments(c []Comment) []Comment {
+	if c == nil {
+		return nil
+	}
+
+	a := make([]Comment, len(c))
+	for i, e := range c {
+		e.Loc = e.Loc.clone()
+		a[i] = e
+	}
+
+	return a
+}
+
+func (l *SourceLocation) clone() *SourceLocation {
+	if l == nil {
+		return nil
+	}
+
+	c := *l
+	if l.Source != nil {
+		s := *l.Source
+		c.Source = &s
+	}
+
+	return &c
+}
@@ -0,0 +1,128 @@
+package ast
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func sum(op BinaryOperator, loc *SourceLocation) *Program {
+	return &Program{
+		SourceType: "script",
+		Body: []StatementOrModuleDeclaration{
+			&ExpressionStatement{
+				BaseNode: BaseNode{Loc: loc},
+				Expression: &BinaryExpression{
+					Operator: op,
+					Left:     &Identifier{Name: "a"},
+					Right:    &NumericLiteral{Value: 1},
+				},
+			},
+		},
+		Directives: []*Directive{
+			{Value: &DirectiveLiteral{Value: "use strict", Extra: &Extra{Raw: `"use strict"`, RawValue: "use strict"}}},
+		},
+	}
+}
+
+func TestEqual(t *testing.T) {
+	a := assert.New(t)
+
+	loc := &SourceLocation{Start: Position{Line: 1}, End: Position{Line: 1, Column: 6}}
+
+	a.True(Equal(sum("+", nil), sum("+", nil), Options{}))
+	a.False(Equal(sum("+", nil), sum("-", nil), Options{}))
+	a.False(Equal(sum("+", nil), sum("+", loc), Options{}))
+	a.True(Equal(sum("+", nil), sum("+", loc), Options{IgnoreLoc: true}))
+
+	p := sum("+", nil)
+	p.Body[0].(*ExpressionStatement).Start = 14
+	a.False(Equal(sum("+", nil), p, Options{}))
+	a.True(Equal(sum("+", nil), p, Options{IgnoreLoc: true}))
+
+	p = sum("+", nil)
+	p.Body[0].(*ExpressionStatement).LeadingComments = []Comment{{Type: "CommentLine", Value: " a"}}
+	a.False(Equal(sum("+", nil), p, Options{}))
+	a.True(Equal(sum("+", nil), p, Options{IgnoreComments: true}))
+
+	p = sum("+", nil)
+	p.Directives[0].Value.Extra.Raw = `'use strict'`
+	a.False(Equal(sum("+", nil), p, Options{}))
+	a.True(Equal(sum("+", nil), p, Options{IgnoreRaw: true}))
+
+	a.True(Equal(nil, (*Identifier)(nil), Options{}))
+	a.False(Equal(&Identifier{Name: "a"}, &StringLiteral{Value: "a"}, Options{}))
+}
+
+func TestDiff(t *testing.T) {
+	a := assert.New(t)
+
+	a.Nil(Diff(sum("+", nil), sum("+", nil)))
+
+	p := sum("-", nil)
+	p.Body[0].(*ExpressionStatement).Expression.(*BinaryExpression).Right = &StringLiteral{Value: "1"}
+	p.Body = append(p.Body, &EmptyStatement{})
+
+	var s []string
+	for _, d := range Diff(sum("+", nil), p) {
+		s = append(s, d.String())
+	}
+
+	a.Equal([]string{
+		"body.length: 1 != 2",
+		`body[0].expression.operator: "+" != "-"`,
+		`body[0].expression.right: "NumericLiteral" != "StringLiteral"`,
+	}, s)
+
+	a.Equal([]string{`"Identifier" != null`}, []string{Diff(&Identifier{}, nil)[0].String()})
+}
+
+func TestClone(t *testing.T) {
+	a := assert.New(t)
+
+	source := "a.js"
+	p := sum("+", &SourceLocation{Source: &source})
+	p.Body[0].(*ExpressionStatement).LeadingComments = []Comment{{Type: "CommentLine", Value: " a"}}
+
+	c := Clone(p).(*Program)
+	a.Equal(p, c)
+	a.True(Equal(p, c, Options{}))
+
+	c.Body[0].(*ExpressionStatement).Expression.(*BinaryExpression).Operator = "-"
+	*c.Body[0].(*ExpressionStatement).Loc.Source = "b.js"
+	c.Body[0].(*ExpressionStatement).LeadingComments[0].Value = " b"
+	c.Directives[0].Value.Extra.Raw = ""
+
+	a.Equal(sum("+", &SourceLocation{Source: &source}).Body[0].(*ExpressionStatement).Expression, p.Body[0].(*ExpressionStatement).Expression)
+	a.Equal("a.js", source)
+	a.Equal(" a", p.Body[0].(*ExpressionStatement).LeadingComments[0].Value)
+	a.Equal(`"use strict"`, p.Directives[0].Value.Extra.Raw)
+
+	a.Equal((*Identifier)(nil), Clone((*Identifier)(nil)))
+	a.Nil(Clone(nil))
+}
+
+func TestSpan(t *testing.T) {
+	a := assert.New(t)
+
+	chain := func(s *Span) *OptionalMemberExpression {
+		return &OptionalMemberExpression{Object: &Identifier{Name: "a"}, Property: &Identifier{Name: "b"}, Optional: true, ChainSpan: s}
+	}
+
+	s := &Span{Start: 1, End: 4, LeadingComments: []Comment{{Type: "CommentBlock", Value: " c "}}}
+
+	a.True(Equal(chain(nil), chain(&Span{}), Options{}))
+	a.Equal([]Difference{
+		{Path: "chainSpan.start", A: 0, B: 1},
+		{Path: "chainSpan.end", A: 0, B: 4},
+		{Path: "chainSpan.leadingComments.length", A: 0, B: 1},
+	}, Diff(chain(nil), chain(s)))
+	a.False(Equal(chain(nil), chain(s), Options{IgnoreLoc: true}))
+	a.True(Equal(chain(nil), chain(s), Options{IgnoreLoc: true, IgnoreComments: true}))
+
+	c := Clone(chain(s)).(*OptionalMemberExpression)
+	a.Equal(s, c.ChainSpan)
+
+	c.ChainSpan.LeadingComments[0].Value = " d "
+	a.Equal(" c ", s.LeadingComments[0].Value)
+}
@@ -1,12 +1,62 @@
 package main
 
 import (
+	"bytes"
 	"fmt"
+	"go/format"
 	"io"
 	"strings"
+	"unicode"
 )
 
+// primitives maps the primitive types used in the specification to Go types.
+var primitives = map[string]string{
+	"string":  "string",
+	"boolean": "bool",
+	"number":  "float64",
+}
+
+// fieldNames are the Go names of fields that strings.Title doesn't get right,
+// either for everything or, qualified by the type, for one type.
+var fieldNames = map[string]string{
+	"id":                                 "ID",
+	"ArrowFunctionExpression.expression": "IsExpression",
+	"Literal.bigint":                     "BigInt",
+}
+
+func fieldName(t, f string) string {
+	if n, ok := fieldNames[t+"."+f]; ok {
+		return n
+	}
+
+	if n, ok := fieldNames[f]; ok {
+		return n
+	}
+
+	return strings.Title(f)
+}
+
+// integers are the numeric fields that only ever hold whole numbers.
+var integers = map[string]bool{
+	"Node.start":      true,
+	"Node.end":        true,
+	"Comment.start":   true,
+	"Comment.end":     true,
+	"Span.start":      true,
+	"Span.end":        true,
+	"Position.line":   true,
+	"Position.column": true,
+}
+
 type Formatter struct {
+	// Package is the name of the package to write, which is ast if it's
+	// empty.
+	Package string
+	// Core leaves out everything but the types, their JSON methods and
+	// Walk, which are all a package needs to read and write trees. The rest
+	// needs the hand-written parts of the ast package to go with it.
+	Core bool
+
 	w io.Writer
 }
 
@@ -17,8 +67,11 @@ type formattingContext struct {
 	e error
 
 	ts map[string]bool
-	ti map[string]bool
 	u  map[string]bool
+
+	// unions are the names of every union type, and the types in them
+	unions map[string][]string
+	ul     []string
 }
 
 func (c *formattingContext) f(format string, a ...interface{}) {
@@ -33,69 +86,219 @@ func NewFormatter(w io.Writer) *Formatter {
 	return &Formatter{w: w}
 }
 
+// Format writes the Go source for the types in a specification, formatted as
+// gofmt would.
 func (f *Formatter) Format(p *Parser) (int, error) {
+	var b bytes.Buffer
+
 	c := formattingContext{
 		p: p,
-		w: f.w,
+		w: &b,
 
 		ts: make(map[string]bool),
-		ti: make(map[string]bool),
 		u:  make(map[string]bool),
+
+		unions: make(map[string][]string),
+	}
+
+	for _, t := range p.types {
+		for _, tf := range t.fields {
+			if l, _ := fieldTypes(tf); len(l) > 1 && !allPrimitive(l) {
+				n := strings.Join(l, "Or")
+				if _, ok := c.unions[n]; !ok {
+					c.unions[n] = l
+					c.ul = append(c.ul, n)
+				}
+			}
+		}
+	}
+
+	pkg := f.Package
+	if pkg == "" {
+		pkg = "ast"
 	}
 
+	c.f("// Code generated by ast/generator from spec.md; DO NOT EDIT.\n\n")
+	c.f("package %s\n\n", pkg)
+	c.f("import \"encoding/json\"\n\n")
+
 	for _, e := range p.enums {
 		f.formatEnum(&c, e)
 	}
 
 	for _, t := range p.types {
-		f.formatTypeAsStruct(&c, t)
+		switch {
+		case t.name == "Node":
+			f.formatNode(&c, t)
+		case !c.p.isNode(t.name):
+			f.formatTypeAsStruct(&c, t)
+		case c.p.isAbstract(t.name):
+			f.formatTypeAsInterface(&c, t)
+		default:
+			f.formatTypeAsStruct(&c, t)
+		}
+	}
+
+	f.formatDecoders(&c)
+	f.formatWalk(&c)
+
+	if !f.Core {
+		f.formatApply(&c)
+		f.formatCompare(&c)
+		f.formatClone(&c)
+		f.formatValidate(&c)
+	}
+
+	if c.e != nil {
+		return 0, c.e
+	}
+
+	src, err := format.Source(b.Bytes())
+	if err != nil {
+		return 0, err
+	}
+
+	return f.w.Write(src)
+}
+
+// operatorNames are the names of the operators in enums, which go after the
+// name of the enum in the names of their constants. Operators that are words,
+// like typeof, are named after themselves.
+var operatorNames = map[string]string{
+	"-":    "Minus",
+	"+":    "Plus",
+	"!":    "Bang",
+	"~":    "Tilde",
+	"++":   "Increment",
+	"--":   "Decrement",
+	"==":   "Equal",
+	"!=":   "NotEqual",
+	"===":  "StrictEqual",
+	"!==":  "StrictNotEqual",
+	"<":    "Less",
+	"<=":   "LessOrEqual",
+	">":    "Greater",
+	">=":   "GreaterOrEqual",
+	"<<":   "ShiftLeft",
+	">>":   "ShiftRight",
+	">>>":  "ShiftRightSigned",
+	"*":    "Multiply",
+	"/":    "Divide",
+	"%":    "Modulo",
+	"|":    "Or",
+	"^":    "Xor",
+	"&":    "And",
+	"**":   "Exponent",
+	"=":    "Equals",
+	"+=":   "Add",
+	"-=":   "Subtract",
+	"*=":   "Multiply",
+	"/=":   "Divide",
+	"%=":   "Modulo",
+	"<<=":  "ShiftLeft",
+	">>=":  "ShiftRight",
+	">>>=": "ShiftRightSigned",
+	"|=":   "Or",
+	"^=":   "Xor",
+	"&=":   "And",
+	"**=":  "Exponent",
+	"||=":  "LogicalOr",
+	"&&=":  "LogicalAnd",
+	"??=":  "Nullish",
+	"||":   "Or",
+	"&&":   "And",
+	"??":   "Nullish",
+}
+
+// enumConstant returns the name of the constant for a value of an enum.
+func enumConstant(enum, value string) (string, error) {
+	if n, ok := operatorNames[value]; ok {
+		return enum + n, nil
 	}
 
-	// for _, t := range p.types {
-	// 	f.formatTypeAsInterface(&c, t)
-	// }
+	for _, r := range value {
+		if !unicode.IsLetter(r) {
+			return "", fmt.Errorf("no name for %q in %s", value, enum)
+		}
+	}
 
-	return c.n, c.e
+	return enum + strings.ToUpper(value[:1]) + value[1:], nil
 }
 
 func (f *Formatter) formatEnum(c *formattingContext, e esEnum) {
 	w := c.f
 
-	w("type %s string\n\n", e.name.Value())
+	name := e.name.Value()
+
+	var names []string
+	for _, v := range e.values {
+		n, err := enumConstant(name, v.Value())
+		if err != nil && c.e == nil {
+			c.e = err
+		}
+
+		names = append(names, n)
+	}
+
+	w("type %s string\n\n", name)
 
 	w("const (\n")
 	for i, v := range e.values {
-		w("  %s_%d = %q\n", e.name.Value(), i, v.Value())
+		w("  %s %s = %q\n", names[i], name, v.Value())
 	}
 	w(")\n\n")
 
-	w("func (v %s) Valid() bool { return ", e.name.Value())
-	for i := range e.values {
-		w("v == %s_%d", e.name.Value(), i)
-		if i != len(e.values)-1 {
+	w("func (v %s) Valid() bool {\n", name)
+	w("  return ")
+	for i, n := range names {
+		w("v == %s", n)
+		if i != len(names)-1 {
 			w(" || ")
 		}
 	}
-	w(" }\n\n")
+	w("\n}\n\n")
 }
 
-func (f *Formatter) formatTypeAsInterface(c *formattingContext, t esType) {
-	if c.ti[t.name] {
-		return
+// formatNode writes the Node interface, and BaseNode, which holds the fields
+// common to every node and is embedded in each concrete node type.
+func (f *Formatter) formatNode(c *formattingContext, t esType) {
+	w := c.f
+
+	w("type Node interface {\n")
+	w("  Base() *BaseNode\n")
+	w("}\n\n")
+
+	// Lists, like comments, are left out of the JSON when they're empty,
+	// rather than being written on every node.
+	w("type BaseNode struct {\n")
+	for _, tf := range t.fields {
+		tag := tf.name
+		if tf.list {
+			tag += ",omitempty"
+		}
+
+		ft := f.formatFieldType(c, tf)
+		if integers[t.name+"."+tf.name] {
+			ft = strings.Replace(ft, "float64", "int", 1)
+		}
+
+		w("  %s %s `json:\"%s\"`\n", strings.Title(tf.name), ft, tag)
 	}
+	w("}\n\n")
 
-	c.ti[t.name] = true
+	w("func (n *BaseNode) Base() *BaseNode { return n }\n\n")
+}
 
+// formatTypeAsInterface writes an abstract node type, like Expression, as an
+// interface that's implemented by every concrete node type that extends it.
+func (f *Formatter) formatTypeAsInterface(c *formattingContext, t esType) {
 	w := c.f
 
-	w("type Is%s interface {\n", strings.Title(t.name))
+	w("type %s interface {\n", t.name)
 	for _, e := range t.extends {
-		w("  Is%s\n", strings.Title(e))
-	}
-
-	for _, tf := range t.fields {
-		w("  Get%s() %s\n", strings.Title(tf.name), f.formatFieldType(c, tf))
+		w("  %s\n", e)
 	}
+	w("  %s()\n", marker(t.name))
 	w("}\n\n")
 }
 
@@ -106,50 +309,242 @@ func (f *Formatter) formatTypeAsStruct(c *formattingContext, t esType) {
 
 	c.ts[t.name] = true
 
-	for _, tf := range t.fields {
+	node := c.p.isNode(t.name)
+
+	fields := c.p.fields(t.name)
+
+	for _, tf := range fields {
 		f.maybeMakeUnion(c, tf)
 	}
 
 	w := c.f
 
 	w("type %s struct {\n", t.name)
-	for _, e := range t.extends {
-		w("  %s\n", e)
+	if node {
+		w("  BaseNode\n")
 	}
 
-	for _, tf := range t.fields {
+	for _, tf := range fields {
 		if tf.Static() {
 			continue
 		}
 
-		w("  %s %s `json:\"%s\"`\n", strings.Title(tf.name), f.formatFieldType(c, tf), tf.name)
+		ft := f.formatFieldType(c, tf)
+		if integers[t.name+"."+tf.name] {
+			ft = strings.Replace(ft, "float64", "int", 1)
+		}
+
+		// As in BaseNode, which a Span copies, empty lists are left out.
+		tag := tf.name
+		if !node && tf.list {
+			tag += ",omitempty"
+		}
+
+		w("  %s %s `json:\"%s\"`\n", fieldName(t.name, tf.name), ft, tag)
 	}
 	w("}\n\n")
+
+	if !node {
+		return
+	}
+
+	for _, a := range c.p.ancestors(t.name) {
+		if a != "Node" && c.p.isAbstract(a) {
+			w("func (*%s) %s() {}\n", t.name, marker(a))
+		}
+	}
+
+	for _, n := range c.ul {
+		for _, m := range c.unions[n] {
+			if c.p.conforms(t.name, m) {
+				w("func (*%s) is%s() {}\n", t.name, n)
+				break
+			}
+		}
+	}
+
+	w("\n")
+
+	f.formatMarshal(c, t.name, fields)
+	f.formatUnmarshal(c, t.name, fields)
+
+	if !f.Core {
+		f.formatConstructor(c, t.name, fields)
+	}
 }
 
-func (f *Formatter) maybeMakeUnion(c *formattingContext, tf esTypeField) {
-	m := make(map[string]bool)
-	var l []string
+// paramName is the name of the constructor parameter for a field.
+func paramName(t, f string) string {
+	n := fieldName(t, f)
+	if n == "ID" {
+		return "id"
+	}
+
+	return strings.ToLower(n[:1]) + n[1:]
+}
+
+// isEnum reports whether a field holds one of the enums, like BinaryOperator.
+func (f *Formatter) isEnum(c *formattingContext, tf esTypeField) bool {
+	l, _ := fieldTypes(tf)
+	if len(l) != 1 {
+		return false
+	}
+
+	for _, e := range c.p.enums {
+		if e.name.Value() == l[0] {
+			return true
+		}
+	}
+
+	return false
+}
 
+// literals returns the strings a field is limited to, like the kinds of a
+// VariableDeclaration, if it's limited to a set of strings.
+func literals(tf esTypeField) []string {
+	var a []string
 	for _, o := range tf.opts {
-		var t string
-		if o.Kind() == TokenKindString {
-			t = "string"
-		} else {
-			t = o.(IdentifierToken).Value()
+		if o.Kind() != TokenKindString {
+			return nil
 		}
 
-		if t == "null" {
+		a = append(a, o.(StringToken).Value())
+	}
+
+	return a
+}
+
+// formatCheck writes the checks for the value v of a field, indented by
+// indent, calling fail to write what happens when one fails. Enums have to be
+// valid, and nodes can only be nil where the specification allows null.
+func (f *Formatter) formatCheck(c *formattingContext, tf esTypeField, v, indent string, fail func(value string)) {
+	w := func(format string, a ...interface{}) {
+		c.f(indent+format, a...)
+	}
+
+	_, maybeNull := fieldTypes(tf)
+
+	switch {
+	case f.isEnum(c, tf):
+		w("if !%s.Valid() {\n", v)
+		fail(v)
+		w("}\n")
+	case len(literals(tf)) > 1:
+		var cond []string
+		for _, l := range literals(tf) {
+			cond = append(cond, fmt.Sprintf("%s != %q", v, l))
+		}
+
+		w("if %s {\n", strings.Join(cond, " && "))
+		fail(v)
+		w("}\n")
+	case !f.isNodeField(c, tf) || maybeNull:
+	case tf.list:
+		w("for _, e := range %s {\n", v)
+		w("  if missing(e) {\n")
+		c.f("  ")
+		fail("nil")
+		w("  }\n")
+		w("}\n")
+	default:
+		w("if missing(%s) {\n", v)
+		fail("nil")
+		w("}\n")
+	}
+}
+
+// formatConstructor writes a New function for a concrete node type, which
+// sets its type and checks the values it's given. Enums have to be valid,
+// and nodes can only be nil where the specification allows null.
+func (f *Formatter) formatConstructor(c *formattingContext, name string, fields []esTypeField) {
+	w := c.f
+
+	var params []string
+	for _, tf := range fields {
+		if tf.Static() {
 			continue
 		}
 
-		if _, ok := m[t]; !ok {
-			m[t] = true
-			l = append(l, t)
+		ft := f.formatFieldType(c, tf)
+		if integers[name+"."+tf.name] {
+			ft = strings.Replace(ft, "float64", "int", 1)
+		}
+
+		params = append(params, paramName(name, tf.name)+" "+ft)
+	}
+
+	w("// New%s returns a new %s, or a *FieldError if one of the values\n", name, name)
+	w("// given can't go in it.\n")
+	w("func New%s(%s) (*%s, error) {\n", name, strings.Join(params, ", "), name)
+
+	checked := false
+
+	fail := func(tf esTypeField, value string) {
+		w("    return nil, &FieldError{Type: %q, Field: %q, Value: %s}\n", c.p.nodeType(name), tf.name, value)
+	}
+
+	for _, tf := range fields {
+		if tf.Static() {
+			continue
+		}
+
+		f.formatCheck(c, tf, paramName(name, tf.name), "  ", func(value string) {
+			checked = true
+			fail(tf, value)
+		})
+	}
+
+	if checked {
+		w("\n")
+	}
+
+	w("  n := &%s{", name)
+	first := true
+	for _, tf := range fields {
+		if tf.Static() {
+			continue
+		}
+
+		if !first {
+			w(", ")
 		}
+		first = false
+
+		w("%s: %s", fieldName(name, tf.name), paramName(name, tf.name))
 	}
+	w("}\n")
+	w("  n.Type = %q\n\n", c.p.nodeType(name))
+	w("  return n, nil\n")
+	w("}\n\n")
+}
+
+// formatMarshal writes a MarshalJSON method that fills in the `type` field,
+// and writes empty lists rather than null, as Babel does.
+func (f *Formatter) formatMarshal(c *formattingContext, name string, fields []esTypeField) {
+	w := c.f
+
+	w("func (n %s) MarshalJSON() ([]byte, error) {\n", name)
+	w("  type plain %s\n", name)
+	w("  v := plain(n)\n")
+	w("  v.Type = %q\n", c.p.nodeType(name))
+
+	for _, tf := range fields {
+		if tf.list {
+			n := fieldName(name, tf.name)
+			w("  if v.%s == nil {\n", n)
+			w("    v.%s = %s{}\n", n, f.formatFieldType(c, tf))
+			w("  }\n")
+		}
+	}
+
+	w("  return json.Marshal(v)\n")
+	w("}\n\n")
+}
+
+func (f *Formatter) maybeMakeUnion(c *formattingContext, tf esTypeField) {
+	l, _ := fieldTypes(tf)
 
-	if len(l) < 2 {
+	if len(l) < 2 || allPrimitive(l) {
 		return
 	}
 
@@ -163,15 +558,52 @@ func (f *Formatter) maybeMakeUnion(c *formattingContext, tf esTypeField) {
 
 	w := c.f
 
-	w("type %s interface { Is%s() bool }\n", n, n)
-	for _, s := range l {
-		w("func (%s) Is%s() bool { return true }\n", s, n)
-	}
-	w("\n")
+	w("type %s interface {\n", n)
+	w("  Node\n")
+	w("  is%s()\n", n)
+	w("}\n\n")
 }
 
 func (f *Formatter) formatFieldType(c *formattingContext, tf esTypeField) string {
-	m := make(map[string]int)
+	l, maybeNull := fieldTypes(tf)
+
+	p := ""
+	if tf.list {
+		p = "[]"
+	}
+
+	if allPrimitive(l) && len(l) > 1 {
+		// A field that can hold more than one kind of primitive, like the
+		// value of a literal, can hold anything JSON can.
+		return p + "interface{}"
+	}
+
+	if len(l) != 1 {
+		return p + strings.Join(l, "Or")
+	}
+
+	t := l[0]
+
+	switch {
+	case primitives[t] != "":
+		t = primitives[t]
+	case c.p.isAbstract(t):
+		return p + t
+	case c.p.isNode(t):
+		return p + "*" + t
+	}
+
+	if maybeNull {
+		return p + "*" + t
+	}
+
+	return p + t
+}
+
+// fieldTypes lists the distinct types a field can hold, not counting null.
+// String literals count as strings.
+func fieldTypes(tf esTypeField) ([]string, bool) {
+	m := make(map[string]bool)
 	var l []string
 
 	maybeNull := false
@@ -189,26 +621,547 @@ func (f *Formatter) formatFieldType(c *formattingContext, tf esTypeField) string
 			continue
 		}
 
-		if _, ok := m[t]; !ok {
-			m[t] = 0
+		if !m[t] {
+			m[t] = true
 			l = append(l, t)
 		}
+	}
 
-		m[t]++
+	return l, maybeNull
+}
+
+func allPrimitive(l []string) bool {
+	for _, t := range l {
+		if primitives[t] == "" {
+			return false
+		}
 	}
 
-	p := ""
-	if tf.list {
-		p = "[]"
+	return true
+}
+
+// marker is the name of the method that marks a type as implementing an
+// abstract node type, e.g. expressionNode for Expression.
+func marker(name string) string {
+	return strings.ToLower(name[:1]) + name[1:] + "Node"
+}
+
+// iface returns the name of the interface a field holds, if it holds one,
+// rather than a concrete type or a primitive.
+func (f *Formatter) iface(c *formattingContext, tf esTypeField) (string, bool) {
+	t := strings.TrimPrefix(f.formatFieldType(c, tf), "[]")
+
+	if _, ok := c.unions[t]; ok || c.p.isAbstract(t) {
+		return t, true
+	}
+
+	return "", false
+}
+
+// formatUnmarshal writes an UnmarshalJSON method for types with fields that
+// hold interfaces, which encoding/json can't decode by itself. Those fields
+// are decoded by the type of node they hold.
+func (f *Formatter) formatUnmarshal(c *formattingContext, name string, fields []esTypeField) {
+	var a []esTypeField
+	for _, tf := range fields {
+		if _, ok := f.iface(c, tf); ok {
+			a = append(a, tf)
+		}
+	}
+
+	if len(a) == 0 {
+		return
+	}
+
+	w := c.f
+
+	w("func (n *%s) UnmarshalJSON(b []byte) error {\n", name)
+	w("  type plain %s\n", name)
+	w("  var v struct {\n")
+	w("    *plain\n")
+	for _, tf := range a {
+		t := "json.RawMessage"
+		if tf.list {
+			t = "[]json.RawMessage"
+		}
+
+		w("    %s %s `json:\"%s\"`\n", fieldName(name, tf.name), t, tf.name)
+	}
+	w("  }\n\n")
+	w("  v.plain = (*plain)(n)\n\n")
+	w("  if err := json.Unmarshal(b, &v); err != nil {\n")
+	w("    return err\n")
+	w("  }\n\n")
+
+	for _, tf := range a {
+		n := fieldName(name, tf.name)
+		t, _ := f.iface(c, tf)
+
+		if tf.list {
+			w("  if v.%s != nil {\n", n)
+			w("    n.%s = make([]%s, len(v.%s))\n", n, t, n)
+			w("  }\n")
+			w("  for i, e := range v.%s {\n", n)
+			w("    x, err := decode%s(e)\n", t)
+			w("    if err != nil {\n")
+			w("      return err\n")
+			w("    }\n\n")
+			w("    n.%s[i] = x\n", n)
+			w("  }\n\n")
+		} else {
+			w("  if x, err := decode%s(v.%s); err != nil {\n", t, n)
+			w("    return err\n")
+			w("  } else {\n")
+			w("    n.%s = x\n", n)
+			w("  }\n\n")
+		}
+	}
+
+	w("  return nil\n")
+	w("}\n\n")
+}
+
+// formatDecoders writes the table of node types used by decodeNode, a decode
+// function for each interface, and the marker methods that let Unknown stand
+// in for any of them.
+func (f *Formatter) formatDecoders(c *formattingContext) {
+	w := c.f
+
+	var abstract []string
+	var concrete []string
+	for _, t := range c.p.types {
+		switch {
+		case t.name == "Node" || !c.p.isNode(t.name):
+		case c.p.isAbstract(t.name):
+			abstract = append(abstract, t.name)
+		default:
+			concrete = append(concrete, t.name)
+		}
+	}
+
+	var types []string
+	m := make(map[string][]string)
+	for _, n := range concrete {
+		t := c.p.nodeType(n)
+		if _, ok := m[t]; !ok {
+			types = append(types, t)
+		}
+
+		m[t] = append(m[t], n)
+	}
+
+	w("// nodeTypes has the concrete types for each value of the type field. Some\n")
+	w("// values have more than one, and the first one that fits is used.\n")
+	w("var nodeTypes = map[string][]func() Node{\n")
+	for _, t := range types {
+		w("  %q: {", t)
+		for i, n := range m[t] {
+			if i > 0 {
+				w(", ")
+			}
+
+			w("func() Node { return &%s{} }", n)
+		}
+		w("},\n")
+	}
+	w("}\n\n")
+
+	used := make(map[string]bool)
+	for _, t := range c.p.types {
+		for _, tf := range c.p.fields(t.name) {
+			if n, ok := f.iface(c, tf); ok {
+				used[n] = true
+			}
+		}
+	}
+
+	for _, n := range append(abstract, c.ul...) {
+		if !used[n] {
+			continue
+		}
+
+		w("func decode%s(b []byte) (%s, error) {\n", n, n)
+		w("  v, err := decodeNode(b, func(n Node) bool { _, ok := n.(%s); return ok })\n", n)
+		w("  if v == nil || err != nil {\n")
+		w("    return nil, err\n")
+		w("  }\n\n")
+		w("  return v.(%s), nil\n", n)
+		w("}\n\n")
+	}
+
+	for _, n := range abstract {
+		w("func (*Unknown) %s() {}\n", marker(n))
+	}
+
+	for _, n := range c.ul {
+		w("func (*Unknown) is%s() {}\n", n)
+	}
+}
+
+// sourceOrder overrides the order that the children of a node are visited in,
+// for types where the specification doesn't list them in the order they're
+// written.
+var sourceOrder = map[string][]string{
+	"ConditionalExpression": {"test", "consequent", "alternate"},
+	"ImportSpecifier":       {"imported", "local"},
+}
+
+// interleaved are lists of children that are written alternately, like the
+// quasis and expressions of a template literal.
+var interleaved = map[string][2]string{
+	"TemplateLiteral": {"quasis", "expressions"},
+}
+
+// isNodeField reports whether a field holds nodes, either through an
+// interface or a pointer to a concrete type.
+func (f *Formatter) isNodeField(c *formattingContext, tf esTypeField) bool {
+	if _, ok := f.iface(c, tf); ok {
+		return true
+	}
+
+	l, _ := fieldTypes(tf)
+
+	return len(l) == 1 && c.p.isNode(l[0])
+}
+
+// children lists the fields of a type that hold nodes, in the order they're
+// written in source. Decorators and directives always come first.
+func (f *Formatter) children(c *formattingContext, name string) []esTypeField {
+	var a []esTypeField
+	for _, tf := range c.p.fields(name) {
+		if f.isNodeField(c, tf) {
+			a = append(a, tf)
+		}
+	}
+
+	order := append([]string{"decorators", "directives"}, sourceOrder[name]...)
+
+	var r []esTypeField
+	for _, n := range order {
+		for _, tf := range a {
+			if tf.name == n {
+				r = append(r, tf)
+			}
+		}
 	}
 
-	if len(l) == 1 {
-		if maybeNull {
-			return p + "*" + l[0]
+	for _, tf := range a {
+		found := false
+		for _, n := range order {
+			found = found || tf.name == n
 		}
 
-		return p + l[0]
+		if !found {
+			r = append(r, tf)
+		}
+	}
+
+	return r
+}
+
+func (f *Formatter) concrete(c *formattingContext) []string {
+	var a []string
+	for _, t := range c.p.types {
+		if t.name != "Node" && c.p.isNode(t.name) && !c.p.isAbstract(t.name) {
+			a = append(a, t.name)
+		}
+	}
+
+	return a
+}
+
+func (f *Formatter) formatWalk(c *formattingContext) {
+	w := c.f
+
+	w("// A Visitor's Visit method is invoked for each node encountered by Walk.\n")
+	w("// If the result visitor w is not nil, Walk visits each of the children\n")
+	w("// of node with the visitor w, followed by a call of w.Visit(nil).\n")
+	w("type Visitor interface {\n")
+	w("  Visit(node Node) (w Visitor)\n")
+	w("}\n\n")
+
+	w("// Walk traverses an AST in depth-first order, visiting the children of\n")
+	w("// each node in the order they're written in source. It starts by calling\n")
+	w("// v.Visit(node); node must not be nil.\n")
+	w("func Walk(v Visitor, node Node) {\n")
+	w("  if v = v.Visit(node); v == nil {\n")
+	w("    return\n")
+	w("  }\n\n")
+	w("  switch n := node.(type) {\n")
+
+	for _, name := range f.concrete(c) {
+		fields := f.children(c, name)
+		if len(fields) == 0 {
+			continue
+		}
+
+		w("  case *%s:\n", name)
+
+		il, hasInterleaved := interleaved[name]
+
+		for _, tf := range fields {
+			n := fieldName(name, tf.name)
+
+			if hasInterleaved && tf.name == il[1] {
+				continue
+			}
+
+			if hasInterleaved && tf.name == il[0] {
+				m := fieldName(name, il[1])
+				w("    for i, e := range n.%s {\n", n)
+				w("      if e != nil {\n")
+				w("        Walk(v, e)\n")
+				w("      }\n")
+				w("      if i < len(n.%s) && n.%s[i] != nil {\n", m, m)
+				w("        Walk(v, n.%s[i])\n", m)
+				w("      }\n")
+				w("    }\n")
+				continue
+			}
+
+			if tf.list {
+				w("    for _, e := range n.%s {\n", n)
+				w("      if e != nil {\n")
+				w("        Walk(v, e)\n")
+				w("      }\n")
+				w("    }\n")
+			} else {
+				w("    if n.%s != nil {\n", n)
+				w("      Walk(v, n.%s)\n", n)
+				w("    }\n")
+			}
+		}
+	}
+
+	w("  }\n\n")
+	w("  v.Visit(nil)\n")
+	w("}\n\n")
+
+	w("type inspector func(Node) bool\n\n")
+	w("func (f inspector) Visit(node Node) Visitor {\n")
+	w("  if f(node) {\n")
+	w("    return f\n")
+	w("  }\n\n")
+	w("  return nil\n")
+	w("}\n\n")
+
+	w("// Inspect traverses an AST in depth-first order: It starts by calling\n")
+	w("// f(node); node must not be nil. If f returns true, Inspect invokes f\n")
+	w("// recursively for each of the non-nil children of node, followed by a\n")
+	w("// call of f(nil).\n")
+	w("func Inspect(node Node, f func(Node) bool) {\n")
+	w("  Walk(inspector(f), node)\n")
+	w("}\n\n")
+}
+
+// formatApply writes the part of Apply that knows which fields of each type
+// hold children. Lists are walked by applyList, or applyInterleaved for the
+// interleaved ones, so that the cursor can change them.
+func (f *Formatter) formatApply(c *formattingContext) {
+	w := c.f
+
+	w("func (a *application) children(node Node) {\n")
+	w("  switch n := node.(type) {\n")
+
+	for _, name := range f.concrete(c) {
+		fields := f.children(c, name)
+		if len(fields) == 0 {
+			continue
+		}
+
+		w("  case *%s:\n", name)
+
+		il, hasInterleaved := interleaved[name]
+
+		for _, tf := range fields {
+			n := fieldName(name, tf.name)
+
+			if hasInterleaved && tf.name == il[1] {
+				continue
+			}
+
+			if hasInterleaved && tf.name == il[0] {
+				w("    a.applyInterleaved(n, %q, %q)\n", n, fieldName(name, il[1]))
+				continue
+			}
+
+			if tf.list {
+				w("    a.applyList(n, %q)\n", n)
+			} else {
+				w("    a.apply(n, %q, nil, n.%s)\n", n, n)
+			}
+		}
+	}
+
+	w("  }\n")
+	w("}\n\n")
+}
+
+// rawFields hold the exact source text of a node, which Equal and Diff can be
+// told to ignore.
+var rawFields = map[string]bool{
+	"extra": true,
+}
+
+// isSpan reports whether a field holds a Span, which has its own location and
+// comments that Equal, Diff and Clone have to treat like a node's.
+func isSpan(tf esTypeField) bool {
+	l, _ := fieldTypes(tf)
+
+	return !tf.list && len(l) == 1 && l[0] == "Span"
+}
+
+// formatCompare writes the part of Equal and Diff that compares the fields of
+// two nodes of the same type.
+func (f *Formatter) formatCompare(c *formattingContext) {
+	w := c.f
+
+	w("func (d *differ) fields(path string, a, b Node) {\n")
+	w("  switch a := a.(type) {\n")
+
+	for _, name := range f.concrete(c) {
+		var fields []esTypeField
+		for _, tf := range c.p.fields(name) {
+			if !tf.Static() {
+				fields = append(fields, tf)
+			}
+		}
+
+		if len(fields) == 0 {
+			continue
+		}
+
+		w("  case *%s:\n", name)
+		w("    b := b.(*%s)\n", name)
+
+		for _, tf := range fields {
+			n := fieldName(name, tf.name)
+			p := fmt.Sprintf("field(path, %q)", tf.name)
+
+			switch {
+			case f.isNodeField(c, tf) && tf.list:
+				w("    d.length(%s, len(a.%s), len(b.%s))\n", p, n, n)
+				w("    for i := 0; i < len(a.%s) && i < len(b.%s); i++ {\n", n, n)
+				w("      d.node(index(%s, i), a.%s[i], b.%s[i])\n", p, n, n)
+				w("    }\n")
+			case f.isNodeField(c, tf):
+				w("    d.node(%s, a.%s, b.%s)\n", p, n, n)
+			case isSpan(tf):
+				w("    d.span(%s, a.%s, b.%s)\n", p, n, n)
+			case rawFields[tf.name]:
+				w("    if !d.IgnoreRaw {\n")
+				w("      d.value(%s, a.%s, b.%s)\n", p, n, n)
+				w("    }\n")
+			default:
+				w("    d.value(%s, a.%s, b.%s)\n", p, n, n)
+			}
+		}
+	}
+
+	w("  }\n")
+	w("}\n\n")
+}
+
+// formatClone writes Clone, which copies each node in a tree, along with
+// anything it points to.
+func (f *Formatter) formatClone(c *formattingContext) {
+	w := c.f
+
+	w("// Clone returns a deep copy of a tree, which shares nothing with the\n")
+	w("// original. Each node has the same concrete type as the one it copies.\n")
+	w("func Clone(node Node) Node {\n")
+	w("  switch n := node.(type) {\n")
+	w("  case *Unknown:\n")
+	w("    if n == nil {\n")
+	w("      return n\n")
+	w("    }\n\n")
+	w("    c := *n\n")
+	w("    c.BaseNode = n.BaseNode.clone()\n")
+	w("    c.Raw = append(json.RawMessage(nil), n.Raw...)\n")
+	w("    return &c\n")
+
+	for _, name := range f.concrete(c) {
+		w("  case *%s:\n", name)
+		w("    if n == nil {\n")
+		w("      return n\n")
+		w("    }\n\n")
+		w("    c := *n\n")
+		w("    c.BaseNode = n.BaseNode.clone()\n")
+
+		for _, tf := range c.p.fields(name) {
+			if tf.Static() {
+				continue
+			}
+
+			n := fieldName(name, tf.name)
+			t := f.formatFieldType(c, tf)
+
+			switch {
+			case f.isNodeField(c, tf) && tf.list:
+				w("    if n.%s != nil {\n", n)
+				w("      c.%s = make(%s, len(n.%s))\n", n, t, n)
+				w("      for i, e := range n.%s {\n", n)
+				w("        if e != nil {\n")
+				w("          c.%s[i] = Clone(e).(%s)\n", n, strings.TrimPrefix(t, "[]"))
+				w("        }\n")
+				w("      }\n")
+				w("    }\n")
+			case f.isNodeField(c, tf):
+				w("    if n.%s != nil {\n", n)
+				w("      c.%s = Clone(n.%s).(%s)\n", n, n, t)
+				w("    }\n")
+			case tf.list:
+				w("    c.%s = append(%s(nil), n.%s...)\n", n, t, n)
+			case isSpan(tf):
+				w("    c.%s = n.%s.clone()\n", n, n)
+			case strings.HasPrefix(t, "*"):
+				w("    if n.%s != nil {\n", n)
+				w("      v := *n.%s\n", n)
+				w("      c.%s = &v\n", n)
+				w("    }\n")
+			}
+		}
+
+		w("    return &c\n")
+	}
+
+	w("  }\n\n")
+	w("  return node\n")
+	w("}\n")
+}
+
+// formatValidate writes the part of Validate that checks the fields of a
+// node, in the same way as its New function does.
+func (f *Formatter) formatValidate(c *formattingContext) {
+	w := c.f
+
+	w("func (v *validator) fields(path string, node Node) {\n")
+	w("  switch n := node.(type) {\n")
+
+	for _, name := range f.concrete(c) {
+		var b bytes.Buffer
+
+		out := c.w
+		c.w = &b
+
+		for _, tf := range c.p.fields(name) {
+			if tf.Static() {
+				continue
+			}
+
+			f.formatCheck(c, tf, "n."+fieldName(name, tf.name), "    ", func(value string) {
+				c.f("      v.add(path, &FieldError{Type: %q, Field: %q, Value: %s})\n", c.p.nodeType(name), tf.name, value)
+			})
+		}
+
+		c.w = out
+
+		if b.Len() > 0 {
+			w("  case *%s:\n", name)
+			w("%s", b.String())
+		}
 	}
 
-	return p + strings.Join(l, "Or")
+	w("  }\n")
+	w("}\n")
 }
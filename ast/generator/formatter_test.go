@@ -0,0 +1,67 @@
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestRegenerate checks that ast.go and estree.go are what the generator
+// makes from their specifications. If it fails, run go generate in the ast or
+// estree package.
+func TestRegenerate(t *testing.T) {
+	a := assert.New(t)
+
+	for _, c := range []struct {
+		spec, out, pkg string
+		core           bool
+	}{
+		{"spec.md", "../ast.go", "ast", false},
+		{"../../estree/spec.md", "../../estree/estree.go", "estree", true},
+	} {
+		rd, err := os.Open(c.spec)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer rd.Close()
+
+		p := NewParser(NewTokeniser(rd))
+		if err := p.parse(); err != nil {
+			t.Fatal(err)
+		}
+
+		var b bytes.Buffer
+		fm := NewFormatter(&b)
+		fm.Package = c.pkg
+		fm.Core = c.core
+		if _, err := fm.Format(p); err != nil {
+			t.Fatal(err)
+		}
+
+		expected, err := ioutil.ReadFile(c.out)
+		if a.NoError(err) {
+			a.True(bytes.Equal(expected, b.Bytes()), "%s is out of date with %s", c.out, c.spec)
+		}
+	}
+}
+
+func TestEnumConstant(t *testing.T) {
+	a := assert.New(t)
+
+	for _, c := range []struct{ enum, value, name string }{
+		{"UnaryOperator", "typeof", "UnaryOperatorTypeof"},
+		{"BinaryOperator", ">>>", "BinaryOperatorShiftRightSigned"},
+		{"AssignmentOperator", "??=", "AssignmentOperatorNullish"},
+	} {
+		n, err := enumConstant(c.enum, c.value)
+		if a.NoError(err) {
+			a.Equal(c.name, n)
+		}
+	}
+
+	_, err := enumConstant("BinaryOperator", "|>")
+	a.EqualError(err, `no name for "|>" in BinaryOperator`)
+}
@@ -1,11 +1,24 @@
 package main
 
 import (
+	"bytes"
+	"flag"
+	"io/ioutil"
 	"os"
 )
 
+var (
+	outFlag     = flag.String("o", "", "File to write the generated code to, rather than standard output")
+	packageFlag = flag.String("package", "ast", "Name of the package to write")
+	coreFlag    = flag.Bool("core", false, "Only write the types, their JSON methods and Walk")
+)
+
 func main() {
-	for _, f := range os.Args[1:] {
+	flag.Parse()
+
+	var b bytes.Buffer
+
+	for _, f := range flag.Args() {
 		rd, err := os.Open(f)
 		if err != nil {
 			panic(err)
@@ -17,8 +30,21 @@ func main() {
 			panic(err)
 		}
 
-		if _, err := NewFormatter(os.Stdout).Format(p); err != nil {
+		fm := NewFormatter(&b)
+		fm.Package = *packageFlag
+		fm.Core = *coreFlag
+
+		if _, err := fm.Format(p); err != nil {
 			panic(err)
 		}
 	}
+
+	if *outFlag == "" {
+		os.Stdout.Write(b.Bytes())
+		return
+	}
+
+	if err := ioutil.WriteFile(*outFlag, b.Bytes(), 0644); err != nil {
+		panic(err)
+	}
 }
@@ -26,6 +26,124 @@ func (p *Parser) Type(name string) *esType {
 	return nil
 }
 
+// ancestors lists every type that a type extends, directly or indirectly.
+func (p *Parser) ancestors(name string) []string {
+	var a []string
+	seen := make(map[string]bool)
+
+	var visit func(n string)
+	visit = func(n string) {
+		t := p.Type(n)
+		if t == nil {
+			return
+		}
+
+		for _, e := range t.extends {
+			if !seen[e] {
+				seen[e] = true
+				a = append(a, e)
+				visit(e)
+			}
+		}
+	}
+
+	visit(name)
+
+	return a
+}
+
+// conforms reports whether a value of type name can be used where a value of
+// type other is expected.
+func (p *Parser) conforms(name, other string) bool {
+	if name == other {
+		return true
+	}
+
+	for _, a := range p.ancestors(name) {
+		if a == other {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (p *Parser) isNode(name string) bool {
+	return p.Type(name) != nil && p.conforms(name, "Node")
+}
+
+// isAbstract reports whether a node type is only ever extended, and never
+// used directly. Concrete types have a fixed `type` field, either of their
+// own or inherited from a concrete type they extend.
+func (p *Parser) isAbstract(name string) bool {
+	if !p.isNode(name) {
+		return false
+	}
+
+	for _, n := range append([]string{name}, p.ancestors(name)...) {
+		for _, f := range p.Type(n).fields {
+			if f.name == "type" && f.Static() {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// nodeType is the value of the `type` field of a concrete node type, which
+// it either declares itself or inherits.
+func (p *Parser) nodeType(name string) string {
+	for _, n := range append([]string{name}, p.ancestors(name)...) {
+		for _, f := range p.Type(n).fields {
+			if f.name == "type" && f.Static() {
+				return f.opts[0].(StringToken).Value()
+			}
+		}
+	}
+
+	return ""
+}
+
+// fields lists the fields of a type, including the ones it inherits, except
+// for the ones that every node has. A field redeclared by a type replaces the
+// one it inherited.
+func (p *Parser) fields(name string) []esTypeField {
+	if name == "Node" {
+		return nil
+	}
+
+	t := p.Type(name)
+	if t == nil {
+		return nil
+	}
+
+	var a []esTypeField
+
+	add := func(f esTypeField) {
+		for i := range a {
+			if a[i].name == f.name {
+				a[i] = f
+				return
+			}
+		}
+
+		a = append(a, f)
+	}
+
+	for _, e := range t.extends {
+		for _, f := range p.fields(e) {
+			add(f)
+		}
+	}
+
+	for _, f := range t.fields {
+		add(f)
+	}
+
+	return a
+}
+
 func (p *Parser) read() (Token, error) {
 	if len(p.buf) > 0 {
 		tk := p.buf[len(p.buf)-1]
@@ -0,0 +1,75 @@
+package ast
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// Unknown holds a node with a type that this package doesn't know about, like
+// one from a syntax extension. It can be used anywhere a node can, and
+// marshals back to the JSON it was read from.
+type Unknown struct {
+	BaseNode
+	Raw json.RawMessage `json:"-"`
+}
+
+func (n *Unknown) UnmarshalJSON(b []byte) error {
+	if err := json.Unmarshal(b, &n.BaseNode); err != nil {
+		return err
+	}
+
+	n.Raw = append(json.RawMessage(nil), b...)
+
+	return nil
+}
+
+func (n Unknown) MarshalJSON() ([]byte, error) {
+	if n.Raw == nil {
+		return json.Marshal(n.BaseNode)
+	}
+
+	return n.Raw, nil
+}
+
+// UnmarshalNode reads a node of any type, as written by Babel, from JSON. A
+// node with a type that this package doesn't know about is read as an
+// Unknown.
+func UnmarshalNode(b []byte) (Node, error) {
+	return decodeNode(b, func(Node) bool { return true })
+}
+
+// decodeNode reads a node from JSON, picking its type using the type field.
+// If more than one Go type has that value, the first one that fits is used.
+// A null is read as a nil node.
+func decodeNode(b []byte, fits func(n Node) bool) (Node, error) {
+	if len(b) == 0 || bytes.Equal(bytes.TrimSpace(b), []byte("null")) {
+		return nil, nil
+	}
+
+	var v struct {
+		Type *string `json:"type"`
+	}
+
+	if err := json.Unmarshal(b, &v); err != nil {
+		return nil, err
+	}
+
+	if v.Type == nil {
+		return nil, fmt.Errorf("ast: node has no type")
+	}
+
+	fns, ok := nodeTypes[*v.Type]
+	if !ok {
+		n := &Unknown{}
+		return n, json.Unmarshal(b, n)
+	}
+
+	for _, fn := range fns {
+		if n := fn(); fits(n) {
+			return n, json.Unmarshal(b, n)
+		}
+	}
+
+	return nil, fmt.Errorf("ast: a %s node can't be used here", *v.Type)
+}
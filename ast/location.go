@@ -0,0 +1,233 @@
+package ast
+
+import (
+	"sort"
+	"unicode/utf8"
+)
+
+// A LineMap converts between byte offsets in a source file, like the Start
+// and End of a node, and Positions, whose lines start at 1 and whose columns
+// count UTF-16 code units, as Babel's do. ByteOffset and UTF16Offset convert
+// to and from the UTF-16 offsets that Babel and JavaScript's string indexes
+// use.
+type LineMap struct {
+	src   string
+	units int
+	lines []lineStart
+}
+
+// A lineStart is where a line starts, in bytes and in UTF-16 code units.
+type lineStart struct {
+	bytes, units int
+}
+
+// NewLineMap returns a LineMap for a source file. Lines end at "\n", "\r\n",
+// "\r", U+2028 and U+2029, as they do in JavaScript.
+func NewLineMap(src string) *LineMap {
+	lines := []lineStart{{}}
+	units := 0
+	for i := 0; i < len(src); {
+		r, n := utf8.DecodeRuneInString(src[i:])
+		i += n
+		units += utf16Len(r)
+
+		if r == '\r' && i < len(src) && src[i] == '\n' {
+			i++
+			units++
+		}
+
+		if lineTerminator(r) {
+			lines = append(lines, lineStart{bytes: i, units: units})
+		}
+	}
+
+	return &LineMap{src: src, units: units, lines: lines}
+}
+
+func lineTerminator(r rune) bool {
+	return r == '\r' || r == '\n' || r == '\u2028' || r == '\u2029'
+}
+
+// line returns the index of the line that a byte offset is on.
+func (m *LineMap) line(offset int) int {
+	return sort.Search(len(m.lines), func(i int) bool { return m.lines[i].bytes > offset }) - 1
+}
+
+// unitLine returns the index of the line that a UTF-16 offset is on.
+func (m *LineMap) unitLine(units int) int {
+	return sort.Search(len(m.lines), func(i int) bool { return m.lines[i].units > units }) - 1
+}
+
+// Position returns the Position of a byte offset. An offset outside of the
+// source is moved to its start or end.
+func (m *LineMap) Position(offset int) Position {
+	offset = clamp(offset, 0, len(m.src))
+	l := m.line(offset)
+
+	return Position{Line: l + 1, Column: unitsIn(m.src[m.lines[l].bytes:offset])}
+}
+
+// Offset returns the byte offset of a Position. A line outside of the source
+// is moved to its first or last line, and a column past the end of its line
+// gives the offset of the end of the line.
+func (m *LineMap) Offset(p Position) int {
+	l := clamp(p.Line, 1, len(m.lines)) - 1
+
+	bytes, _ := m.walk(l, p.Column, true)
+
+	return m.lines[l].bytes + bytes
+}
+
+// ByteOffset returns the byte offset of a UTF-16 offset, like the start or end
+// of a node from Babel. An offset outside of the source is moved to its start
+// or end.
+func (m *LineMap) ByteOffset(units int) int {
+	units = clamp(units, 0, m.units)
+	l := m.unitLine(units)
+
+	bytes, _ := m.walk(l, units-m.lines[l].units, false)
+
+	return m.lines[l].bytes + bytes
+}
+
+// UTF16Offset returns the UTF-16 offset of a byte offset, reversing
+// ByteOffset. An offset outside of the source is moved to its start or end.
+func (m *LineMap) UTF16Offset(offset int) int {
+	offset = clamp(offset, 0, len(m.src))
+	l := m.line(offset)
+
+	return m.lines[l].units + unitsIn(m.src[m.lines[l].bytes:offset])
+}
+
+// Slice returns the source between two byte offsets, like the Start and End of
+// a node.
+func (m *LineMap) Slice(start, end int) string {
+	return m.src[clamp(start, 0, len(m.src)):clamp(end, 0, len(m.src))]
+}
+
+// walk moves along line l until it has covered the given number of UTF-16
+// code units, stopping at the end of the source or, if content is set, at the
+// end of the line's content. It returns how far it went in bytes and in units.
+func (m *LineMap) walk(l, units int, content bool) (int, int) {
+	i, u := m.lines[l].bytes, 0
+	for i < len(m.src) && u < units {
+		r, n := utf8.DecodeRuneInString(m.src[i:])
+		if content && lineTerminator(r) {
+			break
+		}
+
+		i += n
+		u += utf16Len(r)
+	}
+
+	return i - m.lines[l].bytes, u
+}
+
+// Location returns a SourceLocation for the source between two byte offsets,
+// like the Start and End of a node.
+func (m *LineMap) Location(start, end int) *SourceLocation {
+	return &SourceLocation{Start: m.Position(start), End: m.Position(end)}
+}
+
+func clamp(v, min, max int) int {
+	if v < min {
+		return min
+	}
+	if v > max {
+		return max
+	}
+
+	return v
+}
+
+func utf16Len(r rune) int {
+	if r >= 0x10000 {
+		return 2
+	}
+
+	return 1
+}
+
+// unitsIn returns the number of UTF-16 code units in s.
+func unitsIn(s string) int {
+	u := 0
+	for _, r := range s {
+		u += utf16Len(r)
+	}
+
+	return u
+}
+
+// ToByteOffsets rewrites the Start and End of every node, comment and Span in
+// a tree from the UTF-16 offsets that Babel writes to byte offsets, using the
+// LineMap of the source the tree came from.
+func ToByteOffsets(node Node, m *LineMap) {
+	comments := func(c []Comment) {
+		for i := range c {
+			c[i].Start, c[i].End = m.ByteOffset(c[i].Start), m.ByteOffset(c[i].End)
+		}
+	}
+
+	span := func(s *Span) {
+		if s != nil {
+			s.Start, s.End = m.ByteOffset(s.Start), m.ByteOffset(s.End)
+			comments(s.LeadingComments)
+			comments(s.TrailingComments)
+			comments(s.InnerComments)
+		}
+	}
+
+	Apply(node, func(c *Cursor) bool {
+		if c.Node() == nil {
+			return true
+		}
+
+		b := c.Node().Base()
+
+		b.Start, b.End = m.ByteOffset(b.Start), m.ByteOffset(b.End)
+		comments(b.LeadingComments)
+		comments(b.TrailingComments)
+		comments(b.InnerComments)
+
+		switch n := c.Node().(type) {
+		case *ObjectMethod:
+			span(n.FunctionSpan)
+		case *OptionalMemberExpression:
+			span(n.ChainSpan)
+		case *OptionalCallExpression:
+			span(n.ChainSpan)
+		}
+
+		return true
+	}, nil)
+}
+
+// SetSource sets the Source of the Loc of every node and comment in a tree to
+// the name of the file it came from, so that trees from more than one file can
+// be told apart once they're put together. Nodes and comments without a Loc
+// are left alone.
+func SetSource(node Node, source string) {
+	set := func(l *SourceLocation) {
+		if l != nil {
+			s := source
+			l.Source = &s
+		}
+	}
+
+	Apply(node, func(c *Cursor) bool {
+		if c.Node() == nil {
+			return true
+		}
+
+		b := c.Node().Base()
+
+		set(b.Loc)
+		for _, comments := range [][]Comment{b.LeadingComments, b.TrailingComments, b.InnerComments} {
+			for _, c := range comments {
+				set(c.Loc)
+			}
+		}
+
+		return true
+	}, nil)
+}
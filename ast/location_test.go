@@ -0,0 +1,125 @@
+package ast
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLineMap(t *testing.T) {
+	a := assert.New(t)
+
+	src := "a\nbé😀c\r\nd\u2028e"
+	m := NewLineMap(src)
+
+	for _, c := range []struct {
+		offset, units int
+		pos           Position
+	}{
+		{0, 0, Position{Line: 1, Column: 0}},
+		{1, 1, Position{Line: 1, Column: 1}},
+		{2, 2, Position{Line: 2, Column: 0}},
+		{3, 3, Position{Line: 2, Column: 1}},
+		{5, 4, Position{Line: 2, Column: 2}},
+		{9, 6, Position{Line: 2, Column: 4}},
+		{10, 7, Position{Line: 2, Column: 5}},
+		{12, 9, Position{Line: 3, Column: 0}},
+		{16, 11, Position{Line: 4, Column: 0}},
+		{17, 12, Position{Line: 4, Column: 1}},
+	} {
+		a.Equal(c.pos, m.Position(c.offset), "offset %d", c.offset)
+		a.Equal(c.offset, m.Offset(c.pos), "%v", c.pos)
+		a.Equal(c.offset, m.ByteOffset(c.units), "units %d", c.units)
+		a.Equal(c.units, m.UTF16Offset(c.offset), "offset %d", c.offset)
+	}
+
+	a.Equal(Position{Line: 1, Column: 0}, m.Position(-1))
+	a.Equal(Position{Line: 4, Column: 1}, m.Position(100))
+	a.Equal(10, m.Offset(Position{Line: 2, Column: 50}))
+	a.Equal(16, m.Offset(Position{Line: 9, Column: 0}))
+	a.Equal(len(src), m.ByteOffset(100))
+	a.Equal(12, m.UTF16Offset(100))
+
+	a.Equal(&SourceLocation{Start: Position{Line: 2, Column: 1}, End: Position{Line: 3, Column: 0}}, m.Location(3, 12))
+
+	a.Equal("é😀c", m.Slice(3, 10))
+	a.Equal("e", m.Slice(16, 100))
+}
+
+func TestToByteOffsets(t *testing.T) {
+	a := assert.New(t)
+
+	// Babel's offsets for '😀'+a?.b/*é*/, which are in UTF-16 code units.
+	base := func(start, end int) BaseNode { return BaseNode{Start: start, End: end} }
+	comment := Comment{Type: "CommentBlock", Value: "é", Start: 9, End: 14}
+
+	member := &OptionalMemberExpression{
+		BaseNode:  base(5, 9),
+		Object:    &Identifier{BaseNode: base(5, 6), Name: "a"},
+		Property:  &Identifier{BaseNode: base(8, 9), Name: "b"},
+		Optional:  true,
+		ChainSpan: &Span{Start: 5, End: 9, TrailingComments: []Comment{comment}},
+	}
+
+	s := &ExpressionStatement{BaseNode: base(0, 14), Expression: &BinaryExpression{
+		BaseNode: base(0, 9),
+		Operator: "+",
+		Left:     &StringLiteral{BaseNode: base(0, 4), Value: "😀"},
+		Right:    member,
+	}}
+	s.TrailingComments = []Comment{comment}
+
+	m := NewLineMap("'😀'+a?.b/*é*/")
+	ToByteOffsets(s, m)
+
+	b := s.Expression.(*BinaryExpression)
+	a.Equal([2]int{0, 17}, [2]int{s.Start, s.End})
+	a.Equal([2]int{11, 17}, [2]int{s.TrailingComments[0].Start, s.TrailingComments[0].End})
+	a.Equal([2]int{0, 11}, [2]int{b.Start, b.End})
+	a.Equal([2]int{0, 6}, [2]int{b.Left.Base().Start, b.Left.Base().End})
+	a.Equal([2]int{7, 11}, [2]int{member.Start, member.End})
+	a.Equal([2]int{10, 11}, [2]int{member.Property.Base().Start, member.Property.Base().End})
+	a.Equal([2]int{7, 11}, [2]int{member.ChainSpan.Start, member.ChainSpan.End})
+	a.Equal([2]int{11, 17}, [2]int{member.ChainSpan.TrailingComments[0].Start, member.ChainSpan.TrailingComments[0].End})
+
+	a.Equal("'😀'", m.Slice(b.Left.Base().Start, b.Left.Base().End))
+	a.Equal("/*é*/", m.Slice(s.TrailingComments[0].Start, s.TrailingComments[0].End))
+}
+
+func TestSetSource(t *testing.T) {
+	a := assert.New(t)
+
+	loc := func() *SourceLocation { return &SourceLocation{End: Position{Line: 1, Column: 1}} }
+
+	p := &Program{
+		BaseNode: BaseNode{Loc: loc()},
+		Body: []StatementOrModuleDeclaration{
+			&ExpressionStatement{
+				BaseNode:   BaseNode{LeadingComments: []Comment{{Type: "CommentLine", Loc: loc()}}},
+				Expression: &Identifier{BaseNode: BaseNode{Loc: loc()}, Name: "a"},
+			},
+		},
+	}
+
+	SetSource(p, "a.js")
+
+	s := p.Body[0].(*ExpressionStatement)
+	for _, l := range []*SourceLocation{p.Loc, s.LeadingComments[0].Loc, s.Expression.Base().Loc} {
+		if a.NotNil(l.Source) {
+			a.Equal("a.js", *l.Source)
+		}
+	}
+	a.Nil(s.Loc)
+}
+
+func TestSetSourceNil(t *testing.T) {
+	a := assert.New(t)
+
+	r := &ReturnStatement{BaseNode: BaseNode{Loc: &SourceLocation{}}}
+
+	a.NotPanics(func() { SetSource(r, "a.js") })
+	if a.NotNil(r.Loc.Source) {
+		a.Equal("a.js", *r.Loc.Source)
+	}
+	a.NotPanics(func() { SetSource(&ReturnStatement{}, "a.js") })
+}
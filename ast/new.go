@@ -0,0 +1,35 @@
+package ast
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// A FieldError is returned by the New functions when a field is given a value
+// that it can't hold: an invalid operator or kind, or a nil node where the
+// specification doesn't allow null.
+type FieldError struct {
+	Type  string      // the type of node, like "BinaryExpression"
+	Field string      // the field, named as it is in JSON
+	Value interface{} // the value, or nil for a missing node
+}
+
+func (e *FieldError) Error() string {
+	if e.Value == nil {
+		return fmt.Sprintf("ast: %s.%s can't be null", e.Type, e.Field)
+	}
+
+	return fmt.Sprintf("ast: %s.%s can't be %q", e.Type, e.Field, e.Value)
+}
+
+// missing reports whether n is nil, either as an interface or as a typed nil
+// pointer inside one.
+func missing(n Node) bool {
+	if n == nil {
+		return true
+	}
+
+	v := reflect.ValueOf(n)
+
+	return v.Kind() == reflect.Ptr && v.IsNil()
+}
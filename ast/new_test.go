@@ -0,0 +1,60 @@
+package ast
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNew(t *testing.T) {
+	a := assert.New(t)
+
+	left, err := NewIdentifier("a")
+	a.NoError(err)
+	right, err := NewNumericLiteral(1, nil)
+	a.NoError(err)
+
+	n, err := NewBinaryExpression(BinaryOperatorPlus, left, right)
+	if a.NoError(err) {
+		a.Equal(&BinaryExpression{
+			BaseNode: BaseNode{Type: "BinaryExpression"},
+			Operator: "+",
+			Left:     &Identifier{BaseNode: BaseNode{Type: "Identifier"}, Name: "a"},
+			Right:    &NumericLiteral{BaseNode: BaseNode{Type: "NumericLiteral"}, Value: 1},
+		}, n)
+	}
+
+	d, err := NewDirectiveLiteral("use strict", nil)
+	if a.NoError(err) {
+		a.Equal("DirectiveLiteral", d.Type)
+	}
+}
+
+func TestNewInvalid(t *testing.T) {
+	a := assert.New(t)
+
+	id := &Identifier{Name: "a"}
+
+	_, err := NewBinaryExpression("=>", id, id)
+	a.Equal(&FieldError{Type: "BinaryExpression", Field: "operator", Value: BinaryOperator("=>")}, err)
+	a.EqualError(err, `ast: BinaryExpression.operator can't be "=>"`)
+
+	_, err = NewBinaryExpression(BinaryOperatorPlus, id, nil)
+	a.Equal(&FieldError{Type: "BinaryExpression", Field: "right"}, err)
+	a.EqualError(err, "ast: BinaryExpression.right can't be null")
+
+	_, err = NewBinaryExpression(BinaryOperatorPlus, (*Identifier)(nil), id)
+	a.Equal(&FieldError{Type: "BinaryExpression", Field: "left"}, err)
+
+	_, err = NewVariableDeclaration(nil, "variable")
+	a.Equal(&FieldError{Type: "VariableDeclaration", Field: "kind", Value: "variable"}, err)
+
+	_, err = NewBlockStatement([]Statement{nil}, nil)
+	a.Equal(&FieldError{Type: "BlockStatement", Field: "body"}, err)
+
+	// Some fields and lists can hold null.
+	_, err = NewReturnStatement(nil)
+	a.NoError(err)
+	_, err = NewArrayExpression([]ExpressionOrSpreadElement{nil, id})
+	a.NoError(err)
+}
@@ -0,0 +1,1736 @@
+// Package printer writes syntax trees from the ast package out as JavaScript
+// source.
+package printer
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"fknsrs.biz/p/jsparser/ast"
+	"fknsrs.biz/p/jsparser/sourcemap"
+)
+
+// A Mode value is a set of flags (or 0). They control printing.
+type Mode uint
+
+const (
+	Compact         Mode = 1 << iota // leave out all optional whitespace
+	InlineSourceMap                  // end the output with the source map in a comment
+)
+
+// A Config node controls the output of Fprint.
+type Config struct {
+	Mode   Mode
+	Indent string // indentation for each level of nesting; ignored in Compact mode
+
+	// If SourceMap is set, a mapping is added to it for the first token of
+	// every node that has a location. Generated positions are relative to
+	// the start of the output. Source names the original file for locations
+	// that don't have one of their own.
+	SourceMap *sourcemap.Generator
+	Source    string
+}
+
+// Fprint "pretty-prints" a node to w. The node can be a Program, or any
+// statement, expression or other node that can be written on its own.
+//
+// Parentheses are added wherever the tree can't be written without them,
+// whether or not the original source had them. Semicolons are always
+// written, so the output never relies on automatic semicolon insertion.
+func (cfg *Config) Fprint(w io.Writer, node ast.Node) error {
+	p := printer{Config: *cfg}
+	p.pos.Line = 1
+
+	p.node(node)
+	if p.err != nil {
+		return p.err
+	}
+
+	if p.Mode&InlineSourceMap != 0 && p.SourceMap != nil {
+		c, err := p.SourceMap.Map().Comment()
+		if err != nil {
+			return err
+		}
+
+		if b := p.buf.Bytes(); len(b) > 0 && b[len(b)-1] != '\n' {
+			p.buf.WriteByte('\n')
+		}
+
+		p.buf.WriteString(c + "\n")
+	}
+
+	_, err := w.Write(p.buf.Bytes())
+
+	return err
+}
+
+// Fprint writes a node to w, indented with two spaces.
+func Fprint(w io.Writer, node ast.Node) error {
+	return (&Config{Indent: "  "}).Fprint(w, node)
+}
+
+// Precedence levels, lowest first. An expression is parenthesised when it's
+// written somewhere that needs a higher level than its own.
+const (
+	precSequence = iota
+	precAssignment
+	precConditional
+	precLogicalOr
+	precLogicalAnd
+	precBitwiseOr
+	precBitwiseXor
+	precBitwiseAnd
+	precEquality
+	precRelational
+	precShift
+	precAdditive
+	precMultiplicative
+	precExponentiation
+	precUnary
+	precUpdate
+	precCall
+	precMember
+	precPrimary
+)
+
+var binaryPrecedence = map[string]int{
+	"||":         precLogicalOr,
+	"??":         precLogicalOr,
+	"&&":         precLogicalAnd,
+	"|":          precBitwiseOr,
+	"^":          precBitwiseXor,
+	"&":          precBitwiseAnd,
+	"==":         precEquality,
+	"!=":         precEquality,
+	"===":        precEquality,
+	"!==":        precEquality,
+	"<":          precRelational,
+	"<=":         precRelational,
+	">":          precRelational,
+	">=":         precRelational,
+	"in":         precRelational,
+	"instanceof": precRelational,
+	"<<":         precShift,
+	">>":         precShift,
+	">>>":        precShift,
+	"+":          precAdditive,
+	"-":          precAdditive,
+	"*":          precMultiplicative,
+	"/":          precMultiplicative,
+	"%":          precMultiplicative,
+	"**":         precExponentiation,
+}
+
+type printer struct {
+	Config
+
+	buf   bytes.Buffer
+	depth int
+	noIn  bool // inside a for initialiser, where "in" can't appear bare
+	err   error
+
+	// Source map state: the node to map to the next token, and the
+	// generated position at offset mapped in buf.
+	pending ast.Node
+	pos     sourcemap.Position
+	mapped  int
+}
+
+func (p *printer) errorf(format string, a ...interface{}) {
+	if p.err == nil {
+		p.err = fmt.Errorf("printer: "+format, a...)
+	}
+}
+
+func (p *printer) compact() bool {
+	return p.Mode&Compact != 0
+}
+
+// print writes a token, with a space before it if it would otherwise run into
+// the one before.
+func (p *printer) print(s string) {
+	if s == "" {
+		return
+	}
+
+	if b := p.buf.Bytes(); len(b) > 0 {
+		l, r := b[len(b)-1], s[0]
+
+		switch {
+		case isWordByte(l) && isWordByte(r),
+			l == '+' && r == '+',
+			l == '-' && r == '-',
+			l == '/' && (r == '/' || r == '*'),
+			l == '<' && r == '!':
+			p.buf.WriteByte(' ')
+		}
+	}
+
+	if p.pending != nil {
+		p.addMapping(p.pending)
+		p.pending = nil
+	}
+
+	p.buf.WriteString(s)
+}
+
+// mark notes that the next token written is the start of n.
+func (p *printer) mark(n ast.Node) {
+	if p.SourceMap != nil && n.Base().Loc != nil {
+		p.pending = n
+	}
+}
+
+func (p *printer) addMapping(n ast.Node) {
+	loc := n.Base().Loc
+
+	m := sourcemap.Mapping{
+		Generated: p.position(),
+		Source:    p.Source,
+		Original:  sourcemap.Position{Line: loc.Start.Line, Column: loc.Start.Column},
+	}
+
+	if loc.Source != nil {
+		m.Source = *loc.Source
+	}
+
+	if id, ok := n.(*ast.Identifier); ok {
+		m.Name = id.Name
+	}
+
+	p.SourceMap.Add(m)
+}
+
+// position returns the generated position at the end of buf.
+func (p *printer) position() sourcemap.Position {
+	s := string(p.buf.Bytes()[p.mapped:])
+	p.mapped = p.buf.Len()
+
+	if i := strings.LastIndexByte(s, '\n'); i >= 0 {
+		p.pos.Line += strings.Count(s, "\n")
+		p.pos.Column = 0
+		s = s[i+1:]
+	}
+
+	p.pos.Column += sourcemap.Columns(s)
+
+	return p.pos
+}
+
+// space writes optional whitespace.
+func (p *printer) space() {
+	if !p.compact() {
+		p.buf.WriteByte(' ')
+	}
+}
+
+// line starts a new line at the current indentation.
+func (p *printer) line() {
+	if !p.compact() && p.buf.Len() > 0 {
+		p.buf.WriteByte('\n')
+		p.buf.WriteString(strings.Repeat(p.Indent, p.depth))
+	}
+}
+
+func isWordByte(b byte) bool {
+	return b == '_' || b == '$' || b == '\\' || b >= 0x80 ||
+		'a' <= b && b <= 'z' || 'A' <= b && b <= 'Z' || '0' <= b && b <= '9'
+}
+
+func (p *printer) node(n ast.Node) {
+	switch n := n.(type) {
+	case *ast.Program:
+		p.statements(n.Directives, statements(n.Body))
+		if !p.compact() && p.buf.Len() > 0 {
+			p.buf.WriteByte('\n')
+		}
+	case ast.Statement:
+		p.statement(n)
+	case ast.Expression:
+		p.expr(n, precSequence)
+	case ast.Pattern:
+		p.pattern(n)
+	case *ast.Directive:
+		p.directive(n)
+	case *ast.SwitchCase:
+		p.switchCase(n)
+	case *ast.CatchClause:
+		p.catchClause(n)
+	case *ast.VariableDeclarator:
+		p.declarator(n)
+	case *ast.ClassBody:
+		p.classBody(n)
+	case ast.ModuleDeclaration:
+		p.statement(n)
+	default:
+		p.errorf("can't print %T", n)
+	}
+}
+
+func statements(a []ast.StatementOrModuleDeclaration) []ast.Node {
+	r := make([]ast.Node, len(a))
+	for i, s := range a {
+		r[i] = s
+	}
+
+	return r
+}
+
+// statements writes a list of statements, each on its own line.
+func (p *printer) statements(directives []*ast.Directive, body []ast.Node) {
+	for _, d := range directives {
+		p.line()
+		p.directive(d)
+	}
+
+	for _, s := range body {
+		p.line()
+		p.statement(s)
+	}
+}
+
+// directive writes a directive as it was written in the source, if that's
+// known. Otherwise its value, which is the text between the quotes rather
+// than what the string means, is quoted with whichever quote it doesn't have.
+func (p *printer) directive(d *ast.Directive) {
+	p.mark(d)
+
+	switch v := d.Value.Value; {
+	case d.Value.Extra != nil && d.Value.Extra.Raw != "":
+		p.print(d.Value.Extra.Raw)
+	case !strings.Contains(v, `"`):
+		p.print(`"` + v + `"`)
+	case !strings.Contains(v, "'"):
+		p.print("'" + v + "'")
+	default:
+		p.errorf("can't print the directive %q, which has both kinds of quote in it", v)
+	}
+
+	p.print(";")
+}
+
+func (p *printer) block(b *ast.BlockStatement) {
+	p.braces(func() { p.statements(b.Directives, statementList(b.Body)) }, len(b.Directives)+len(b.Body))
+}
+
+func statementList(a []ast.Statement) []ast.Node {
+	r := make([]ast.Node, len(a))
+	for i, s := range a {
+		r[i] = s
+	}
+
+	return r
+}
+
+// braces writes a block with n lines in it.
+func (p *printer) braces(f func(), n int) {
+	p.print("{")
+
+	if n > 0 {
+		p.depth++
+		f()
+		p.depth--
+		p.line()
+	}
+
+	p.print("}")
+}
+
+// body writes the body of a compound statement: on the same line if it's a
+// block, and on the next one otherwise.
+func (p *printer) body(s ast.Statement) {
+	if b, ok := s.(*ast.BlockStatement); ok {
+		p.space()
+		p.block(b)
+		return
+	}
+
+	p.depth++
+	p.line()
+	p.statement(s)
+	p.depth--
+}
+
+func (p *printer) statement(n ast.Node) {
+	p.mark(n)
+
+	switch n := n.(type) {
+	case *ast.ExpressionStatement:
+		if _, ok := n.Expression.(*ast.StringLiteral); ok || startsStatement(n.Expression) {
+			// A string on its own could be read as a directive.
+			p.parens(n.Expression, precSequence)
+		} else {
+			p.expr(n.Expression, precSequence)
+		}
+		p.print(";")
+	case *ast.BlockStatement:
+		p.block(n)
+	case *ast.EmptyStatement:
+		p.print(";")
+	case *ast.DebuggerStatement:
+		p.print("debugger")
+		p.print(";")
+	case *ast.WithStatement:
+		p.print("with")
+		p.space()
+		p.parens(n.Object, precSequence)
+		p.body(n.Body)
+	case *ast.ReturnStatement:
+		p.print("return")
+		if n.Argument != nil {
+			p.space()
+			p.expr(n.Argument, precSequence)
+		}
+		p.print(";")
+	case *ast.LabeledStatement:
+		p.identifier(n.Label)
+		p.print(":")
+		p.space()
+		p.statement(n.Body)
+	case *ast.BreakStatement:
+		p.jump("break", n.Label)
+	case *ast.ContinueStatement:
+		p.jump("continue", n.Label)
+	case *ast.IfStatement:
+		p.ifStatement(n)
+	case *ast.SwitchStatement:
+		p.print("switch")
+		p.space()
+		p.parens(n.Discriminant, precSequence)
+		p.space()
+		p.braces(func() {
+			for _, c := range n.Cases {
+				p.line()
+				p.switchCase(c)
+			}
+		}, len(n.Cases))
+	case *ast.ThrowStatement:
+		p.print("throw")
+		p.space()
+		p.expr(n.Argument, precSequence)
+		p.print(";")
+	case *ast.TryStatement:
+		p.print("try")
+		p.space()
+		p.block(n.Block)
+		if n.Handler != nil {
+			p.space()
+			p.catchClause(n.Handler)
+		}
+		if n.Finalizer != nil {
+			p.space()
+			p.print("finally")
+			p.space()
+			p.block(n.Finalizer)
+		}
+	case *ast.WhileStatement:
+		p.print("while")
+		p.space()
+		p.parens(n.Test, precSequence)
+		p.body(n.Body)
+	case *ast.DoWhileStatement:
+		p.print("do")
+		p.body(n.Body)
+		if _, ok := n.Body.(*ast.BlockStatement); ok {
+			p.space()
+		} else {
+			p.line()
+		}
+		p.print("while")
+		p.space()
+		p.parens(n.Test, precSequence)
+		p.print(";")
+	case *ast.ForStatement:
+		p.print("for")
+		p.space()
+		p.print("(")
+		if n.Init != nil {
+			p.noIn = true
+			p.forInit(n.Init)
+			p.noIn = false
+		}
+		p.print(";")
+		if n.Test != nil {
+			p.space()
+			p.expr(n.Test, precSequence)
+		}
+		p.print(";")
+		if n.Update != nil {
+			p.space()
+			p.expr(n.Update, precSequence)
+		}
+		p.print(")")
+		p.body(n.Body)
+	case *ast.ForInStatement:
+		p.forIn("in", n.Left, n.Right, precSequence, n.Body)
+	case *ast.ForOfStatement:
+		p.forIn("of", n.Left, n.Right, precAssignment, n.Body)
+	case *ast.FunctionDeclaration:
+		p.function(n.Async, n.Generator, n.ID, n.Params, n.Body)
+	case *ast.VariableDeclaration:
+		p.variableDeclaration(n)
+		p.print(";")
+	case *ast.ClassDeclaration:
+		p.class(n.Decorators, n.ID, n.SuperClass, n.Body)
+	case *ast.ImportDeclaration:
+		p.importDeclaration(n)
+	case *ast.ExportNamedDeclaration:
+		p.print("export")
+		if n.Declaration != nil {
+			p.space()
+			p.statement(n.Declaration)
+			return
+		}
+		p.space()
+		p.print("{")
+		for i, s := range n.Specifiers {
+			if i > 0 {
+				p.print(",")
+			}
+			p.space()
+			p.name(s.Local)
+			if s.Exported != nil && s.Exported.Name != s.Local.Name {
+				p.print("as")
+				p.name(s.Exported)
+			}
+		}
+		if len(n.Specifiers) > 0 {
+			p.space()
+		}
+		p.print("}")
+		if n.Source != nil {
+			p.space()
+			p.print("from")
+			p.space()
+			p.expr(n.Source, precPrimary)
+			p.attributes(n.Attributes)
+		}
+		p.print(";")
+	case *ast.ExportDefaultDeclaration:
+		p.print("export")
+		p.print("default")
+		p.space()
+		switch d := n.Declaration.(type) {
+		case *ast.FunctionDeclaration, *ast.ClassDeclaration:
+			p.statement(d)
+		case ast.Expression:
+			if startsDefault(d) {
+				p.parens(d, precAssignment)
+			} else {
+				p.expr(d, precAssignment)
+			}
+			p.print(";")
+		default:
+			p.errorf("can't print %T as a default export", d)
+		}
+	case *ast.ExportAllDeclaration:
+		p.print("export")
+		p.space()
+		p.print("*")
+		p.space()
+		p.print("from")
+		p.space()
+		p.expr(n.Source, precPrimary)
+		p.attributes(n.Attributes)
+		p.print(";")
+	default:
+		p.errorf("can't print %T as a statement", n)
+	}
+}
+
+func (p *printer) jump(keyword string, label *ast.Identifier) {
+	p.print(keyword)
+	if label != nil {
+		p.space()
+		p.identifier(label)
+	}
+	p.print(";")
+}
+
+func (p *printer) ifStatement(n *ast.IfStatement) {
+	p.print("if")
+	p.space()
+	p.parens(n.Test, precSequence)
+
+	consequent := n.Consequent
+	if n.Alternate != nil && danglingIf(consequent) {
+		// Without braces, the else would belong to the inner if.
+		consequent = &ast.BlockStatement{Body: []ast.Statement{consequent}}
+	}
+
+	p.body(consequent)
+
+	if n.Alternate == nil {
+		return
+	}
+
+	if _, ok := consequent.(*ast.BlockStatement); ok {
+		p.space()
+	} else {
+		p.line()
+	}
+
+	p.print("else")
+
+	if alt, ok := n.Alternate.(*ast.IfStatement); ok {
+		p.space()
+		p.ifStatement(alt)
+		return
+	}
+
+	p.body(n.Alternate)
+}
+
+// danglingIf reports whether a statement ends with an if that has no else.
+func danglingIf(s ast.Statement) bool {
+	for {
+		switch n := s.(type) {
+		case *ast.IfStatement:
+			if n.Alternate == nil {
+				return true
+			}
+			s = n.Alternate
+		case *ast.LabeledStatement:
+			s = n.Body
+		case *ast.WithStatement:
+			s = n.Body
+		case *ast.WhileStatement:
+			s = n.Body
+		case *ast.ForStatement:
+			s = n.Body
+		case *ast.ForInStatement:
+			s = n.Body
+		case *ast.ForOfStatement:
+			s = n.Body
+		default:
+			return false
+		}
+	}
+}
+
+func (p *printer) switchCase(c *ast.SwitchCase) {
+	if c.Test != nil {
+		p.print("case")
+		p.space()
+		p.expr(c.Test, precSequence)
+	} else {
+		p.print("default")
+	}
+
+	p.print(":")
+
+	p.depth++
+	for _, s := range c.Consequent {
+		p.line()
+		p.statement(s)
+	}
+	p.depth--
+}
+
+func (p *printer) catchClause(c *ast.CatchClause) {
+	p.print("catch")
+	p.space()
+	p.print("(")
+	p.pattern(c.Param)
+	p.print(")")
+	p.space()
+	p.block(c.Body)
+}
+
+func (p *printer) forInit(n ast.VariableDeclarationOrExpression) {
+	switch n := n.(type) {
+	case *ast.VariableDeclaration:
+		p.variableDeclaration(n)
+	case ast.Expression:
+		if startsWithLet(n, true) {
+			p.parens(n, precSequence)
+		} else {
+			p.expr(n, precSequence)
+		}
+	default:
+		p.errorf("can't print %T in a for statement", n)
+	}
+}
+
+func (p *printer) forIn(keyword string, left ast.VariableDeclarationOrExpression, right ast.Expression, prec int, body ast.Statement) {
+	p.print("for")
+	p.space()
+	p.print("(")
+
+	switch n := left.(type) {
+	case *ast.VariableDeclaration:
+		p.variableDeclaration(n)
+	case ast.Pattern:
+		if forInLookahead(keyword, n) {
+			p.parens(n, precSequence)
+		} else {
+			p.pattern(n)
+		}
+	case ast.Expression:
+		if forInLookahead(keyword, n) {
+			p.parens(n, precSequence)
+		} else {
+			p.expr(n, precCall)
+		}
+	default:
+		p.errorf("can't print %T in a for-%s statement", n, keyword)
+	}
+
+	if b := p.buf.Bytes(); len(b) > 0 && !isWordByte(b[len(b)-1]) {
+		// After a bracket, the keyword only needs a space to read well.
+		p.space()
+	}
+	p.print(keyword)
+	p.space()
+	p.expr(right, prec)
+	p.print(")")
+	p.body(body)
+}
+
+// forInLookahead reports whether the left side of a for-in or for-of statement
+// starts with something that would be read as a declaration: "let [" in a
+// for-in, or let or "async of" in a for-of.
+func forInLookahead(keyword string, n ast.Node) bool {
+	if keyword == "in" {
+		return startsWithLet(n, true)
+	}
+
+	id, ok := n.(*ast.Identifier)
+
+	return ok && id.Name == "async" || startsWithLet(n, false)
+}
+
+func (p *printer) variableDeclaration(n *ast.VariableDeclaration) {
+	p.print(n.Kind)
+	p.space()
+
+	for i, d := range n.Declarations {
+		if i > 0 {
+			p.print(",")
+			p.space()
+		}
+
+		p.declarator(d)
+	}
+}
+
+func (p *printer) declarator(d *ast.VariableDeclarator) {
+	p.mark(d)
+	p.pattern(d.ID)
+
+	if d.Init != nil {
+		p.space()
+		p.print("=")
+		p.space()
+		p.expr(d.Init, precAssignment)
+	}
+}
+
+func (p *printer) function(async, generator bool, id *ast.Identifier, params []ast.Pattern, body *ast.BlockStatement) {
+	if async {
+		p.print("async")
+	}
+
+	p.print("function")
+	if generator {
+		p.print("*")
+	}
+
+	if id != nil {
+		if generator {
+			p.space()
+		}
+		p.identifier(id)
+	}
+
+	p.params(params)
+	p.space()
+	p.functionBody(body)
+}
+
+func (p *printer) params(a []ast.Pattern) {
+	p.print("(")
+	for i, n := range a {
+		if i > 0 {
+			p.print(",")
+			p.space()
+		}
+
+		p.pattern(n)
+	}
+	p.print(")")
+}
+
+// functionBody writes a block that can have "in" in it, even if it's in a for
+// initialiser.
+func (p *printer) functionBody(b *ast.BlockStatement) {
+	noIn := p.noIn
+	p.noIn = false
+	p.block(b)
+	p.noIn = noIn
+}
+
+func (p *printer) class(decorators []*ast.Decorator, id *ast.Identifier, superClass ast.Expression, body *ast.ClassBody) {
+	p.decorators(decorators)
+
+	p.print("class")
+	if id != nil {
+		p.identifier(id)
+	}
+
+	if superClass != nil {
+		p.print("extends")
+		p.space()
+		p.expr(superClass, precCall)
+	}
+
+	p.space()
+	p.classBody(body)
+}
+
+func (p *printer) decorators(a []*ast.Decorator) {
+	for _, d := range a {
+		p.print("@")
+		p.expr(d.Expression, precCall)
+		p.space()
+	}
+}
+
+func (p *printer) classBody(b *ast.ClassBody) {
+	noIn := p.noIn
+	p.noIn = false
+
+	p.braces(func() {
+		for _, m := range b.Body {
+			p.line()
+
+			switch m := m.(type) {
+			case *ast.ClassMethod:
+				p.decorators(m.Decorators)
+				if m.Static {
+					p.print("static")
+				}
+				p.method(m.Kind, m.Key, m.Computed, m.Value.Async, m.Value.Generator, m.Value.Params, m.Value.Body)
+			case *ast.ClassPrivateMethod:
+				p.decorators(m.Decorators)
+				if m.Static {
+					p.print("static")
+				}
+				p.method(m.Kind, m.Key, false, m.Value.Async, m.Value.Generator, m.Value.Params, m.Value.Body)
+			case *ast.ClassPrivateProperty:
+				p.decorators(m.Decorators)
+				if m.Static {
+					p.print("static")
+				}
+				p.privateName(m.Key)
+				if m.Value != nil {
+					p.space()
+					p.print("=")
+					p.space()
+					p.expr(m.Value, precAssignment)
+				}
+				p.print(";")
+			case *ast.StaticBlock:
+				p.print("static")
+				p.space()
+				p.braces(func() { p.statements(nil, statementList(m.Body)) }, len(m.Body))
+			case *ast.ClassProperty:
+				p.decorators(m.Decorators)
+				if m.Static {
+					p.print("static")
+					p.space()
+				}
+				p.key(m.Key, m.Computed)
+				if m.Value != nil {
+					p.space()
+					p.print("=")
+					p.space()
+					p.expr(m.Value, precAssignment)
+				}
+				p.print(";")
+			default:
+				p.errorf("can't print %T in a class", m)
+			}
+		}
+	}, len(b.Body))
+
+	p.noIn = noIn
+}
+
+// method writes a method of a class or object, which kind says is "get",
+// "set", or something else.
+func (p *printer) method(kind string, key ast.Node, computed, async, generator bool, params []ast.Pattern, body *ast.BlockStatement) {
+	switch kind {
+	case "get", "set":
+		p.print(kind)
+	}
+
+	if async {
+		p.print("async")
+	}
+
+	if generator {
+		p.print("*")
+	}
+
+	p.key(key, computed)
+	p.params(params)
+	p.space()
+	p.functionBody(body)
+}
+
+// key writes the key of a property, method or import attribute.
+func (p *printer) key(key ast.Node, computed bool) {
+	if computed {
+		p.print("[")
+		p.inner(key, precAssignment)
+		p.print("]")
+		return
+	}
+
+	switch key := key.(type) {
+	case *ast.Identifier:
+		p.name(key)
+	case *ast.PrivateName:
+		p.privateName(key)
+	case *ast.StringLiteral:
+		p.expr(key, precPrimary)
+	case *ast.NumericLiteral:
+		// A key is a literal, not an expression, so there's no way to write
+		// a negative one without making it computed.
+		if key.Value < 0 || math.Signbit(key.Value) {
+			p.errorf("can't print %s as a property key", number(key.Value))
+			return
+		}
+
+		p.mark(key)
+		p.print(number(key.Value))
+	default:
+		p.errorf("can't print %T as a property key", key)
+	}
+}
+
+func (p *printer) importDeclaration(n *ast.ImportDeclaration) {
+	p.print("import")
+	p.space()
+
+	var named []*ast.ImportSpecifier
+	first := true
+
+	for _, s := range n.Specifiers {
+		switch s := s.(type) {
+		case *ast.ImportDefaultSpecifier:
+			if !first {
+				p.print(",")
+				p.space()
+			}
+			p.identifier(s.Local)
+			first = false
+		case *ast.ImportNamespaceSpecifier:
+			if !first {
+				p.print(",")
+				p.space()
+			}
+			p.print("*")
+			p.space()
+			p.print("as")
+			p.identifier(s.Local)
+			first = false
+		case *ast.ImportSpecifier:
+			named = append(named, s)
+		}
+	}
+
+	if len(named) > 0 {
+		if !first {
+			p.print(",")
+			p.space()
+		}
+
+		p.print("{")
+		p.space()
+		for i, s := range named {
+			if i > 0 {
+				p.print(",")
+				p.space()
+			}
+			p.name(s.Imported)
+			if s.Local != nil && s.Local.Name != s.Imported.Name {
+				p.print("as")
+				p.identifier(s.Local)
+			}
+		}
+		p.space()
+		p.print("}")
+		first = false
+	}
+
+	if !first {
+		p.space()
+		p.print("from")
+		p.space()
+	}
+
+	p.expr(n.Source, precPrimary)
+	p.attributes(n.Attributes)
+	p.print(";")
+}
+
+// attributes writes the import attributes of an import or export, if it has
+// any.
+func (p *printer) attributes(a []*ast.ImportAttribute) {
+	if len(a) == 0 {
+		return
+	}
+
+	p.space()
+	p.print("with")
+	p.space()
+	p.print("{")
+	p.space()
+	for i, attr := range a {
+		if i > 0 {
+			p.print(",")
+			p.space()
+		}
+		p.mark(attr)
+		p.key(attr.Key, false)
+		p.print(":")
+		p.space()
+		p.expr(attr.Value, precPrimary)
+	}
+	p.space()
+	p.print("}")
+}
+
+// startsStatement reports whether an expression starts with something that
+// would be read as a different kind of statement at the start of one.
+func startsStatement(n ast.Node) bool {
+	switch leftmost(n).(type) {
+	case *ast.ObjectExpression, *ast.ObjectPattern, *ast.FunctionExpression, *ast.ClassExpression:
+		return true
+	}
+
+	return startsWithLet(n, true)
+}
+
+// startsWithLet reports whether an expression starts with the identifier let,
+// which would be read as a declaration, followed by a "[" if bracket is set.
+func startsWithLet(n ast.Node, bracket bool) bool {
+	for {
+		c := first(n)
+		if c == nil {
+			id, ok := n.(*ast.Identifier)
+			return ok && id.Name == "let" && !bracket
+		}
+
+		if id, ok := c.(*ast.Identifier); ok && id.Name == "let" {
+			m, ok := n.(*ast.MemberExpression)
+			return !bracket || ok && m.Computed
+		}
+
+		n = c
+	}
+}
+
+// startsDefault is like startsStatement, for a default export.
+func startsDefault(n ast.Node) bool {
+	switch leftmost(n).(type) {
+	case *ast.FunctionExpression, *ast.ClassExpression:
+		return true
+	}
+
+	return false
+}
+
+// leftmost returns the node that's written first in an expression.
+func leftmost(n ast.Node) ast.Node {
+	for {
+		c := first(n)
+		if c == nil {
+			return n
+		}
+		n = c
+	}
+}
+
+// first returns the child that's written first in an expression, before any
+// of the expression's own tokens, or nil if the expression starts with one of
+// its own.
+func first(n ast.Node) ast.Node {
+	switch e := n.(type) {
+	case *ast.BinaryExpression:
+		return e.Left
+	case *ast.LogicalExpression:
+		return e.Left
+	case *ast.AssignmentExpression:
+		return e.Left
+	case *ast.ConditionalExpression:
+		return e.Test
+	case *ast.MemberExpression:
+		return e.Object
+	case *ast.OptionalMemberExpression:
+		return e.Object
+	case *ast.CallExpression:
+		return e.Callee
+	case *ast.OptionalCallExpression:
+		return e.Callee
+	case *ast.TaggedTemplateExpression:
+		return e.Tag
+	case *ast.SequenceExpression:
+		if len(e.Expressions) > 0 {
+			return e.Expressions[0]
+		}
+	case *ast.UpdateExpression:
+		if !e.Prefix {
+			return e.Argument
+		}
+	}
+
+	return nil
+}
+
+// precedence returns the level an expression is written at.
+func precedence(n ast.Node) int {
+	switch n := n.(type) {
+	case *ast.SequenceExpression:
+		return precSequence
+	case *ast.AssignmentExpression, *ast.ArrowFunctionExpression, *ast.YieldExpression:
+		return precAssignment
+	case *ast.ConditionalExpression:
+		return precConditional
+	case *ast.BinaryExpression:
+		return binaryPrecedence[string(n.Operator)]
+	case *ast.LogicalExpression:
+		return binaryPrecedence[string(n.Operator)]
+	case *ast.UnaryExpression, *ast.AwaitExpression:
+		return precUnary
+	case *ast.UpdateExpression:
+		if n.Prefix {
+			return precUnary
+		}
+		return precUpdate
+	case *ast.CallExpression, *ast.OptionalCallExpression, *ast.OptionalMemberExpression, *ast.ImportExpression:
+		return precCall
+	case *ast.MemberExpression, *ast.NewExpression, *ast.TaggedTemplateExpression:
+		return precMember
+	case *ast.NumericLiteral:
+		if n.Value < 0 || n.Value == 0 && math.Signbit(n.Value) {
+			return precUnary
+		}
+	}
+
+	return precPrimary
+}
+
+// expr writes an expression where the grammar needs one at level prec,
+// wrapping it in parentheses if it's written at a lower one.
+func (p *printer) expr(n ast.Node, prec int) {
+	p.mark(n)
+
+	if precedence(n) < prec || p.noIn && hasIn(n) {
+		p.parens(n, precSequence)
+		return
+	}
+
+	switch n := n.(type) {
+	case *ast.Identifier:
+		p.identifier(n)
+	case *ast.RegExpLiteral:
+		p.print("/" + n.Pattern + "/" + n.Flags)
+	case *ast.NullLiteral:
+		p.print("null")
+	case *ast.StringLiteral:
+		p.print(quote(n.Value))
+	case *ast.BooleanLiteral:
+		p.print(strconv.FormatBool(n.Value))
+	case *ast.NumericLiteral:
+		if precedence(n) == precUnary {
+			p.print("-")
+		}
+		p.print(number(math.Abs(n.Value)))
+	case *ast.BigIntLiteral:
+		p.print(n.Value + "n")
+	case *ast.PrivateName:
+		p.privateName(n)
+	case *ast.ThisExpression:
+		p.print("this")
+	case *ast.Super:
+		p.print("super")
+	case *ast.ArrowFunctionExpression:
+		p.arrow(n)
+	case *ast.YieldExpression:
+		p.print("yield")
+		if n.Delegate {
+			p.print("*")
+		}
+		if n.Argument != nil {
+			p.space()
+			p.expr(n.Argument, precAssignment)
+		}
+	case *ast.AwaitExpression:
+		p.print("await")
+		p.space()
+		p.expr(n.Argument, precUnary)
+	case *ast.ArrayExpression:
+		p.print("[")
+		for i, e := range n.Elements {
+			if i > 0 {
+				p.print(",")
+				p.space()
+			}
+			if e != nil {
+				p.inner(e, precAssignment)
+			}
+		}
+		if len(n.Elements) > 0 && n.Elements[len(n.Elements)-1] == nil {
+			p.print(",")
+		}
+		p.print("]")
+	case *ast.ObjectExpression:
+		p.object(len(n.Properties), func(i int) { p.property(n.Properties[i]) })
+	case *ast.FunctionExpression:
+		p.function(n.Async, n.Generator, n.ID, n.Params, n.Body)
+	case *ast.ClassExpression:
+		p.class(n.Decorators, n.ID, n.SuperClass, n.Body)
+	case *ast.UnaryExpression:
+		p.print(string(n.Operator))
+		p.expr(n.Argument, precUnary)
+	case *ast.UpdateExpression:
+		if n.Prefix {
+			p.print(string(n.Operator))
+			p.expr(n.Argument, precUnary)
+		} else {
+			p.expr(n.Argument, precCall)
+			p.print(string(n.Operator))
+		}
+	case *ast.BinaryExpression:
+		p.binary(string(n.Operator), n.Left, n.Right)
+	case *ast.LogicalExpression:
+		p.binary(string(n.Operator), n.Left, n.Right)
+	case *ast.AssignmentExpression:
+		switch left := n.Left.(type) {
+		case ast.Expression:
+			p.expr(left, precCall)
+		case ast.Pattern:
+			p.pattern(left)
+		}
+		p.space()
+		p.print(string(n.Operator))
+		p.space()
+		p.expr(n.Right, precAssignment)
+	case *ast.SpreadElement:
+		p.print("...")
+		p.expr(n.Argument, precAssignment)
+	case *ast.MemberExpression:
+		p.member(n.Object, n.Property, n.Computed, false, false)
+	case *ast.OptionalMemberExpression:
+		p.member(n.Object, n.Property, n.Computed, true, n.Optional)
+	case *ast.ConditionalExpression:
+		p.expr(n.Test, precLogicalOr)
+		p.space()
+		p.print("?")
+		p.space()
+		p.inner(n.Consequent, precAssignment)
+		p.space()
+		p.print(":")
+		p.space()
+		p.expr(n.Alternate, precAssignment)
+	case *ast.CallExpression:
+		p.callee(n.Callee)
+		p.arguments(n.Arguments)
+	case *ast.OptionalCallExpression:
+		p.expr(n.Callee, precCall)
+		if n.Optional {
+			p.print("?.")
+		}
+		p.arguments(n.Arguments)
+	case *ast.ImportExpression:
+		p.print("import")
+		p.print("(")
+		p.inner(n.Source, precAssignment)
+		if n.Options != nil {
+			p.print(",")
+			p.space()
+			p.inner(n.Options, precAssignment)
+		}
+		p.print(")")
+	case *ast.NewExpression:
+		p.print("new")
+		if hasCall(n.Callee) {
+			p.space()
+			p.parens(n.Callee, precSequence)
+		} else {
+			p.expr(n.Callee, precMember)
+		}
+		p.arguments(n.Arguments)
+	case *ast.SequenceExpression:
+		for i, e := range n.Expressions {
+			if i > 0 {
+				p.print(",")
+				p.space()
+			}
+			p.expr(e, precAssignment)
+		}
+	case *ast.TemplateLiteral:
+		p.template(n)
+	case *ast.TaggedTemplateExpression:
+		p.callee(n.Tag)
+		p.template(n.Quasi)
+	case *ast.MetaProperty:
+		p.name(n.Meta)
+		p.print(".")
+		p.name(n.Property)
+	case ast.Pattern:
+		p.pattern(n)
+	default:
+		p.errorf("can't print %T as an expression", n)
+	}
+}
+
+// parens writes an expression in parentheses, where "in" is always allowed.
+func (p *printer) parens(n ast.Node, prec int) {
+	p.print("(")
+	p.inner(n, prec)
+	p.print(")")
+}
+
+// inner writes an expression between brackets of some kind, where "in" is
+// always allowed.
+func (p *printer) inner(n ast.Node, prec int) {
+	noIn := p.noIn
+	p.noIn = false
+	p.expr(n, prec)
+	p.noIn = noIn
+}
+
+// hasIn reports whether an expression is an "in" that isn't inside brackets.
+func hasIn(n ast.Node) bool {
+	b, ok := n.(*ast.BinaryExpression)
+	return ok && b.Operator == ast.BinaryOperatorIn
+}
+
+// hasCall reports whether the callee of a new expression has a call in it that
+// would otherwise take the new's arguments, or an optional chain, which new
+// can't be used with.
+func hasCall(n ast.Node) bool {
+	for {
+		switch e := n.(type) {
+		case *ast.CallExpression, *ast.OptionalCallExpression, *ast.OptionalMemberExpression:
+			return true
+		case *ast.MemberExpression:
+			n = e.Object
+		case *ast.TaggedTemplateExpression:
+			n = e.Tag
+		default:
+			return false
+		}
+	}
+}
+
+func (p *printer) binary(op string, left, right ast.Node) {
+	prec := binaryPrecedence[op]
+
+	switch {
+	case op == "**":
+		// Exponentiation is right associative, and can't have a unary
+		// expression on its left.
+		p.expr(left, precUpdate)
+	case mixesNullish(op, left):
+		p.parens(left, precSequence)
+	default:
+		p.expr(left, prec)
+	}
+
+	p.space()
+	p.print(op)
+	p.space()
+
+	switch {
+	case op == "**":
+		p.expr(right, prec)
+	case mixesNullish(op, right):
+		p.parens(right, precSequence)
+	default:
+		p.expr(right, prec+1)
+	}
+}
+
+// mixesNullish reports whether an operand of op would mix ?? with || or &&,
+// which can't be done without parentheses.
+func mixesNullish(op string, operand ast.Node) bool {
+	l, ok := operand.(*ast.LogicalExpression)
+	if !ok {
+		return false
+	}
+
+	switch op {
+	case "??":
+		return l.Operator != ast.LogicalOperatorNullish
+	case "||", "&&":
+		return l.Operator == ast.LogicalOperatorNullish
+	}
+
+	return false
+}
+
+// callee writes the callee of a call, the object of a member expression or
+// the tag of a tagged template, which needs parentheses if it's an optional
+// chain that would otherwise be continued.
+func (p *printer) callee(n ast.Node) {
+	if optionalChain(n) {
+		p.parens(n, precSequence)
+	} else {
+		p.expr(n, precCall)
+	}
+}
+
+// optionalChain reports whether an expression is an optional chain.
+func optionalChain(n ast.Node) bool {
+	switch n.(type) {
+	case *ast.OptionalMemberExpression, *ast.OptionalCallExpression:
+		return true
+	}
+
+	return false
+}
+
+// member writes a member expression. If chain is true, it's part of an
+// optional chain, and optional says whether it's the part with the "?.".
+func (p *printer) member(object, property ast.Node, computed, chain, optional bool) {
+	if lit, ok := object.(*ast.NumericLiteral); ok && !computed && !optional && !strings.Contains(number(lit.Value), ".") {
+		// The dot would be read as part of the number.
+		p.parens(lit, precSequence)
+	} else if chain {
+		p.expr(object, precCall)
+	} else {
+		p.callee(object)
+	}
+
+	if optional {
+		p.print("?.")
+	}
+
+	if computed {
+		p.print("[")
+		p.inner(property, precSequence)
+		p.print("]")
+		return
+	}
+
+	if !optional {
+		p.print(".")
+	}
+
+	switch property := property.(type) {
+	case *ast.Identifier:
+		p.name(property)
+	case *ast.PrivateName:
+		p.privateName(property)
+	default:
+		p.errorf("can't print %T as a property name", property)
+	}
+}
+
+func (p *printer) arguments(a []ast.ExpressionOrSpreadElement) {
+	p.print("(")
+	for i, e := range a {
+		if i > 0 {
+			p.print(",")
+			p.space()
+		}
+		p.inner(e, precAssignment)
+	}
+	p.print(")")
+}
+
+func (p *printer) arrow(n *ast.ArrowFunctionExpression) {
+	if n.Async {
+		p.print("async")
+	}
+
+	p.params(n.Params)
+	p.space()
+	p.print("=>")
+	p.space()
+
+	switch body := n.Body.(type) {
+	case *ast.BlockStatement:
+		p.functionBody(body)
+	case ast.Expression:
+		if _, ok := leftmost(body).(*ast.ObjectExpression); ok {
+			// A brace would start a block.
+			p.parens(body, precAssignment)
+		} else {
+			p.expr(body, precAssignment)
+		}
+	}
+}
+
+// object writes the braces of an object literal or pattern with n properties.
+func (p *printer) object(n int, property func(int)) {
+	noIn := p.noIn
+	p.noIn = false
+
+	p.print("{")
+	for i := 0; i < n; i++ {
+		if i > 0 {
+			p.print(",")
+		}
+		p.space()
+		property(i)
+	}
+	if n > 0 {
+		p.space()
+	}
+	p.print("}")
+
+	p.noIn = noIn
+}
+
+func (p *printer) property(n ast.Node) {
+	p.mark(n)
+
+	switch n := n.(type) {
+	case *ast.ObjectProperty:
+		p.decorators(n.Decorators)
+		if id, ok := n.Value.(*ast.Identifier); ok && n.Shorthand && !n.Computed && sameName(n.Key, id) {
+			p.identifier(id)
+			return
+		}
+		p.key(n.Key, n.Computed)
+		p.print(":")
+		p.space()
+		p.expr(n.Value, precAssignment)
+	case *ast.ObjectMethod:
+		p.decorators(n.Decorators)
+		p.method(n.Kind, n.Key, n.Computed, n.Async, n.Generator, n.Params, n.Body)
+	case *ast.AssignmentProperty:
+		p.decorators(n.Decorators)
+		if n.Shorthand && !n.Computed {
+			switch v := n.Value.(type) {
+			case *ast.Identifier:
+				if sameName(n.Key, v) {
+					p.identifier(v)
+					return
+				}
+			case *ast.AssignmentPattern:
+				if id, ok := v.Left.(*ast.Identifier); ok && sameName(n.Key, id) {
+					p.pattern(v)
+					return
+				}
+			}
+		}
+		p.key(n.Key, n.Computed)
+		p.print(":")
+		p.space()
+		p.pattern(n.Value)
+	case *ast.SpreadProperty:
+		p.print("...")
+		p.expr(n.Argument, precAssignment)
+	case *ast.RestProperty:
+		p.print("...")
+		p.expr(n.Argument, precAssignment)
+	default:
+		p.errorf("can't print %T as a property", n)
+	}
+}
+
+func sameName(key ast.Expression, id *ast.Identifier) bool {
+	k, ok := key.(*ast.Identifier)
+	return ok && k.Name == id.Name
+}
+
+func (p *printer) template(n *ast.TemplateLiteral) {
+	noIn := p.noIn
+	p.noIn = false
+
+	p.print("`")
+	for i, q := range n.Quasis {
+		p.buf.WriteString(q.Raw)
+		if i < len(n.Expressions) {
+			p.buf.WriteString("${")
+			p.expr(n.Expressions[i], precSequence)
+			p.buf.WriteString("}")
+		}
+	}
+	p.buf.WriteString("`")
+
+	p.noIn = noIn
+}
+
+func (p *printer) pattern(n ast.Node) {
+	p.mark(n)
+
+	switch n := n.(type) {
+	case *ast.Identifier:
+		p.identifier(n)
+	case *ast.ObjectPattern:
+		p.object(len(n.Properties), func(i int) { p.property(n.Properties[i]) })
+	case *ast.ArrayPattern:
+		p.print("[")
+		for i, e := range n.Elements {
+			if i > 0 {
+				p.print(",")
+				p.space()
+			}
+			if e != nil {
+				p.inner(e, precAssignment)
+			}
+		}
+		if len(n.Elements) > 0 && n.Elements[len(n.Elements)-1] == nil {
+			p.print(",")
+		}
+		p.print("]")
+	case *ast.RestElement:
+		p.print("...")
+		p.pattern(n.Argument)
+	case *ast.AssignmentPattern:
+		p.pattern(n.Left)
+		p.space()
+		p.print("=")
+		p.space()
+		p.expr(n.Right, precAssignment)
+	case *ast.MemberExpression:
+		p.member(n.Object, n.Property, n.Computed, false, false)
+	default:
+		p.errorf("can't print %T as a pattern", n)
+	}
+}
+
+func (p *printer) privateName(n *ast.PrivateName) {
+	if b := p.buf.Bytes(); len(b) > 0 && isWordByte(b[len(b)-1]) {
+		// A keyword like static or get doesn't need a space before a #,
+		// but reads better with one.
+		p.space()
+	}
+
+	if !isIdentifierName(n.ID.Name) {
+		p.errorf("can't print %q as an identifier", n.ID.Name)
+	}
+
+	p.mark(n)
+	p.print("#" + n.ID.Name)
+}
+
+// identifier writes a name that refers to a binding, which can't be a
+// reserved word.
+func (p *printer) identifier(n *ast.Identifier) {
+	if reserved[n.Name] {
+		p.errorf("can't print the reserved word %q as an identifier", n.Name)
+	}
+
+	p.name(n)
+}
+
+// name writes a name that doesn't refer to a binding, like a property name,
+// which can be a reserved word.
+func (p *printer) name(n *ast.Identifier) {
+	if !isIdentifierName(n.Name) {
+		p.errorf("can't print %q as an identifier", n.Name)
+	}
+
+	p.mark(n)
+	p.print(n.Name)
+}
+
+// reserved holds the reserved words that can't be identifiers anywhere, as
+// opposed to those, like let and yield, that only strict code reserves.
+var reserved = map[string]bool{
+	"break": true, "case": true, "catch": true, "class": true, "const": true,
+	"continue": true, "debugger": true, "default": true, "delete": true,
+	"do": true, "else": true, "enum": true, "export": true, "extends": true,
+	"false": true, "finally": true, "for": true, "function": true, "if": true,
+	"import": true, "in": true, "instanceof": true, "new": true, "null": true,
+	"return": true, "super": true, "switch": true, "this": true, "throw": true,
+	"true": true, "try": true, "typeof": true, "var": true, "void": true,
+	"while": true, "with": true,
+}
+
+func isIdentifierName(s string) bool {
+	if s == "" {
+		return false
+	}
+
+	for i, r := range s {
+		if i == 0 && !isIDStart(r) || i > 0 && !isIDPart(r) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func isIDStart(r rune) bool {
+	return r == '$' || r == '_' || unicode.IsLetter(r) || unicode.Is(unicode.Nl, r)
+}
+
+func isIDPart(r rune) bool {
+	return isIDStart(r) || r == '\u200c' || r == '\u200d' ||
+		unicode.In(r, unicode.Mn, unicode.Mc, unicode.Nd, unicode.Pc)
+}
+
+// quote writes a string literal in double quotes.
+func quote(s string) string {
+	var b strings.Builder
+
+	b.WriteByte('"')
+
+	for i, r := range s {
+		switch r {
+		case '"':
+			b.WriteString(`\"`)
+		case '\\':
+			b.WriteString(`\\`)
+		case '\n':
+			b.WriteString(`\n`)
+		case '\r':
+			b.WriteString(`\r`)
+		case '\t':
+			b.WriteString(`\t`)
+		case '\b':
+			b.WriteString(`\b`)
+		case '\f':
+			b.WriteString(`\f`)
+		case '\v':
+			b.WriteString(`\v`)
+		case '\u2028', '\u2029':
+			fmt.Fprintf(&b, `\u%04x`, r)
+		default:
+			switch {
+			case r == utf8.RuneError && strings.HasPrefix(s[i:], "\xef\xbf\xbd"):
+				b.WriteRune(r)
+			case r == utf8.RuneError:
+				fmt.Fprintf(&b, `\x%02x`, s[i])
+			case r < 0x20 || r == 0x7f:
+				fmt.Fprintf(&b, `\x%02x`, r)
+			default:
+				b.WriteRune(r)
+			}
+		}
+	}
+
+	b.WriteByte('"')
+
+	return b.String()
+}
+
+// number formats a non-negative number as a numeric literal.
+func number(v float64) string {
+	switch {
+	case math.IsNaN(v):
+		return "NaN"
+	case math.IsInf(v, 0):
+		return "Infinity"
+	case v == math.Trunc(v) && v < 1e21:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	}
+
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
@@ -0,0 +1,769 @@
+package printer
+
+import (
+	"bytes"
+	"fmt"
+	"math/rand"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"fknsrs.biz/p/jsparser"
+	"fknsrs.biz/p/jsparser/ast"
+	"fknsrs.biz/p/jsparser/earley"
+	"fknsrs.biz/p/jsparser/sourcemap"
+)
+
+// expr is an expression that can go anywhere an expression can.
+type expr interface {
+	ast.Expression
+	ast.ExpressionOrSuper
+	ast.ExpressionOrSpreadElement
+	ast.ExpressionOrPrivateName
+	ast.PatternOrExpression
+	ast.VariableDeclarationOrExpression
+}
+
+func id(name string) *ast.Identifier { return &ast.Identifier{Name: name} }
+
+func num(v float64) *ast.NumericLiteral { return &ast.NumericLiteral{Value: v} }
+
+func bin(op string, l ast.ExpressionOrPrivateName, r ast.Expression) *ast.BinaryExpression {
+	return &ast.BinaryExpression{Operator: ast.BinaryOperator(op), Left: l, Right: r}
+}
+
+// letIndex is let[0], which can't start a statement or a for-in or for-of.
+func letIndex() *ast.MemberExpression {
+	return &ast.MemberExpression{Object: id("let"), Property: num(0), Computed: true}
+}
+
+func stmt(e ast.Expression) *ast.ExpressionStatement {
+	return &ast.ExpressionStatement{Expression: e}
+}
+
+func printNode(t *testing.T, mode Mode, n ast.Node) string {
+	var b bytes.Buffer
+	if err := (&Config{Mode: mode, Indent: "  "}).Fprint(&b, n); err != nil {
+		t.Fatal(err)
+	}
+
+	return b.String()
+}
+
+func TestPrint(t *testing.T) {
+	a := assert.New(t)
+
+	for _, c := range []struct {
+		node            ast.Node
+		indented, terse string
+	}{
+		{bin("*", bin("+", id("a"), id("b")), id("c")), "(a + b) * c", "(a+b)*c"},
+		{bin("-", id("a"), bin("-", id("b"), id("c"))), "a - (b - c)", "a-(b-c)"},
+		{bin("**", id("a"), bin("**", id("b"), id("c"))), "a ** b ** c", "a**b**c"},
+		{bin("**", bin("**", id("a"), id("b")), id("c")), "(a ** b) ** c", "(a**b)**c"},
+		{bin("**", &ast.UnaryExpression{Operator: "-", Prefix: true, Argument: id("a")}, id("b")), "(-a) ** b", "(-a)**b"},
+		{bin("**", num(-2), id("b")), "(-2) ** b", "(-2)**b"},
+		{bin("-", id("a"), &ast.UnaryExpression{Operator: "-", Prefix: true, Argument: id("b")}), "a - -b", "a- -b"},
+		{bin("+", &ast.UpdateExpression{Operator: "++", Argument: id("a")}, id("b")), "a++ + b", "a++ +b"},
+		{&ast.UnaryExpression{Operator: "typeof", Prefix: true, Argument: id("a")}, "typeof a", "typeof a"},
+		{bin("/", id("a"), &ast.RegExpLiteral{Pattern: "b", Flags: "g"}), "a / /b/g", "a/ /b/g"},
+		{&ast.NewExpression{Callee: &ast.CallExpression{Callee: id("a")}}, "new (a())()", "new(a())()"},
+		{&ast.NewExpression{Callee: &ast.MemberExpression{Object: id("a"), Property: id("b")}}, "new a.b()", "new a.b()"},
+		{&ast.CallExpression{Callee: &ast.NewExpression{Callee: id("a")}}, "new a()()", "new a()()"},
+		{&ast.MemberExpression{Object: num(1), Property: id("a")}, "(1).a", "(1).a"},
+		{&ast.MemberExpression{Object: num(1.5), Property: id("a")}, "1.5.a", "1.5.a"},
+		{&ast.ConditionalExpression{
+			Test:       &ast.ConditionalExpression{Test: id("a"), Consequent: id("b"), Alternate: id("c")},
+			Consequent: &ast.SequenceExpression{Expressions: []ast.Expression{id("d"), id("e")}},
+			Alternate:  &ast.AssignmentExpression{Operator: "=", Left: id("f"), Right: id("g")},
+		}, "(a ? b : c) ? (d, e) : f = g", "(a?b:c)?(d,e):f=g"},
+		{&ast.ArrowFunctionExpression{Body: &ast.ObjectExpression{}}, "() => ({})", "()=>({})"},
+		{&ast.StringLiteral{Value: "a\"b\\c\n\u2028\x00"}, `"a\"b\\c\n\u2028\x00"`, `"a\"b\\c\n\u2028\x00"`},
+		{&ast.MemberExpression{Object: id("a"), Property: id("class")}, "a.class", "a.class"},
+		{num(1e21), "1e+21", "1e+21"},
+		{stmt(&ast.CallExpression{Callee: &ast.FunctionExpression{Body: &ast.BlockStatement{}}}), "(function() {}());", "(function(){}());"},
+		{stmt(&ast.MemberExpression{Object: &ast.ObjectExpression{}, Property: id("a")}), "({}.a);", "({}.a);"},
+		{stmt(&ast.StringLiteral{Value: "a"}), `("a");`, `("a");`},
+		{&ast.ForStatement{
+			Init: &ast.VariableDeclaration{Kind: "var", Declarations: []*ast.VariableDeclarator{
+				{ID: id("a"), Init: bin("in", id("b"), id("c"))},
+			}},
+			Test: bin("in", id("d"), id("e")),
+			Body: &ast.EmptyStatement{},
+		}, "for (var a = (b in c); d in e;)\n  ;", "for(var a=(b in c);d in e;);"},
+		{&ast.ForStatement{
+			Init: &ast.CallExpression{Callee: id("a"), Arguments: []ast.ExpressionOrSpreadElement{bin("in", id("b"), id("c"))}},
+			Body: &ast.BlockStatement{},
+		}, "for (a(b in c);;) {}", "for(a(b in c);;){}"},
+		{&ast.IfStatement{
+			Test:       id("a"),
+			Consequent: &ast.IfStatement{Test: id("b"), Consequent: stmt(id("c"))},
+			Alternate:  stmt(id("d")),
+		}, "if (a) {\n  if (b)\n    c;\n} else\n  d;", "if(a){if(b)c;}else d;"},
+		{stmt(&ast.AssignmentExpression{Operator: "=", Left: letIndex(), Right: num(1)}), "(let[0] = 1);", "(let[0]=1);"},
+		{stmt(&ast.MemberExpression{Object: id("let"), Property: id("a")}), "let.a;", "let.a;"},
+		{&ast.ForInStatement{Left: letIndex(), Right: id("x"), Body: &ast.EmptyStatement{}}, "for ((let[0]) in x)\n  ;", "for((let[0])in x);"},
+		{&ast.ForOfStatement{Left: id("async"), Right: id("x"), Body: &ast.EmptyStatement{}}, "for ((async) of x)\n  ;", "for((async)of x);"},
+		{&ast.ForOfStatement{Left: id("let"), Right: id("x"), Body: &ast.EmptyStatement{}}, "for ((let) of x)\n  ;", "for((let)of x);"},
+		{&ast.ForInStatement{Left: id("async"), Right: id("x"), Body: &ast.EmptyStatement{}}, "for (async in x)\n  ;", "for(async in x);"},
+		{&ast.ReturnStatement{Argument: id("a")}, "return a;", "return a;"},
+		{&ast.LogicalExpression{Operator: "??", Left: id("a"), Right: &ast.LogicalExpression{Operator: "||", Left: id("b"), Right: id("c")}}, "a ?? (b || c)", "a??(b||c)"},
+		{&ast.LogicalExpression{Operator: "||", Left: &ast.LogicalExpression{Operator: "??", Left: id("a"), Right: id("b")}, Right: id("c")}, "(a ?? b) || c", "(a??b)||c"},
+		{&ast.AssignmentExpression{Operator: "??=", Left: id("a"), Right: bin("**", id("b"), id("c"))}, "a ??= b ** c", "a??=b**c"},
+		{&ast.OptionalMemberExpression{
+			Object:   &ast.OptionalMemberExpression{Object: id("a"), Property: id("b"), Optional: true},
+			Property: id("c"),
+		}, "a?.b.c", "a?.b.c"},
+		{&ast.MemberExpression{Object: &ast.OptionalMemberExpression{Object: id("a"), Property: id("b"), Optional: true}, Property: id("c")}, "(a?.b).c", "(a?.b).c"},
+		{&ast.OptionalCallExpression{
+			Callee:   &ast.OptionalMemberExpression{Object: id("a"), Property: num(0), Computed: true, Optional: true},
+			Optional: true,
+		}, "a?.[0]?.()", "a?.[0]?.()"},
+		{&ast.NewExpression{Callee: &ast.OptionalMemberExpression{Object: id("a"), Property: id("b"), Optional: true}}, "new (a?.b)()", "new(a?.b)()"},
+		{bin("in", &ast.PrivateName{ID: id("x")}, &ast.MemberExpression{Object: &ast.ThisExpression{}, Property: &ast.PrivateName{ID: id("y")}}), "#x in this.#y", "#x in this.#y"},
+		{&ast.ImportExpression{Source: &ast.StringLiteral{Value: "a"}, Options: id("b")}, `import("a", b)`, `import("a",b)`},
+		{&ast.MemberExpression{Object: &ast.BigIntLiteral{Value: "10"}, Property: id("a")}, "10n.a", "10n.a"},
+		{&ast.ClassDeclaration{ID: id("a"), Body: &ast.ClassBody{Body: []ast.ClassMethodOrClassPrivateMethodOrClassPropertyOrClassPrivatePropertyOrStaticBlock{
+			&ast.ClassPrivateMethod{Key: &ast.PrivateName{ID: id("b")}, Kind: "get", Value: &ast.FunctionExpression{Body: &ast.BlockStatement{}}},
+			&ast.ClassPrivateProperty{Key: &ast.PrivateName{ID: id("c")}, Value: num(1), Static: true},
+			&ast.StaticBlock{Body: []ast.Statement{stmt(id("d"))}},
+		}}}, "class a {\n  get #b() {}\n  static #c = 1;\n  static {\n    d;\n  }\n}", "class a{get#b(){}static#c=1;static{d;}}"},
+		{&ast.ClassExpression{Body: &ast.ClassBody{Body: []ast.ClassMethodOrClassPrivateMethodOrClassPropertyOrClassPrivatePropertyOrStaticBlock{
+			&ast.ClassProperty{Key: id("a")},
+			&ast.ClassProperty{Key: bin("+", id("b"), id("c")), Value: num(1), Computed: true, Static: true},
+			&ast.ClassProperty{Key: &ast.StringLiteral{Value: "d"}, Decorators: []*ast.Decorator{{Expression: id("e")}}},
+			&ast.ClassPrivateProperty{Key: &ast.PrivateName{ID: id("f")}, Decorators: []*ast.Decorator{{Expression: id("g")}}},
+		}}}, "class {\n  a;\n  static [b + c] = 1;\n  @e \"d\";\n  @g #f;\n}", "class{a;static[b+c]=1;@e\"d\";@g#f;}"},
+		{&ast.ImportDeclaration{
+			Specifiers: []ast.ImportSpecifierOrImportDefaultSpecifierOrImportNamespaceSpecifier{&ast.ImportDefaultSpecifier{Local: id("a")}},
+			Source:     &ast.StringLiteral{Value: "b"},
+			Attributes: []*ast.ImportAttribute{{Key: id("type"), Value: &ast.StringLiteral{Value: "json"}}},
+		}, `import a from "b" with { type: "json" };`, `import a from"b"with{type:"json"};`},
+		{&ast.ObjectExpression{Properties: []ast.ObjectPropertyOrObjectMethodOrSpreadProperty{
+			&ast.ObjectProperty{Key: num(1), Value: id("a")},
+			&ast.ObjectProperty{Key: num(1.5), Value: id("b")},
+			&ast.ObjectMethod{Kind: "get", Key: num(2), Body: &ast.BlockStatement{}},
+		}}, "{ 1: a, 1.5: b, get 2() {} }", "{1:a,1.5:b,get 2(){}}"},
+		{&ast.Program{
+			Directives: []*ast.Directive{
+				{Value: &ast.DirectiveLiteral{Value: `use\x20strict`, Extra: &ast.Extra{Raw: `'use\x20strict'`, RawValue: `use\x20strict`}}},
+				{Value: &ast.DirectiveLiteral{Value: `a"b`}},
+				{Value: &ast.DirectiveLiteral{Value: `c`}},
+			},
+			Body: []ast.StatementOrModuleDeclaration{stmt(&ast.StringLiteral{Value: "d"})},
+		}, "'use\\x20strict';\n'a\"b';\n\"c\";\n(\"d\");\n", `'use\x20strict';'a"b';"c";("d");`},
+		{&ast.Program{Body: []ast.StatementOrModuleDeclaration{
+			&ast.FunctionDeclaration{ID: id("f"), Params: []ast.Pattern{id("a")}, Body: &ast.BlockStatement{
+				Body: []ast.Statement{&ast.ReturnStatement{Argument: bin("+", id("a"), num(1))}},
+			}},
+			stmt(&ast.CallExpression{Callee: id("f"), Arguments: []ast.ExpressionOrSpreadElement{num(2)}}),
+		}}, "function f(a) {\n  return a + 1;\n}\nf(2);\n", "function f(a){return a+1;}f(2);"},
+	} {
+		a.Equal(c.indented, printNode(t, 0, c.node))
+		a.Equal(c.terse, printNode(t, Compact, c.node))
+	}
+}
+
+func TestPrintUnknown(t *testing.T) {
+	a := assert.New(t)
+
+	var b bytes.Buffer
+	a.Error(Fprint(&b, stmt(bin("+", id("a"), &ast.Unknown{}))))
+	a.Empty(b.String())
+}
+
+func TestPrintInvalidKey(t *testing.T) {
+	a := assert.New(t)
+
+	var b bytes.Buffer
+	a.EqualError(Fprint(&b, &ast.ObjectExpression{Properties: []ast.ObjectPropertyOrObjectMethodOrSpreadProperty{
+		&ast.ObjectProperty{Key: num(-1), Value: id("a")},
+	}}), "printer: can't print -1 as a property key")
+	a.EqualError(Fprint(&b, &ast.Program{Directives: []*ast.Directive{
+		{Value: &ast.DirectiveLiteral{Value: `a"b'c`}},
+	}}), `printer: can't print the directive "a\"b'c", which has both kinds of quote in it`)
+	a.Empty(b.String())
+}
+
+func TestPrintInvalidIdentifier(t *testing.T) {
+	a := assert.New(t)
+
+	var b bytes.Buffer
+	a.EqualError(Fprint(&b, stmt(id("a-b"))), `printer: can't print "a-b" as an identifier`)
+	a.EqualError(Fprint(&b, stmt(id("class"))), `printer: can't print the reserved word "class" as an identifier`)
+	a.EqualError(Fprint(&b, &ast.MemberExpression{Object: id("a"), Property: id("")}), `printer: can't print "" as an identifier`)
+	a.Empty(b.String())
+}
+
+// TestRoundTrip prints random expressions, parses them again, and checks that
+// they come back the same.
+func TestRoundTrip(t *testing.T) {
+	a := assert.New(t)
+
+	g, err := earley.LoadFile("../../internal/parser_generator/es6.ebnf")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r := rand.New(rand.NewSource(1))
+
+	for i := 0; i < 100; i++ {
+		e := randomExpression(r, 4)
+
+		for _, mode := range []Mode{0, Compact} {
+			for _, n := range []ast.Node{
+				stmt(e),
+				&ast.ForStatement{Init: e, Body: &ast.EmptyStatement{}},
+			} {
+				s := printNode(t, mode, n)
+
+				tokens, err := jsparser.ParseString(s)
+				if !a.NoError(err, s) {
+					continue
+				}
+
+				f, err := g.Parse(earley.Script, tokens)
+				if !a.NoError(err, s) {
+					continue
+				}
+
+				a.Equal(e, expression(find(f, "Expression")), s)
+			}
+		}
+	}
+}
+
+var (
+	names             = []string{"a", "b", "c"}
+	binaryOperators   = []string{"==", "!=", "===", "!==", "<", "<=", ">", ">=", "<<", ">>", ">>>", "+", "-", "*", "/", "%", "|", "^", "&", "in", "instanceof", "**"}
+	logicalOperators  = []string{"||", "&&"}
+	unaryOperators    = []string{"-", "+", "!", "~", "typeof", "void", "delete"}
+	updateOperators   = []string{"++", "--"}
+	assignOperators   = []string{"=", "+=", "-=", "*=", "/=", "%=", "<<=", ">>=", ">>>=", "|=", "^=", "&="}
+	numericLiterals   = []float64{0, 1, 1.5, 0.25, 100}
+	memberExpressions = 2
+)
+
+func randomExpression(r *rand.Rand, depth int) expr {
+	if depth == 0 || r.Intn(5) == 0 {
+		if r.Intn(3) == 0 {
+			return num(numericLiterals[r.Intn(len(numericLiterals))])
+		}
+
+		return id(names[r.Intn(len(names))])
+	}
+
+	sub := func() expr { return randomExpression(r, depth-1) }
+
+	switch r.Intn(11) {
+	case 0:
+		return bin(binaryOperators[r.Intn(len(binaryOperators))], sub(), sub())
+	case 1:
+		return &ast.LogicalExpression{Operator: ast.LogicalOperator(logicalOperators[r.Intn(len(logicalOperators))]), Left: sub(), Right: sub()}
+	case 2:
+		return &ast.UnaryExpression{Operator: ast.UnaryOperator(unaryOperators[r.Intn(len(unaryOperators))]), Prefix: true, Argument: sub()}
+	case 3:
+		return &ast.UpdateExpression{Operator: ast.UpdateOperator(updateOperators[r.Intn(len(updateOperators))]), Prefix: r.Intn(2) == 0, Argument: randomTarget(r, depth-1)}
+	case 4:
+		return &ast.ConditionalExpression{Test: sub(), Consequent: sub(), Alternate: sub()}
+	case 5:
+		return &ast.AssignmentExpression{Operator: ast.AssignmentOperator(assignOperators[r.Intn(len(assignOperators))]), Left: randomTarget(r, depth-1), Right: sub()}
+	case 6:
+		return &ast.SequenceExpression{Expressions: []ast.Expression{sub(), sub()}}
+	case 7:
+		return randomMember(r, depth)
+	case 8:
+		return &ast.CallExpression{Callee: sub(), Arguments: randomArguments(r, depth)}
+	case 9:
+		return &ast.NewExpression{Callee: sub(), Arguments: randomArguments(r, depth)}
+	default:
+		return bin("**", sub(), sub())
+	}
+}
+
+func randomTarget(r *rand.Rand, depth int) expr {
+	if depth == 0 || r.Intn(2) == 0 {
+		return id(names[r.Intn(len(names))])
+	}
+
+	return randomMember(r, depth)
+}
+
+func randomMember(r *rand.Rand, depth int) *ast.MemberExpression {
+	if r.Intn(memberExpressions) == 0 {
+		return &ast.MemberExpression{Object: randomExpression(r, depth-1), Property: randomExpression(r, depth-1), Computed: true}
+	}
+
+	return &ast.MemberExpression{Object: randomExpression(r, depth-1), Property: id(names[r.Intn(len(names))])}
+}
+
+func randomArguments(r *rand.Rand, depth int) []ast.ExpressionOrSpreadElement {
+	var a []ast.ExpressionOrSpreadElement
+	for i := r.Intn(3); i > 0; i-- {
+		a = append(a, randomExpression(r, depth-1))
+	}
+
+	return a
+}
+
+// find returns the first node in a parse forest with the given production
+// name, ignoring parameters.
+func find(n *earley.Node, name string) *earley.Node {
+	if symbol(n) == name {
+		return n
+	}
+
+	if len(n.Derivations) == 0 {
+		return nil
+	}
+
+	for _, c := range n.Derivations[0].Children {
+		if f := find(c, name); f != nil {
+			return f
+		}
+	}
+
+	return nil
+}
+
+func symbol(n *earley.Node) string {
+	return strings.SplitN(n.Symbol, "_", 2)[0]
+}
+
+func terminal(n *earley.Node) bool {
+	return strings.HasPrefix(n.Symbol, `"`)
+}
+
+// text returns the text of a node that's made of a single token.
+func text(n *earley.Node) string {
+	for n.Token == nil && len(n.Derivations) > 0 && len(n.Derivations[0].Children) == 1 {
+		n = n.Derivations[0].Children[0]
+	}
+
+	if n.Token == nil {
+		return ""
+	}
+
+	return n.Token.Raw
+}
+
+// expression builds the expression that a parse forest node stands for, from
+// its first derivation. Only the kinds of expression that randomExpression
+// makes are understood.
+func expression(n *earley.Node) expr {
+	if n.Token != nil {
+		switch symbol(n) {
+		case "IdentifierName":
+			return id(n.Token.Value)
+		case "StringLiteral":
+			return &ast.StringLiteral{Value: unescape(n.Token.Raw[1 : len(n.Token.Raw)-1])}
+		case "NoSubstitutionTemplate":
+			return &ast.TemplateLiteral{Quasis: []*ast.TemplateElement{quasi(n.Token)}}
+		case "NumericLiteral":
+			v, err := strconv.ParseFloat(n.Token.Raw, 64)
+			if err != nil {
+				panic(err)
+			}
+			return num(v)
+		}
+
+		panic(fmt.Sprintf("unexpected token %s", n.Symbol))
+	}
+
+	c := n.Derivations[0].Children
+
+	switch {
+	case symbol(n) == "TemplateLiteral" && len(c) > 1:
+		t := &ast.TemplateLiteral{}
+		template(n, t)
+		return t
+	case len(c) == 1:
+		return expression(c[0])
+	case len(c) == 2 && terminal(c[0]) && c[0].Symbol == `"new"`:
+		return &ast.NewExpression{Callee: expression(c[1])}
+	case len(c) == 2 && terminal(c[0]) && symbol(n) == "UnaryExpression":
+		return &ast.UnaryExpression{Operator: ast.UnaryOperator(text(c[0])), Prefix: true, Argument: expression(c[1])}
+	case len(c) == 2 && terminal(c[0]):
+		return &ast.UpdateExpression{Operator: ast.UpdateOperator(text(c[0])), Prefix: true, Argument: expression(c[1])}
+	case len(c) == 2 && terminal(c[1]):
+		return &ast.UpdateExpression{Operator: ast.UpdateOperator(text(c[1])), Argument: expression(c[0])}
+	case len(c) == 2 && symbol(c[1]) == "Arguments":
+		return &ast.CallExpression{Callee: expression(c[0]), Arguments: arguments(c[1])}
+	case len(c) == 3 && c[0].Symbol == `"("`:
+		return expression(c[1])
+	case len(c) == 3 && c[0].Symbol == `"new"`:
+		return &ast.NewExpression{Callee: expression(c[1]), Arguments: arguments(c[2])}
+	case len(c) == 3 && c[1].Symbol == `"."`:
+		return &ast.MemberExpression{Object: expression(c[0]), Property: id(c[2].Token.Value)}
+	case len(c) == 4 && c[1].Symbol == `"["`:
+		return &ast.MemberExpression{Object: expression(c[0]), Property: expression(c[2]), Computed: true}
+	case len(c) == 5 && c[1].Symbol == `"?"`:
+		return &ast.ConditionalExpression{Test: expression(c[0]), Consequent: expression(c[2]), Alternate: expression(c[4])}
+	case len(c) == 3:
+		op := text(c[1])
+
+		switch symbol(n) {
+		case "Expression":
+			var a []ast.Expression
+			if l := c[0].Derivations[0].Children; len(l) == 3 && l[1].Symbol == `","` {
+				a = expression(c[0]).(*ast.SequenceExpression).Expressions
+			} else {
+				a = []ast.Expression{expression(c[0])}
+			}
+			return &ast.SequenceExpression{Expressions: append(a, expression(c[2]))}
+		case "AssignmentExpression":
+			return &ast.AssignmentExpression{Operator: ast.AssignmentOperator(op), Left: expression(c[0]), Right: expression(c[2])}
+		case "LogicalORExpression", "LogicalANDExpression":
+			return &ast.LogicalExpression{Operator: ast.LogicalOperator(op), Left: expression(c[0]), Right: expression(c[2])}
+		}
+
+		return bin(op, expression(c[0]), expression(c[2]))
+	}
+
+	panic(fmt.Sprintf("unexpected %s", n.Symbol))
+}
+
+func arguments(n *earley.Node) []ast.ExpressionOrSpreadElement {
+	c := n.Derivations[0].Children
+	if len(c) == 2 {
+		return nil
+	}
+
+	var a []ast.ExpressionOrSpreadElement
+	for l := c[1]; ; {
+		d := l.Derivations[0].Children
+		if len(d) == 1 {
+			return append([]ast.ExpressionOrSpreadElement{expression(d[0])}, a...)
+		}
+
+		a = append([]ast.ExpressionOrSpreadElement{expression(d[2])}, a...)
+		l = d[0]
+	}
+}
+
+// template adds the quasis and expressions under a parse forest node to t.
+func template(n *earley.Node, t *ast.TemplateLiteral) {
+	for _, c := range n.Derivations[0].Children {
+		switch {
+		case c.Token != nil:
+			t.Quasis = append(t.Quasis, quasi(c.Token))
+		case symbol(c) == "Expression":
+			t.Expressions = append(t.Expressions, expression(c))
+		default:
+			template(c, t)
+		}
+	}
+}
+
+func quasi(tk *jsparser.Token) *ast.TemplateElement {
+	raw := strings.TrimPrefix(strings.TrimPrefix(tk.Raw, "`"), "}")
+
+	tail := strings.HasSuffix(raw, "`")
+	if tail {
+		raw = strings.TrimSuffix(raw, "`")
+	} else {
+		raw = strings.TrimSuffix(raw, "${")
+	}
+
+	cooked := unescape(raw)
+
+	return &ast.TemplateElement{Raw: raw, Cooked: &cooked, Tail: tail}
+}
+
+// unescape works out the value of the text of a string literal or template
+// element, which the tokeniser doesn't do for every escape.
+func unescape(s string) string {
+	var b strings.Builder
+
+	hex := func(h string) {
+		v, err := strconv.ParseUint(h, 16, 32)
+		if err != nil {
+			panic(err)
+		}
+
+		b.WriteRune(rune(v))
+	}
+
+	for i := 0; i < len(s); i++ {
+		if s[i] != '\\' {
+			b.WriteByte(s[i])
+			continue
+		}
+
+		i++
+
+		switch c := s[i]; {
+		case strings.IndexByte("bfnrtv0", c) >= 0:
+			b.WriteByte("\b\f\n\r\t\v\x00"[strings.IndexByte("bfnrtv0", c)])
+		case c == 'x':
+			hex(s[i+1 : i+3])
+			i += 2
+		case c == 'u' && s[i+1] == '{':
+			j := i + strings.IndexByte(s[i:], '}')
+			hex(s[i+2 : j])
+			i = j
+		case c == 'u':
+			hex(s[i+1 : i+5])
+			i += 4
+		case c == '\r' && i+1 < len(s) && s[i+1] == '\n':
+			i++
+		case c == '\n' || c == '\r':
+		case strings.HasPrefix(s[i:], "\u2028") || strings.HasPrefix(s[i:], "\u2029"):
+			i += 2
+		default:
+			b.WriteByte(c)
+		}
+	}
+
+	return b.String()
+}
+
+// unwrap skips over the helper productions that the grammar makes for
+// optional items, returning nil if the item isn't there.
+func unwrap(n *earley.Node) *earley.Node {
+	for n != nil && strings.HasSuffix(n.Symbol, "?") {
+		if len(n.Derivations) == 0 || len(n.Derivations[0].Children) == 0 {
+			return nil
+		}
+
+		n = n.Derivations[0].Children[0]
+	}
+
+	return n
+}
+
+// items returns the StatementListItems in a StatementList.
+func items(n *earley.Node) []*earley.Node {
+	if n = unwrap(n); n == nil {
+		return nil
+	}
+
+	c := n.Derivations[0].Children
+	if len(c) == 1 {
+		return c
+	}
+
+	return append(items(c[0]), c[1])
+}
+
+// leaves returns the tokens under a parse forest node, including any
+// semicolons that were inserted.
+func leaves(n *earley.Node) []*earley.Node {
+	if terminal(n) || n.Token != nil {
+		return []*earley.Node{n}
+	}
+
+	var a []*earley.Node
+	if len(n.Derivations) > 0 {
+		for _, c := range n.Derivations[0].Children {
+			a = append(a, leaves(c)...)
+		}
+	}
+
+	return a
+}
+
+// body builds the directives and statements of a statement list, which is the
+// list itself for a block, or the FunctionBody or ScriptBody it's in.
+func body(n *earley.Node) ([]*ast.Directive, []ast.Statement) {
+	var directives []*ast.Directive
+	var statements []ast.Statement
+
+	for _, e := range items(find(n, "StatementList")) {
+		if l := leaves(e); len(statements) == 0 && len(l) == 2 && l[0].Token != nil && l[0].Token.Kind == jsparser.TokenKindString {
+			raw := l[0].Token.Raw
+			v := raw[1 : len(raw)-1]
+			directives = append(directives, &ast.Directive{Value: &ast.DirectiveLiteral{Value: v, Extra: &ast.Extra{Raw: raw, RawValue: v}}})
+			continue
+		}
+
+		statements = append(statements, statement(e))
+	}
+
+	return directives, statements
+}
+
+func program(n *earley.Node) *ast.Program {
+	directives, statements := body(n)
+
+	p := &ast.Program{Directives: directives}
+	for _, s := range statements {
+		p.Body = append(p.Body, s.(ast.StatementOrModuleDeclaration))
+	}
+
+	return p
+}
+
+// statement builds the statement that a parse forest node stands for. Only
+// the kinds of statement used by TestRoundTripStatements are understood.
+func statement(n *earley.Node) ast.Statement {
+	c := n.Derivations[0].Children
+
+	switch symbol(n) {
+	case "StatementListItem", "Statement", "Declaration", "HoistableDeclaration":
+		return statement(c[0])
+	case "ExpressionStatement":
+		return stmt(expression(find(n, "Expression")))
+	case "EmptyStatement":
+		return &ast.EmptyStatement{}
+	case "BlockStatement":
+		directives, statements := body(n)
+		return &ast.BlockStatement{Directives: directives, Body: statements}
+	case "IfStatement":
+		s := &ast.IfStatement{Test: expression(c[2]), Consequent: statement(c[4])}
+		if len(c) == 7 {
+			s.Alternate = statement(c[6])
+		}
+		return s
+	case "ReturnStatement":
+		s := &ast.ReturnStatement{}
+		if e := find(n, "Expression"); e != nil {
+			s.Argument = expression(e)
+		}
+		return s
+	case "FunctionDeclaration":
+		directives, statements := body(find(n, "FunctionBody"))
+		return &ast.FunctionDeclaration{
+			ID:   id(find(n, "BindingIdentifier").Derivations[0].Children[0].Derivations[0].Children[0].Token.Value),
+			Body: &ast.BlockStatement{Directives: directives, Body: statements},
+		}
+	}
+
+	panic(fmt.Sprintf("unexpected %s", n.Symbol))
+}
+
+// TestRoundTripStatements prints programs, parses them again, and checks that
+// they come back the way they were, or as want if the printer has to change
+// them to keep their meaning.
+func TestRoundTripStatements(t *testing.T) {
+	a := assert.New(t)
+
+	g, err := earley.LoadFile("../../internal/parser_generator/es6.ebnf")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	prog := func(body ...ast.Statement) *ast.Program {
+		p := &ast.Program{}
+		for _, s := range body {
+			p.Body = append(p.Body, s.(ast.StatementOrModuleDeclaration))
+		}
+		return p
+	}
+
+	fn := func(name string, directives []*ast.Directive, body ...ast.Statement) *ast.FunctionDeclaration {
+		return &ast.FunctionDeclaration{ID: id(name), Body: &ast.BlockStatement{Directives: directives, Body: body}}
+	}
+
+	directive := func(raw string) *ast.Directive {
+		v := raw[1 : len(raw)-1]
+		return &ast.Directive{Value: &ast.DirectiveLiteral{Value: v, Extra: &ast.Extra{Raw: raw, RawValue: v}}}
+	}
+
+	str := func(v string) *ast.StringLiteral { return &ast.StringLiteral{Value: v} }
+
+	q := func(raw, cooked string, tail bool) *ast.TemplateElement {
+		return &ast.TemplateElement{Raw: raw, Cooked: &cooked, Tail: tail}
+	}
+
+	for _, c := range []struct {
+		name       string
+		node, want *ast.Program
+	}{
+		{"statements", prog(
+			stmt(&ast.AssignmentExpression{Operator: "=", Left: id("a"), Right: num(1)}),
+			&ast.EmptyStatement{},
+			&ast.BlockStatement{Body: []ast.Statement{stmt(id("b")), &ast.BlockStatement{}}},
+			&ast.IfStatement{Test: id("c"), Consequent: stmt(id("d"))},
+			&ast.IfStatement{Test: id("e"), Consequent: &ast.BlockStatement{}, Alternate: &ast.IfStatement{Test: id("f"), Consequent: &ast.EmptyStatement{}}},
+		), nil},
+		{"string escapes", prog(
+			stmt(bin("+", str("a\"b'c\\d\n\r\t\b\f\v"), str("\u2028\u2029\x00\x1f\x7fé😀"))),
+			stmt(str("e")),
+		), nil},
+		{"templates", prog(
+			stmt(&ast.TemplateLiteral{Quasis: []*ast.TemplateElement{q("a\\n\\`", "a\n`", true)}}),
+			stmt(&ast.TemplateLiteral{
+				Quasis:      []*ast.TemplateElement{q("a", "a", false), q("", "", false), q("$c\\u{41}", "$cA", true)},
+				Expressions: []ast.Expression{id("b"), &ast.TemplateLiteral{Quasis: []*ast.TemplateElement{q("d", "d", true)}}},
+			}),
+		), nil},
+		{"directives", &ast.Program{
+			Directives: []*ast.Directive{directive(`"use strict"`), directive(`'a\x20b'`)},
+			Body: []ast.StatementOrModuleDeclaration{
+				fn("f", []*ast.Directive{directive(`'c'`)}, stmt(str("d"))),
+				stmt(str("e")),
+			},
+		}, nil},
+		{"let [", prog(
+			stmt(&ast.AssignmentExpression{Operator: "=", Left: letIndex(), Right: num(1)}),
+			stmt(&ast.MemberExpression{Object: id("let"), Property: id("a")}),
+			stmt(id("let")),
+		), nil},
+		{"dangling else", prog(
+			&ast.IfStatement{Test: id("a"), Consequent: &ast.IfStatement{Test: id("b"), Consequent: stmt(id("c"))}, Alternate: stmt(id("d"))},
+		), prog(
+			&ast.IfStatement{Test: id("a"), Consequent: &ast.BlockStatement{Body: []ast.Statement{&ast.IfStatement{Test: id("b"), Consequent: stmt(id("c"))}}}, Alternate: stmt(id("d"))},
+		)},
+		{"return and a newline", prog(
+			fn("f", nil, &ast.ReturnStatement{}, stmt(id("a"))),
+			fn("g", nil, &ast.ReturnStatement{Argument: &ast.TemplateLiteral{Quasis: []*ast.TemplateElement{q("\n", "\n", true)}}}),
+		), nil},
+	} {
+		want := c.want
+		if want == nil {
+			want = c.node
+		}
+
+		for _, mode := range []Mode{0, Compact} {
+			s := printNode(t, mode, c.node)
+
+			tokens, err := jsparser.ParseString(s)
+			if !a.NoError(err, "%s: %s", c.name, s) {
+				continue
+			}
+
+			f, err := g.Parse(earley.Script, tokens)
+			if !a.NoError(err, "%s: %s", c.name, s) {
+				continue
+			}
+
+			a.Equal(want, program(f), "%s: %s", c.name, s)
+		}
+	}
+}
+
+func TestSourceMap(t *testing.T) {
+	a := assert.New(t)
+
+	loc := func(line, column int) *ast.SourceLocation {
+		return &ast.SourceLocation{Start: ast.Position{Line: line, Column: column}}
+	}
+
+	callee := id("f")
+	callee.Loc = loc(2, 2)
+	arg := id("a")
+	arg.Loc = loc(3, 4)
+	call := &ast.CallExpression{Callee: callee, Arguments: []ast.ExpressionOrSpreadElement{arg}}
+	call.Loc = loc(2, 2)
+	ret := &ast.ReturnStatement{Argument: call}
+	ret.Loc = loc(2, 0)
+	fn := &ast.FunctionDeclaration{ID: id("g"), Body: &ast.BlockStatement{Body: []ast.Statement{ret}}}
+
+	g := sourcemap.NewGenerator("out.js")
+
+	var b bytes.Buffer
+	a.NoError((&Config{Indent: "  ", SourceMap: g, Source: "in.js"}).Fprint(&b, fn))
+	a.Equal("function g() {\n  return f(a);\n}", b.String())
+
+	m := g.Map()
+	a.Equal([]string{"in.js"}, m.Sources)
+	a.Equal([]string{"f", "a"}, m.Names)
+	a.Equal(";EACA,OAAEA,EACEC", m.Mappings)
+
+	b.Reset()
+	a.NoError((&Config{Mode: Compact | InlineSourceMap, SourceMap: sourcemap.NewGenerator("out.js"), Source: "in.js"}).Fprint(&b, ret))
+	a.Regexp(`^return f\(a\);\n//# sourceMappingURL=data:application/json;charset=utf-8;base64,[A-Za-z0-9+/]+=*\n$`, b.String())
+}
@@ -0,0 +1,207 @@
+package ast
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+)
+
+// A ValidationError is returned by Validate for a node that breaks one of the
+// rules of the specification. Path is the path to the node, written as it is
+// in a Difference, and Err is what's wrong with it: a *FieldError for a field
+// that holds a value it can't, or a description of a node that's put together
+// wrongly.
+type ValidationError struct {
+	Path string
+	Err  error
+}
+
+func (e *ValidationError) Error() string {
+	msg := strings.TrimPrefix(e.Err.Error(), "ast: ")
+	if e.Path == "" {
+		return "ast: " + msg
+	}
+
+	return "ast: " + e.Path + ": " + msg
+}
+
+// Validate checks that a tree follows the specification. Enums and kinds have
+// to hold one of their values, nodes can only be nil where the specification
+// allows null, and nodes have to be put together in ways that can be written
+// as JavaScript: a RestElement has to come last, a getter can't have
+// parameters, and so on. It returns every problem it finds, in the order of a
+// walk through the tree, or nil if there aren't any.
+func Validate(node Node) []error {
+	v := &validator{}
+
+	Apply(node, v.pre, v.post)
+
+	return v.errs
+}
+
+type validator struct {
+	paths []string
+	errs  []error
+}
+
+func (v *validator) add(path string, err error) {
+	v.errs = append(v.errs, &ValidationError{Path: path, Err: err})
+}
+
+func (v *validator) pre(c *Cursor) bool {
+	path := ""
+	if len(v.paths) > 0 {
+		path = field(v.paths[len(v.paths)-1], jsonName(c.Parent(), c.Name()))
+		if i := c.Index(); i >= 0 {
+			path = index(path, i)
+		}
+	}
+
+	v.paths = append(v.paths, path)
+
+	if n := c.Node(); n != nil {
+		v.fields(path, n)
+		v.structure(path, n)
+	}
+
+	return true
+}
+
+func (v *validator) post(c *Cursor) bool {
+	v.paths = v.paths[:len(v.paths)-1]
+
+	return true
+}
+
+// jsonName returns the name that a field of a node has in JSON.
+func jsonName(n Node, name string) string {
+	f, ok := reflect.TypeOf(n).Elem().FieldByName(name)
+	if !ok {
+		return name
+	}
+
+	return strings.Split(f.Tag.Get("json"), ",")[0]
+}
+
+var (
+	errRestElement  = errors.New("a RestElement has to come last")
+	errRestProperty = errors.New("a RestProperty has to come last")
+	errTry          = errors.New("a TryStatement needs a handler, a finalizer or both")
+	errProperty     = errors.New("a member that isn't computed needs an Identifier or a PrivateName as its property")
+	errComputed     = errors.New("a computed member can't have a PrivateName as its property")
+	errPrivateIn    = errors.New("a PrivateName can only be on the left of in")
+	errShorthand    = errors.New("a shorthand property can't be computed, and needs an Identifier as its key")
+	errConstructor  = errors.New("a constructor has to be a method called constructor that isn't static or computed")
+	errGetter       = errors.New("a getter can't have parameters")
+	errSetter       = errors.New("a setter has to have one parameter, which isn't a RestElement")
+	errArrow        = errors.New("an ArrowFunctionExpression can't be a generator")
+)
+
+// structure checks the rules of the specification that involve more than one
+// field of a node.
+func (v *validator) structure(path string, node Node) {
+	switch n := node.(type) {
+	case *FunctionDeclaration:
+		v.params(path, n.Params)
+	case *FunctionExpression:
+		v.params(path, n.Params)
+	case *ArrowFunctionExpression:
+		v.params(path, n.Params)
+
+		if n.Generator {
+			v.add(path, errArrow)
+		}
+	case *ObjectMethod:
+		v.params(path, n.Params)
+		v.accessor(path, n.Kind, n.Params)
+	case *ClassMethod:
+		if n.Kind == "constructor" {
+			if id, ok := n.Key.(*Identifier); !ok || id.Name != "constructor" || n.Static || n.Computed {
+				v.add(path, errConstructor)
+			}
+		}
+
+		if n.Value != nil {
+			v.accessor(path, n.Kind, n.Value.Params)
+		}
+	case *ClassPrivateMethod:
+		if n.Value != nil {
+			v.accessor(path, n.Kind, n.Value.Params)
+		}
+	case *ArrayPattern:
+		for i, e := range n.Elements {
+			if _, ok := e.(*RestElement); ok && i != len(n.Elements)-1 {
+				v.add(index(field(path, "elements"), i), errRestElement)
+			}
+		}
+	case *ObjectPattern:
+		for i, e := range n.Properties {
+			if _, ok := e.(*RestProperty); ok && i != len(n.Properties)-1 {
+				v.add(index(field(path, "properties"), i), errRestProperty)
+			}
+		}
+	case *TryStatement:
+		if n.Handler == nil && n.Finalizer == nil {
+			v.add(path, errTry)
+		}
+	case *MemberExpression:
+		v.property(path, n.Property, n.Computed)
+	case *OptionalMemberExpression:
+		v.property(path, n.Property, n.Computed)
+	case *BinaryExpression:
+		if _, ok := n.Left.(*PrivateName); ok && n.Operator != BinaryOperatorIn {
+			v.add(path, errPrivateIn)
+		}
+	case *ObjectProperty:
+		v.shorthand(path, n.Shorthand, n.Computed, n.Key)
+	case *AssignmentProperty:
+		v.shorthand(path, n.Shorthand, n.Computed, n.Key)
+	}
+}
+
+func (v *validator) params(path string, params []Pattern) {
+	for i, e := range params {
+		if _, ok := e.(*RestElement); ok && i != len(params)-1 {
+			v.add(index(field(path, "params"), i), errRestElement)
+		}
+	}
+}
+
+func (v *validator) property(path string, property Node, computed bool) {
+	switch property.(type) {
+	case *PrivateName:
+		if computed {
+			v.add(path, errComputed)
+		}
+	case *Identifier:
+	default:
+		if !computed && !missing(property) {
+			v.add(path, errProperty)
+		}
+	}
+}
+
+func (v *validator) accessor(path, kind string, params []Pattern) {
+	switch kind {
+	case "get":
+		if len(params) != 0 {
+			v.add(path, errGetter)
+		}
+	case "set":
+		if len(params) != 1 {
+			v.add(path, errSetter)
+		} else if _, ok := params[0].(*RestElement); ok {
+			v.add(path, errSetter)
+		}
+	}
+}
+
+func (v *validator) shorthand(path string, shorthand, computed bool, key Expression) {
+	if !shorthand {
+		return
+	}
+
+	if _, ok := key.(*Identifier); !ok || computed {
+		v.add(path, errShorthand)
+	}
+}
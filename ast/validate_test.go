@@ -0,0 +1,69 @@
+package ast
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidate(t *testing.T) {
+	a := assert.New(t)
+
+	a.Nil(Validate(sum("+", nil)))
+
+	id := &Identifier{Name: "a"}
+
+	p := &Program{
+		SourceType: "script",
+		Body: []StatementOrModuleDeclaration{
+			&VariableDeclaration{
+				Kind:         "banana",
+				Declarations: []*VariableDeclarator{{ID: id}},
+			},
+			&ExpressionStatement{
+				Expression: &AssignmentExpression{Operator: "=>", Left: id, Right: id},
+			},
+			&FunctionDeclaration{
+				ID:     id,
+				Params: []Pattern{&RestElement{Argument: id}, id},
+				Body:   &BlockStatement{},
+			},
+			&ClassDeclaration{
+				ID: id,
+				Body: &ClassBody{
+					Body: []ClassMethodOrClassPrivateMethodOrClassPropertyOrClassPrivatePropertyOrStaticBlock{
+						&ClassMethod{Key: id, Kind: "get", Value: &FunctionExpression{Params: []Pattern{id}, Body: &BlockStatement{}}},
+					},
+				},
+			},
+			&TryStatement{Block: &BlockStatement{}},
+			&ExpressionStatement{},
+		},
+	}
+
+	var s []string
+	for _, err := range Validate(p) {
+		s = append(s, err.Error())
+	}
+
+	a.Equal([]string{
+		`ast: body[0]: VariableDeclaration.kind can't be "banana"`,
+		`ast: body[1].expression: AssignmentExpression.operator can't be "=>"`,
+		`ast: body[2].params[0]: a RestElement has to come last`,
+		`ast: body[3].body.body[0]: a getter can't have parameters`,
+		`ast: body[4]: a TryStatement needs a handler, a finalizer or both`,
+		`ast: body[5]: ExpressionStatement.expression can't be null`,
+	}, s)
+
+	errs := Validate(&MemberExpression{Object: id, Property: &StringLiteral{Value: "a"}})
+	if a.Len(errs, 1) {
+		a.Equal(&ValidationError{Err: errProperty}, errs[0])
+	}
+
+	a.Nil(Validate(&MemberExpression{Object: &ThisExpression{}, Property: &PrivateName{ID: id}}))
+
+	errs = Validate(&BinaryExpression{Operator: BinaryOperatorPlus, Left: &PrivateName{ID: id}, Right: id})
+	if a.Len(errs, 1) {
+		a.Equal(&ValidationError{Err: errPrivateIn}, errs[0])
+	}
+}
@@ -0,0 +1,440 @@
+package earley
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"fknsrs.biz/p/jsparser"
+)
+
+// Goal symbols of the syntactic grammar.
+const (
+	Script = "Script"
+	Module = "Module"
+)
+
+// tokenClasses are the productions of the lexical grammar that the tokeniser
+// reads as a single token. They're matched against the kind of a token, rather
+// than being expanded.
+var tokenClasses = map[string][]jsparser.TokenKind{
+	"IdentifierName":           {jsparser.TokenKindIdentifier, jsparser.TokenKindKeyword},
+	"NumericLiteral":           {jsparser.TokenKindNumber},
+	"StringLiteral":            {jsparser.TokenKindString},
+	"RegularExpressionLiteral": {jsparser.TokenKindRegexp},
+	"NoSubstitutionTemplate":   {jsparser.TokenKindTemplateNoSubstitution},
+	"TemplateHead":             {jsparser.TokenKindTemplateHead},
+	"TemplateMiddle":           {jsparser.TokenKindTemplateMiddle},
+	"TemplateTail":             {jsparser.TokenKindTemplateTail},
+}
+
+// trivia are the kinds of token that the syntactic grammar never sees.
+var trivia = map[jsparser.TokenKind]bool{
+	jsparser.TokenKindWhitespace:          true,
+	jsparser.TokenKindSingleLineComment:   true,
+	jsparser.TokenKindMultipleLineComment: true,
+	jsparser.TokenKindMetaShebangLine:     true,
+}
+
+type SyntaxError struct {
+	// Index is the position in the TokenSet of the earliest token that can't
+	// be parsed, or the length of the TokenSet if the input ends too soon.
+	Index  int
+	Offset int
+	// Expected lists the terminals and token classes that would have been
+	// accepted instead.
+	Expected []string
+}
+
+func (e SyntaxError) Error() string {
+	return fmt.Sprintf("SyntaxError (token %d at offset %d): expected one of %s", e.Index, e.Offset, strings.Join(e.Expected, ", "))
+}
+
+// input is a significant token, along with whether there's a line terminator
+// between it and the one before. Semicolons added by automatic semicolon
+// insertion have no token.
+type input struct {
+	token   *jsparser.Token
+	index   int
+	newline bool
+}
+
+func (i input) text() string {
+	if i.token == nil {
+		return ";"
+	}
+
+	if i.token.Value != "" {
+		return i.token.Value
+	}
+
+	return i.token.Raw
+}
+
+type item struct {
+	rule   *Rule
+	dot    int
+	origin int
+}
+
+func (i item) next() *Symbol {
+	if i.dot < len(i.rule.Symbols) {
+		return &i.rule.Symbols[i.dot]
+	}
+
+	return nil
+}
+
+type set struct {
+	items []item
+	seen  map[item]bool
+}
+
+func (s *set) add(i item) {
+	if s.seen == nil {
+		s.seen = make(map[item]bool)
+	}
+
+	if !s.seen[i] {
+		s.seen[i] = true
+		s.items = append(s.items, i)
+	}
+}
+
+type chart struct {
+	g     *Grammar
+	goal  string
+	in    []input
+	sets  []*set
+	end   int
+	nodes map[nodeKey]*Node
+}
+
+// Recognise checks whether tokens are a valid instance of the goal symbol,
+// usually Script or Module. Automatic semicolon insertion is applied as the
+// specification describes.
+func (g *Grammar) Recognise(goal string, tokens jsparser.TokenSet) error {
+	_, err := g.run(goal, tokens)
+	return err
+}
+
+func (g *Grammar) run(goal string, tokens jsparser.TokenSet) (*chart, error) {
+	if _, ok := g.Rules[goal]; !ok {
+		return nil, fmt.Errorf("goal symbol %s isn't in the grammar", goal)
+	}
+
+	c := chart{g: g, goal: goal}
+
+	newline := false
+	for i := range tokens {
+		t := &tokens[i]
+
+		if trivia[t.Kind] {
+			switch t.Kind {
+			case jsparser.TokenKindWhitespace, jsparser.TokenKindMultipleLineComment:
+				newline = newline || strings.ContainsAny(t.Raw, "\n\r\u2028\u2029")
+			case jsparser.TokenKindMetaShebangLine, jsparser.TokenKindSingleLineComment:
+				newline = true
+			}
+
+			continue
+		}
+
+		c.in = append(c.in, input{token: t, index: i, newline: newline})
+		newline = false
+	}
+
+	c.sets = []*set{{}}
+	for _, r := range g.Rules[goal] {
+		c.sets[0].add(item{rule: r})
+	}
+
+	c.close(0)
+
+	for i := 0; ; i++ {
+		if i == len(c.in) {
+			if c.accepted(i) {
+				c.end = i
+				return &c, nil
+			}
+
+			// At the end of the input, a semicolon is inserted if that makes
+			// the program valid.
+			c.insert(i)
+			c.scan(i)
+
+			if len(c.sets[i+1].items) > 0 {
+				c.close(i + 1)
+
+				if c.accepted(i + 1) {
+					c.end = i + 1
+					return &c, nil
+				}
+			}
+
+			c.sets = c.sets[:i+1]
+			c.in = c.in[:i]
+
+			offset := 0
+			if i > 0 && c.in[i-1].token != nil {
+				offset = c.in[i-1].token.Offset + len(c.in[i-1].token.Raw)
+			}
+
+			return nil, SyntaxError{Index: len(tokens), Offset: offset, Expected: c.expected(i)}
+		}
+
+		c.scan(i)
+
+		if len(c.sets[i+1].items) == 0 && c.asi(i) {
+			c.sets = c.sets[:i+1]
+			c.insert(i)
+			c.scan(i)
+		}
+
+		if len(c.sets[i+1].items) == 0 {
+			c.sets = c.sets[:i+1]
+
+			if c.in[i].token == nil {
+				c.in = append(c.in[:i], c.in[i+1:]...)
+			}
+
+			return nil, SyntaxError{Index: c.in[i].index, Offset: c.in[i].token.Offset, Expected: c.expected(i)}
+		}
+
+		c.close(i + 1)
+	}
+}
+
+func (c *chart) accepted(i int) bool {
+	if i >= len(c.sets) {
+		return false
+	}
+
+	for _, it := range c.sets[i].items {
+		if it.origin == 0 && it.rule.Name == c.goal && it.next() == nil {
+			return true
+		}
+	}
+
+	return false
+}
+
+// asi reports whether a semicolon can be inserted before the token at i,
+// which can't be parsed: it has to be on a new line, or be a "}".
+func (c *chart) asi(i int) bool {
+	if c.in[i].token == nil {
+		return false
+	}
+
+	return c.in[i].newline || c.in[i].text() == "}" || (i > 0 && c.in[i-1].text() == ")")
+}
+
+// insert puts an automatically inserted semicolon into the input at i.
+func (c *chart) insert(i int) {
+	c.in = append(c.in, input{})
+	copy(c.in[i+1:], c.in[i:])
+	c.in[i] = input{index: -1}
+}
+
+// virtualAllowed reports whether an inserted semicolon can be shifted by an
+// item. A semicolon is never inserted if it would become an empty statement
+// or one of the semicolons in the header of a for statement, and it's only
+// inserted after a ")" on the same line if it ends a do-while statement.
+func (c *chart) virtualAllowed(it item, i int) bool {
+	n := base(it.rule.Name)
+	if n == "EmptyStatement" {
+		return false
+	}
+
+	if s := it.rule.Symbols; n == "IterationStatement" {
+		switch s[0].Value {
+		case "for":
+			return false
+		case "do":
+			return true
+		}
+	}
+
+	next := i + 1
+	if next < len(c.in) && (c.in[next].newline || c.in[next].text() == "}") {
+		return true
+	}
+
+	return next >= len(c.in)
+}
+
+func (c *chart) matches(s Symbol, in input) bool {
+	switch s.Kind {
+	case SymbolTerminal:
+		if in.token == nil {
+			return s.Value == ";"
+		}
+
+		switch in.token.Kind {
+		case jsparser.TokenKindIdentifier, jsparser.TokenKindKeyword:
+			return in.token.Value == s.Value
+		case jsparser.TokenKindString, jsparser.TokenKindNumber, jsparser.TokenKindRegexp,
+			jsparser.TokenKindTemplateHead, jsparser.TokenKindTemplateMiddle,
+			jsparser.TokenKindTemplateNoSubstitution, jsparser.TokenKindTemplateTail:
+			return false
+		}
+
+		return in.token.Raw == s.Value
+	case SymbolNonterminal:
+		if in.token == nil {
+			return false
+		}
+
+		kinds, ok := tokenClasses[base(s.Value)]
+		if !ok {
+			for _, e := range c.g.language(s) {
+				if e == in.text() {
+					return true
+				}
+			}
+
+			return false
+		}
+
+		for _, k := range kinds {
+			if in.token.Kind == k {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func (c *chart) excluded(s *Symbol, in input) bool {
+	for _, b := range s.ButNot {
+		for _, e := range c.g.language(b) {
+			if e == in.text() {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func terminal(s *Symbol) bool {
+	if s.Kind == SymbolTerminal {
+		return true
+	}
+
+	_, ok := tokenClasses[base(s.Value)]
+
+	return s.Kind == SymbolNonterminal && ok
+}
+
+func (c *chart) scan(i int) {
+	for len(c.sets) <= i+1 {
+		c.sets = append(c.sets, &set{})
+	}
+
+	for _, it := range c.sets[i].items {
+		s := it.next()
+		if s == nil || !terminal(s) || !c.matches(*s, c.in[i]) {
+			continue
+		}
+
+		if c.in[i].token == nil && !c.virtualAllowed(it, i) {
+			continue
+		}
+
+		if c.in[i].token != nil && c.excluded(s, c.in[i]) {
+			continue
+		}
+
+		c.sets[i+1].add(item{rule: it.rule, dot: it.dot + 1, origin: it.origin})
+	}
+}
+
+// close adds everything that can be predicted or completed in set i.
+func (c *chart) close(i int) {
+	s := c.sets[i]
+
+	for n := 0; n < len(s.items); n++ {
+		it := s.items[n]
+		sym := it.next()
+
+		switch {
+		case sym == nil:
+			for _, p := range c.sets[it.origin].items {
+				q := p.next()
+				if q == nil || q.Kind != SymbolNonterminal || q.Value != it.rule.Name {
+					continue
+				}
+
+				if len(q.ButNot) > 0 && i == it.origin+1 && c.excluded(q, c.in[it.origin]) {
+					continue
+				}
+
+				s.add(item{rule: p.rule, dot: p.dot + 1, origin: p.origin})
+			}
+		case sym.Kind == SymbolLookahead:
+			if c.lookahead(sym, i) {
+				s.add(item{rule: it.rule, dot: it.dot + 1, origin: it.origin})
+			}
+		case sym.Kind == SymbolNoLineTerminator:
+			if i >= len(c.in) || !c.in[i].newline {
+				s.add(item{rule: it.rule, dot: it.dot + 1, origin: it.origin})
+			}
+		case sym.Kind == SymbolNonterminal && !terminal(sym):
+			for _, r := range c.g.Rules[sym.Value] {
+				s.add(item{rule: r, origin: i})
+			}
+
+			// Aycock and Horspool's fix for nullable symbols.
+			if c.g.nullable[sym.Value] {
+				s.add(item{rule: it.rule, dot: it.dot + 1, origin: it.origin})
+			}
+		}
+	}
+}
+
+func (c *chart) lookahead(s *Symbol, i int) bool {
+	found := false
+
+	for _, q := range s.Set {
+		if i+len(q) > len(c.in) {
+			continue
+		}
+
+		ok := true
+		for j, e := range q {
+			if !c.matches(e, c.in[i+j]) {
+				ok = false
+				break
+			}
+		}
+
+		found = found || ok
+	}
+
+	return found != s.Inverse
+}
+
+func (c *chart) expected(i int) []string {
+	m := make(map[string]bool)
+
+	for _, it := range c.sets[i].items {
+		if s := it.next(); s != nil && terminal(s) {
+			if s.Kind == SymbolTerminal {
+				m[fmt.Sprintf("%q", s.Value)] = true
+			} else {
+				m[base(s.Value)] = true
+			}
+		}
+	}
+
+	var a []string
+	for k := range m {
+		a = append(a, k)
+	}
+
+	sort.Strings(a)
+
+	return a
+}
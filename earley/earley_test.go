@@ -0,0 +1,85 @@
+package earley
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"fknsrs.biz/p/jsparser"
+)
+
+func loadGrammar(t *testing.T) *Grammar {
+	g, err := LoadFile("../internal/parser_generator/es6.ebnf")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return g
+}
+
+func TestRecognise(t *testing.T) {
+	a := assert.New(t)
+
+	g := loadGrammar(t)
+
+	for _, s := range []string{
+		"var a = 1;\n",
+		"function f(a, b) { return a + b; }\n",
+		"if (a) { b(); } else c = `x${d}y`;\n",
+		"for (var i = 0; i < 10; i++) {}\n",
+		"a\nb\n",
+		"do x(); while (y) z();\n",
+		"function f() { return\n}\n",
+	} {
+		tokens, err := jsparser.ParseString(s)
+		if !a.NoError(err, s) {
+			continue
+		}
+
+		a.NoError(g.Recognise(Script, tokens), s)
+	}
+
+	tokens, err := jsparser.ParseString("import a from \"b\";\nexport default function () {}\n")
+	a.NoError(err)
+	a.NoError(g.Recognise(Module, tokens))
+	a.Error(g.Recognise(Script, tokens))
+}
+
+func TestRecogniseError(t *testing.T) {
+	a := assert.New(t)
+
+	g := loadGrammar(t)
+
+	tokens, err := jsparser.ParseString("var a = 1 2;\n")
+	a.NoError(err)
+
+	err = g.Recognise(Script, tokens)
+	if a.Error(err) {
+		a.Equal(8, err.(SyntaxError).Index)
+		a.Equal(10, err.(SyntaxError).Offset)
+	}
+
+	tokens, err = jsparser.ParseString("for (a b) {}\n")
+	a.NoError(err)
+
+	err = g.Recognise(Script, tokens)
+	if a.Error(err) {
+		a.Equal(5, err.(SyntaxError).Index)
+	}
+}
+
+func TestParse(t *testing.T) {
+	a := assert.New(t)
+
+	g := loadGrammar(t)
+
+	tokens, err := jsparser.ParseString("a = b\n")
+	a.NoError(err)
+
+	n, err := g.Parse(Script, tokens)
+	if a.NoError(err) {
+		a.Equal(Script, n.Symbol)
+		a.Contains(n.String(), "IdentifierName \"a\"")
+		a.Contains(n.String(), "\";\" (inserted)")
+	}
+}
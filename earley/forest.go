@@ -0,0 +1,220 @@
+package earley
+
+import (
+	"fmt"
+	"strings"
+
+	"fknsrs.biz/p/jsparser"
+)
+
+// maxDerivations caps the number of derivations kept for any node, so that a
+// highly ambiguous input can't make the forest explode.
+const maxDerivations = 8
+
+// Node is a node in a parse forest. A nonterminal has one derivation for each
+// way it can be parsed, so more than one means that the input is ambiguous at
+// that point. Terminals and token classes are leaves, with the token they
+// matched, which is nil for an automatically inserted semicolon.
+//
+// Start and End count significant tokens, including inserted semicolons, not
+// positions in the TokenSet.
+type Node struct {
+	Symbol      string
+	Start       int
+	End         int
+	Token       *jsparser.Token
+	Derivations []Derivation
+}
+
+type Derivation struct {
+	Rule     *Rule
+	Children []*Node
+}
+
+func (n *Node) Ambiguous() bool {
+	return len(n.Derivations) > 1
+}
+
+func (n *Node) String() string {
+	var b strings.Builder
+	n.format(&b, "", make(map[*Node]bool))
+	return b.String()
+}
+
+func (n *Node) format(b *strings.Builder, indent string, seen map[*Node]bool) {
+	if n.Token != nil {
+		if _, ok := tokenClasses[n.Symbol]; ok {
+			fmt.Fprintf(b, "%s%s %q\n", indent, n.Symbol, n.Token.Raw)
+		} else {
+			fmt.Fprintf(b, "%s%s\n", indent, n.Symbol)
+		}
+
+		return
+	}
+
+	if len(n.Derivations) == 0 {
+		if n.End > n.Start {
+			fmt.Fprintf(b, "%s%s (inserted)\n", indent, n.Symbol)
+		} else {
+			fmt.Fprintf(b, "%s%s\n", indent, n.Symbol)
+		}
+
+		return
+	}
+
+	if seen[n] {
+		fmt.Fprintf(b, "%s%s ...\n", indent, n.Symbol)
+		return
+	}
+
+	seen[n] = true
+	defer delete(seen, n)
+
+	fmt.Fprintf(b, "%s%s\n", indent, n.Symbol)
+
+	for i, d := range n.Derivations {
+		child := indent + "  "
+		if n.Ambiguous() {
+			fmt.Fprintf(b, "%s  | %d\n", indent, i+1)
+			child += "  "
+		}
+
+		for _, c := range d.Children {
+			c.format(b, child, seen)
+		}
+	}
+}
+
+// Uses counts the nodes in the forest that were derived with each rule. Every
+// derivation of an ambiguous node is counted.
+func (n *Node) Uses() map[*Rule]int {
+	m := make(map[*Rule]int)
+	n.uses(m, make(map[*Node]bool))
+	return m
+}
+
+func (n *Node) uses(m map[*Rule]int, seen map[*Node]bool) {
+	if seen[n] {
+		return
+	}
+
+	seen[n] = true
+
+	for _, d := range n.Derivations {
+		m[d.Rule]++
+
+		for _, c := range d.Children {
+			c.uses(m, seen)
+		}
+	}
+}
+
+// Parse is like Recognise, but also returns the parse forest.
+func (g *Grammar) Parse(goal string, tokens jsparser.TokenSet) (*Node, error) {
+	c, err := g.run(goal, tokens)
+	if err != nil {
+		return nil, err
+	}
+
+	c.nodes = make(map[nodeKey]*Node)
+
+	return c.node(goal, 0, c.end), nil
+}
+
+type nodeKey struct {
+	symbol string
+	start  int
+	end    int
+}
+
+func (c *chart) node(symbol string, i, j int) *Node {
+	k := nodeKey{symbol, i, j}
+	if n, ok := c.nodes[k]; ok {
+		return n
+	}
+
+	n := &Node{Symbol: symbol, Start: i, End: j}
+	c.nodes[k] = n
+
+	for _, it := range c.sets[j].items {
+		if it.rule.Name != symbol || it.origin != i || it.next() != nil {
+			continue
+		}
+
+		for _, children := range c.derive(it.rule, len(it.rule.Symbols), i, j) {
+			if len(n.Derivations) == maxDerivations {
+				break
+			}
+
+			n.Derivations = append(n.Derivations, Derivation{Rule: it.rule, Children: children})
+		}
+	}
+
+	return n
+}
+
+func (c *chart) leaf(s *Symbol, j int) *Node {
+	n := &Node{Symbol: s.Value, Start: j, End: j + 1, Token: c.in[j].token}
+	if s.Kind == SymbolTerminal {
+		n.Symbol = fmt.Sprintf("%q", s.Value)
+	}
+
+	return n
+}
+
+// derive returns the ways that the first k symbols of r can span i to j, as
+// lists of child nodes.
+func (c *chart) derive(r *Rule, k, i, j int) [][]*Node {
+	if k == 0 {
+		if i == j {
+			return [][]*Node{nil}
+		}
+
+		return nil
+	}
+
+	s := &r.Symbols[k-1]
+	prev := item{rule: r, dot: k - 1, origin: i}
+
+	var a [][]*Node
+
+	switch {
+	case s.Kind == SymbolLookahead || s.Kind == SymbolNoLineTerminator:
+		if c.sets[j].seen[prev] {
+			return c.derive(r, k-1, i, j)
+		}
+	case terminal(s):
+		if j-1 >= i && c.sets[j-1].seen[prev] {
+			for _, p := range c.derive(r, k-1, i, j-1) {
+				a = append(a, append(p[:len(p):len(p)], c.leaf(s, j-1)))
+			}
+		}
+	default:
+		for mid := i; mid <= j && len(a) < maxDerivations; mid++ {
+			if !c.sets[mid].seen[prev] || !c.completed(s.Value, mid, j) {
+				continue
+			}
+
+			n := c.node(s.Value, mid, j)
+			for _, p := range c.derive(r, k-1, i, mid) {
+				a = append(a, append(p[:len(p):len(p)], n))
+			}
+		}
+	}
+
+	if len(a) > maxDerivations {
+		a = a[:maxDerivations]
+	}
+
+	return a
+}
+
+func (c *chart) completed(symbol string, i, j int) bool {
+	for _, it := range c.sets[j].items {
+		if it.rule.Name == symbol && it.origin == i && it.next() == nil {
+			return true
+		}
+	}
+
+	return false
+}
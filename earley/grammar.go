@@ -0,0 +1,770 @@
+// Package earley checks token streams against the grammar in the
+// specification, as written out by parser_generator in es6.ebnf. It's slow,
+// but it doesn't have any opinions of its own about the language, which makes
+// it useful as an oracle when testing the tokeniser and parser.
+package earley // import "fknsrs.biz/p/jsparser/earley"
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+type SymbolKind int
+
+const (
+	SymbolTerminal SymbolKind = iota
+	SymbolNonterminal
+	SymbolLookahead
+	SymbolNoLineTerminator
+)
+
+func (k SymbolKind) String() string {
+	switch k {
+	case SymbolTerminal:
+		return "terminal"
+	case SymbolNonterminal:
+		return "nonterminal"
+	case SymbolLookahead:
+		return "lookahead"
+	case SymbolNoLineTerminator:
+		return "noLineTerminator"
+	default:
+		return "unknown"
+	}
+}
+
+// Symbol is one element of a rule. Lookahead and no LineTerminator symbols
+// are zero-width assertions about the tokens that follow.
+type Symbol struct {
+	Kind  SymbolKind
+	Value string
+	// ButNot is the `X - Y` restriction on a terminal or nonterminal.
+	ButNot []Symbol
+	// Inverse and Set describe a lookahead restriction. Each entry in Set is a
+	// sequence of tokens, and the restriction is satisfied if the input starts
+	// with one of them, or none of them when Inverse is set.
+	Inverse bool
+	Set     [][]Symbol
+}
+
+func (s Symbol) String() string {
+	switch s.Kind {
+	case SymbolLookahead:
+		a := make([]string, len(s.Set))
+		for i, q := range s.Set {
+			b := make([]string, len(q))
+			for j, e := range q {
+				b[j] = e.String()
+			}
+			a[i] = strings.Join(b, " ")
+		}
+
+		op := "∈"
+		if s.Inverse {
+			op = "∉"
+		}
+
+		return fmt.Sprintf("[lookahead %s { %s }]", op, strings.Join(a, ", "))
+	case SymbolNoLineTerminator:
+		return "[no LineTerminator here]"
+	}
+
+	v := s.Value
+	if s.Kind == SymbolTerminal {
+		v = fmt.Sprintf("%q", s.Value)
+	}
+
+	if len(s.ButNot) > 0 {
+		a := make([]string, len(s.ButNot))
+		for i, e := range s.ButNot {
+			a[i] = e.String()
+		}
+
+		v += " - ( " + strings.Join(a, " | ") + " )"
+	}
+
+	return v
+}
+
+type Rule struct {
+	Name    string
+	Symbols []Symbol
+}
+
+func (r Rule) String() string {
+	a := make([]string, len(r.Symbols))
+	for i, s := range r.Symbols {
+		a[i] = s.String()
+	}
+
+	return r.Name + " ::= " + strings.Join(a, " ")
+}
+
+// Grammar is a set of rules, keyed by the name of the production they belong
+// to. Optional items, groups and character classes in the EBNF become
+// productions of their own, named after the text they came from, e.g.
+// `ScriptBody?` or `( "+" | "-" )`.
+type Grammar struct {
+	Names    []string
+	Rules    map[string][]*Rule
+	nullable map[string]bool
+	finite   map[string][]string
+}
+
+func LoadFile(path string) (*Grammar, error) {
+	fd, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer fd.Close()
+
+	return Load(fd)
+}
+
+// Load reads a grammar in the format written by `parser_generator -format
+// ebnf`.
+func Load(rd io.Reader) (*Grammar, error) {
+	b, err := ioutil.ReadAll(rd)
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := lexEBNF(string(b))
+	if err != nil {
+		return nil, err
+	}
+
+	g := Grammar{
+		Rules:  make(map[string][]*Rule),
+		finite: make(map[string][]string),
+	}
+
+	p := ebnfParser{g: &g, items: items}
+	if err := p.parse(); err != nil {
+		return nil, err
+	}
+
+	g.computeNullable()
+
+	return &g, nil
+}
+
+func (g *Grammar) add(name string, symbols []Symbol) {
+	if _, ok := g.Rules[name]; !ok {
+		g.Names = append(g.Names, name)
+	}
+
+	g.Rules[name] = append(g.Rules[name], &Rule{Name: name, Symbols: symbols})
+}
+
+func (g *Grammar) define(name string) {
+	if _, ok := g.Rules[name]; !ok {
+		g.Names = append(g.Names, name)
+		g.Rules[name] = nil
+	}
+}
+
+// Syntactic returns the productions that can be reached from the goal
+// symbols without going through a token class, in the order they were
+// defined. Helper productions for optional items and groups are included.
+func (g *Grammar) Syntactic(goals ...string) []string {
+	seen := make(map[string]bool)
+
+	var visit func(n string)
+	visit = func(n string) {
+		if seen[n] {
+			return
+		}
+
+		if _, ok := tokenClasses[base(n)]; ok {
+			return
+		}
+
+		seen[n] = true
+
+		for _, r := range g.Rules[n] {
+			for _, s := range r.Symbols {
+				if s.Kind == SymbolNonterminal {
+					visit(s.Value)
+				}
+			}
+		}
+	}
+
+	for _, n := range goals {
+		visit(n)
+	}
+
+	var a []string
+	for _, n := range g.Names {
+		if seen[n] {
+			a = append(a, n)
+		}
+	}
+
+	return a
+}
+
+func (g *Grammar) computeNullable() {
+	g.nullable = make(map[string]bool)
+
+	for changed := true; changed; {
+		changed = false
+
+		for _, n := range g.Names {
+			if g.nullable[n] {
+				continue
+			}
+
+			for _, r := range g.Rules[n] {
+				if g.nullableSymbols(r.Symbols) {
+					g.nullable[n] = true
+					changed = true
+					break
+				}
+			}
+		}
+	}
+}
+
+func (g *Grammar) nullableSymbols(a []Symbol) bool {
+	for _, s := range a {
+		switch s.Kind {
+		case SymbolTerminal:
+			return false
+		case SymbolNonterminal:
+			if _, ok := tokenClasses[base(s.Value)]; ok || !g.nullable[s.Value] {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// language returns every string that a symbol can produce, if there are few
+// enough of them to list, or nil.
+func (g *Grammar) language(s Symbol) []string {
+	switch s.Kind {
+	case SymbolTerminal:
+		return []string{s.Value}
+	case SymbolNonterminal:
+		return g.finiteLanguage(s.Value, make(map[string]bool))
+	}
+
+	return nil
+}
+
+const finiteLimit = 1024
+
+func (g *Grammar) finiteLanguage(n string, visiting map[string]bool) []string {
+	if l, ok := g.finite[n]; ok {
+		return l
+	}
+
+	if visiting[n] {
+		return nil
+	}
+
+	visiting[n] = true
+	defer delete(visiting, n)
+
+	var l []string
+	for _, r := range g.Rules[n] {
+		a := []string{""}
+
+		for _, s := range r.Symbols {
+			var e []string
+			switch s.Kind {
+			case SymbolTerminal:
+				e = []string{s.Value}
+			case SymbolNonterminal:
+				e = g.finiteLanguage(s.Value, visiting)
+			default:
+				continue
+			}
+
+			if e == nil || len(s.ButNot) > 0 {
+				g.finite[n] = nil
+				return nil
+			}
+
+			var next []string
+			for _, p := range a {
+				for _, x := range e {
+					next = append(next, p+x)
+				}
+			}
+
+			if len(next) > finiteLimit {
+				g.finite[n] = nil
+				return nil
+			}
+
+			a = next
+		}
+
+		l = append(l, a...)
+	}
+
+	if len(l) > finiteLimit {
+		l = nil
+	}
+
+	g.finite[n] = l
+
+	return l
+}
+
+// The EBNF is read in two passes: lexEBNF splits it into items, and
+// ebnfParser builds rules out of them.
+
+type ebnfKind int
+
+const (
+	ebnfName ebnfKind = iota
+	ebnfString
+	ebnfDefine
+	ebnfBar
+	ebnfOptional
+	ebnfMinus
+	ebnfOpen
+	ebnfClose
+	ebnfBracket
+)
+
+type ebnfItem struct {
+	kind  ebnfKind
+	value string
+	line  int
+}
+
+func lexEBNF(s string) ([]ebnfItem, error) {
+	var a []ebnfItem
+
+	line := 1
+	for i := 0; i < len(s); {
+		r, n := utf8.DecodeRuneInString(s[i:])
+
+		switch {
+		case r == '\n':
+			line++
+			i++
+		case unicode.IsSpace(r):
+			i += n
+		case strings.HasPrefix(s[i:], "::="):
+			a = append(a, ebnfItem{ebnfDefine, "::=", line})
+			i += 3
+		case r == '|':
+			a = append(a, ebnfItem{ebnfBar, "|", line})
+			i++
+		case r == '?':
+			a = append(a, ebnfItem{ebnfOptional, "?", line})
+			i++
+		case r == '-':
+			a = append(a, ebnfItem{ebnfMinus, "-", line})
+			i++
+		case r == '(':
+			a = append(a, ebnfItem{ebnfOpen, "(", line})
+			i++
+		case r == ')':
+			a = append(a, ebnfItem{ebnfClose, ")", line})
+			i++
+		case r == '"' || r == '\'':
+			v, j, err := lexEBNFString(s, i)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %s", line, err)
+			}
+
+			a = append(a, ebnfItem{ebnfString, v, line})
+			i = j
+		case r == '[':
+			j := i + 1
+			for q := rune(0); j < len(s) && (q != 0 || s[j] != ']'); j++ {
+				switch {
+				case q == 0 && (s[j] == '"' || s[j] == '\''):
+					q = rune(s[j])
+				case q != 0 && s[j] == '\\':
+					j++
+				case q != 0 && rune(s[j]) == q:
+					q = 0
+				}
+			}
+
+			if j >= len(s) {
+				return nil, fmt.Errorf("line %d: unterminated bracket", line)
+			}
+
+			a = append(a, ebnfItem{ebnfBracket, s[i+1 : j], line})
+			i = j + 1
+		case r == '_' || unicode.IsLetter(r):
+			j := i
+			for j < len(s) {
+				r, n := utf8.DecodeRuneInString(s[j:])
+				if r != '_' && !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+					break
+				}
+				j += n
+			}
+
+			a = append(a, ebnfItem{ebnfName, s[i:j], line})
+			i = j
+		default:
+			return nil, fmt.Errorf("line %d: unexpected character %q", line, r)
+		}
+	}
+
+	return a, nil
+}
+
+func lexEBNFString(s string, i int) (string, int, error) {
+	q := s[i]
+
+	var b []byte
+	for j := i + 1; j < len(s); j++ {
+		switch s[j] {
+		case '\\':
+			j++
+			if j < len(s) {
+				b = append(b, s[j])
+			}
+		case q:
+			return string(b), j + 1, nil
+		case '\n':
+			return "", 0, fmt.Errorf("unterminated string")
+		default:
+			b = append(b, s[j])
+		}
+	}
+
+	return "", 0, fmt.Errorf("unterminated string")
+}
+
+type ebnfParser struct {
+	g     *Grammar
+	items []ebnfItem
+	pos   int
+}
+
+func (p *ebnfParser) peek(n int) *ebnfItem {
+	if p.pos+n < len(p.items) {
+		return &p.items[p.pos+n]
+	}
+
+	return nil
+}
+
+func (p *ebnfParser) errf(format string, a ...interface{}) error {
+	line := 0
+	if e := p.peek(0); e != nil {
+		line = e.line
+	} else if len(p.items) > 0 {
+		line = p.items[len(p.items)-1].line
+	}
+
+	return fmt.Errorf("line %d: %s", line, fmt.Sprintf(format, a...))
+}
+
+// startsProduction reports whether the parser is at the name of a new
+// production. Productions with no alternatives are written as just a name.
+func (p *ebnfParser) startsProduction() bool {
+	e := p.peek(0)
+	if e == nil || e.kind != ebnfName {
+		return false
+	}
+
+	if n := p.peek(1); n != nil && n.kind == ebnfDefine {
+		return true
+	}
+
+	return p.pos > 0 && e.line != p.items[p.pos-1].line
+}
+
+func (p *ebnfParser) parse() error {
+	for p.pos < len(p.items) {
+		e := p.peek(0)
+		if e.kind != ebnfName {
+			return p.errf("expected a production name, found %q", e.value)
+		}
+
+		name := e.value
+		p.pos++
+
+		p.g.define(name)
+
+		if n := p.peek(0); n == nil || n.kind != ebnfDefine {
+			continue
+		}
+
+		p.pos++
+
+		for {
+			symbols, err := p.sequence()
+			if err != nil {
+				return err
+			}
+
+			p.g.add(name, symbols)
+
+			if n := p.peek(0); n == nil || n.kind != ebnfBar {
+				break
+			}
+
+			p.pos++
+		}
+	}
+
+	return nil
+}
+
+func (p *ebnfParser) sequence() ([]Symbol, error) {
+	var a []Symbol
+
+	for {
+		e := p.peek(0)
+		if e == nil || e.kind == ebnfBar || e.kind == ebnfClose || p.startsProduction() {
+			return a, nil
+		}
+
+		s, ok, err := p.item()
+		if err != nil {
+			return nil, err
+		}
+
+		if ok {
+			a = append(a, s)
+		}
+	}
+}
+
+// item reads one element of a sequence, including any `?` or `- Y` that
+// follows it. It returns false for [empty], which doesn't become a symbol.
+func (p *ebnfParser) item() (Symbol, bool, error) {
+	start := p.pos
+
+	s, ok, err := p.primary()
+	if err != nil || !ok {
+		return s, ok, err
+	}
+
+	if e := p.peek(0); e != nil && e.kind == ebnfOptional {
+		p.pos++
+
+		name := p.text(start, p.pos)
+		if _, ok := p.g.Rules[name]; !ok {
+			p.g.add(name, []Symbol{s})
+			p.g.add(name, nil)
+		}
+
+		s = Symbol{Kind: SymbolNonterminal, Value: name}
+	}
+
+	if e := p.peek(0); e != nil && e.kind == ebnfMinus {
+		p.pos++
+
+		if e := p.peek(0); e != nil && e.kind == ebnfOpen {
+			p.pos++
+
+			for {
+				b, _, err := p.primary()
+				if err != nil {
+					return s, false, err
+				}
+
+				s.ButNot = append(s.ButNot, b)
+
+				e := p.peek(0)
+				if e == nil {
+					return s, false, p.errf("unterminated group")
+				}
+
+				p.pos++
+
+				if e.kind == ebnfClose {
+					break
+				}
+
+				if e.kind != ebnfBar {
+					return s, false, p.errf("unexpected %q in group", e.value)
+				}
+			}
+		} else {
+			b, _, err := p.primary()
+			if err != nil {
+				return s, false, err
+			}
+
+			s.ButNot = append(s.ButNot, b)
+		}
+	}
+
+	return s, true, nil
+}
+
+func (p *ebnfParser) text(from, to int) string {
+	a := make([]string, to-from)
+	for i, e := range p.items[from:to] {
+		switch e.kind {
+		case ebnfString:
+			a[i] = fmt.Sprintf("%q", e.value)
+		case ebnfBracket:
+			a[i] = "[" + e.value + "]"
+		default:
+			a[i] = e.value
+		}
+	}
+
+	s := strings.Join(a, " ")
+	s = strings.Replace(s, " ?", "?", -1)
+
+	return s
+}
+
+func (p *ebnfParser) primary() (Symbol, bool, error) {
+	e := p.peek(0)
+	if e == nil {
+		return Symbol{}, false, p.errf("unexpected end of grammar")
+	}
+
+	start := p.pos
+	p.pos++
+
+	switch e.kind {
+	case ebnfName:
+		return Symbol{Kind: SymbolNonterminal, Value: e.value}, true, nil
+	case ebnfString:
+		return Symbol{Kind: SymbolTerminal, Value: e.value}, true, nil
+	case ebnfOpen:
+		var alts [][]Symbol
+		for {
+			q, err := p.sequence()
+			if err != nil {
+				return Symbol{}, false, err
+			}
+
+			alts = append(alts, q)
+
+			e := p.peek(0)
+			if e == nil {
+				return Symbol{}, false, p.errf("unterminated group")
+			}
+
+			p.pos++
+
+			if e.kind == ebnfClose {
+				break
+			}
+		}
+
+		name := p.text(start, p.pos)
+		if _, ok := p.g.Rules[name]; !ok {
+			for _, q := range alts {
+				p.g.add(name, q)
+			}
+		}
+
+		return Symbol{Kind: SymbolNonterminal, Value: name}, true, nil
+	case ebnfBracket:
+		return p.bracket(e.value)
+	}
+
+	return Symbol{}, false, p.errf("unexpected %q", e.value)
+}
+
+// bracket handles the annotations and character classes written in square
+// brackets.
+func (p *ebnfParser) bracket(v string) (Symbol, bool, error) {
+	switch {
+	case v == "empty":
+		return Symbol{}, false, nil
+	case v == "no LineTerminator here":
+		return Symbol{Kind: SymbolNoLineTerminator}, true, nil
+	case strings.HasPrefix(v, "lookahead "):
+		v = strings.TrimSpace(strings.TrimPrefix(v, "lookahead "))
+
+		s := Symbol{Kind: SymbolLookahead}
+
+		op, n := utf8.DecodeRuneInString(v)
+		switch op {
+		case '∉', '≠':
+			s.Inverse = true
+		case '∈', '=':
+		default:
+			return Symbol{}, false, p.errf("unrecognised lookahead operator %q", op)
+		}
+
+		v = strings.TrimSpace(v[n:])
+		if strings.HasPrefix(v, "{") && strings.HasSuffix(v, "}") {
+			v = v[1 : len(v)-1]
+		}
+
+		for _, e := range splitSet(v) {
+			items, err := lexEBNF(e)
+			if err != nil {
+				return Symbol{}, false, p.errf("%s in lookahead", err)
+			}
+
+			var seq []Symbol
+			for _, e := range items {
+				switch e.kind {
+				case ebnfName:
+					seq = append(seq, Symbol{Kind: SymbolNonterminal, Value: e.value})
+				case ebnfString:
+					seq = append(seq, Symbol{Kind: SymbolTerminal, Value: e.value})
+				default:
+					return Symbol{}, false, p.errf("unexpected %q in lookahead", e.value)
+				}
+			}
+
+			if len(seq) > 0 {
+				s.Set = append(s.Set, seq)
+			}
+		}
+
+		return s, true, nil
+	}
+
+	name := "[" + v + "]"
+	if _, ok := p.g.Rules[name]; !ok {
+		for _, r := range v {
+			p.g.add(name, []Symbol{{Kind: SymbolTerminal, Value: string(r)}})
+		}
+	}
+
+	return Symbol{Kind: SymbolNonterminal, Value: name}, true, nil
+}
+
+// splitSet splits the entries of a lookahead set at the commas that aren't
+// inside a string.
+func splitSet(s string) []string {
+	var a []string
+
+	var q byte
+	from := 0
+	for i := 0; i < len(s); i++ {
+		switch {
+		case q == 0 && (s[i] == '"' || s[i] == '\''):
+			q = s[i]
+		case q != 0 && s[i] == '\\':
+			i++
+		case q != 0 && s[i] == q:
+			q = 0
+		case q == 0 && s[i] == ',':
+			a = append(a, s[from:i])
+			from = i + 1
+		}
+	}
+
+	return append(a, s[from:])
+}
+
+// base returns the name of the production that a variant belongs to, e.g.
+// Expression for Expression_In_Yield.
+func base(n string) string {
+	return strings.SplitN(n, "_", 2)[0]
+}
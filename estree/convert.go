@@ -0,0 +1,823 @@
+package estree
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"fknsrs.biz/p/jsparser/ast"
+)
+
+// FromBabel converts a tree in the Babel dialect of the ast package to ESTree.
+// Literals become Literal nodes, directives become the ExpressionStatements
+// at the start of their body, object and class members become Property,
+// MethodDefinition and PropertyDefinition nodes, and optional chains are
+// wrapped in a ChainExpression. A tree that converts back with ToBabel is
+// equal to the one it started as, with the location and offsets of the
+// Identifier in a PrivateName worked out from those of the PrivateName.
+//
+// The location and comments of a ChainExpression, or of the
+// FunctionExpression of a method in an object, come from the chainSpan or
+// functionSpan that ToBabel keeps them in. Without one, a ChainExpression is
+// where the chain in it is, and a FunctionExpression has no location.
+//
+// It returns an error for a node that ESTree can't represent, like a
+// BindExpression or an ObjectProperty with decorators.
+func FromBabel(n ast.Node) (node Node, err error) {
+	defer recoverError(&err)
+
+	return fromBabel(n), nil
+}
+
+// ToBabel converts a tree in the ESTree dialect to the Babel dialect of the
+// ast package, reversing FromBabel. Nothing is lost, so a tree that converts
+// back with FromBabel is equal to the one it started as.
+//
+// It returns an error for a node that Babel's trees can't represent, like an
+// ExportAllDeclaration with a name or a computed PropertyDefinition.
+func ToBabel(n Node) (node ast.Node, err error) {
+	defer recoverError(&err)
+
+	return toBabel(n), nil
+}
+
+type convertError struct{ err error }
+
+func fail(format string, args ...interface{}) {
+	panic(convertError{fmt.Errorf("estree: "+format, args...)})
+}
+
+func recoverError(err *error) {
+	if r := recover(); r != nil {
+		e, ok := r.(convertError)
+		if !ok {
+			panic(r)
+		}
+		*err = e.err
+	}
+}
+
+// sameTypes maps the names of the node types that both dialects have to the
+// Babel and ESTree types. Their fields have the same names, so they convert
+// field by field, unless there's a case for them in fromBabel or toBabel.
+var sameTypes = map[string][2]reflect.Type{}
+
+func init() {
+	for _, n := range []ast.Node{
+		&ast.Identifier{}, &ast.ExpressionStatement{}, &ast.EmptyStatement{},
+		&ast.DebuggerStatement{}, &ast.WithStatement{}, &ast.ReturnStatement{},
+		&ast.LabeledStatement{}, &ast.BreakStatement{}, &ast.ContinueStatement{},
+		&ast.IfStatement{}, &ast.SwitchStatement{}, &ast.SwitchCase{},
+		&ast.ThrowStatement{}, &ast.TryStatement{}, &ast.CatchClause{},
+		&ast.WhileStatement{}, &ast.DoWhileStatement{}, &ast.ForStatement{},
+		&ast.ForInStatement{}, &ast.ForOfStatement{}, &ast.FunctionDeclaration{},
+		&ast.VariableDeclaration{}, &ast.VariableDeclarator{}, &ast.Decorator{},
+		&ast.Super{}, &ast.ThisExpression{}, &ast.ArrowFunctionExpression{},
+		&ast.YieldExpression{}, &ast.AwaitExpression{}, &ast.ArrayExpression{},
+		&ast.ObjectExpression{}, &ast.FunctionExpression{}, &ast.UnaryExpression{},
+		&ast.UpdateExpression{}, &ast.BinaryExpression{}, &ast.AssignmentExpression{},
+		&ast.LogicalExpression{}, &ast.SpreadElement{}, &ast.MemberExpression{},
+		&ast.ConditionalExpression{}, &ast.CallExpression{}, &ast.NewExpression{},
+		&ast.SequenceExpression{}, &ast.ImportExpression{}, &ast.TemplateLiteral{},
+		&ast.TaggedTemplateExpression{}, &ast.ObjectPattern{}, &ast.ArrayPattern{},
+		&ast.RestElement{}, &ast.AssignmentPattern{}, &ast.ClassBody{},
+		&ast.StaticBlock{}, &ast.ClassDeclaration{}, &ast.ClassExpression{},
+		&ast.MetaProperty{}, &ast.ImportDeclaration{}, &ast.ImportSpecifier{},
+		&ast.ImportDefaultSpecifier{}, &ast.ImportNamespaceSpecifier{},
+		&ast.ImportAttribute{}, &ast.ExportNamedDeclaration{},
+		&ast.ExportSpecifier{}, &ast.ExportDefaultDeclaration{},
+		&ast.ExportAllDeclaration{},
+	} {
+		b := reflect.TypeOf(n).Elem()
+		e := reflect.TypeOf(nodeTypes[b.Name()][0]()).Elem()
+		sameTypes[b.Name()] = [2]reflect.Type{b, e}
+	}
+}
+
+var (
+	babelNode  = reflect.TypeOf((*ast.Node)(nil)).Elem()
+	estreeNode = reflect.TypeOf((*Node)(nil)).Elem()
+)
+
+// missing reports whether a node is nil, or a nil pointer in an interface.
+func missing(n interface{}) bool {
+	if n == nil {
+		return true
+	}
+
+	v := reflect.ValueOf(n)
+
+	return v.Kind() == reflect.Ptr && v.IsNil()
+}
+
+// convert converts a node from one dialect to the other.
+func convert(n interface{}) interface{} {
+	if n, ok := n.(ast.Node); ok {
+		return fromBabel(n)
+	}
+
+	return toBabel(n.(Node))
+}
+
+// set converts src, which can be a node or a list of nodes from either
+// dialect, and stores it in the field dst points to. It fails if the result
+// can't go in the field; where names the field for the error.
+func set(dst, src interface{}, where string) {
+	value(reflect.ValueOf(dst).Elem(), reflect.ValueOf(src), where)
+}
+
+// put stores n, a node that's already been converted, in the field dst
+// points to.
+func put(dst, n interface{}, where string) {
+	if missing(n) {
+		return
+	}
+
+	d, v := reflect.ValueOf(dst).Elem(), reflect.ValueOf(n)
+	if !v.Type().AssignableTo(d.Type()) {
+		fail("a %s can't be used as %s", v.Elem().Type().Name(), where)
+	}
+
+	d.Set(v)
+}
+
+func value(d, s reflect.Value, where string) {
+	if !s.IsValid() {
+		return
+	}
+	if s.Kind() == reflect.Interface {
+		if s.IsNil() {
+			return
+		}
+		s = s.Elem()
+	}
+
+	switch {
+	case s.Type().Implements(babelNode) || s.Type().Implements(estreeNode):
+		if s.IsNil() {
+			return
+		}
+		put(d.Addr().Interface(), convert(s.Interface()), where)
+	case s.Kind() == reflect.Slice && s.Type().Elem().Kind() != reflect.Uint8:
+		if s.IsNil() {
+			return
+		}
+		l := reflect.MakeSlice(d.Type(), s.Len(), s.Len())
+		for i := 0; i < s.Len(); i++ {
+			value(l.Index(i), s.Index(i), where)
+		}
+		d.Set(l)
+	case s.Type().ConvertibleTo(d.Type()):
+		d.Set(s.Convert(d.Type()))
+	default:
+		fail("can't convert %s", where)
+	}
+}
+
+// fields converts the fields of the node src into the fields with the same
+// names in the node dst. A field that dst doesn't have has to be empty, or
+// its value would be lost.
+func fields(dst, src reflect.Value, other string) {
+	dst, src = dst.Elem(), src.Elem()
+
+	for i := 0; i < src.NumField(); i++ {
+		f := src.Type().Field(i)
+		if f.Anonymous {
+			continue
+		}
+
+		where := src.Type().Name() + "." + strings.Split(f.Tag.Get("json"), ",")[0]
+
+		d := dst.FieldByName(f.Name)
+		if !d.IsValid() {
+			if !src.Field(i).IsZero() {
+				fail("%s has no equivalent in %s", where, other)
+			}
+			continue
+		}
+
+		value(d, src.Field(i), where)
+	}
+}
+
+func fromBabel(n ast.Node) Node {
+	if missing(n) {
+		return nil
+	}
+
+	switch n := n.(type) {
+	case *ast.Unknown:
+		return &Unknown{BaseNode: fromBase(n, n.Type), Raw: append(n.Raw[:0:0], n.Raw...)}
+	case *ast.StringLiteral:
+		return &Literal{BaseNode: fromBase(n, "Literal"), Value: n.Value, Raw: fromExtra(n.Extra)}
+	case *ast.NumericLiteral:
+		return &Literal{BaseNode: fromBase(n, "Literal"), Value: n.Value, Raw: fromExtra(n.Extra)}
+	case *ast.BooleanLiteral:
+		return &Literal{BaseNode: fromBase(n, "Literal"), Value: n.Value, Raw: fromExtra(n.Extra)}
+	case *ast.NullLiteral:
+		return &Literal{BaseNode: fromBase(n, "Literal"), Raw: fromExtra(n.Extra)}
+	case *ast.RegExpLiteral:
+		return &Literal{BaseNode: fromBase(n, "Literal"), Regex: &RegExp{Pattern: n.Pattern, Flags: n.Flags}, Raw: fromExtra(n.Extra)}
+	case *ast.BigIntLiteral:
+		v := n.Value
+		return &Literal{BaseNode: fromBase(n, "Literal"), BigInt: &v, Raw: fromExtra(n.Extra)}
+	case *ast.DirectiveLiteral:
+		return &Literal{BaseNode: fromBase(n, "Literal"), Value: n.Value, Raw: fromExtra(n.Extra)}
+	case *ast.Directive:
+		s := &ExpressionStatement{BaseNode: fromBase(n, "ExpressionStatement")}
+		if n.Value != nil {
+			v := n.Value.Value
+			s.Expression, s.Directive = fromBabel(n.Value).(*Literal), &v
+		}
+		return s
+	case *ast.Program:
+		p := &Program{BaseNode: fromBase(n, "Program"), SourceType: n.SourceType}
+		if n.Body != nil || n.Directives != nil {
+			p.Body = make([]StatementOrModuleDeclaration, 0, len(n.Directives)+len(n.Body))
+		}
+		for _, d := range n.Directives {
+			p.Body = append(p.Body, fromBabel(d).(*ExpressionStatement))
+		}
+		var body []StatementOrModuleDeclaration
+		set(&body, n.Body, "Program.body")
+		p.Body = append(p.Body, body...)
+		return p
+	case *ast.BlockStatement:
+		b := &BlockStatement{BaseNode: fromBase(n, "BlockStatement")}
+		if n.Body != nil || n.Directives != nil {
+			b.Body = make([]Statement, 0, len(n.Directives)+len(n.Body))
+		}
+		for _, d := range n.Directives {
+			b.Body = append(b.Body, fromBabel(d).(*ExpressionStatement))
+		}
+		var body []Statement
+		set(&body, n.Body, "BlockStatement.body")
+		b.Body = append(b.Body, body...)
+		return b
+	case *ast.PrivateName:
+		p := &PrivateIdentifier{BaseNode: fromBase(n, "PrivateIdentifier")}
+		if n.ID != nil {
+			p.Name = n.ID.Name
+		}
+		return p
+	case *ast.TemplateElement:
+		return &TemplateElement{BaseNode: fromBase(n, "TemplateElement"), Tail: n.Tail, Value: TemplateValue{Cooked: copyString(n.Cooked), Raw: n.Raw}}
+	case *ast.ObjectProperty:
+		if len(n.Decorators) > 0 {
+			fail("ObjectProperty.decorators has no equivalent in ESTree")
+		}
+		p := &Property{BaseNode: fromBase(n, "Property"), Kind: "init", Computed: n.Computed, Shorthand: n.Shorthand}
+		set(&p.Key, n.Key, "ObjectProperty.key")
+		set(&p.Value, n.Value, "ObjectProperty.value")
+		return p
+	case *ast.AssignmentProperty:
+		if len(n.Decorators) > 0 {
+			fail("ObjectProperty.decorators has no equivalent in ESTree")
+		}
+		p := &Property{BaseNode: fromBase(n, "Property"), Kind: "init", Computed: n.Computed, Shorthand: n.Shorthand}
+		set(&p.Key, n.Key, "ObjectProperty.key")
+		set(&p.Value, n.Value, "ObjectProperty.value")
+		return p
+	case *ast.ObjectMethod:
+		if len(n.Decorators) > 0 {
+			fail("ObjectMethod.decorators has no equivalent in ESTree")
+		}
+		if !missing(n.Value) {
+			fail("ObjectMethod.value has no equivalent in ESTree")
+		}
+		p := &Property{BaseNode: fromBase(n, "Property"), Kind: n.Kind, Method: n.Kind == "method", Computed: n.Computed}
+		if p.Method {
+			p.Kind = "init"
+		}
+		f := &FunctionExpression{BaseNode: fromSpan(n.FunctionSpan, "FunctionExpression"), Generator: n.Generator, Async: n.Async}
+		set(&f.ID, n.ID, "ObjectMethod.id")
+		set(&f.Params, n.Params, "ObjectMethod.params")
+		set(&f.Body, n.Body, "ObjectMethod.body")
+		set(&p.Key, n.Key, "ObjectMethod.key")
+		p.Value = f
+		return p
+	case *ast.SpreadProperty:
+		s := &SpreadElement{BaseNode: fromBase(n, "SpreadElement")}
+		set(&s.Argument, n.Argument, "SpreadProperty.argument")
+		return s
+	case *ast.RestProperty:
+		r := &RestElement{BaseNode: fromBase(n, "RestElement")}
+		set(&r.Argument, n.Argument, "RestProperty.argument")
+		return r
+	case *ast.ClassMethod:
+		m := &MethodDefinition{BaseNode: fromBase(n, "MethodDefinition"), Kind: n.Kind, Computed: n.Computed, Static: n.Static}
+		set(&m.Key, n.Key, "ClassMethod.key")
+		set(&m.Value, n.Value, "ClassMethod.value")
+		set(&m.Decorators, n.Decorators, "ClassMethod.decorators")
+		return m
+	case *ast.ClassPrivateMethod:
+		m := &MethodDefinition{BaseNode: fromBase(n, "MethodDefinition"), Kind: n.Kind, Static: n.Static}
+		set(&m.Key, n.Key, "ClassPrivateMethod.key")
+		set(&m.Value, n.Value, "ClassPrivateMethod.value")
+		set(&m.Decorators, n.Decorators, "ClassPrivateMethod.decorators")
+		return m
+	case *ast.ClassProperty:
+		p := &PropertyDefinition{BaseNode: fromBase(n, "PropertyDefinition"), Computed: n.Computed, Static: n.Static}
+		set(&p.Key, n.Key, "ClassProperty.key")
+		set(&p.Value, n.Value, "ClassProperty.value")
+		set(&p.Decorators, n.Decorators, "ClassProperty.decorators")
+		return p
+	case *ast.ClassPrivateProperty:
+		p := &PropertyDefinition{BaseNode: fromBase(n, "PropertyDefinition"), Static: n.Static}
+		set(&p.Key, n.Key, "ClassPrivateProperty.key")
+		set(&p.Value, n.Value, "ClassPrivateProperty.value")
+		set(&p.Decorators, n.Decorators, "ClassPrivateProperty.decorators")
+		return p
+	case *ast.OptionalMemberExpression, *ast.OptionalCallExpression:
+		c := &ChainExpression{BaseNode: fromSpan(chainSpan(n), "ChainExpression")}
+		c.Expression = fromChain(n)
+		return c
+	}
+
+	t := reflect.TypeOf(n).Elem()
+	types, ok := sameTypes[t.Name()]
+	if !ok || types[0] != t {
+		fail("%s has no equivalent in ESTree", t.Name())
+	}
+
+	d := reflect.New(types[1])
+	fields(d, reflect.ValueOf(n), "ESTree")
+	d.Elem().Field(0).Set(reflect.ValueOf(fromBase(n, types[1].Name())))
+
+	return d.Interface().(Node)
+}
+
+// fromChain converts the part of an optional chain that Babel builds out of
+// OptionalMemberExpressions and OptionalCallExpressions, which goes inside a
+// ChainExpression in ESTree.
+func fromChain(n ast.Node) CallExpressionOrMemberExpression {
+	switch n := n.(type) {
+	case *ast.OptionalMemberExpression:
+		m := &MemberExpression{BaseNode: fromBase(n, "MemberExpression"), Computed: n.Computed, Optional: n.Optional}
+		put(&m.Object, fromLink(n.Object), "OptionalMemberExpression.object")
+		set(&m.Property, n.Property, "OptionalMemberExpression.property")
+		return m
+	case *ast.OptionalCallExpression:
+		c := &CallExpression{BaseNode: fromBase(n, "CallExpression"), Optional: n.Optional}
+		put(&c.Callee, fromLink(n.Callee), "OptionalCallExpression.callee")
+		set(&c.Arguments, n.Arguments, "OptionalCallExpression.arguments")
+		return c
+	}
+
+	return nil
+}
+
+func fromLink(n ast.Expression) Node {
+	switch n.(type) {
+	case *ast.OptionalMemberExpression, *ast.OptionalCallExpression:
+		return fromChain(n)
+	}
+
+	return fromBabel(n)
+}
+
+func toBabel(n Node) ast.Node {
+	if missing(n) {
+		return nil
+	}
+
+	switch n := n.(type) {
+	case *Unknown:
+		return &ast.Unknown{BaseNode: toBase(n, n.Type), Raw: append(n.Raw[:0:0], n.Raw...)}
+	case *Literal:
+		return toLiteral(n)
+	case *Program:
+		p := &ast.Program{BaseNode: toBase(n, "Program"), SourceType: n.SourceType}
+		body := n.Body
+		for len(body) > 0 && isDirective(body[0]) {
+			p.Directives = append(p.Directives, toDirective(body[0].(*ExpressionStatement)))
+			body = body[1:]
+		}
+		set(&p.Body, body, "Program.body")
+		return p
+	case *BlockStatement:
+		b := &ast.BlockStatement{BaseNode: toBase(n, "BlockStatement")}
+		body := n.Body
+		for len(body) > 0 && isDirective(body[0]) {
+			b.Directives = append(b.Directives, toDirective(body[0].(*ExpressionStatement)))
+			body = body[1:]
+		}
+		set(&b.Body, body, "BlockStatement.body")
+		return b
+	case *PrivateIdentifier:
+		id := &ast.Identifier{BaseNode: ast.BaseNode{Type: "Identifier", Start: n.Start + 1, End: n.End, Loc: privateLoc(n.Loc)}, Name: n.Name}
+		return &ast.PrivateName{BaseNode: toBase(n, "PrivateName"), ID: id}
+	case *TemplateElement:
+		return &ast.TemplateElement{BaseNode: toBase(n, "TemplateElement"), Tail: n.Tail, Cooked: copyString(n.Value.Cooked), Raw: n.Value.Raw}
+	case *ObjectExpression:
+		o := &ast.ObjectExpression{BaseNode: toBase(n, "ObjectExpression")}
+		if n.Properties != nil {
+			o.Properties = make([]ast.ObjectPropertyOrObjectMethodOrSpreadProperty, len(n.Properties))
+		}
+		for i, p := range n.Properties {
+			switch p := p.(type) {
+			case *Property:
+				o.Properties[i] = toObjectMember(p)
+			case *SpreadElement:
+				s := &ast.SpreadProperty{BaseNode: toBase(p, "SpreadProperty")}
+				set(&s.Argument, p.Argument, "SpreadElement.argument")
+				o.Properties[i] = s
+			}
+		}
+		return o
+	case *ObjectPattern:
+		o := &ast.ObjectPattern{BaseNode: toBase(n, "ObjectPattern")}
+		if n.Properties != nil {
+			o.Properties = make([]ast.AssignmentPropertyOrRestProperty, len(n.Properties))
+		}
+		for i, p := range n.Properties {
+			switch p := p.(type) {
+			case *Property:
+				if p.Kind != "init" || p.Method {
+					fail("a %s Property can't be used as ObjectPattern.properties", p.Kind)
+				}
+				a := &ast.AssignmentProperty{BaseNode: toBase(p, "ObjectProperty"), Computed: p.Computed, Shorthand: p.Shorthand}
+				set(&a.Key, p.Key, "Property.key")
+				set(&a.Value, p.Value, "Property.value")
+				o.Properties[i] = a
+			case *RestElement:
+				r := &ast.RestProperty{BaseNode: toBase(p, "RestProperty")}
+				set(&r.Argument, p.Argument, "RestElement.argument")
+				o.Properties[i] = r
+			}
+		}
+		return o
+	case *Property:
+		fail("a Property has to be in an ObjectExpression or an ObjectPattern")
+	case *ChainExpression:
+		c := toChain(n.Expression)
+		s := toSpan(n)
+		if s == nil {
+			s = &ast.Span{}
+		}
+		if !reflect.DeepEqual(s, defaultChainSpan(c)) {
+			switch c := c.(type) {
+			case *ast.OptionalMemberExpression:
+				c.ChainSpan = s
+			case *ast.OptionalCallExpression:
+				c.ChainSpan = s
+			default:
+				fail("a ChainExpression has to have an optional member or call in it")
+			}
+		}
+		return c
+	case *MethodDefinition:
+		if p, ok := n.Key.(*PrivateIdentifier); ok {
+			if n.Computed {
+				fail("a computed MethodDefinition can't have a PrivateIdentifier key")
+			}
+			m := &ast.ClassPrivateMethod{BaseNode: toBase(n, "ClassPrivateMethod"), Kind: n.Kind, Static: n.Static}
+			m.Key = toBabel(p).(*ast.PrivateName)
+			set(&m.Value, n.Value, "MethodDefinition.value")
+			set(&m.Decorators, n.Decorators, "MethodDefinition.decorators")
+			return m
+		}
+		m := &ast.ClassMethod{BaseNode: toBase(n, "ClassMethod"), Kind: n.Kind, Computed: n.Computed, Static: n.Static}
+		set(&m.Key, n.Key, "MethodDefinition.key")
+		set(&m.Value, n.Value, "MethodDefinition.value")
+		set(&m.Decorators, n.Decorators, "MethodDefinition.decorators")
+		return m
+	case *PropertyDefinition:
+		if p, ok := n.Key.(*PrivateIdentifier); ok {
+			if n.Computed {
+				fail("a computed PropertyDefinition can't have a PrivateIdentifier key")
+			}
+			c := &ast.ClassPrivateProperty{BaseNode: toBase(n, "ClassPrivateProperty"), Static: n.Static}
+			c.Key = toBabel(p).(*ast.PrivateName)
+			set(&c.Value, n.Value, "PropertyDefinition.value")
+			set(&c.Decorators, n.Decorators, "PropertyDefinition.decorators")
+			return c
+		}
+		c := &ast.ClassProperty{BaseNode: toBase(n, "ClassProperty"), Computed: n.Computed, Static: n.Static}
+		set(&c.Key, n.Key, "PropertyDefinition.key")
+		set(&c.Value, n.Value, "PropertyDefinition.value")
+		set(&c.Decorators, n.Decorators, "PropertyDefinition.decorators")
+		return c
+	}
+
+	t := reflect.TypeOf(n).Elem()
+	types, ok := sameTypes[t.Name()]
+	if !ok || types[1] != t {
+		fail("%s has no equivalent in Babel's AST", t.Name())
+	}
+
+	d := reflect.New(types[0])
+	fields(d, reflect.ValueOf(n), "Babel's AST")
+	d.Elem().Field(0).Set(reflect.ValueOf(toBase(n, types[0].Name())))
+
+	return d.Interface().(ast.Node)
+}
+
+func toLiteral(n *Literal) ast.Node {
+	switch {
+	case n.Regex != nil:
+		return &ast.RegExpLiteral{BaseNode: toBase(n, "RegExpLiteral"), Pattern: n.Regex.Pattern, Flags: n.Regex.Flags, Extra: toExtra(n.Raw, nil)}
+	case n.BigInt != nil:
+		return &ast.BigIntLiteral{BaseNode: toBase(n, "BigIntLiteral"), Value: *n.BigInt, Extra: toExtra(n.Raw, *n.BigInt)}
+	}
+
+	switch v := n.Value.(type) {
+	case nil:
+		return &ast.NullLiteral{BaseNode: toBase(n, "NullLiteral"), Extra: toExtra(n.Raw, nil)}
+	case string:
+		return &ast.StringLiteral{BaseNode: toBase(n, "StringLiteral"), Value: v, Extra: toExtra(n.Raw, v)}
+	case bool:
+		return &ast.BooleanLiteral{BaseNode: toBase(n, "BooleanLiteral"), Value: v, Extra: toExtra(n.Raw, v)}
+	case float64:
+		return &ast.NumericLiteral{BaseNode: toBase(n, "NumericLiteral"), Value: v, Extra: toExtra(n.Raw, v)}
+	case int:
+		return &ast.NumericLiteral{BaseNode: toBase(n, "NumericLiteral"), Value: float64(v), Extra: toExtra(n.Raw, float64(v))}
+	}
+
+	fail("a Literal can't have a value of type %T", n.Value)
+
+	return nil
+}
+
+// fromExtra returns the raw text of a Babel literal, which ESTree keeps on the
+// Literal itself.
+func fromExtra(e *ast.Extra) *string {
+	if e == nil {
+		return nil
+	}
+
+	raw := e.Raw
+
+	return &raw
+}
+
+// toExtra returns the Extra for a literal written as raw.
+func toExtra(raw *string, rawValue interface{}) *ast.Extra {
+	if raw == nil {
+		return nil
+	}
+
+	return &ast.Extra{Raw: *raw, RawValue: rawValue}
+}
+
+func isDirective(n Node) bool {
+	s, ok := n.(*ExpressionStatement)
+	if !ok || s.Directive == nil {
+		return false
+	}
+
+	l, ok := s.Expression.(*Literal)
+	if !ok {
+		return false
+	}
+
+	_, ok = l.Value.(string)
+
+	return ok
+}
+
+func toDirective(s *ExpressionStatement) *ast.Directive {
+	l := s.Expression.(*Literal)
+
+	v := &ast.DirectiveLiteral{BaseNode: toBase(l, "DirectiveLiteral"), Value: *s.Directive, Extra: toExtra(l.Raw, *s.Directive)}
+
+	return &ast.Directive{BaseNode: toBase(s, "Directive"), Value: v}
+}
+
+func toObjectMember(p *Property) ast.ObjectPropertyOrObjectMethodOrSpreadProperty {
+	if !p.Method && p.Kind == "init" {
+		o := &ast.ObjectProperty{BaseNode: toBase(p, "ObjectProperty"), Computed: p.Computed, Shorthand: p.Shorthand}
+		set(&o.Key, p.Key, "Property.key")
+		set(&o.Value, p.Value, "Property.value")
+		return o
+	}
+
+	f, ok := p.Value.(*FunctionExpression)
+	if !ok {
+		fail("a method Property has to have a FunctionExpression value")
+	}
+
+	m := &ast.ObjectMethod{BaseNode: toBase(p, "ObjectMethod"), Kind: p.Kind, Computed: p.Computed, Generator: f.Generator, Async: f.Async}
+	if p.Method {
+		m.Kind = "method"
+	}
+	m.FunctionSpan = toSpan(f)
+	set(&m.Key, p.Key, "Property.key")
+	set(&m.ID, f.ID, "FunctionExpression.id")
+	set(&m.Params, f.Params, "FunctionExpression.params")
+	set(&m.Body, f.Body, "FunctionExpression.body")
+
+	return m
+}
+
+// toChain converts the inside of a ChainExpression. The members and calls from
+// the outside of the chain down to the last optional one become Babel's
+// OptionalMemberExpressions and OptionalCallExpressions.
+func toChain(n Node) ast.Node {
+	if !optional(n) {
+		return toBabel(n)
+	}
+
+	switch n := n.(type) {
+	case *MemberExpression:
+		m := &ast.OptionalMemberExpression{BaseNode: toBase(n, "OptionalMemberExpression"), Computed: n.Computed, Optional: n.Optional}
+		put(&m.Object, toChain(n.Object), "MemberExpression.object")
+		set(&m.Property, n.Property, "MemberExpression.property")
+		return m
+	case *CallExpression:
+		c := &ast.OptionalCallExpression{BaseNode: toBase(n, "OptionalCallExpression"), Optional: n.Optional}
+		put(&c.Callee, toChain(n.Callee), "CallExpression.callee")
+		set(&c.Arguments, n.Arguments, "CallExpression.arguments")
+		return c
+	}
+
+	return toBabel(n)
+}
+
+// optional reports whether a member or call, or one of the members and calls
+// it's made from, is optional.
+func optional(n Node) bool {
+	for {
+		switch m := n.(type) {
+		case *MemberExpression:
+			if m.Optional {
+				return true
+			}
+			n = m.Object
+		case *CallExpression:
+			if m.Optional {
+				return true
+			}
+			n = m.Callee
+		default:
+			return false
+		}
+	}
+}
+
+// chainSpan returns where the ChainExpression around an optional chain is,
+// which is where the chain is unless ToBabel kept something else.
+func chainSpan(n ast.Node) *ast.Span {
+	var s *ast.Span
+	switch n := n.(type) {
+	case *ast.OptionalMemberExpression:
+		s = n.ChainSpan
+	case *ast.OptionalCallExpression:
+		s = n.ChainSpan
+	}
+
+	if s != nil {
+		return s
+	}
+
+	return defaultChainSpan(n)
+}
+
+// defaultChainSpan returns the span FromBabel gives a ChainExpression around
+// n when n has no chainSpan.
+func defaultChainSpan(n ast.Node) *ast.Span {
+	if missing(n) {
+		return &ast.Span{}
+	}
+
+	b := n.Base()
+
+	return &ast.Span{Start: b.Start, End: b.End, Loc: b.Loc}
+}
+
+func fromSpan(s *ast.Span, typ string) BaseNode {
+	if s == nil {
+		return BaseNode{Type: typ}
+	}
+
+	return BaseNode{
+		Type:             typ,
+		Start:            s.Start,
+		End:              s.End,
+		Loc:              fromLoc(s.Loc),
+		LeadingComments:  fromComments(s.LeadingComments),
+		TrailingComments: fromComments(s.TrailingComments),
+		InnerComments:    fromComments(s.InnerComments),
+	}
+}
+
+// toSpan keeps the position and comments of an ESTree node that Babel's
+// trees don't have, or returns nil if it has none.
+func toSpan(n Node) *ast.Span {
+	b := toBase(n, "")
+	if reflect.DeepEqual(b, ast.BaseNode{}) {
+		return nil
+	}
+
+	return &ast.Span{
+		Start:            b.Start,
+		End:              b.End,
+		Loc:              b.Loc,
+		LeadingComments:  b.LeadingComments,
+		TrailingComments: b.TrailingComments,
+		InnerComments:    b.InnerComments,
+	}
+}
+
+func fromBase(n ast.Node, typ string) BaseNode {
+	b := n.Base()
+
+	return BaseNode{
+		Type:             typ,
+		Start:            b.Start,
+		End:              b.End,
+		Loc:              fromLoc(b.Loc),
+		LeadingComments:  fromComments(b.LeadingComments),
+		TrailingComments: fromComments(b.TrailingComments),
+		InnerComments:    fromComments(b.InnerComments),
+	}
+}
+
+func fromLoc(l *ast.SourceLocation) *SourceLocation {
+	if l == nil {
+		return nil
+	}
+
+	return &SourceLocation{
+		Source: copyString(l.Source),
+		Start:  Position{Line: l.Start.Line, Column: l.Start.Column},
+		End:    Position{Line: l.End.Line, Column: l.End.Column},
+	}
+}
+
+func fromComments(c []ast.Comment) []Comment {
+	if c == nil {
+		return nil
+	}
+
+	r := make([]Comment, len(c))
+	for i, c := range c {
+		r[i] = Comment{Type: strings.TrimPrefix(c.Type, "Comment"), Value: c.Value, Start: c.Start, End: c.End, Loc: fromLoc(c.Loc)}
+	}
+
+	return r
+}
+
+func toBase(n Node, typ string) ast.BaseNode {
+	b := n.Base()
+
+	return ast.BaseNode{
+		Type:             typ,
+		Start:            b.Start,
+		End:              b.End,
+		Loc:              toLoc(b.Loc),
+		LeadingComments:  toComments(b.LeadingComments),
+		TrailingComments: toComments(b.TrailingComments),
+		InnerComments:    toComments(b.InnerComments),
+	}
+}
+
+func toLoc(l *SourceLocation) *ast.SourceLocation {
+	if l == nil {
+		return nil
+	}
+
+	return &ast.SourceLocation{
+		Source: copyString(l.Source),
+		Start:  ast.Position{Line: l.Start.Line, Column: l.Start.Column},
+		End:    ast.Position{Line: l.End.Line, Column: l.End.Column},
+	}
+}
+
+// privateLoc works out the location of the Identifier in a PrivateName, which
+// starts after the "#", as its Start does.
+func privateLoc(l *SourceLocation) *ast.SourceLocation {
+	r := toLoc(l)
+	if r != nil {
+		r.Start.Column++
+	}
+
+	return r
+}
+
+func toComments(c []Comment) []ast.Comment {
+	if c == nil {
+		return nil
+	}
+
+	r := make([]ast.Comment, len(c))
+	for i, c := range c {
+		typ := c.Type
+		if typ == "Block" || typ == "Line" {
+			typ = "Comment" + typ
+		}
+		r[i] = ast.Comment{Type: typ, Value: c.Value, Start: c.Start, End: c.End, Loc: toLoc(c.Loc)}
+	}
+
+	return r
+}
+
+func copyString(s *string) *string {
+	if s == nil {
+		return nil
+	}
+
+	v := *s
+
+	return &v
+}
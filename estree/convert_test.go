@@ -0,0 +1,214 @@
+package estree
+
+import (
+	"encoding/json"
+	"flag"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"fknsrs.biz/p/jsparser/ast"
+)
+
+var update = flag.Bool("update", false, "Rewrite the golden files in testdata")
+
+// babel is the tree Babel gives for this program:
+//
+//	"use strict";
+//	class A { #x = 1; static #m() {} get y() {} z; @dec static [k] = 2; static {} }
+//	let o = { a, b: null, m() {}, set s(v) {}, ...r };
+//	let { c, ...d } = o;
+//	a?.b.c(1n)?.[d];
+//	(a?.b).c;
+//	`x${/y/g}`;
+//	String.raw`\u`;
+const babel = `{
+  "type": "Program",
+  "sourceType": "script",
+  "directives": [
+    {"type": "Directive", "value": {"type": "DirectiveLiteral", "value": "use strict", "extra": {"raw": "\"use strict\"", "rawValue": "use strict"}}}
+  ],
+  "body": [
+    {"type": "ClassDeclaration", "id": {"type": "Identifier", "name": "A"}, "superClass": null, "body": {"type": "ClassBody", "body": [
+      {"type": "ClassPrivateProperty", "static": false,
+        "key": {"type": "PrivateName", "start": 24, "end": 26, "loc": {"source": null, "start": {"line": 2, "column": 10}, "end": {"line": 2, "column": 12}},
+          "id": {"type": "Identifier", "name": "x", "start": 25, "end": 26, "loc": {"source": null, "start": {"line": 2, "column": 11}, "end": {"line": 2, "column": 12}}}},
+        "value": {"type": "NumericLiteral", "value": 1, "extra": {"raw": "1", "rawValue": 1}}},
+      {"type": "ClassPrivateMethod", "kind": "method", "static": true,
+        "key": {"type": "PrivateName", "start": 39, "end": 41, "id": {"type": "Identifier", "name": "m", "start": 40, "end": 41}},
+        "value": {"type": "FunctionExpression", "id": null, "params": [], "body": {"type": "BlockStatement", "body": [], "directives": []}, "generator": false, "async": false}},
+      {"type": "ClassMethod", "kind": "get", "computed": false, "static": false,
+        "key": {"type": "Identifier", "name": "y"},
+        "value": {"type": "FunctionExpression", "id": null, "params": [], "body": {"type": "BlockStatement", "body": [], "directives": []}, "generator": false, "async": false}},
+      {"type": "ClassProperty", "key": {"type": "Identifier", "name": "z"}, "value": null},
+      {"type": "ClassProperty", "computed": true, "static": true, "key": {"type": "Identifier", "name": "k"}, "value": {"type": "NumericLiteral", "value": 2},
+        "decorators": [{"type": "Decorator", "expression": {"type": "Identifier", "name": "dec"}}]},
+      {"type": "StaticBlock", "body": []}
+    ]}},
+    {"type": "VariableDeclaration", "kind": "let", "declarations": [
+      {"type": "VariableDeclarator", "id": {"type": "Identifier", "name": "o"}, "init": {"type": "ObjectExpression", "properties": [
+        {"type": "ObjectProperty", "key": {"type": "Identifier", "name": "a"}, "value": {"type": "Identifier", "name": "a"}, "computed": false, "shorthand": true},
+        {"type": "ObjectProperty", "key": {"type": "Identifier", "name": "b"}, "value": {"type": "NullLiteral"}, "computed": false, "shorthand": false},
+        {"type": "ObjectMethod", "kind": "method", "key": {"type": "Identifier", "name": "m"}, "computed": false, "id": null, "params": [],
+          "body": {"type": "BlockStatement", "body": [], "directives": []}, "generator": false, "async": false},
+        {"type": "ObjectMethod", "kind": "set", "key": {"type": "Identifier", "name": "s"}, "computed": false, "id": null, "params": [{"type": "Identifier", "name": "v"}],
+          "body": {"type": "BlockStatement", "body": [], "directives": []}, "generator": false, "async": false},
+        {"type": "SpreadProperty", "argument": {"type": "Identifier", "name": "r"}}
+      ]}}
+    ]},
+    {"type": "VariableDeclaration", "kind": "let", "declarations": [
+      {"type": "VariableDeclarator", "id": {"type": "ObjectPattern", "properties": [
+        {"type": "ObjectProperty", "key": {"type": "Identifier", "name": "c"}, "value": {"type": "Identifier", "name": "c"}, "computed": false, "shorthand": true},
+        {"type": "RestProperty", "argument": {"type": "Identifier", "name": "d"}}
+      ]}, "init": {"type": "Identifier", "name": "o"}}
+    ]},
+    {"type": "ExpressionStatement", "expression": {"type": "OptionalMemberExpression", "computed": true, "optional": true,
+      "object": {"type": "OptionalCallExpression", "optional": false, "arguments": [{"type": "BigIntLiteral", "value": "1", "extra": {"raw": "1n", "rawValue": "1"}}],
+        "callee": {"type": "OptionalMemberExpression", "computed": false, "optional": false, "property": {"type": "Identifier", "name": "c"},
+          "object": {"type": "OptionalMemberExpression", "computed": false, "optional": true, "object": {"type": "Identifier", "name": "a"}, "property": {"type": "Identifier", "name": "b"}}}},
+      "property": {"type": "Identifier", "name": "d"}}},
+    {"type": "ExpressionStatement", "expression": {"type": "MemberExpression", "computed": false, "property": {"type": "Identifier", "name": "c"},
+      "object": {"type": "OptionalMemberExpression", "computed": false, "optional": true, "object": {"type": "Identifier", "name": "a"}, "property": {"type": "Identifier", "name": "b"}}}},
+    {"type": "ExpressionStatement", "expression": {"type": "TemplateLiteral",
+      "quasis": [{"type": "TemplateElement", "tail": false, "cooked": "x", "raw": "x"}, {"type": "TemplateElement", "tail": true, "cooked": "", "raw": ""}],
+      "expressions": [{"type": "RegExpLiteral", "pattern": "y", "flags": "g"}]}},
+    {"type": "ExpressionStatement", "expression": {"type": "TaggedTemplateExpression",
+      "tag": {"type": "MemberExpression", "computed": false, "object": {"type": "Identifier", "name": "String"}, "property": {"type": "Identifier", "name": "raw"}},
+      "quasi": {"type": "TemplateLiteral", "quasis": [{"type": "TemplateElement", "tail": true, "cooked": null, "raw": "\\u"}], "expressions": []}}}
+  ]
+}`
+
+func TestConvert(t *testing.T) {
+	a := assert.New(t)
+
+	b, err := ast.UnmarshalNode([]byte(babel))
+	if !a.NoError(err) {
+		return
+	}
+
+	e, err := FromBabel(b)
+	if !a.NoError(err) {
+		return
+	}
+
+	out, err := json.MarshalIndent(e, "", "  ")
+	if !a.NoError(err) {
+		return
+	}
+
+	out = append(out, '\n')
+
+	p := filepath.Join("testdata", "convert.json")
+
+	if *update {
+		a.NoError(ioutil.WriteFile(p, out, 0644))
+	} else if expected, err := ioutil.ReadFile(p); a.NoError(err) {
+		a.Equal(string(expected), string(out))
+	}
+
+	e, err = UnmarshalNode(out)
+	if !a.NoError(err) {
+		return
+	}
+
+	back, err := ToBabel(e)
+	if a.NoError(err) {
+		a.Nil(ast.Diff(b, back))
+	}
+}
+
+// TestConvertFromESTree checks that what ESTree has and Babel doesn't, like
+// the raw text of literals and the positions of a ChainExpression and of a
+// method's FunctionExpression, survives a trip through Babel's AST.
+func TestConvertFromESTree(t *testing.T) {
+	a := assert.New(t)
+
+	loc := func(start, end int) *SourceLocation {
+		return &SourceLocation{Start: Position{Line: 1, Column: start}, End: Position{Line: 1, Column: end}}
+	}
+
+	base := func(start, end int) BaseNode {
+		return BaseNode{Start: start, End: end, Loc: loc(start, end)}
+	}
+
+	raw := func(s string) *string { return &s }
+
+	// ({ m() {} }, /* c */ a?.b, 'x', 0x10, true, null, tag`\u`);
+	e := &Program{SourceType: "script", Body: []StatementOrModuleDeclaration{
+		&ExpressionStatement{BaseNode: base(0, 54), Expression: &SequenceExpression{BaseNode: base(1, 52), Expressions: []Expression{
+			&ObjectExpression{BaseNode: base(1, 12), Properties: []PropertyOrSpreadElement{
+				&Property{BaseNode: base(3, 10), Key: &Identifier{BaseNode: base(3, 4), Name: "m"}, Kind: "init", Method: true,
+					Value: &FunctionExpression{BaseNode: base(4, 10), Params: []Pattern{}, Body: &BlockStatement{BaseNode: base(8, 10), Body: []Statement{}}}},
+			}},
+			&ChainExpression{
+				BaseNode:   BaseNode{Start: 14, End: 26, Loc: loc(14, 26), LeadingComments: []Comment{{Type: "Block", Value: " c ", Start: 14, End: 21, Loc: loc(14, 21)}}},
+				Expression: &MemberExpression{BaseNode: base(22, 26), Object: &Identifier{BaseNode: base(22, 23), Name: "a"}, Property: &Identifier{BaseNode: base(25, 26), Name: "b"}, Optional: true},
+			},
+			&Literal{BaseNode: base(28, 31), Value: "x", Raw: raw("'x'")},
+			&Literal{BaseNode: base(33, 37), Value: 16.0, Raw: raw("0x10")},
+			&Literal{BaseNode: base(39, 43), Value: true, Raw: raw("true")},
+			&TaggedTemplateExpression{BaseNode: base(45, 52), Tag: &Identifier{BaseNode: base(45, 48), Name: "tag"}, Quasi: &TemplateLiteral{BaseNode: base(48, 52),
+				Quasis:      []*TemplateElement{{BaseNode: base(49, 51), Tail: true, Value: TemplateValue{Raw: "\\u"}}},
+				Expressions: []Expression{},
+			}},
+		}}},
+	}}
+
+	b, err := ToBabel(e)
+	if !a.NoError(err) {
+		return
+	}
+
+	back, err := FromBabel(b)
+	if !a.NoError(err) {
+		return
+	}
+
+	want, err := json.Marshal(e)
+	if !a.NoError(err) {
+		return
+	}
+
+	got, err := json.Marshal(back)
+	if a.NoError(err) {
+		a.JSONEq(string(want), string(got))
+	}
+
+	// A ChainExpression that's just where its chain is, as FromBabel makes
+	// them, doesn't need a chainSpan.
+	b, err = ToBabel(&ChainExpression{BaseNode: base(0, 4), Expression: &MemberExpression{BaseNode: base(0, 4), Object: &Identifier{Name: "a"}, Property: &Identifier{Name: "b"}, Optional: true}})
+	if a.NoError(err) {
+		a.Nil(b.(*ast.OptionalMemberExpression).ChainSpan)
+	}
+}
+
+func TestConvertErrors(t *testing.T) {
+	a := assert.New(t)
+
+	_, err := FromBabel(&ast.BindExpression{Callee: []ast.Expression{&ast.Identifier{Name: "f"}}})
+	a.EqualError(err, "estree: BindExpression has no equivalent in ESTree")
+
+	_, err = FromBabel(&ast.ObjectProperty{
+		Key:        &ast.Identifier{Name: "a"},
+		Value:      &ast.Identifier{Name: "a"},
+		Decorators: []*ast.Decorator{{Expression: &ast.Identifier{Name: "d"}}},
+	})
+	a.EqualError(err, "estree: ObjectProperty.decorators has no equivalent in ESTree")
+
+	_, err = ToBabel(&MemberExpression{Object: &Identifier{Name: "a"}, Property: &Identifier{Name: "b"}, Optional: true})
+	a.EqualError(err, "estree: MemberExpression.optional has no equivalent in Babel's AST")
+
+	_, err = ToBabel(&ExportAllDeclaration{Exported: &Identifier{Name: "a"}, Source: &Literal{Value: "m"}})
+	a.EqualError(err, "estree: ExportAllDeclaration.exported has no equivalent in Babel's AST")
+
+	_, err = ToBabel(&Property{Key: &Identifier{Name: "a"}, Value: &Identifier{Name: "a"}, Kind: "init"})
+	a.EqualError(err, "estree: a Property has to be in an ObjectExpression or an ObjectPattern")
+
+	_, err = ToBabel(&ImportAttribute{Key: &Identifier{Name: "type"}, Value: &Literal{Value: true}})
+	a.EqualError(err, "estree: a BooleanLiteral can't be used as ImportAttribute.value")
+
+	_, err = ToBabel(&ChainExpression{BaseNode: BaseNode{Start: 1, End: 2}, Expression: &MemberExpression{Object: &Identifier{Name: "a"}, Property: &Identifier{Name: "b"}}})
+	a.EqualError(err, "estree: a ChainExpression has to have an optional member or call in it")
+}
@@ -0,0 +1,10 @@
+// Package estree declares the types used to represent syntax trees for
+// JavaScript in the ESTree dialect, which tools like acorn and ESLint use,
+// following the specification in spec.md. It converts trees to and from the
+// Babel dialect of the ast package.
+//
+// The types, in estree.go, are generated from the specification by the ast
+// package's generator.
+package estree
+
+//go:generate go run ../ast/generator -package estree -core -o estree.go spec.md
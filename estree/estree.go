@@ -0,0 +1,4028 @@
+// Code generated by ast/generator from spec.md; DO NOT EDIT.
+
+package estree
+
+import "encoding/json"
+
+type UnaryOperator string
+
+const (
+	UnaryOperatorMinus  UnaryOperator = "-"
+	UnaryOperatorPlus   UnaryOperator = "+"
+	UnaryOperatorBang   UnaryOperator = "!"
+	UnaryOperatorTilde  UnaryOperator = "~"
+	UnaryOperatorTypeof UnaryOperator = "typeof"
+	UnaryOperatorVoid   UnaryOperator = "void"
+	UnaryOperatorDelete UnaryOperator = "delete"
+)
+
+func (v UnaryOperator) Valid() bool {
+	return v == UnaryOperatorMinus || v == UnaryOperatorPlus || v == UnaryOperatorBang || v == UnaryOperatorTilde || v == UnaryOperatorTypeof || v == UnaryOperatorVoid || v == UnaryOperatorDelete
+}
+
+type UpdateOperator string
+
+const (
+	UpdateOperatorIncrement UpdateOperator = "++"
+	UpdateOperatorDecrement UpdateOperator = "--"
+)
+
+func (v UpdateOperator) Valid() bool {
+	return v == UpdateOperatorIncrement || v == UpdateOperatorDecrement
+}
+
+type BinaryOperator string
+
+const (
+	BinaryOperatorEqual            BinaryOperator = "=="
+	BinaryOperatorNotEqual         BinaryOperator = "!="
+	BinaryOperatorStrictEqual      BinaryOperator = "==="
+	BinaryOperatorStrictNotEqual   BinaryOperator = "!=="
+	BinaryOperatorLess             BinaryOperator = "<"
+	BinaryOperatorLessOrEqual      BinaryOperator = "<="
+	BinaryOperatorGreater          BinaryOperator = ">"
+	BinaryOperatorGreaterOrEqual   BinaryOperator = ">="
+	BinaryOperatorShiftLeft        BinaryOperator = "<<"
+	BinaryOperatorShiftRight       BinaryOperator = ">>"
+	BinaryOperatorShiftRightSigned BinaryOperator = ">>>"
+	BinaryOperatorPlus             BinaryOperator = "+"
+	BinaryOperatorMinus            BinaryOperator = "-"
+	BinaryOperatorMultiply         BinaryOperator = "*"
+	BinaryOperatorDivide           BinaryOperator = "/"
+	BinaryOperatorModulo           BinaryOperator = "%"
+	BinaryOperatorOr               BinaryOperator = "|"
+	BinaryOperatorXor              BinaryOperator = "^"
+	BinaryOperatorAnd              BinaryOperator = "&"
+	BinaryOperatorIn               BinaryOperator = "in"
+	BinaryOperatorInstanceof       BinaryOperator = "instanceof"
+	BinaryOperatorExponent         BinaryOperator = "**"
+)
+
+func (v BinaryOperator) Valid() bool {
+	return v == BinaryOperatorEqual || v == BinaryOperatorNotEqual || v == BinaryOperatorStrictEqual || v == BinaryOperatorStrictNotEqual || v == BinaryOperatorLess || v == BinaryOperatorLessOrEqual || v == BinaryOperatorGreater || v == BinaryOperatorGreaterOrEqual || v == BinaryOperatorShiftLeft || v == BinaryOperatorShiftRight || v == BinaryOperatorShiftRightSigned || v == BinaryOperatorPlus || v == BinaryOperatorMinus || v == BinaryOperatorMultiply || v == BinaryOperatorDivide || v == BinaryOperatorModulo || v == BinaryOperatorOr || v == BinaryOperatorXor || v == BinaryOperatorAnd || v == BinaryOperatorIn || v == BinaryOperatorInstanceof || v == BinaryOperatorExponent
+}
+
+type AssignmentOperator string
+
+const (
+	AssignmentOperatorEquals           AssignmentOperator = "="
+	AssignmentOperatorAdd              AssignmentOperator = "+="
+	AssignmentOperatorSubtract         AssignmentOperator = "-="
+	AssignmentOperatorMultiply         AssignmentOperator = "*="
+	AssignmentOperatorDivide           AssignmentOperator = "/="
+	AssignmentOperatorModulo           AssignmentOperator = "%="
+	AssignmentOperatorShiftLeft        AssignmentOperator = "<<="
+	AssignmentOperatorShiftRight       AssignmentOperator = ">>="
+	AssignmentOperatorShiftRightSigned AssignmentOperator = ">>>="
+	AssignmentOperatorOr               AssignmentOperator = "|="
+	AssignmentOperatorXor              AssignmentOperator = "^="
+	AssignmentOperatorAnd              AssignmentOperator = "&="
+	AssignmentOperatorExponent         AssignmentOperator = "**="
+	AssignmentOperatorLogicalOr        AssignmentOperator = "||="
+	AssignmentOperatorLogicalAnd       AssignmentOperator = "&&="
+	AssignmentOperatorNullish          AssignmentOperator = "??="
+)
+
+func (v AssignmentOperator) Valid() bool {
+	return v == AssignmentOperatorEquals || v == AssignmentOperatorAdd || v == AssignmentOperatorSubtract || v == AssignmentOperatorMultiply || v == AssignmentOperatorDivide || v == AssignmentOperatorModulo || v == AssignmentOperatorShiftLeft || v == AssignmentOperatorShiftRight || v == AssignmentOperatorShiftRightSigned || v == AssignmentOperatorOr || v == AssignmentOperatorXor || v == AssignmentOperatorAnd || v == AssignmentOperatorExponent || v == AssignmentOperatorLogicalOr || v == AssignmentOperatorLogicalAnd || v == AssignmentOperatorNullish
+}
+
+type LogicalOperator string
+
+const (
+	LogicalOperatorOr      LogicalOperator = "||"
+	LogicalOperatorAnd     LogicalOperator = "&&"
+	LogicalOperatorNullish LogicalOperator = "??"
+)
+
+func (v LogicalOperator) Valid() bool {
+	return v == LogicalOperatorOr || v == LogicalOperatorAnd || v == LogicalOperatorNullish
+}
+
+type Node interface {
+	Base() *BaseNode
+}
+
+type BaseNode struct {
+	Type             string          `json:"type"`
+	Start            int             `json:"start"`
+	End              int             `json:"end"`
+	Loc              *SourceLocation `json:"loc"`
+	LeadingComments  []Comment       `json:"leadingComments,omitempty"`
+	TrailingComments []Comment       `json:"trailingComments,omitempty"`
+	InnerComments    []Comment       `json:"innerComments,omitempty"`
+}
+
+func (n *BaseNode) Base() *BaseNode { return n }
+
+type SourceLocation struct {
+	Source *string  `json:"source"`
+	Start  Position `json:"start"`
+	End    Position `json:"end"`
+}
+
+type Position struct {
+	Line   int `json:"line"`
+	Column int `json:"column"`
+}
+
+type Comment struct {
+	Type  string          `json:"type"`
+	Value string          `json:"value"`
+	Start int             `json:"start"`
+	End   int             `json:"end"`
+	Loc   *SourceLocation `json:"loc"`
+}
+
+type Identifier struct {
+	BaseNode
+	Name string `json:"name"`
+}
+
+func (*Identifier) expressionNode()                    {}
+func (*Identifier) patternNode()                       {}
+func (*Identifier) isVariableDeclarationOrExpression() {}
+func (*Identifier) isVariableDeclarationOrPattern()    {}
+func (*Identifier) isBlockStatementOrExpression()      {}
+func (*Identifier) isExpressionOrSpreadElement()       {}
+func (*Identifier) isExpressionOrPattern()             {}
+func (*Identifier) isExpressionOrPrivateIdentifier()   {}
+func (*Identifier) isPatternOrExpression()             {}
+func (*Identifier) isExpressionOrSuper()               {}
+func (*Identifier) isIdentifierOrLiteral()             {}
+func (*Identifier) isDeclarationOrExpression()         {}
+
+func (n Identifier) MarshalJSON() ([]byte, error) {
+	type plain Identifier
+	v := plain(n)
+	v.Type = "Identifier"
+	return json.Marshal(v)
+}
+
+type PrivateIdentifier struct {
+	BaseNode
+	Name string `json:"name"`
+}
+
+func (*PrivateIdentifier) isExpressionOrPrivateIdentifier() {}
+
+func (n PrivateIdentifier) MarshalJSON() ([]byte, error) {
+	type plain PrivateIdentifier
+	v := plain(n)
+	v.Type = "PrivateIdentifier"
+	return json.Marshal(v)
+}
+
+type Literal struct {
+	BaseNode
+	Value  interface{} `json:"value"`
+	Raw    *string     `json:"raw"`
+	Regex  *RegExp     `json:"regex"`
+	BigInt *string     `json:"bigint"`
+}
+
+func (*Literal) expressionNode()                    {}
+func (*Literal) isVariableDeclarationOrExpression() {}
+func (*Literal) isBlockStatementOrExpression()      {}
+func (*Literal) isExpressionOrSpreadElement()       {}
+func (*Literal) isExpressionOrPattern()             {}
+func (*Literal) isExpressionOrPrivateIdentifier()   {}
+func (*Literal) isPatternOrExpression()             {}
+func (*Literal) isExpressionOrSuper()               {}
+func (*Literal) isIdentifierOrLiteral()             {}
+func (*Literal) isDeclarationOrExpression()         {}
+
+func (n Literal) MarshalJSON() ([]byte, error) {
+	type plain Literal
+	v := plain(n)
+	v.Type = "Literal"
+	return json.Marshal(v)
+}
+
+type RegExp struct {
+	Pattern string `json:"pattern"`
+	Flags   string `json:"flags"`
+}
+
+type StatementOrModuleDeclaration interface {
+	Node
+	isStatementOrModuleDeclaration()
+}
+
+type Program struct {
+	BaseNode
+	SourceType string                         `json:"sourceType"`
+	Body       []StatementOrModuleDeclaration `json:"body"`
+}
+
+func (n Program) MarshalJSON() ([]byte, error) {
+	type plain Program
+	v := plain(n)
+	v.Type = "Program"
+	if v.Body == nil {
+		v.Body = []StatementOrModuleDeclaration{}
+	}
+	return json.Marshal(v)
+}
+
+func (n *Program) UnmarshalJSON(b []byte) error {
+	type plain Program
+	var v struct {
+		*plain
+		Body []json.RawMessage `json:"body"`
+	}
+
+	v.plain = (*plain)(n)
+
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	if v.Body != nil {
+		n.Body = make([]StatementOrModuleDeclaration, len(v.Body))
+	}
+	for i, e := range v.Body {
+		x, err := decodeStatementOrModuleDeclaration(e)
+		if err != nil {
+			return err
+		}
+
+		n.Body[i] = x
+	}
+
+	return nil
+}
+
+type Function interface {
+	Node
+	functionNode()
+}
+
+type Statement interface {
+	Node
+	statementNode()
+}
+
+type ExpressionStatement struct {
+	BaseNode
+	Expression Expression `json:"expression"`
+	Directive  *string    `json:"directive"`
+}
+
+func (*ExpressionStatement) statementNode()                  {}
+func (*ExpressionStatement) isStatementOrModuleDeclaration() {}
+
+func (n ExpressionStatement) MarshalJSON() ([]byte, error) {
+	type plain ExpressionStatement
+	v := plain(n)
+	v.Type = "ExpressionStatement"
+	return json.Marshal(v)
+}
+
+func (n *ExpressionStatement) UnmarshalJSON(b []byte) error {
+	type plain ExpressionStatement
+	var v struct {
+		*plain
+		Expression json.RawMessage `json:"expression"`
+	}
+
+	v.plain = (*plain)(n)
+
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	if x, err := decodeExpression(v.Expression); err != nil {
+		return err
+	} else {
+		n.Expression = x
+	}
+
+	return nil
+}
+
+type BlockStatement struct {
+	BaseNode
+	Body []Statement `json:"body"`
+}
+
+func (*BlockStatement) statementNode()                  {}
+func (*BlockStatement) isStatementOrModuleDeclaration() {}
+func (*BlockStatement) isBlockStatementOrExpression()   {}
+
+func (n BlockStatement) MarshalJSON() ([]byte, error) {
+	type plain BlockStatement
+	v := plain(n)
+	v.Type = "BlockStatement"
+	if v.Body == nil {
+		v.Body = []Statement{}
+	}
+	return json.Marshal(v)
+}
+
+func (n *BlockStatement) UnmarshalJSON(b []byte) error {
+	type plain BlockStatement
+	var v struct {
+		*plain
+		Body []json.RawMessage `json:"body"`
+	}
+
+	v.plain = (*plain)(n)
+
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	if v.Body != nil {
+		n.Body = make([]Statement, len(v.Body))
+	}
+	for i, e := range v.Body {
+		x, err := decodeStatement(e)
+		if err != nil {
+			return err
+		}
+
+		n.Body[i] = x
+	}
+
+	return nil
+}
+
+type EmptyStatement struct {
+	BaseNode
+}
+
+func (*EmptyStatement) statementNode()                  {}
+func (*EmptyStatement) isStatementOrModuleDeclaration() {}
+
+func (n EmptyStatement) MarshalJSON() ([]byte, error) {
+	type plain EmptyStatement
+	v := plain(n)
+	v.Type = "EmptyStatement"
+	return json.Marshal(v)
+}
+
+type DebuggerStatement struct {
+	BaseNode
+}
+
+func (*DebuggerStatement) statementNode()                  {}
+func (*DebuggerStatement) isStatementOrModuleDeclaration() {}
+
+func (n DebuggerStatement) MarshalJSON() ([]byte, error) {
+	type plain DebuggerStatement
+	v := plain(n)
+	v.Type = "DebuggerStatement"
+	return json.Marshal(v)
+}
+
+type WithStatement struct {
+	BaseNode
+	Object Expression `json:"object"`
+	Body   Statement  `json:"body"`
+}
+
+func (*WithStatement) statementNode()                  {}
+func (*WithStatement) isStatementOrModuleDeclaration() {}
+
+func (n WithStatement) MarshalJSON() ([]byte, error) {
+	type plain WithStatement
+	v := plain(n)
+	v.Type = "WithStatement"
+	return json.Marshal(v)
+}
+
+func (n *WithStatement) UnmarshalJSON(b []byte) error {
+	type plain WithStatement
+	var v struct {
+		*plain
+		Object json.RawMessage `json:"object"`
+		Body   json.RawMessage `json:"body"`
+	}
+
+	v.plain = (*plain)(n)
+
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	if x, err := decodeExpression(v.Object); err != nil {
+		return err
+	} else {
+		n.Object = x
+	}
+
+	if x, err := decodeStatement(v.Body); err != nil {
+		return err
+	} else {
+		n.Body = x
+	}
+
+	return nil
+}
+
+type ReturnStatement struct {
+	BaseNode
+	Argument Expression `json:"argument"`
+}
+
+func (*ReturnStatement) statementNode()                  {}
+func (*ReturnStatement) isStatementOrModuleDeclaration() {}
+
+func (n ReturnStatement) MarshalJSON() ([]byte, error) {
+	type plain ReturnStatement
+	v := plain(n)
+	v.Type = "ReturnStatement"
+	return json.Marshal(v)
+}
+
+func (n *ReturnStatement) UnmarshalJSON(b []byte) error {
+	type plain ReturnStatement
+	var v struct {
+		*plain
+		Argument json.RawMessage `json:"argument"`
+	}
+
+	v.plain = (*plain)(n)
+
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	if x, err := decodeExpression(v.Argument); err != nil {
+		return err
+	} else {
+		n.Argument = x
+	}
+
+	return nil
+}
+
+type LabeledStatement struct {
+	BaseNode
+	Label *Identifier `json:"label"`
+	Body  Statement   `json:"body"`
+}
+
+func (*LabeledStatement) statementNode()                  {}
+func (*LabeledStatement) isStatementOrModuleDeclaration() {}
+
+func (n LabeledStatement) MarshalJSON() ([]byte, error) {
+	type plain LabeledStatement
+	v := plain(n)
+	v.Type = "LabeledStatement"
+	return json.Marshal(v)
+}
+
+func (n *LabeledStatement) UnmarshalJSON(b []byte) error {
+	type plain LabeledStatement
+	var v struct {
+		*plain
+		Body json.RawMessage `json:"body"`
+	}
+
+	v.plain = (*plain)(n)
+
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	if x, err := decodeStatement(v.Body); err != nil {
+		return err
+	} else {
+		n.Body = x
+	}
+
+	return nil
+}
+
+type BreakStatement struct {
+	BaseNode
+	Label *Identifier `json:"label"`
+}
+
+func (*BreakStatement) statementNode()                  {}
+func (*BreakStatement) isStatementOrModuleDeclaration() {}
+
+func (n BreakStatement) MarshalJSON() ([]byte, error) {
+	type plain BreakStatement
+	v := plain(n)
+	v.Type = "BreakStatement"
+	return json.Marshal(v)
+}
+
+type ContinueStatement struct {
+	BaseNode
+	Label *Identifier `json:"label"`
+}
+
+func (*ContinueStatement) statementNode()                  {}
+func (*ContinueStatement) isStatementOrModuleDeclaration() {}
+
+func (n ContinueStatement) MarshalJSON() ([]byte, error) {
+	type plain ContinueStatement
+	v := plain(n)
+	v.Type = "ContinueStatement"
+	return json.Marshal(v)
+}
+
+type IfStatement struct {
+	BaseNode
+	Test       Expression `json:"test"`
+	Consequent Statement  `json:"consequent"`
+	Alternate  Statement  `json:"alternate"`
+}
+
+func (*IfStatement) statementNode()                  {}
+func (*IfStatement) isStatementOrModuleDeclaration() {}
+
+func (n IfStatement) MarshalJSON() ([]byte, error) {
+	type plain IfStatement
+	v := plain(n)
+	v.Type = "IfStatement"
+	return json.Marshal(v)
+}
+
+func (n *IfStatement) UnmarshalJSON(b []byte) error {
+	type plain IfStatement
+	var v struct {
+		*plain
+		Test       json.RawMessage `json:"test"`
+		Consequent json.RawMessage `json:"consequent"`
+		Alternate  json.RawMessage `json:"alternate"`
+	}
+
+	v.plain = (*plain)(n)
+
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	if x, err := decodeExpression(v.Test); err != nil {
+		return err
+	} else {
+		n.Test = x
+	}
+
+	if x, err := decodeStatement(v.Consequent); err != nil {
+		return err
+	} else {
+		n.Consequent = x
+	}
+
+	if x, err := decodeStatement(v.Alternate); err != nil {
+		return err
+	} else {
+		n.Alternate = x
+	}
+
+	return nil
+}
+
+type SwitchStatement struct {
+	BaseNode
+	Discriminant Expression    `json:"discriminant"`
+	Cases        []*SwitchCase `json:"cases"`
+}
+
+func (*SwitchStatement) statementNode()                  {}
+func (*SwitchStatement) isStatementOrModuleDeclaration() {}
+
+func (n SwitchStatement) MarshalJSON() ([]byte, error) {
+	type plain SwitchStatement
+	v := plain(n)
+	v.Type = "SwitchStatement"
+	if v.Cases == nil {
+		v.Cases = []*SwitchCase{}
+	}
+	return json.Marshal(v)
+}
+
+func (n *SwitchStatement) UnmarshalJSON(b []byte) error {
+	type plain SwitchStatement
+	var v struct {
+		*plain
+		Discriminant json.RawMessage `json:"discriminant"`
+	}
+
+	v.plain = (*plain)(n)
+
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	if x, err := decodeExpression(v.Discriminant); err != nil {
+		return err
+	} else {
+		n.Discriminant = x
+	}
+
+	return nil
+}
+
+type SwitchCase struct {
+	BaseNode
+	Test       Expression  `json:"test"`
+	Consequent []Statement `json:"consequent"`
+}
+
+func (n SwitchCase) MarshalJSON() ([]byte, error) {
+	type plain SwitchCase
+	v := plain(n)
+	v.Type = "SwitchCase"
+	if v.Consequent == nil {
+		v.Consequent = []Statement{}
+	}
+	return json.Marshal(v)
+}
+
+func (n *SwitchCase) UnmarshalJSON(b []byte) error {
+	type plain SwitchCase
+	var v struct {
+		*plain
+		Test       json.RawMessage   `json:"test"`
+		Consequent []json.RawMessage `json:"consequent"`
+	}
+
+	v.plain = (*plain)(n)
+
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	if x, err := decodeExpression(v.Test); err != nil {
+		return err
+	} else {
+		n.Test = x
+	}
+
+	if v.Consequent != nil {
+		n.Consequent = make([]Statement, len(v.Consequent))
+	}
+	for i, e := range v.Consequent {
+		x, err := decodeStatement(e)
+		if err != nil {
+			return err
+		}
+
+		n.Consequent[i] = x
+	}
+
+	return nil
+}
+
+type ThrowStatement struct {
+	BaseNode
+	Argument Expression `json:"argument"`
+}
+
+func (*ThrowStatement) statementNode()                  {}
+func (*ThrowStatement) isStatementOrModuleDeclaration() {}
+
+func (n ThrowStatement) MarshalJSON() ([]byte, error) {
+	type plain ThrowStatement
+	v := plain(n)
+	v.Type = "ThrowStatement"
+	return json.Marshal(v)
+}
+
+func (n *ThrowStatement) UnmarshalJSON(b []byte) error {
+	type plain ThrowStatement
+	var v struct {
+		*plain
+		Argument json.RawMessage `json:"argument"`
+	}
+
+	v.plain = (*plain)(n)
+
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	if x, err := decodeExpression(v.Argument); err != nil {
+		return err
+	} else {
+		n.Argument = x
+	}
+
+	return nil
+}
+
+type TryStatement struct {
+	BaseNode
+	Block     *BlockStatement `json:"block"`
+	Handler   *CatchClause    `json:"handler"`
+	Finalizer *BlockStatement `json:"finalizer"`
+}
+
+func (*TryStatement) statementNode()                  {}
+func (*TryStatement) isStatementOrModuleDeclaration() {}
+
+func (n TryStatement) MarshalJSON() ([]byte, error) {
+	type plain TryStatement
+	v := plain(n)
+	v.Type = "TryStatement"
+	return json.Marshal(v)
+}
+
+type CatchClause struct {
+	BaseNode
+	Param Pattern         `json:"param"`
+	Body  *BlockStatement `json:"body"`
+}
+
+func (n CatchClause) MarshalJSON() ([]byte, error) {
+	type plain CatchClause
+	v := plain(n)
+	v.Type = "CatchClause"
+	return json.Marshal(v)
+}
+
+func (n *CatchClause) UnmarshalJSON(b []byte) error {
+	type plain CatchClause
+	var v struct {
+		*plain
+		Param json.RawMessage `json:"param"`
+	}
+
+	v.plain = (*plain)(n)
+
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	if x, err := decodePattern(v.Param); err != nil {
+		return err
+	} else {
+		n.Param = x
+	}
+
+	return nil
+}
+
+type WhileStatement struct {
+	BaseNode
+	Test Expression `json:"test"`
+	Body Statement  `json:"body"`
+}
+
+func (*WhileStatement) statementNode()                  {}
+func (*WhileStatement) isStatementOrModuleDeclaration() {}
+
+func (n WhileStatement) MarshalJSON() ([]byte, error) {
+	type plain WhileStatement
+	v := plain(n)
+	v.Type = "WhileStatement"
+	return json.Marshal(v)
+}
+
+func (n *WhileStatement) UnmarshalJSON(b []byte) error {
+	type plain WhileStatement
+	var v struct {
+		*plain
+		Test json.RawMessage `json:"test"`
+		Body json.RawMessage `json:"body"`
+	}
+
+	v.plain = (*plain)(n)
+
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	if x, err := decodeExpression(v.Test); err != nil {
+		return err
+	} else {
+		n.Test = x
+	}
+
+	if x, err := decodeStatement(v.Body); err != nil {
+		return err
+	} else {
+		n.Body = x
+	}
+
+	return nil
+}
+
+type DoWhileStatement struct {
+	BaseNode
+	Body Statement  `json:"body"`
+	Test Expression `json:"test"`
+}
+
+func (*DoWhileStatement) statementNode()                  {}
+func (*DoWhileStatement) isStatementOrModuleDeclaration() {}
+
+func (n DoWhileStatement) MarshalJSON() ([]byte, error) {
+	type plain DoWhileStatement
+	v := plain(n)
+	v.Type = "DoWhileStatement"
+	return json.Marshal(v)
+}
+
+func (n *DoWhileStatement) UnmarshalJSON(b []byte) error {
+	type plain DoWhileStatement
+	var v struct {
+		*plain
+		Body json.RawMessage `json:"body"`
+		Test json.RawMessage `json:"test"`
+	}
+
+	v.plain = (*plain)(n)
+
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	if x, err := decodeStatement(v.Body); err != nil {
+		return err
+	} else {
+		n.Body = x
+	}
+
+	if x, err := decodeExpression(v.Test); err != nil {
+		return err
+	} else {
+		n.Test = x
+	}
+
+	return nil
+}
+
+type VariableDeclarationOrExpression interface {
+	Node
+	isVariableDeclarationOrExpression()
+}
+
+type ForStatement struct {
+	BaseNode
+	Init   VariableDeclarationOrExpression `json:"init"`
+	Test   Expression                      `json:"test"`
+	Update Expression                      `json:"update"`
+	Body   Statement                       `json:"body"`
+}
+
+func (*ForStatement) statementNode()                  {}
+func (*ForStatement) isStatementOrModuleDeclaration() {}
+
+func (n ForStatement) MarshalJSON() ([]byte, error) {
+	type plain ForStatement
+	v := plain(n)
+	v.Type = "ForStatement"
+	return json.Marshal(v)
+}
+
+func (n *ForStatement) UnmarshalJSON(b []byte) error {
+	type plain ForStatement
+	var v struct {
+		*plain
+		Init   json.RawMessage `json:"init"`
+		Test   json.RawMessage `json:"test"`
+		Update json.RawMessage `json:"update"`
+		Body   json.RawMessage `json:"body"`
+	}
+
+	v.plain = (*plain)(n)
+
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	if x, err := decodeVariableDeclarationOrExpression(v.Init); err != nil {
+		return err
+	} else {
+		n.Init = x
+	}
+
+	if x, err := decodeExpression(v.Test); err != nil {
+		return err
+	} else {
+		n.Test = x
+	}
+
+	if x, err := decodeExpression(v.Update); err != nil {
+		return err
+	} else {
+		n.Update = x
+	}
+
+	if x, err := decodeStatement(v.Body); err != nil {
+		return err
+	} else {
+		n.Body = x
+	}
+
+	return nil
+}
+
+type VariableDeclarationOrPattern interface {
+	Node
+	isVariableDeclarationOrPattern()
+}
+
+type ForInStatement struct {
+	BaseNode
+	Left  VariableDeclarationOrPattern `json:"left"`
+	Right Expression                   `json:"right"`
+	Body  Statement                    `json:"body"`
+}
+
+func (*ForInStatement) statementNode()                  {}
+func (*ForInStatement) isStatementOrModuleDeclaration() {}
+
+func (n ForInStatement) MarshalJSON() ([]byte, error) {
+	type plain ForInStatement
+	v := plain(n)
+	v.Type = "ForInStatement"
+	return json.Marshal(v)
+}
+
+func (n *ForInStatement) UnmarshalJSON(b []byte) error {
+	type plain ForInStatement
+	var v struct {
+		*plain
+		Left  json.RawMessage `json:"left"`
+		Right json.RawMessage `json:"right"`
+		Body  json.RawMessage `json:"body"`
+	}
+
+	v.plain = (*plain)(n)
+
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	if x, err := decodeVariableDeclarationOrPattern(v.Left); err != nil {
+		return err
+	} else {
+		n.Left = x
+	}
+
+	if x, err := decodeExpression(v.Right); err != nil {
+		return err
+	} else {
+		n.Right = x
+	}
+
+	if x, err := decodeStatement(v.Body); err != nil {
+		return err
+	} else {
+		n.Body = x
+	}
+
+	return nil
+}
+
+type ForOfStatement struct {
+	BaseNode
+	Left  VariableDeclarationOrPattern `json:"left"`
+	Right Expression                   `json:"right"`
+	Body  Statement                    `json:"body"`
+}
+
+func (*ForOfStatement) statementNode()                  {}
+func (*ForOfStatement) isStatementOrModuleDeclaration() {}
+
+func (n ForOfStatement) MarshalJSON() ([]byte, error) {
+	type plain ForOfStatement
+	v := plain(n)
+	v.Type = "ForOfStatement"
+	return json.Marshal(v)
+}
+
+func (n *ForOfStatement) UnmarshalJSON(b []byte) error {
+	type plain ForOfStatement
+	var v struct {
+		*plain
+		Left  json.RawMessage `json:"left"`
+		Right json.RawMessage `json:"right"`
+		Body  json.RawMessage `json:"body"`
+	}
+
+	v.plain = (*plain)(n)
+
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	if x, err := decodeVariableDeclarationOrPattern(v.Left); err != nil {
+		return err
+	} else {
+		n.Left = x
+	}
+
+	if x, err := decodeExpression(v.Right); err != nil {
+		return err
+	} else {
+		n.Right = x
+	}
+
+	if x, err := decodeStatement(v.Body); err != nil {
+		return err
+	} else {
+		n.Body = x
+	}
+
+	return nil
+}
+
+type Declaration interface {
+	Statement
+	declarationNode()
+}
+
+type FunctionDeclaration struct {
+	BaseNode
+	ID        *Identifier     `json:"id"`
+	Params    []Pattern       `json:"params"`
+	Body      *BlockStatement `json:"body"`
+	Generator bool            `json:"generator"`
+	Async     bool            `json:"async"`
+}
+
+func (*FunctionDeclaration) functionNode()                   {}
+func (*FunctionDeclaration) declarationNode()                {}
+func (*FunctionDeclaration) statementNode()                  {}
+func (*FunctionDeclaration) isStatementOrModuleDeclaration() {}
+func (*FunctionDeclaration) isDeclarationOrExpression()      {}
+
+func (n FunctionDeclaration) MarshalJSON() ([]byte, error) {
+	type plain FunctionDeclaration
+	v := plain(n)
+	v.Type = "FunctionDeclaration"
+	if v.Params == nil {
+		v.Params = []Pattern{}
+	}
+	return json.Marshal(v)
+}
+
+func (n *FunctionDeclaration) UnmarshalJSON(b []byte) error {
+	type plain FunctionDeclaration
+	var v struct {
+		*plain
+		Params []json.RawMessage `json:"params"`
+	}
+
+	v.plain = (*plain)(n)
+
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	if v.Params != nil {
+		n.Params = make([]Pattern, len(v.Params))
+	}
+	for i, e := range v.Params {
+		x, err := decodePattern(e)
+		if err != nil {
+			return err
+		}
+
+		n.Params[i] = x
+	}
+
+	return nil
+}
+
+type VariableDeclaration struct {
+	BaseNode
+	Declarations []*VariableDeclarator `json:"declarations"`
+	Kind         string                `json:"kind"`
+}
+
+func (*VariableDeclaration) declarationNode()                   {}
+func (*VariableDeclaration) statementNode()                     {}
+func (*VariableDeclaration) isStatementOrModuleDeclaration()    {}
+func (*VariableDeclaration) isVariableDeclarationOrExpression() {}
+func (*VariableDeclaration) isVariableDeclarationOrPattern()    {}
+func (*VariableDeclaration) isDeclarationOrExpression()         {}
+
+func (n VariableDeclaration) MarshalJSON() ([]byte, error) {
+	type plain VariableDeclaration
+	v := plain(n)
+	v.Type = "VariableDeclaration"
+	if v.Declarations == nil {
+		v.Declarations = []*VariableDeclarator{}
+	}
+	return json.Marshal(v)
+}
+
+type VariableDeclarator struct {
+	BaseNode
+	ID   Pattern    `json:"id"`
+	Init Expression `json:"init"`
+}
+
+func (n VariableDeclarator) MarshalJSON() ([]byte, error) {
+	type plain VariableDeclarator
+	v := plain(n)
+	v.Type = "VariableDeclarator"
+	return json.Marshal(v)
+}
+
+func (n *VariableDeclarator) UnmarshalJSON(b []byte) error {
+	type plain VariableDeclarator
+	var v struct {
+		*plain
+		ID   json.RawMessage `json:"id"`
+		Init json.RawMessage `json:"init"`
+	}
+
+	v.plain = (*plain)(n)
+
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	if x, err := decodePattern(v.ID); err != nil {
+		return err
+	} else {
+		n.ID = x
+	}
+
+	if x, err := decodeExpression(v.Init); err != nil {
+		return err
+	} else {
+		n.Init = x
+	}
+
+	return nil
+}
+
+type Decorator struct {
+	BaseNode
+	Expression Expression `json:"expression"`
+}
+
+func (n Decorator) MarshalJSON() ([]byte, error) {
+	type plain Decorator
+	v := plain(n)
+	v.Type = "Decorator"
+	return json.Marshal(v)
+}
+
+func (n *Decorator) UnmarshalJSON(b []byte) error {
+	type plain Decorator
+	var v struct {
+		*plain
+		Expression json.RawMessage `json:"expression"`
+	}
+
+	v.plain = (*plain)(n)
+
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	if x, err := decodeExpression(v.Expression); err != nil {
+		return err
+	} else {
+		n.Expression = x
+	}
+
+	return nil
+}
+
+type Expression interface {
+	Node
+	expressionNode()
+}
+
+type Super struct {
+	BaseNode
+}
+
+func (*Super) isExpressionOrSuper() {}
+
+func (n Super) MarshalJSON() ([]byte, error) {
+	type plain Super
+	v := plain(n)
+	v.Type = "Super"
+	return json.Marshal(v)
+}
+
+type ThisExpression struct {
+	BaseNode
+}
+
+func (*ThisExpression) expressionNode()                    {}
+func (*ThisExpression) isVariableDeclarationOrExpression() {}
+func (*ThisExpression) isBlockStatementOrExpression()      {}
+func (*ThisExpression) isExpressionOrSpreadElement()       {}
+func (*ThisExpression) isExpressionOrPattern()             {}
+func (*ThisExpression) isExpressionOrPrivateIdentifier()   {}
+func (*ThisExpression) isPatternOrExpression()             {}
+func (*ThisExpression) isExpressionOrSuper()               {}
+func (*ThisExpression) isDeclarationOrExpression()         {}
+
+func (n ThisExpression) MarshalJSON() ([]byte, error) {
+	type plain ThisExpression
+	v := plain(n)
+	v.Type = "ThisExpression"
+	return json.Marshal(v)
+}
+
+type BlockStatementOrExpression interface {
+	Node
+	isBlockStatementOrExpression()
+}
+
+type ArrowFunctionExpression struct {
+	BaseNode
+	ID           *Identifier                `json:"id"`
+	Params       []Pattern                  `json:"params"`
+	Body         BlockStatementOrExpression `json:"body"`
+	Generator    bool                       `json:"generator"`
+	Async        bool                       `json:"async"`
+	IsExpression bool                       `json:"expression"`
+}
+
+func (*ArrowFunctionExpression) functionNode()                      {}
+func (*ArrowFunctionExpression) expressionNode()                    {}
+func (*ArrowFunctionExpression) isVariableDeclarationOrExpression() {}
+func (*ArrowFunctionExpression) isBlockStatementOrExpression()      {}
+func (*ArrowFunctionExpression) isExpressionOrSpreadElement()       {}
+func (*ArrowFunctionExpression) isExpressionOrPattern()             {}
+func (*ArrowFunctionExpression) isExpressionOrPrivateIdentifier()   {}
+func (*ArrowFunctionExpression) isPatternOrExpression()             {}
+func (*ArrowFunctionExpression) isExpressionOrSuper()               {}
+func (*ArrowFunctionExpression) isDeclarationOrExpression()         {}
+
+func (n ArrowFunctionExpression) MarshalJSON() ([]byte, error) {
+	type plain ArrowFunctionExpression
+	v := plain(n)
+	v.Type = "ArrowFunctionExpression"
+	if v.Params == nil {
+		v.Params = []Pattern{}
+	}
+	return json.Marshal(v)
+}
+
+func (n *ArrowFunctionExpression) UnmarshalJSON(b []byte) error {
+	type plain ArrowFunctionExpression
+	var v struct {
+		*plain
+		Params []json.RawMessage `json:"params"`
+		Body   json.RawMessage   `json:"body"`
+	}
+
+	v.plain = (*plain)(n)
+
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	if v.Params != nil {
+		n.Params = make([]Pattern, len(v.Params))
+	}
+	for i, e := range v.Params {
+		x, err := decodePattern(e)
+		if err != nil {
+			return err
+		}
+
+		n.Params[i] = x
+	}
+
+	if x, err := decodeBlockStatementOrExpression(v.Body); err != nil {
+		return err
+	} else {
+		n.Body = x
+	}
+
+	return nil
+}
+
+type YieldExpression struct {
+	BaseNode
+	Argument Expression `json:"argument"`
+	Delegate bool       `json:"delegate"`
+}
+
+func (*YieldExpression) expressionNode()                    {}
+func (*YieldExpression) isVariableDeclarationOrExpression() {}
+func (*YieldExpression) isBlockStatementOrExpression()      {}
+func (*YieldExpression) isExpressionOrSpreadElement()       {}
+func (*YieldExpression) isExpressionOrPattern()             {}
+func (*YieldExpression) isExpressionOrPrivateIdentifier()   {}
+func (*YieldExpression) isPatternOrExpression()             {}
+func (*YieldExpression) isExpressionOrSuper()               {}
+func (*YieldExpression) isDeclarationOrExpression()         {}
+
+func (n YieldExpression) MarshalJSON() ([]byte, error) {
+	type plain YieldExpression
+	v := plain(n)
+	v.Type = "YieldExpression"
+	return json.Marshal(v)
+}
+
+func (n *YieldExpression) UnmarshalJSON(b []byte) error {
+	type plain YieldExpression
+	var v struct {
+		*plain
+		Argument json.RawMessage `json:"argument"`
+	}
+
+	v.plain = (*plain)(n)
+
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	if x, err := decodeExpression(v.Argument); err != nil {
+		return err
+	} else {
+		n.Argument = x
+	}
+
+	return nil
+}
+
+type AwaitExpression struct {
+	BaseNode
+	Argument Expression `json:"argument"`
+}
+
+func (*AwaitExpression) expressionNode()                    {}
+func (*AwaitExpression) isVariableDeclarationOrExpression() {}
+func (*AwaitExpression) isBlockStatementOrExpression()      {}
+func (*AwaitExpression) isExpressionOrSpreadElement()       {}
+func (*AwaitExpression) isExpressionOrPattern()             {}
+func (*AwaitExpression) isExpressionOrPrivateIdentifier()   {}
+func (*AwaitExpression) isPatternOrExpression()             {}
+func (*AwaitExpression) isExpressionOrSuper()               {}
+func (*AwaitExpression) isDeclarationOrExpression()         {}
+
+func (n AwaitExpression) MarshalJSON() ([]byte, error) {
+	type plain AwaitExpression
+	v := plain(n)
+	v.Type = "AwaitExpression"
+	return json.Marshal(v)
+}
+
+func (n *AwaitExpression) UnmarshalJSON(b []byte) error {
+	type plain AwaitExpression
+	var v struct {
+		*plain
+		Argument json.RawMessage `json:"argument"`
+	}
+
+	v.plain = (*plain)(n)
+
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	if x, err := decodeExpression(v.Argument); err != nil {
+		return err
+	} else {
+		n.Argument = x
+	}
+
+	return nil
+}
+
+type ExpressionOrSpreadElement interface {
+	Node
+	isExpressionOrSpreadElement()
+}
+
+type ArrayExpression struct {
+	BaseNode
+	Elements []ExpressionOrSpreadElement `json:"elements"`
+}
+
+func (*ArrayExpression) expressionNode()                    {}
+func (*ArrayExpression) isVariableDeclarationOrExpression() {}
+func (*ArrayExpression) isBlockStatementOrExpression()      {}
+func (*ArrayExpression) isExpressionOrSpreadElement()       {}
+func (*ArrayExpression) isExpressionOrPattern()             {}
+func (*ArrayExpression) isExpressionOrPrivateIdentifier()   {}
+func (*ArrayExpression) isPatternOrExpression()             {}
+func (*ArrayExpression) isExpressionOrSuper()               {}
+func (*ArrayExpression) isDeclarationOrExpression()         {}
+
+func (n ArrayExpression) MarshalJSON() ([]byte, error) {
+	type plain ArrayExpression
+	v := plain(n)
+	v.Type = "ArrayExpression"
+	if v.Elements == nil {
+		v.Elements = []ExpressionOrSpreadElement{}
+	}
+	return json.Marshal(v)
+}
+
+func (n *ArrayExpression) UnmarshalJSON(b []byte) error {
+	type plain ArrayExpression
+	var v struct {
+		*plain
+		Elements []json.RawMessage `json:"elements"`
+	}
+
+	v.plain = (*plain)(n)
+
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	if v.Elements != nil {
+		n.Elements = make([]ExpressionOrSpreadElement, len(v.Elements))
+	}
+	for i, e := range v.Elements {
+		x, err := decodeExpressionOrSpreadElement(e)
+		if err != nil {
+			return err
+		}
+
+		n.Elements[i] = x
+	}
+
+	return nil
+}
+
+type PropertyOrSpreadElement interface {
+	Node
+	isPropertyOrSpreadElement()
+}
+
+type ObjectExpression struct {
+	BaseNode
+	Properties []PropertyOrSpreadElement `json:"properties"`
+}
+
+func (*ObjectExpression) expressionNode()                    {}
+func (*ObjectExpression) isVariableDeclarationOrExpression() {}
+func (*ObjectExpression) isBlockStatementOrExpression()      {}
+func (*ObjectExpression) isExpressionOrSpreadElement()       {}
+func (*ObjectExpression) isExpressionOrPattern()             {}
+func (*ObjectExpression) isExpressionOrPrivateIdentifier()   {}
+func (*ObjectExpression) isPatternOrExpression()             {}
+func (*ObjectExpression) isExpressionOrSuper()               {}
+func (*ObjectExpression) isDeclarationOrExpression()         {}
+
+func (n ObjectExpression) MarshalJSON() ([]byte, error) {
+	type plain ObjectExpression
+	v := plain(n)
+	v.Type = "ObjectExpression"
+	if v.Properties == nil {
+		v.Properties = []PropertyOrSpreadElement{}
+	}
+	return json.Marshal(v)
+}
+
+func (n *ObjectExpression) UnmarshalJSON(b []byte) error {
+	type plain ObjectExpression
+	var v struct {
+		*plain
+		Properties []json.RawMessage `json:"properties"`
+	}
+
+	v.plain = (*plain)(n)
+
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	if v.Properties != nil {
+		n.Properties = make([]PropertyOrSpreadElement, len(v.Properties))
+	}
+	for i, e := range v.Properties {
+		x, err := decodePropertyOrSpreadElement(e)
+		if err != nil {
+			return err
+		}
+
+		n.Properties[i] = x
+	}
+
+	return nil
+}
+
+type ExpressionOrPattern interface {
+	Node
+	isExpressionOrPattern()
+}
+
+type Property struct {
+	BaseNode
+	Key       Expression          `json:"key"`
+	Value     ExpressionOrPattern `json:"value"`
+	Kind      string              `json:"kind"`
+	Method    bool                `json:"method"`
+	Shorthand bool                `json:"shorthand"`
+	Computed  bool                `json:"computed"`
+}
+
+func (*Property) isPropertyOrSpreadElement() {}
+func (*Property) isPropertyOrRestElement()   {}
+
+func (n Property) MarshalJSON() ([]byte, error) {
+	type plain Property
+	v := plain(n)
+	v.Type = "Property"
+	return json.Marshal(v)
+}
+
+func (n *Property) UnmarshalJSON(b []byte) error {
+	type plain Property
+	var v struct {
+		*plain
+		Key   json.RawMessage `json:"key"`
+		Value json.RawMessage `json:"value"`
+	}
+
+	v.plain = (*plain)(n)
+
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	if x, err := decodeExpression(v.Key); err != nil {
+		return err
+	} else {
+		n.Key = x
+	}
+
+	if x, err := decodeExpressionOrPattern(v.Value); err != nil {
+		return err
+	} else {
+		n.Value = x
+	}
+
+	return nil
+}
+
+type FunctionExpression struct {
+	BaseNode
+	ID        *Identifier     `json:"id"`
+	Params    []Pattern       `json:"params"`
+	Body      *BlockStatement `json:"body"`
+	Generator bool            `json:"generator"`
+	Async     bool            `json:"async"`
+}
+
+func (*FunctionExpression) functionNode()                      {}
+func (*FunctionExpression) expressionNode()                    {}
+func (*FunctionExpression) isVariableDeclarationOrExpression() {}
+func (*FunctionExpression) isBlockStatementOrExpression()      {}
+func (*FunctionExpression) isExpressionOrSpreadElement()       {}
+func (*FunctionExpression) isExpressionOrPattern()             {}
+func (*FunctionExpression) isExpressionOrPrivateIdentifier()   {}
+func (*FunctionExpression) isPatternOrExpression()             {}
+func (*FunctionExpression) isExpressionOrSuper()               {}
+func (*FunctionExpression) isDeclarationOrExpression()         {}
+
+func (n FunctionExpression) MarshalJSON() ([]byte, error) {
+	type plain FunctionExpression
+	v := plain(n)
+	v.Type = "FunctionExpression"
+	if v.Params == nil {
+		v.Params = []Pattern{}
+	}
+	return json.Marshal(v)
+}
+
+func (n *FunctionExpression) UnmarshalJSON(b []byte) error {
+	type plain FunctionExpression
+	var v struct {
+		*plain
+		Params []json.RawMessage `json:"params"`
+	}
+
+	v.plain = (*plain)(n)
+
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	if v.Params != nil {
+		n.Params = make([]Pattern, len(v.Params))
+	}
+	for i, e := range v.Params {
+		x, err := decodePattern(e)
+		if err != nil {
+			return err
+		}
+
+		n.Params[i] = x
+	}
+
+	return nil
+}
+
+type UnaryExpression struct {
+	BaseNode
+	Operator UnaryOperator `json:"operator"`
+	Prefix   bool          `json:"prefix"`
+	Argument Expression    `json:"argument"`
+}
+
+func (*UnaryExpression) expressionNode()                    {}
+func (*UnaryExpression) isVariableDeclarationOrExpression() {}
+func (*UnaryExpression) isBlockStatementOrExpression()      {}
+func (*UnaryExpression) isExpressionOrSpreadElement()       {}
+func (*UnaryExpression) isExpressionOrPattern()             {}
+func (*UnaryExpression) isExpressionOrPrivateIdentifier()   {}
+func (*UnaryExpression) isPatternOrExpression()             {}
+func (*UnaryExpression) isExpressionOrSuper()               {}
+func (*UnaryExpression) isDeclarationOrExpression()         {}
+
+func (n UnaryExpression) MarshalJSON() ([]byte, error) {
+	type plain UnaryExpression
+	v := plain(n)
+	v.Type = "UnaryExpression"
+	return json.Marshal(v)
+}
+
+func (n *UnaryExpression) UnmarshalJSON(b []byte) error {
+	type plain UnaryExpression
+	var v struct {
+		*plain
+		Argument json.RawMessage `json:"argument"`
+	}
+
+	v.plain = (*plain)(n)
+
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	if x, err := decodeExpression(v.Argument); err != nil {
+		return err
+	} else {
+		n.Argument = x
+	}
+
+	return nil
+}
+
+type UpdateExpression struct {
+	BaseNode
+	Operator UpdateOperator `json:"operator"`
+	Argument Expression     `json:"argument"`
+	Prefix   bool           `json:"prefix"`
+}
+
+func (*UpdateExpression) expressionNode()                    {}
+func (*UpdateExpression) isVariableDeclarationOrExpression() {}
+func (*UpdateExpression) isBlockStatementOrExpression()      {}
+func (*UpdateExpression) isExpressionOrSpreadElement()       {}
+func (*UpdateExpression) isExpressionOrPattern()             {}
+func (*UpdateExpression) isExpressionOrPrivateIdentifier()   {}
+func (*UpdateExpression) isPatternOrExpression()             {}
+func (*UpdateExpression) isExpressionOrSuper()               {}
+func (*UpdateExpression) isDeclarationOrExpression()         {}
+
+func (n UpdateExpression) MarshalJSON() ([]byte, error) {
+	type plain UpdateExpression
+	v := plain(n)
+	v.Type = "UpdateExpression"
+	return json.Marshal(v)
+}
+
+func (n *UpdateExpression) UnmarshalJSON(b []byte) error {
+	type plain UpdateExpression
+	var v struct {
+		*plain
+		Argument json.RawMessage `json:"argument"`
+	}
+
+	v.plain = (*plain)(n)
+
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	if x, err := decodeExpression(v.Argument); err != nil {
+		return err
+	} else {
+		n.Argument = x
+	}
+
+	return nil
+}
+
+type ExpressionOrPrivateIdentifier interface {
+	Node
+	isExpressionOrPrivateIdentifier()
+}
+
+type BinaryExpression struct {
+	BaseNode
+	Operator BinaryOperator                `json:"operator"`
+	Left     ExpressionOrPrivateIdentifier `json:"left"`
+	Right    Expression                    `json:"right"`
+}
+
+func (*BinaryExpression) expressionNode()                    {}
+func (*BinaryExpression) isVariableDeclarationOrExpression() {}
+func (*BinaryExpression) isBlockStatementOrExpression()      {}
+func (*BinaryExpression) isExpressionOrSpreadElement()       {}
+func (*BinaryExpression) isExpressionOrPattern()             {}
+func (*BinaryExpression) isExpressionOrPrivateIdentifier()   {}
+func (*BinaryExpression) isPatternOrExpression()             {}
+func (*BinaryExpression) isExpressionOrSuper()               {}
+func (*BinaryExpression) isDeclarationOrExpression()         {}
+
+func (n BinaryExpression) MarshalJSON() ([]byte, error) {
+	type plain BinaryExpression
+	v := plain(n)
+	v.Type = "BinaryExpression"
+	return json.Marshal(v)
+}
+
+func (n *BinaryExpression) UnmarshalJSON(b []byte) error {
+	type plain BinaryExpression
+	var v struct {
+		*plain
+		Left  json.RawMessage `json:"left"`
+		Right json.RawMessage `json:"right"`
+	}
+
+	v.plain = (*plain)(n)
+
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	if x, err := decodeExpressionOrPrivateIdentifier(v.Left); err != nil {
+		return err
+	} else {
+		n.Left = x
+	}
+
+	if x, err := decodeExpression(v.Right); err != nil {
+		return err
+	} else {
+		n.Right = x
+	}
+
+	return nil
+}
+
+type PatternOrExpression interface {
+	Node
+	isPatternOrExpression()
+}
+
+type AssignmentExpression struct {
+	BaseNode
+	Operator AssignmentOperator  `json:"operator"`
+	Left     PatternOrExpression `json:"left"`
+	Right    Expression          `json:"right"`
+}
+
+func (*AssignmentExpression) expressionNode()                    {}
+func (*AssignmentExpression) isVariableDeclarationOrExpression() {}
+func (*AssignmentExpression) isBlockStatementOrExpression()      {}
+func (*AssignmentExpression) isExpressionOrSpreadElement()       {}
+func (*AssignmentExpression) isExpressionOrPattern()             {}
+func (*AssignmentExpression) isExpressionOrPrivateIdentifier()   {}
+func (*AssignmentExpression) isPatternOrExpression()             {}
+func (*AssignmentExpression) isExpressionOrSuper()               {}
+func (*AssignmentExpression) isDeclarationOrExpression()         {}
+
+func (n AssignmentExpression) MarshalJSON() ([]byte, error) {
+	type plain AssignmentExpression
+	v := plain(n)
+	v.Type = "AssignmentExpression"
+	return json.Marshal(v)
+}
+
+func (n *AssignmentExpression) UnmarshalJSON(b []byte) error {
+	type plain AssignmentExpression
+	var v struct {
+		*plain
+		Left  json.RawMessage `json:"left"`
+		Right json.RawMessage `json:"right"`
+	}
+
+	v.plain = (*plain)(n)
+
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	if x, err := decodePatternOrExpression(v.Left); err != nil {
+		return err
+	} else {
+		n.Left = x
+	}
+
+	if x, err := decodeExpression(v.Right); err != nil {
+		return err
+	} else {
+		n.Right = x
+	}
+
+	return nil
+}
+
+type LogicalExpression struct {
+	BaseNode
+	Operator LogicalOperator `json:"operator"`
+	Left     Expression      `json:"left"`
+	Right    Expression      `json:"right"`
+}
+
+func (*LogicalExpression) expressionNode()                    {}
+func (*LogicalExpression) isVariableDeclarationOrExpression() {}
+func (*LogicalExpression) isBlockStatementOrExpression()      {}
+func (*LogicalExpression) isExpressionOrSpreadElement()       {}
+func (*LogicalExpression) isExpressionOrPattern()             {}
+func (*LogicalExpression) isExpressionOrPrivateIdentifier()   {}
+func (*LogicalExpression) isPatternOrExpression()             {}
+func (*LogicalExpression) isExpressionOrSuper()               {}
+func (*LogicalExpression) isDeclarationOrExpression()         {}
+
+func (n LogicalExpression) MarshalJSON() ([]byte, error) {
+	type plain LogicalExpression
+	v := plain(n)
+	v.Type = "LogicalExpression"
+	return json.Marshal(v)
+}
+
+func (n *LogicalExpression) UnmarshalJSON(b []byte) error {
+	type plain LogicalExpression
+	var v struct {
+		*plain
+		Left  json.RawMessage `json:"left"`
+		Right json.RawMessage `json:"right"`
+	}
+
+	v.plain = (*plain)(n)
+
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	if x, err := decodeExpression(v.Left); err != nil {
+		return err
+	} else {
+		n.Left = x
+	}
+
+	if x, err := decodeExpression(v.Right); err != nil {
+		return err
+	} else {
+		n.Right = x
+	}
+
+	return nil
+}
+
+type SpreadElement struct {
+	BaseNode
+	Argument Expression `json:"argument"`
+}
+
+func (*SpreadElement) isExpressionOrSpreadElement() {}
+func (*SpreadElement) isPropertyOrSpreadElement()   {}
+
+func (n SpreadElement) MarshalJSON() ([]byte, error) {
+	type plain SpreadElement
+	v := plain(n)
+	v.Type = "SpreadElement"
+	return json.Marshal(v)
+}
+
+func (n *SpreadElement) UnmarshalJSON(b []byte) error {
+	type plain SpreadElement
+	var v struct {
+		*plain
+		Argument json.RawMessage `json:"argument"`
+	}
+
+	v.plain = (*plain)(n)
+
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	if x, err := decodeExpression(v.Argument); err != nil {
+		return err
+	} else {
+		n.Argument = x
+	}
+
+	return nil
+}
+
+type ExpressionOrSuper interface {
+	Node
+	isExpressionOrSuper()
+}
+
+type MemberExpression struct {
+	BaseNode
+	Object   ExpressionOrSuper             `json:"object"`
+	Property ExpressionOrPrivateIdentifier `json:"property"`
+	Computed bool                          `json:"computed"`
+	Optional bool                          `json:"optional"`
+}
+
+func (*MemberExpression) expressionNode()                     {}
+func (*MemberExpression) patternNode()                        {}
+func (*MemberExpression) isVariableDeclarationOrExpression()  {}
+func (*MemberExpression) isVariableDeclarationOrPattern()     {}
+func (*MemberExpression) isBlockStatementOrExpression()       {}
+func (*MemberExpression) isExpressionOrSpreadElement()        {}
+func (*MemberExpression) isExpressionOrPattern()              {}
+func (*MemberExpression) isExpressionOrPrivateIdentifier()    {}
+func (*MemberExpression) isPatternOrExpression()              {}
+func (*MemberExpression) isExpressionOrSuper()                {}
+func (*MemberExpression) isCallExpressionOrMemberExpression() {}
+func (*MemberExpression) isDeclarationOrExpression()          {}
+
+func (n MemberExpression) MarshalJSON() ([]byte, error) {
+	type plain MemberExpression
+	v := plain(n)
+	v.Type = "MemberExpression"
+	return json.Marshal(v)
+}
+
+func (n *MemberExpression) UnmarshalJSON(b []byte) error {
+	type plain MemberExpression
+	var v struct {
+		*plain
+		Object   json.RawMessage `json:"object"`
+		Property json.RawMessage `json:"property"`
+	}
+
+	v.plain = (*plain)(n)
+
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	if x, err := decodeExpressionOrSuper(v.Object); err != nil {
+		return err
+	} else {
+		n.Object = x
+	}
+
+	if x, err := decodeExpressionOrPrivateIdentifier(v.Property); err != nil {
+		return err
+	} else {
+		n.Property = x
+	}
+
+	return nil
+}
+
+type CallExpressionOrMemberExpression interface {
+	Node
+	isCallExpressionOrMemberExpression()
+}
+
+type ChainExpression struct {
+	BaseNode
+	Expression CallExpressionOrMemberExpression `json:"expression"`
+}
+
+func (*ChainExpression) expressionNode()                    {}
+func (*ChainExpression) isVariableDeclarationOrExpression() {}
+func (*ChainExpression) isBlockStatementOrExpression()      {}
+func (*ChainExpression) isExpressionOrSpreadElement()       {}
+func (*ChainExpression) isExpressionOrPattern()             {}
+func (*ChainExpression) isExpressionOrPrivateIdentifier()   {}
+func (*ChainExpression) isPatternOrExpression()             {}
+func (*ChainExpression) isExpressionOrSuper()               {}
+func (*ChainExpression) isDeclarationOrExpression()         {}
+
+func (n ChainExpression) MarshalJSON() ([]byte, error) {
+	type plain ChainExpression
+	v := plain(n)
+	v.Type = "ChainExpression"
+	return json.Marshal(v)
+}
+
+func (n *ChainExpression) UnmarshalJSON(b []byte) error {
+	type plain ChainExpression
+	var v struct {
+		*plain
+		Expression json.RawMessage `json:"expression"`
+	}
+
+	v.plain = (*plain)(n)
+
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	if x, err := decodeCallExpressionOrMemberExpression(v.Expression); err != nil {
+		return err
+	} else {
+		n.Expression = x
+	}
+
+	return nil
+}
+
+type ConditionalExpression struct {
+	BaseNode
+	Test       Expression `json:"test"`
+	Alternate  Expression `json:"alternate"`
+	Consequent Expression `json:"consequent"`
+}
+
+func (*ConditionalExpression) expressionNode()                    {}
+func (*ConditionalExpression) isVariableDeclarationOrExpression() {}
+func (*ConditionalExpression) isBlockStatementOrExpression()      {}
+func (*ConditionalExpression) isExpressionOrSpreadElement()       {}
+func (*ConditionalExpression) isExpressionOrPattern()             {}
+func (*ConditionalExpression) isExpressionOrPrivateIdentifier()   {}
+func (*ConditionalExpression) isPatternOrExpression()             {}
+func (*ConditionalExpression) isExpressionOrSuper()               {}
+func (*ConditionalExpression) isDeclarationOrExpression()         {}
+
+func (n ConditionalExpression) MarshalJSON() ([]byte, error) {
+	type plain ConditionalExpression
+	v := plain(n)
+	v.Type = "ConditionalExpression"
+	return json.Marshal(v)
+}
+
+func (n *ConditionalExpression) UnmarshalJSON(b []byte) error {
+	type plain ConditionalExpression
+	var v struct {
+		*plain
+		Test       json.RawMessage `json:"test"`
+		Alternate  json.RawMessage `json:"alternate"`
+		Consequent json.RawMessage `json:"consequent"`
+	}
+
+	v.plain = (*plain)(n)
+
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	if x, err := decodeExpression(v.Test); err != nil {
+		return err
+	} else {
+		n.Test = x
+	}
+
+	if x, err := decodeExpression(v.Alternate); err != nil {
+		return err
+	} else {
+		n.Alternate = x
+	}
+
+	if x, err := decodeExpression(v.Consequent); err != nil {
+		return err
+	} else {
+		n.Consequent = x
+	}
+
+	return nil
+}
+
+type CallExpression struct {
+	BaseNode
+	Callee    ExpressionOrSuper           `json:"callee"`
+	Arguments []ExpressionOrSpreadElement `json:"arguments"`
+	Optional  bool                        `json:"optional"`
+}
+
+func (*CallExpression) expressionNode()                     {}
+func (*CallExpression) isVariableDeclarationOrExpression()  {}
+func (*CallExpression) isBlockStatementOrExpression()       {}
+func (*CallExpression) isExpressionOrSpreadElement()        {}
+func (*CallExpression) isExpressionOrPattern()              {}
+func (*CallExpression) isExpressionOrPrivateIdentifier()    {}
+func (*CallExpression) isPatternOrExpression()              {}
+func (*CallExpression) isExpressionOrSuper()                {}
+func (*CallExpression) isCallExpressionOrMemberExpression() {}
+func (*CallExpression) isDeclarationOrExpression()          {}
+
+func (n CallExpression) MarshalJSON() ([]byte, error) {
+	type plain CallExpression
+	v := plain(n)
+	v.Type = "CallExpression"
+	if v.Arguments == nil {
+		v.Arguments = []ExpressionOrSpreadElement{}
+	}
+	return json.Marshal(v)
+}
+
+func (n *CallExpression) UnmarshalJSON(b []byte) error {
+	type plain CallExpression
+	var v struct {
+		*plain
+		Callee    json.RawMessage   `json:"callee"`
+		Arguments []json.RawMessage `json:"arguments"`
+	}
+
+	v.plain = (*plain)(n)
+
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	if x, err := decodeExpressionOrSuper(v.Callee); err != nil {
+		return err
+	} else {
+		n.Callee = x
+	}
+
+	if v.Arguments != nil {
+		n.Arguments = make([]ExpressionOrSpreadElement, len(v.Arguments))
+	}
+	for i, e := range v.Arguments {
+		x, err := decodeExpressionOrSpreadElement(e)
+		if err != nil {
+			return err
+		}
+
+		n.Arguments[i] = x
+	}
+
+	return nil
+}
+
+type NewExpression struct {
+	BaseNode
+	Callee    Expression                  `json:"callee"`
+	Arguments []ExpressionOrSpreadElement `json:"arguments"`
+}
+
+func (*NewExpression) expressionNode()                    {}
+func (*NewExpression) isVariableDeclarationOrExpression() {}
+func (*NewExpression) isBlockStatementOrExpression()      {}
+func (*NewExpression) isExpressionOrSpreadElement()       {}
+func (*NewExpression) isExpressionOrPattern()             {}
+func (*NewExpression) isExpressionOrPrivateIdentifier()   {}
+func (*NewExpression) isPatternOrExpression()             {}
+func (*NewExpression) isExpressionOrSuper()               {}
+func (*NewExpression) isDeclarationOrExpression()         {}
+
+func (n NewExpression) MarshalJSON() ([]byte, error) {
+	type plain NewExpression
+	v := plain(n)
+	v.Type = "NewExpression"
+	if v.Arguments == nil {
+		v.Arguments = []ExpressionOrSpreadElement{}
+	}
+	return json.Marshal(v)
+}
+
+func (n *NewExpression) UnmarshalJSON(b []byte) error {
+	type plain NewExpression
+	var v struct {
+		*plain
+		Callee    json.RawMessage   `json:"callee"`
+		Arguments []json.RawMessage `json:"arguments"`
+	}
+
+	v.plain = (*plain)(n)
+
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	if x, err := decodeExpression(v.Callee); err != nil {
+		return err
+	} else {
+		n.Callee = x
+	}
+
+	if v.Arguments != nil {
+		n.Arguments = make([]ExpressionOrSpreadElement, len(v.Arguments))
+	}
+	for i, e := range v.Arguments {
+		x, err := decodeExpressionOrSpreadElement(e)
+		if err != nil {
+			return err
+		}
+
+		n.Arguments[i] = x
+	}
+
+	return nil
+}
+
+type SequenceExpression struct {
+	BaseNode
+	Expressions []Expression `json:"expressions"`
+}
+
+func (*SequenceExpression) expressionNode()                    {}
+func (*SequenceExpression) isVariableDeclarationOrExpression() {}
+func (*SequenceExpression) isBlockStatementOrExpression()      {}
+func (*SequenceExpression) isExpressionOrSpreadElement()       {}
+func (*SequenceExpression) isExpressionOrPattern()             {}
+func (*SequenceExpression) isExpressionOrPrivateIdentifier()   {}
+func (*SequenceExpression) isPatternOrExpression()             {}
+func (*SequenceExpression) isExpressionOrSuper()               {}
+func (*SequenceExpression) isDeclarationOrExpression()         {}
+
+func (n SequenceExpression) MarshalJSON() ([]byte, error) {
+	type plain SequenceExpression
+	v := plain(n)
+	v.Type = "SequenceExpression"
+	if v.Expressions == nil {
+		v.Expressions = []Expression{}
+	}
+	return json.Marshal(v)
+}
+
+func (n *SequenceExpression) UnmarshalJSON(b []byte) error {
+	type plain SequenceExpression
+	var v struct {
+		*plain
+		Expressions []json.RawMessage `json:"expressions"`
+	}
+
+	v.plain = (*plain)(n)
+
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	if v.Expressions != nil {
+		n.Expressions = make([]Expression, len(v.Expressions))
+	}
+	for i, e := range v.Expressions {
+		x, err := decodeExpression(e)
+		if err != nil {
+			return err
+		}
+
+		n.Expressions[i] = x
+	}
+
+	return nil
+}
+
+type ImportExpression struct {
+	BaseNode
+	Source  Expression `json:"source"`
+	Options Expression `json:"options"`
+}
+
+func (*ImportExpression) expressionNode()                    {}
+func (*ImportExpression) isVariableDeclarationOrExpression() {}
+func (*ImportExpression) isBlockStatementOrExpression()      {}
+func (*ImportExpression) isExpressionOrSpreadElement()       {}
+func (*ImportExpression) isExpressionOrPattern()             {}
+func (*ImportExpression) isExpressionOrPrivateIdentifier()   {}
+func (*ImportExpression) isPatternOrExpression()             {}
+func (*ImportExpression) isExpressionOrSuper()               {}
+func (*ImportExpression) isDeclarationOrExpression()         {}
+
+func (n ImportExpression) MarshalJSON() ([]byte, error) {
+	type plain ImportExpression
+	v := plain(n)
+	v.Type = "ImportExpression"
+	return json.Marshal(v)
+}
+
+func (n *ImportExpression) UnmarshalJSON(b []byte) error {
+	type plain ImportExpression
+	var v struct {
+		*plain
+		Source  json.RawMessage `json:"source"`
+		Options json.RawMessage `json:"options"`
+	}
+
+	v.plain = (*plain)(n)
+
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	if x, err := decodeExpression(v.Source); err != nil {
+		return err
+	} else {
+		n.Source = x
+	}
+
+	if x, err := decodeExpression(v.Options); err != nil {
+		return err
+	} else {
+		n.Options = x
+	}
+
+	return nil
+}
+
+type TemplateLiteral struct {
+	BaseNode
+	Quasis      []*TemplateElement `json:"quasis"`
+	Expressions []Expression       `json:"expressions"`
+}
+
+func (*TemplateLiteral) expressionNode()                    {}
+func (*TemplateLiteral) isVariableDeclarationOrExpression() {}
+func (*TemplateLiteral) isBlockStatementOrExpression()      {}
+func (*TemplateLiteral) isExpressionOrSpreadElement()       {}
+func (*TemplateLiteral) isExpressionOrPattern()             {}
+func (*TemplateLiteral) isExpressionOrPrivateIdentifier()   {}
+func (*TemplateLiteral) isPatternOrExpression()             {}
+func (*TemplateLiteral) isExpressionOrSuper()               {}
+func (*TemplateLiteral) isDeclarationOrExpression()         {}
+
+func (n TemplateLiteral) MarshalJSON() ([]byte, error) {
+	type plain TemplateLiteral
+	v := plain(n)
+	v.Type = "TemplateLiteral"
+	if v.Quasis == nil {
+		v.Quasis = []*TemplateElement{}
+	}
+	if v.Expressions == nil {
+		v.Expressions = []Expression{}
+	}
+	return json.Marshal(v)
+}
+
+func (n *TemplateLiteral) UnmarshalJSON(b []byte) error {
+	type plain TemplateLiteral
+	var v struct {
+		*plain
+		Expressions []json.RawMessage `json:"expressions"`
+	}
+
+	v.plain = (*plain)(n)
+
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	if v.Expressions != nil {
+		n.Expressions = make([]Expression, len(v.Expressions))
+	}
+	for i, e := range v.Expressions {
+		x, err := decodeExpression(e)
+		if err != nil {
+			return err
+		}
+
+		n.Expressions[i] = x
+	}
+
+	return nil
+}
+
+type TaggedTemplateExpression struct {
+	BaseNode
+	Tag   Expression       `json:"tag"`
+	Quasi *TemplateLiteral `json:"quasi"`
+}
+
+func (*TaggedTemplateExpression) expressionNode()                    {}
+func (*TaggedTemplateExpression) isVariableDeclarationOrExpression() {}
+func (*TaggedTemplateExpression) isBlockStatementOrExpression()      {}
+func (*TaggedTemplateExpression) isExpressionOrSpreadElement()       {}
+func (*TaggedTemplateExpression) isExpressionOrPattern()             {}
+func (*TaggedTemplateExpression) isExpressionOrPrivateIdentifier()   {}
+func (*TaggedTemplateExpression) isPatternOrExpression()             {}
+func (*TaggedTemplateExpression) isExpressionOrSuper()               {}
+func (*TaggedTemplateExpression) isDeclarationOrExpression()         {}
+
+func (n TaggedTemplateExpression) MarshalJSON() ([]byte, error) {
+	type plain TaggedTemplateExpression
+	v := plain(n)
+	v.Type = "TaggedTemplateExpression"
+	return json.Marshal(v)
+}
+
+func (n *TaggedTemplateExpression) UnmarshalJSON(b []byte) error {
+	type plain TaggedTemplateExpression
+	var v struct {
+		*plain
+		Tag json.RawMessage `json:"tag"`
+	}
+
+	v.plain = (*plain)(n)
+
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	if x, err := decodeExpression(v.Tag); err != nil {
+		return err
+	} else {
+		n.Tag = x
+	}
+
+	return nil
+}
+
+type TemplateElement struct {
+	BaseNode
+	Tail  bool          `json:"tail"`
+	Value TemplateValue `json:"value"`
+}
+
+func (n TemplateElement) MarshalJSON() ([]byte, error) {
+	type plain TemplateElement
+	v := plain(n)
+	v.Type = "TemplateElement"
+	return json.Marshal(v)
+}
+
+type TemplateValue struct {
+	Cooked *string `json:"cooked"`
+	Raw    string  `json:"raw"`
+}
+
+type Pattern interface {
+	Node
+	patternNode()
+}
+
+type PropertyOrRestElement interface {
+	Node
+	isPropertyOrRestElement()
+}
+
+type ObjectPattern struct {
+	BaseNode
+	Properties []PropertyOrRestElement `json:"properties"`
+}
+
+func (*ObjectPattern) patternNode()                    {}
+func (*ObjectPattern) isVariableDeclarationOrPattern() {}
+func (*ObjectPattern) isExpressionOrPattern()          {}
+func (*ObjectPattern) isPatternOrExpression()          {}
+
+func (n ObjectPattern) MarshalJSON() ([]byte, error) {
+	type plain ObjectPattern
+	v := plain(n)
+	v.Type = "ObjectPattern"
+	if v.Properties == nil {
+		v.Properties = []PropertyOrRestElement{}
+	}
+	return json.Marshal(v)
+}
+
+func (n *ObjectPattern) UnmarshalJSON(b []byte) error {
+	type plain ObjectPattern
+	var v struct {
+		*plain
+		Properties []json.RawMessage `json:"properties"`
+	}
+
+	v.plain = (*plain)(n)
+
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	if v.Properties != nil {
+		n.Properties = make([]PropertyOrRestElement, len(v.Properties))
+	}
+	for i, e := range v.Properties {
+		x, err := decodePropertyOrRestElement(e)
+		if err != nil {
+			return err
+		}
+
+		n.Properties[i] = x
+	}
+
+	return nil
+}
+
+type ArrayPattern struct {
+	BaseNode
+	Elements []Pattern `json:"elements"`
+}
+
+func (*ArrayPattern) patternNode()                    {}
+func (*ArrayPattern) isVariableDeclarationOrPattern() {}
+func (*ArrayPattern) isExpressionOrPattern()          {}
+func (*ArrayPattern) isPatternOrExpression()          {}
+
+func (n ArrayPattern) MarshalJSON() ([]byte, error) {
+	type plain ArrayPattern
+	v := plain(n)
+	v.Type = "ArrayPattern"
+	if v.Elements == nil {
+		v.Elements = []Pattern{}
+	}
+	return json.Marshal(v)
+}
+
+func (n *ArrayPattern) UnmarshalJSON(b []byte) error {
+	type plain ArrayPattern
+	var v struct {
+		*plain
+		Elements []json.RawMessage `json:"elements"`
+	}
+
+	v.plain = (*plain)(n)
+
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	if v.Elements != nil {
+		n.Elements = make([]Pattern, len(v.Elements))
+	}
+	for i, e := range v.Elements {
+		x, err := decodePattern(e)
+		if err != nil {
+			return err
+		}
+
+		n.Elements[i] = x
+	}
+
+	return nil
+}
+
+type RestElement struct {
+	BaseNode
+	Argument Pattern `json:"argument"`
+}
+
+func (*RestElement) patternNode()                    {}
+func (*RestElement) isVariableDeclarationOrPattern() {}
+func (*RestElement) isExpressionOrPattern()          {}
+func (*RestElement) isPatternOrExpression()          {}
+func (*RestElement) isPropertyOrRestElement()        {}
+
+func (n RestElement) MarshalJSON() ([]byte, error) {
+	type plain RestElement
+	v := plain(n)
+	v.Type = "RestElement"
+	return json.Marshal(v)
+}
+
+func (n *RestElement) UnmarshalJSON(b []byte) error {
+	type plain RestElement
+	var v struct {
+		*plain
+		Argument json.RawMessage `json:"argument"`
+	}
+
+	v.plain = (*plain)(n)
+
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	if x, err := decodePattern(v.Argument); err != nil {
+		return err
+	} else {
+		n.Argument = x
+	}
+
+	return nil
+}
+
+type AssignmentPattern struct {
+	BaseNode
+	Left  Pattern    `json:"left"`
+	Right Expression `json:"right"`
+}
+
+func (*AssignmentPattern) patternNode()                    {}
+func (*AssignmentPattern) isVariableDeclarationOrPattern() {}
+func (*AssignmentPattern) isExpressionOrPattern()          {}
+func (*AssignmentPattern) isPatternOrExpression()          {}
+
+func (n AssignmentPattern) MarshalJSON() ([]byte, error) {
+	type plain AssignmentPattern
+	v := plain(n)
+	v.Type = "AssignmentPattern"
+	return json.Marshal(v)
+}
+
+func (n *AssignmentPattern) UnmarshalJSON(b []byte) error {
+	type plain AssignmentPattern
+	var v struct {
+		*plain
+		Left  json.RawMessage `json:"left"`
+		Right json.RawMessage `json:"right"`
+	}
+
+	v.plain = (*plain)(n)
+
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	if x, err := decodePattern(v.Left); err != nil {
+		return err
+	} else {
+		n.Left = x
+	}
+
+	if x, err := decodeExpression(v.Right); err != nil {
+		return err
+	} else {
+		n.Right = x
+	}
+
+	return nil
+}
+
+type Class interface {
+	Node
+	classNode()
+}
+
+type MethodDefinitionOrPropertyDefinitionOrStaticBlock interface {
+	Node
+	isMethodDefinitionOrPropertyDefinitionOrStaticBlock()
+}
+
+type ClassBody struct {
+	BaseNode
+	Body []MethodDefinitionOrPropertyDefinitionOrStaticBlock `json:"body"`
+}
+
+func (n ClassBody) MarshalJSON() ([]byte, error) {
+	type plain ClassBody
+	v := plain(n)
+	v.Type = "ClassBody"
+	if v.Body == nil {
+		v.Body = []MethodDefinitionOrPropertyDefinitionOrStaticBlock{}
+	}
+	return json.Marshal(v)
+}
+
+func (n *ClassBody) UnmarshalJSON(b []byte) error {
+	type plain ClassBody
+	var v struct {
+		*plain
+		Body []json.RawMessage `json:"body"`
+	}
+
+	v.plain = (*plain)(n)
+
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	if v.Body != nil {
+		n.Body = make([]MethodDefinitionOrPropertyDefinitionOrStaticBlock, len(v.Body))
+	}
+	for i, e := range v.Body {
+		x, err := decodeMethodDefinitionOrPropertyDefinitionOrStaticBlock(e)
+		if err != nil {
+			return err
+		}
+
+		n.Body[i] = x
+	}
+
+	return nil
+}
+
+type MethodDefinition struct {
+	BaseNode
+	Key        ExpressionOrPrivateIdentifier `json:"key"`
+	Value      *FunctionExpression           `json:"value"`
+	Kind       string                        `json:"kind"`
+	Computed   bool                          `json:"computed"`
+	Static     bool                          `json:"static"`
+	Decorators []*Decorator                  `json:"decorators"`
+}
+
+func (*MethodDefinition) isMethodDefinitionOrPropertyDefinitionOrStaticBlock() {}
+
+func (n MethodDefinition) MarshalJSON() ([]byte, error) {
+	type plain MethodDefinition
+	v := plain(n)
+	v.Type = "MethodDefinition"
+	if v.Decorators == nil {
+		v.Decorators = []*Decorator{}
+	}
+	return json.Marshal(v)
+}
+
+func (n *MethodDefinition) UnmarshalJSON(b []byte) error {
+	type plain MethodDefinition
+	var v struct {
+		*plain
+		Key json.RawMessage `json:"key"`
+	}
+
+	v.plain = (*plain)(n)
+
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	if x, err := decodeExpressionOrPrivateIdentifier(v.Key); err != nil {
+		return err
+	} else {
+		n.Key = x
+	}
+
+	return nil
+}
+
+type PropertyDefinition struct {
+	BaseNode
+	Key        ExpressionOrPrivateIdentifier `json:"key"`
+	Value      Expression                    `json:"value"`
+	Computed   bool                          `json:"computed"`
+	Static     bool                          `json:"static"`
+	Decorators []*Decorator                  `json:"decorators"`
+}
+
+func (*PropertyDefinition) isMethodDefinitionOrPropertyDefinitionOrStaticBlock() {}
+
+func (n PropertyDefinition) MarshalJSON() ([]byte, error) {
+	type plain PropertyDefinition
+	v := plain(n)
+	v.Type = "PropertyDefinition"
+	if v.Decorators == nil {
+		v.Decorators = []*Decorator{}
+	}
+	return json.Marshal(v)
+}
+
+func (n *PropertyDefinition) UnmarshalJSON(b []byte) error {
+	type plain PropertyDefinition
+	var v struct {
+		*plain
+		Key   json.RawMessage `json:"key"`
+		Value json.RawMessage `json:"value"`
+	}
+
+	v.plain = (*plain)(n)
+
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	if x, err := decodeExpressionOrPrivateIdentifier(v.Key); err != nil {
+		return err
+	} else {
+		n.Key = x
+	}
+
+	if x, err := decodeExpression(v.Value); err != nil {
+		return err
+	} else {
+		n.Value = x
+	}
+
+	return nil
+}
+
+type StaticBlock struct {
+	BaseNode
+	Body []Statement `json:"body"`
+}
+
+func (*StaticBlock) isMethodDefinitionOrPropertyDefinitionOrStaticBlock() {}
+
+func (n StaticBlock) MarshalJSON() ([]byte, error) {
+	type plain StaticBlock
+	v := plain(n)
+	v.Type = "StaticBlock"
+	if v.Body == nil {
+		v.Body = []Statement{}
+	}
+	return json.Marshal(v)
+}
+
+func (n *StaticBlock) UnmarshalJSON(b []byte) error {
+	type plain StaticBlock
+	var v struct {
+		*plain
+		Body []json.RawMessage `json:"body"`
+	}
+
+	v.plain = (*plain)(n)
+
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	if v.Body != nil {
+		n.Body = make([]Statement, len(v.Body))
+	}
+	for i, e := range v.Body {
+		x, err := decodeStatement(e)
+		if err != nil {
+			return err
+		}
+
+		n.Body[i] = x
+	}
+
+	return nil
+}
+
+type ClassDeclaration struct {
+	BaseNode
+	ID         *Identifier  `json:"id"`
+	SuperClass Expression   `json:"superClass"`
+	Body       *ClassBody   `json:"body"`
+	Decorators []*Decorator `json:"decorators"`
+}
+
+func (*ClassDeclaration) classNode()                      {}
+func (*ClassDeclaration) declarationNode()                {}
+func (*ClassDeclaration) statementNode()                  {}
+func (*ClassDeclaration) isStatementOrModuleDeclaration() {}
+func (*ClassDeclaration) isDeclarationOrExpression()      {}
+
+func (n ClassDeclaration) MarshalJSON() ([]byte, error) {
+	type plain ClassDeclaration
+	v := plain(n)
+	v.Type = "ClassDeclaration"
+	if v.Decorators == nil {
+		v.Decorators = []*Decorator{}
+	}
+	return json.Marshal(v)
+}
+
+func (n *ClassDeclaration) UnmarshalJSON(b []byte) error {
+	type plain ClassDeclaration
+	var v struct {
+		*plain
+		SuperClass json.RawMessage `json:"superClass"`
+	}
+
+	v.plain = (*plain)(n)
+
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	if x, err := decodeExpression(v.SuperClass); err != nil {
+		return err
+	} else {
+		n.SuperClass = x
+	}
+
+	return nil
+}
+
+type ClassExpression struct {
+	BaseNode
+	ID         *Identifier  `json:"id"`
+	SuperClass Expression   `json:"superClass"`
+	Body       *ClassBody   `json:"body"`
+	Decorators []*Decorator `json:"decorators"`
+}
+
+func (*ClassExpression) classNode()                         {}
+func (*ClassExpression) expressionNode()                    {}
+func (*ClassExpression) isVariableDeclarationOrExpression() {}
+func (*ClassExpression) isBlockStatementOrExpression()      {}
+func (*ClassExpression) isExpressionOrSpreadElement()       {}
+func (*ClassExpression) isExpressionOrPattern()             {}
+func (*ClassExpression) isExpressionOrPrivateIdentifier()   {}
+func (*ClassExpression) isPatternOrExpression()             {}
+func (*ClassExpression) isExpressionOrSuper()               {}
+func (*ClassExpression) isDeclarationOrExpression()         {}
+
+func (n ClassExpression) MarshalJSON() ([]byte, error) {
+	type plain ClassExpression
+	v := plain(n)
+	v.Type = "ClassExpression"
+	if v.Decorators == nil {
+		v.Decorators = []*Decorator{}
+	}
+	return json.Marshal(v)
+}
+
+func (n *ClassExpression) UnmarshalJSON(b []byte) error {
+	type plain ClassExpression
+	var v struct {
+		*plain
+		SuperClass json.RawMessage `json:"superClass"`
+	}
+
+	v.plain = (*plain)(n)
+
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	if x, err := decodeExpression(v.SuperClass); err != nil {
+		return err
+	} else {
+		n.SuperClass = x
+	}
+
+	return nil
+}
+
+type MetaProperty struct {
+	BaseNode
+	Meta     *Identifier `json:"meta"`
+	Property *Identifier `json:"property"`
+}
+
+func (*MetaProperty) expressionNode()                    {}
+func (*MetaProperty) isVariableDeclarationOrExpression() {}
+func (*MetaProperty) isBlockStatementOrExpression()      {}
+func (*MetaProperty) isExpressionOrSpreadElement()       {}
+func (*MetaProperty) isExpressionOrPattern()             {}
+func (*MetaProperty) isExpressionOrPrivateIdentifier()   {}
+func (*MetaProperty) isPatternOrExpression()             {}
+func (*MetaProperty) isExpressionOrSuper()               {}
+func (*MetaProperty) isDeclarationOrExpression()         {}
+
+func (n MetaProperty) MarshalJSON() ([]byte, error) {
+	type plain MetaProperty
+	v := plain(n)
+	v.Type = "MetaProperty"
+	return json.Marshal(v)
+}
+
+type ModuleDeclaration interface {
+	Node
+	moduleDeclarationNode()
+}
+
+type ModuleSpecifier interface {
+	Node
+	moduleSpecifierNode()
+}
+
+type ImportSpecifierOrImportDefaultSpecifierOrImportNamespaceSpecifier interface {
+	Node
+	isImportSpecifierOrImportDefaultSpecifierOrImportNamespaceSpecifier()
+}
+
+type ImportDeclaration struct {
+	BaseNode
+	Specifiers []ImportSpecifierOrImportDefaultSpecifierOrImportNamespaceSpecifier `json:"specifiers"`
+	Source     *Literal                                                            `json:"source"`
+	Attributes []*ImportAttribute                                                  `json:"attributes"`
+}
+
+func (*ImportDeclaration) moduleDeclarationNode()          {}
+func (*ImportDeclaration) isStatementOrModuleDeclaration() {}
+
+func (n ImportDeclaration) MarshalJSON() ([]byte, error) {
+	type plain ImportDeclaration
+	v := plain(n)
+	v.Type = "ImportDeclaration"
+	if v.Specifiers == nil {
+		v.Specifiers = []ImportSpecifierOrImportDefaultSpecifierOrImportNamespaceSpecifier{}
+	}
+	if v.Attributes == nil {
+		v.Attributes = []*ImportAttribute{}
+	}
+	return json.Marshal(v)
+}
+
+func (n *ImportDeclaration) UnmarshalJSON(b []byte) error {
+	type plain ImportDeclaration
+	var v struct {
+		*plain
+		Specifiers []json.RawMessage `json:"specifiers"`
+	}
+
+	v.plain = (*plain)(n)
+
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	if v.Specifiers != nil {
+		n.Specifiers = make([]ImportSpecifierOrImportDefaultSpecifierOrImportNamespaceSpecifier, len(v.Specifiers))
+	}
+	for i, e := range v.Specifiers {
+		x, err := decodeImportSpecifierOrImportDefaultSpecifierOrImportNamespaceSpecifier(e)
+		if err != nil {
+			return err
+		}
+
+		n.Specifiers[i] = x
+	}
+
+	return nil
+}
+
+type ImportSpecifier struct {
+	BaseNode
+	Local    *Identifier `json:"local"`
+	Imported *Identifier `json:"imported"`
+}
+
+func (*ImportSpecifier) moduleSpecifierNode()                                                 {}
+func (*ImportSpecifier) isImportSpecifierOrImportDefaultSpecifierOrImportNamespaceSpecifier() {}
+
+func (n ImportSpecifier) MarshalJSON() ([]byte, error) {
+	type plain ImportSpecifier
+	v := plain(n)
+	v.Type = "ImportSpecifier"
+	return json.Marshal(v)
+}
+
+type ImportDefaultSpecifier struct {
+	BaseNode
+	Local *Identifier `json:"local"`
+}
+
+func (*ImportDefaultSpecifier) moduleSpecifierNode() {}
+func (*ImportDefaultSpecifier) isImportSpecifierOrImportDefaultSpecifierOrImportNamespaceSpecifier() {
+}
+
+func (n ImportDefaultSpecifier) MarshalJSON() ([]byte, error) {
+	type plain ImportDefaultSpecifier
+	v := plain(n)
+	v.Type = "ImportDefaultSpecifier"
+	return json.Marshal(v)
+}
+
+type ImportNamespaceSpecifier struct {
+	BaseNode
+	Local *Identifier `json:"local"`
+}
+
+func (*ImportNamespaceSpecifier) moduleSpecifierNode() {}
+func (*ImportNamespaceSpecifier) isImportSpecifierOrImportDefaultSpecifierOrImportNamespaceSpecifier() {
+}
+
+func (n ImportNamespaceSpecifier) MarshalJSON() ([]byte, error) {
+	type plain ImportNamespaceSpecifier
+	v := plain(n)
+	v.Type = "ImportNamespaceSpecifier"
+	return json.Marshal(v)
+}
+
+type IdentifierOrLiteral interface {
+	Node
+	isIdentifierOrLiteral()
+}
+
+type ImportAttribute struct {
+	BaseNode
+	Key   IdentifierOrLiteral `json:"key"`
+	Value *Literal            `json:"value"`
+}
+
+func (n ImportAttribute) MarshalJSON() ([]byte, error) {
+	type plain ImportAttribute
+	v := plain(n)
+	v.Type = "ImportAttribute"
+	return json.Marshal(v)
+}
+
+func (n *ImportAttribute) UnmarshalJSON(b []byte) error {
+	type plain ImportAttribute
+	var v struct {
+		*plain
+		Key json.RawMessage `json:"key"`
+	}
+
+	v.plain = (*plain)(n)
+
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	if x, err := decodeIdentifierOrLiteral(v.Key); err != nil {
+		return err
+	} else {
+		n.Key = x
+	}
+
+	return nil
+}
+
+type ExportNamedDeclaration struct {
+	BaseNode
+	Declaration Declaration        `json:"declaration"`
+	Specifiers  []*ExportSpecifier `json:"specifiers"`
+	Source      *Literal           `json:"source"`
+	Attributes  []*ImportAttribute `json:"attributes"`
+}
+
+func (*ExportNamedDeclaration) moduleDeclarationNode()          {}
+func (*ExportNamedDeclaration) isStatementOrModuleDeclaration() {}
+
+func (n ExportNamedDeclaration) MarshalJSON() ([]byte, error) {
+	type plain ExportNamedDeclaration
+	v := plain(n)
+	v.Type = "ExportNamedDeclaration"
+	if v.Specifiers == nil {
+		v.Specifiers = []*ExportSpecifier{}
+	}
+	if v.Attributes == nil {
+		v.Attributes = []*ImportAttribute{}
+	}
+	return json.Marshal(v)
+}
+
+func (n *ExportNamedDeclaration) UnmarshalJSON(b []byte) error {
+	type plain ExportNamedDeclaration
+	var v struct {
+		*plain
+		Declaration json.RawMessage `json:"declaration"`
+	}
+
+	v.plain = (*plain)(n)
+
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	if x, err := decodeDeclaration(v.Declaration); err != nil {
+		return err
+	} else {
+		n.Declaration = x
+	}
+
+	return nil
+}
+
+type ExportSpecifier struct {
+	BaseNode
+	Local    *Identifier `json:"local"`
+	Exported *Identifier `json:"exported"`
+}
+
+func (*ExportSpecifier) moduleSpecifierNode() {}
+
+func (n ExportSpecifier) MarshalJSON() ([]byte, error) {
+	type plain ExportSpecifier
+	v := plain(n)
+	v.Type = "ExportSpecifier"
+	return json.Marshal(v)
+}
+
+type DeclarationOrExpression interface {
+	Node
+	isDeclarationOrExpression()
+}
+
+type ExportDefaultDeclaration struct {
+	BaseNode
+	Declaration DeclarationOrExpression `json:"declaration"`
+}
+
+func (*ExportDefaultDeclaration) moduleDeclarationNode()          {}
+func (*ExportDefaultDeclaration) isStatementOrModuleDeclaration() {}
+
+func (n ExportDefaultDeclaration) MarshalJSON() ([]byte, error) {
+	type plain ExportDefaultDeclaration
+	v := plain(n)
+	v.Type = "ExportDefaultDeclaration"
+	return json.Marshal(v)
+}
+
+func (n *ExportDefaultDeclaration) UnmarshalJSON(b []byte) error {
+	type plain ExportDefaultDeclaration
+	var v struct {
+		*plain
+		Declaration json.RawMessage `json:"declaration"`
+	}
+
+	v.plain = (*plain)(n)
+
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	if x, err := decodeDeclarationOrExpression(v.Declaration); err != nil {
+		return err
+	} else {
+		n.Declaration = x
+	}
+
+	return nil
+}
+
+type ExportAllDeclaration struct {
+	BaseNode
+	Exported   *Identifier        `json:"exported"`
+	Source     *Literal           `json:"source"`
+	Attributes []*ImportAttribute `json:"attributes"`
+}
+
+func (*ExportAllDeclaration) moduleDeclarationNode()          {}
+func (*ExportAllDeclaration) isStatementOrModuleDeclaration() {}
+
+func (n ExportAllDeclaration) MarshalJSON() ([]byte, error) {
+	type plain ExportAllDeclaration
+	v := plain(n)
+	v.Type = "ExportAllDeclaration"
+	if v.Attributes == nil {
+		v.Attributes = []*ImportAttribute{}
+	}
+	return json.Marshal(v)
+}
+
+// nodeTypes has the concrete types for each value of the type field. Some
+// values have more than one, and the first one that fits is used.
+var nodeTypes = map[string][]func() Node{
+	"Identifier":               {func() Node { return &Identifier{} }},
+	"PrivateIdentifier":        {func() Node { return &PrivateIdentifier{} }},
+	"Literal":                  {func() Node { return &Literal{} }},
+	"Program":                  {func() Node { return &Program{} }},
+	"ExpressionStatement":      {func() Node { return &ExpressionStatement{} }},
+	"BlockStatement":           {func() Node { return &BlockStatement{} }},
+	"EmptyStatement":           {func() Node { return &EmptyStatement{} }},
+	"DebuggerStatement":        {func() Node { return &DebuggerStatement{} }},
+	"WithStatement":            {func() Node { return &WithStatement{} }},
+	"ReturnStatement":          {func() Node { return &ReturnStatement{} }},
+	"LabeledStatement":         {func() Node { return &LabeledStatement{} }},
+	"BreakStatement":           {func() Node { return &BreakStatement{} }},
+	"ContinueStatement":        {func() Node { return &ContinueStatement{} }},
+	"IfStatement":              {func() Node { return &IfStatement{} }},
+	"SwitchStatement":          {func() Node { return &SwitchStatement{} }},
+	"SwitchCase":               {func() Node { return &SwitchCase{} }},
+	"ThrowStatement":           {func() Node { return &ThrowStatement{} }},
+	"TryStatement":             {func() Node { return &TryStatement{} }},
+	"CatchClause":              {func() Node { return &CatchClause{} }},
+	"WhileStatement":           {func() Node { return &WhileStatement{} }},
+	"DoWhileStatement":         {func() Node { return &DoWhileStatement{} }},
+	"ForStatement":             {func() Node { return &ForStatement{} }},
+	"ForInStatement":           {func() Node { return &ForInStatement{} }},
+	"ForOfStatement":           {func() Node { return &ForOfStatement{} }},
+	"FunctionDeclaration":      {func() Node { return &FunctionDeclaration{} }},
+	"VariableDeclaration":      {func() Node { return &VariableDeclaration{} }},
+	"VariableDeclarator":       {func() Node { return &VariableDeclarator{} }},
+	"Decorator":                {func() Node { return &Decorator{} }},
+	"Super":                    {func() Node { return &Super{} }},
+	"ThisExpression":           {func() Node { return &ThisExpression{} }},
+	"ArrowFunctionExpression":  {func() Node { return &ArrowFunctionExpression{} }},
+	"YieldExpression":          {func() Node { return &YieldExpression{} }},
+	"AwaitExpression":          {func() Node { return &AwaitExpression{} }},
+	"ArrayExpression":          {func() Node { return &ArrayExpression{} }},
+	"ObjectExpression":         {func() Node { return &ObjectExpression{} }},
+	"Property":                 {func() Node { return &Property{} }},
+	"FunctionExpression":       {func() Node { return &FunctionExpression{} }},
+	"UnaryExpression":          {func() Node { return &UnaryExpression{} }},
+	"UpdateExpression":         {func() Node { return &UpdateExpression{} }},
+	"BinaryExpression":         {func() Node { return &BinaryExpression{} }},
+	"AssignmentExpression":     {func() Node { return &AssignmentExpression{} }},
+	"LogicalExpression":        {func() Node { return &LogicalExpression{} }},
+	"SpreadElement":            {func() Node { return &SpreadElement{} }},
+	"MemberExpression":         {func() Node { return &MemberExpression{} }},
+	"ChainExpression":          {func() Node { return &ChainExpression{} }},
+	"ConditionalExpression":    {func() Node { return &ConditionalExpression{} }},
+	"CallExpression":           {func() Node { return &CallExpression{} }},
+	"NewExpression":            {func() Node { return &NewExpression{} }},
+	"SequenceExpression":       {func() Node { return &SequenceExpression{} }},
+	"ImportExpression":         {func() Node { return &ImportExpression{} }},
+	"TemplateLiteral":          {func() Node { return &TemplateLiteral{} }},
+	"TaggedTemplateExpression": {func() Node { return &TaggedTemplateExpression{} }},
+	"TemplateElement":          {func() Node { return &TemplateElement{} }},
+	"ObjectPattern":            {func() Node { return &ObjectPattern{} }},
+	"ArrayPattern":             {func() Node { return &ArrayPattern{} }},
+	"RestElement":              {func() Node { return &RestElement{} }},
+	"AssignmentPattern":        {func() Node { return &AssignmentPattern{} }},
+	"ClassBody":                {func() Node { return &ClassBody{} }},
+	"MethodDefinition":         {func() Node { return &MethodDefinition{} }},
+	"PropertyDefinition":       {func() Node { return &PropertyDefinition{} }},
+	"StaticBlock":              {func() Node { return &StaticBlock{} }},
+	"ClassDeclaration":         {func() Node { return &ClassDeclaration{} }},
+	"ClassExpression":          {func() Node { return &ClassExpression{} }},
+	"MetaProperty":             {func() Node { return &MetaProperty{} }},
+	"ImportDeclaration":        {func() Node { return &ImportDeclaration{} }},
+	"ImportSpecifier":          {func() Node { return &ImportSpecifier{} }},
+	"ImportDefaultSpecifier":   {func() Node { return &ImportDefaultSpecifier{} }},
+	"ImportNamespaceSpecifier": {func() Node { return &ImportNamespaceSpecifier{} }},
+	"ImportAttribute":          {func() Node { return &ImportAttribute{} }},
+	"ExportNamedDeclaration":   {func() Node { return &ExportNamedDeclaration{} }},
+	"ExportSpecifier":          {func() Node { return &ExportSpecifier{} }},
+	"ExportDefaultDeclaration": {func() Node { return &ExportDefaultDeclaration{} }},
+	"ExportAllDeclaration":     {func() Node { return &ExportAllDeclaration{} }},
+}
+
+func decodeStatement(b []byte) (Statement, error) {
+	v, err := decodeNode(b, func(n Node) bool { _, ok := n.(Statement); return ok })
+	if v == nil || err != nil {
+		return nil, err
+	}
+
+	return v.(Statement), nil
+}
+
+func decodeDeclaration(b []byte) (Declaration, error) {
+	v, err := decodeNode(b, func(n Node) bool { _, ok := n.(Declaration); return ok })
+	if v == nil || err != nil {
+		return nil, err
+	}
+
+	return v.(Declaration), nil
+}
+
+func decodeExpression(b []byte) (Expression, error) {
+	v, err := decodeNode(b, func(n Node) bool { _, ok := n.(Expression); return ok })
+	if v == nil || err != nil {
+		return nil, err
+	}
+
+	return v.(Expression), nil
+}
+
+func decodePattern(b []byte) (Pattern, error) {
+	v, err := decodeNode(b, func(n Node) bool { _, ok := n.(Pattern); return ok })
+	if v == nil || err != nil {
+		return nil, err
+	}
+
+	return v.(Pattern), nil
+}
+
+func decodeStatementOrModuleDeclaration(b []byte) (StatementOrModuleDeclaration, error) {
+	v, err := decodeNode(b, func(n Node) bool { _, ok := n.(StatementOrModuleDeclaration); return ok })
+	if v == nil || err != nil {
+		return nil, err
+	}
+
+	return v.(StatementOrModuleDeclaration), nil
+}
+
+func decodeVariableDeclarationOrExpression(b []byte) (VariableDeclarationOrExpression, error) {
+	v, err := decodeNode(b, func(n Node) bool { _, ok := n.(VariableDeclarationOrExpression); return ok })
+	if v == nil || err != nil {
+		return nil, err
+	}
+
+	return v.(VariableDeclarationOrExpression), nil
+}
+
+func decodeVariableDeclarationOrPattern(b []byte) (VariableDeclarationOrPattern, error) {
+	v, err := decodeNode(b, func(n Node) bool { _, ok := n.(VariableDeclarationOrPattern); return ok })
+	if v == nil || err != nil {
+		return nil, err
+	}
+
+	return v.(VariableDeclarationOrPattern), nil
+}
+
+func decodeBlockStatementOrExpression(b []byte) (BlockStatementOrExpression, error) {
+	v, err := decodeNode(b, func(n Node) bool { _, ok := n.(BlockStatementOrExpression); return ok })
+	if v == nil || err != nil {
+		return nil, err
+	}
+
+	return v.(BlockStatementOrExpression), nil
+}
+
+func decodeExpressionOrSpreadElement(b []byte) (ExpressionOrSpreadElement, error) {
+	v, err := decodeNode(b, func(n Node) bool { _, ok := n.(ExpressionOrSpreadElement); return ok })
+	if v == nil || err != nil {
+		return nil, err
+	}
+
+	return v.(ExpressionOrSpreadElement), nil
+}
+
+func decodePropertyOrSpreadElement(b []byte) (PropertyOrSpreadElement, error) {
+	v, err := decodeNode(b, func(n Node) bool { _, ok := n.(PropertyOrSpreadElement); return ok })
+	if v == nil || err != nil {
+		return nil, err
+	}
+
+	return v.(PropertyOrSpreadElement), nil
+}
+
+func decodeExpressionOrPattern(b []byte) (ExpressionOrPattern, error) {
+	v, err := decodeNode(b, func(n Node) bool { _, ok := n.(ExpressionOrPattern); return ok })
+	if v == nil || err != nil {
+		return nil, err
+	}
+
+	return v.(ExpressionOrPattern), nil
+}
+
+func decodeExpressionOrPrivateIdentifier(b []byte) (ExpressionOrPrivateIdentifier, error) {
+	v, err := decodeNode(b, func(n Node) bool { _, ok := n.(ExpressionOrPrivateIdentifier); return ok })
+	if v == nil || err != nil {
+		return nil, err
+	}
+
+	return v.(ExpressionOrPrivateIdentifier), nil
+}
+
+func decodePatternOrExpression(b []byte) (PatternOrExpression, error) {
+	v, err := decodeNode(b, func(n Node) bool { _, ok := n.(PatternOrExpression); return ok })
+	if v == nil || err != nil {
+		return nil, err
+	}
+
+	return v.(PatternOrExpression), nil
+}
+
+func decodeExpressionOrSuper(b []byte) (ExpressionOrSuper, error) {
+	v, err := decodeNode(b, func(n Node) bool { _, ok := n.(ExpressionOrSuper); return ok })
+	if v == nil || err != nil {
+		return nil, err
+	}
+
+	return v.(ExpressionOrSuper), nil
+}
+
+func decodeCallExpressionOrMemberExpression(b []byte) (CallExpressionOrMemberExpression, error) {
+	v, err := decodeNode(b, func(n Node) bool { _, ok := n.(CallExpressionOrMemberExpression); return ok })
+	if v == nil || err != nil {
+		return nil, err
+	}
+
+	return v.(CallExpressionOrMemberExpression), nil
+}
+
+func decodePropertyOrRestElement(b []byte) (PropertyOrRestElement, error) {
+	v, err := decodeNode(b, func(n Node) bool { _, ok := n.(PropertyOrRestElement); return ok })
+	if v == nil || err != nil {
+		return nil, err
+	}
+
+	return v.(PropertyOrRestElement), nil
+}
+
+func decodeMethodDefinitionOrPropertyDefinitionOrStaticBlock(b []byte) (MethodDefinitionOrPropertyDefinitionOrStaticBlock, error) {
+	v, err := decodeNode(b, func(n Node) bool { _, ok := n.(MethodDefinitionOrPropertyDefinitionOrStaticBlock); return ok })
+	if v == nil || err != nil {
+		return nil, err
+	}
+
+	return v.(MethodDefinitionOrPropertyDefinitionOrStaticBlock), nil
+}
+
+func decodeImportSpecifierOrImportDefaultSpecifierOrImportNamespaceSpecifier(b []byte) (ImportSpecifierOrImportDefaultSpecifierOrImportNamespaceSpecifier, error) {
+	v, err := decodeNode(b, func(n Node) bool {
+		_, ok := n.(ImportSpecifierOrImportDefaultSpecifierOrImportNamespaceSpecifier)
+		return ok
+	})
+	if v == nil || err != nil {
+		return nil, err
+	}
+
+	return v.(ImportSpecifierOrImportDefaultSpecifierOrImportNamespaceSpecifier), nil
+}
+
+func decodeIdentifierOrLiteral(b []byte) (IdentifierOrLiteral, error) {
+	v, err := decodeNode(b, func(n Node) bool { _, ok := n.(IdentifierOrLiteral); return ok })
+	if v == nil || err != nil {
+		return nil, err
+	}
+
+	return v.(IdentifierOrLiteral), nil
+}
+
+func decodeDeclarationOrExpression(b []byte) (DeclarationOrExpression, error) {
+	v, err := decodeNode(b, func(n Node) bool { _, ok := n.(DeclarationOrExpression); return ok })
+	if v == nil || err != nil {
+		return nil, err
+	}
+
+	return v.(DeclarationOrExpression), nil
+}
+
+func (*Unknown) functionNode()                                                        {}
+func (*Unknown) statementNode()                                                       {}
+func (*Unknown) declarationNode()                                                     {}
+func (*Unknown) expressionNode()                                                      {}
+func (*Unknown) patternNode()                                                         {}
+func (*Unknown) classNode()                                                           {}
+func (*Unknown) moduleDeclarationNode()                                               {}
+func (*Unknown) moduleSpecifierNode()                                                 {}
+func (*Unknown) isStatementOrModuleDeclaration()                                      {}
+func (*Unknown) isVariableDeclarationOrExpression()                                   {}
+func (*Unknown) isVariableDeclarationOrPattern()                                      {}
+func (*Unknown) isBlockStatementOrExpression()                                        {}
+func (*Unknown) isExpressionOrSpreadElement()                                         {}
+func (*Unknown) isPropertyOrSpreadElement()                                           {}
+func (*Unknown) isExpressionOrPattern()                                               {}
+func (*Unknown) isExpressionOrPrivateIdentifier()                                     {}
+func (*Unknown) isPatternOrExpression()                                               {}
+func (*Unknown) isExpressionOrSuper()                                                 {}
+func (*Unknown) isCallExpressionOrMemberExpression()                                  {}
+func (*Unknown) isPropertyOrRestElement()                                             {}
+func (*Unknown) isMethodDefinitionOrPropertyDefinitionOrStaticBlock()                 {}
+func (*Unknown) isImportSpecifierOrImportDefaultSpecifierOrImportNamespaceSpecifier() {}
+func (*Unknown) isIdentifierOrLiteral()                                               {}
+func (*Unknown) isDeclarationOrExpression()                                           {}
+
+// A Visitor's Visit method is invoked for each node encountered by Walk.
+// If the result visitor w is not nil, Walk visits each of the children
+// of node with the visitor w, followed by a call of w.Visit(nil).
+type Visitor interface {
+	Visit(node Node) (w Visitor)
+}
+
+// Walk traverses an AST in depth-first order, visiting the children of
+// each node in the order they're written in source. It starts by calling
+// v.Visit(node); node must not be nil.
+func Walk(v Visitor, node Node) {
+	if v = v.Visit(node); v == nil {
+		return
+	}
+
+	switch n := node.(type) {
+	case *Program:
+		for _, e := range n.Body {
+			if e != nil {
+				Walk(v, e)
+			}
+		}
+	case *ExpressionStatement:
+		if n.Expression != nil {
+			Walk(v, n.Expression)
+		}
+	case *BlockStatement:
+		for _, e := range n.Body {
+			if e != nil {
+				Walk(v, e)
+			}
+		}
+	case *WithStatement:
+		if n.Object != nil {
+			Walk(v, n.Object)
+		}
+		if n.Body != nil {
+			Walk(v, n.Body)
+		}
+	case *ReturnStatement:
+		if n.Argument != nil {
+			Walk(v, n.Argument)
+		}
+	case *LabeledStatement:
+		if n.Label != nil {
+			Walk(v, n.Label)
+		}
+		if n.Body != nil {
+			Walk(v, n.Body)
+		}
+	case *BreakStatement:
+		if n.Label != nil {
+			Walk(v, n.Label)
+		}
+	case *ContinueStatement:
+		if n.Label != nil {
+			Walk(v, n.Label)
+		}
+	case *IfStatement:
+		if n.Test != nil {
+			Walk(v, n.Test)
+		}
+		if n.Consequent != nil {
+			Walk(v, n.Consequent)
+		}
+		if n.Alternate != nil {
+			Walk(v, n.Alternate)
+		}
+	case *SwitchStatement:
+		if n.Discriminant != nil {
+			Walk(v, n.Discriminant)
+		}
+		for _, e := range n.Cases {
+			if e != nil {
+				Walk(v, e)
+			}
+		}
+	case *SwitchCase:
+		if n.Test != nil {
+			Walk(v, n.Test)
+		}
+		for _, e := range n.Consequent {
+			if e != nil {
+				Walk(v, e)
+			}
+		}
+	case *ThrowStatement:
+		if n.Argument != nil {
+			Walk(v, n.Argument)
+		}
+	case *TryStatement:
+		if n.Block != nil {
+			Walk(v, n.Block)
+		}
+		if n.Handler != nil {
+			Walk(v, n.Handler)
+		}
+		if n.Finalizer != nil {
+			Walk(v, n.Finalizer)
+		}
+	case *CatchClause:
+		if n.Param != nil {
+			Walk(v, n.Param)
+		}
+		if n.Body != nil {
+			Walk(v, n.Body)
+		}
+	case *WhileStatement:
+		if n.Test != nil {
+			Walk(v, n.Test)
+		}
+		if n.Body != nil {
+			Walk(v, n.Body)
+		}
+	case *DoWhileStatement:
+		if n.Body != nil {
+			Walk(v, n.Body)
+		}
+		if n.Test != nil {
+			Walk(v, n.Test)
+		}
+	case *ForStatement:
+		if n.Init != nil {
+			Walk(v, n.Init)
+		}
+		if n.Test != nil {
+			Walk(v, n.Test)
+		}
+		if n.Update != nil {
+			Walk(v, n.Update)
+		}
+		if n.Body != nil {
+			Walk(v, n.Body)
+		}
+	case *ForInStatement:
+		if n.Left != nil {
+			Walk(v, n.Left)
+		}
+		if n.Right != nil {
+			Walk(v, n.Right)
+		}
+		if n.Body != nil {
+			Walk(v, n.Body)
+		}
+	case *ForOfStatement:
+		if n.Left != nil {
+			Walk(v, n.Left)
+		}
+		if n.Right != nil {
+			Walk(v, n.Right)
+		}
+		if n.Body != nil {
+			Walk(v, n.Body)
+		}
+	case *FunctionDeclaration:
+		if n.ID != nil {
+			Walk(v, n.ID)
+		}
+		for _, e := range n.Params {
+			if e != nil {
+				Walk(v, e)
+			}
+		}
+		if n.Body != nil {
+			Walk(v, n.Body)
+		}
+	case *VariableDeclaration:
+		for _, e := range n.Declarations {
+			if e != nil {
+				Walk(v, e)
+			}
+		}
+	case *VariableDeclarator:
+		if n.ID != nil {
+			Walk(v, n.ID)
+		}
+		if n.Init != nil {
+			Walk(v, n.Init)
+		}
+	case *Decorator:
+		if n.Expression != nil {
+			Walk(v, n.Expression)
+		}
+	case *ArrowFunctionExpression:
+		if n.ID != nil {
+			Walk(v, n.ID)
+		}
+		for _, e := range n.Params {
+			if e != nil {
+				Walk(v, e)
+			}
+		}
+		if n.Body != nil {
+			Walk(v, n.Body)
+		}
+	case *YieldExpression:
+		if n.Argument != nil {
+			Walk(v, n.Argument)
+		}
+	case *AwaitExpression:
+		if n.Argument != nil {
+			Walk(v, n.Argument)
+		}
+	case *ArrayExpression:
+		for _, e := range n.Elements {
+			if e != nil {
+				Walk(v, e)
+			}
+		}
+	case *ObjectExpression:
+		for _, e := range n.Properties {
+			if e != nil {
+				Walk(v, e)
+			}
+		}
+	case *Property:
+		if n.Key != nil {
+			Walk(v, n.Key)
+		}
+		if n.Value != nil {
+			Walk(v, n.Value)
+		}
+	case *FunctionExpression:
+		if n.ID != nil {
+			Walk(v, n.ID)
+		}
+		for _, e := range n.Params {
+			if e != nil {
+				Walk(v, e)
+			}
+		}
+		if n.Body != nil {
+			Walk(v, n.Body)
+		}
+	case *UnaryExpression:
+		if n.Argument != nil {
+			Walk(v, n.Argument)
+		}
+	case *UpdateExpression:
+		if n.Argument != nil {
+			Walk(v, n.Argument)
+		}
+	case *BinaryExpression:
+		if n.Left != nil {
+			Walk(v, n.Left)
+		}
+		if n.Right != nil {
+			Walk(v, n.Right)
+		}
+	case *AssignmentExpression:
+		if n.Left != nil {
+			Walk(v, n.Left)
+		}
+		if n.Right != nil {
+			Walk(v, n.Right)
+		}
+	case *LogicalExpression:
+		if n.Left != nil {
+			Walk(v, n.Left)
+		}
+		if n.Right != nil {
+			Walk(v, n.Right)
+		}
+	case *SpreadElement:
+		if n.Argument != nil {
+			Walk(v, n.Argument)
+		}
+	case *MemberExpression:
+		if n.Object != nil {
+			Walk(v, n.Object)
+		}
+		if n.Property != nil {
+			Walk(v, n.Property)
+		}
+	case *ChainExpression:
+		if n.Expression != nil {
+			Walk(v, n.Expression)
+		}
+	case *ConditionalExpression:
+		if n.Test != nil {
+			Walk(v, n.Test)
+		}
+		if n.Consequent != nil {
+			Walk(v, n.Consequent)
+		}
+		if n.Alternate != nil {
+			Walk(v, n.Alternate)
+		}
+	case *CallExpression:
+		if n.Callee != nil {
+			Walk(v, n.Callee)
+		}
+		for _, e := range n.Arguments {
+			if e != nil {
+				Walk(v, e)
+			}
+		}
+	case *NewExpression:
+		if n.Callee != nil {
+			Walk(v, n.Callee)
+		}
+		for _, e := range n.Arguments {
+			if e != nil {
+				Walk(v, e)
+			}
+		}
+	case *SequenceExpression:
+		for _, e := range n.Expressions {
+			if e != nil {
+				Walk(v, e)
+			}
+		}
+	case *ImportExpression:
+		if n.Source != nil {
+			Walk(v, n.Source)
+		}
+		if n.Options != nil {
+			Walk(v, n.Options)
+		}
+	case *TemplateLiteral:
+		for i, e := range n.Quasis {
+			if e != nil {
+				Walk(v, e)
+			}
+			if i < len(n.Expressions) && n.Expressions[i] != nil {
+				Walk(v, n.Expressions[i])
+			}
+		}
+	case *TaggedTemplateExpression:
+		if n.Tag != nil {
+			Walk(v, n.Tag)
+		}
+		if n.Quasi != nil {
+			Walk(v, n.Quasi)
+		}
+	case *ObjectPattern:
+		for _, e := range n.Properties {
+			if e != nil {
+				Walk(v, e)
+			}
+		}
+	case *ArrayPattern:
+		for _, e := range n.Elements {
+			if e != nil {
+				Walk(v, e)
+			}
+		}
+	case *RestElement:
+		if n.Argument != nil {
+			Walk(v, n.Argument)
+		}
+	case *AssignmentPattern:
+		if n.Left != nil {
+			Walk(v, n.Left)
+		}
+		if n.Right != nil {
+			Walk(v, n.Right)
+		}
+	case *ClassBody:
+		for _, e := range n.Body {
+			if e != nil {
+				Walk(v, e)
+			}
+		}
+	case *MethodDefinition:
+		for _, e := range n.Decorators {
+			if e != nil {
+				Walk(v, e)
+			}
+		}
+		if n.Key != nil {
+			Walk(v, n.Key)
+		}
+		if n.Value != nil {
+			Walk(v, n.Value)
+		}
+	case *PropertyDefinition:
+		for _, e := range n.Decorators {
+			if e != nil {
+				Walk(v, e)
+			}
+		}
+		if n.Key != nil {
+			Walk(v, n.Key)
+		}
+		if n.Value != nil {
+			Walk(v, n.Value)
+		}
+	case *StaticBlock:
+		for _, e := range n.Body {
+			if e != nil {
+				Walk(v, e)
+			}
+		}
+	case *ClassDeclaration:
+		for _, e := range n.Decorators {
+			if e != nil {
+				Walk(v, e)
+			}
+		}
+		if n.ID != nil {
+			Walk(v, n.ID)
+		}
+		if n.SuperClass != nil {
+			Walk(v, n.SuperClass)
+		}
+		if n.Body != nil {
+			Walk(v, n.Body)
+		}
+	case *ClassExpression:
+		for _, e := range n.Decorators {
+			if e != nil {
+				Walk(v, e)
+			}
+		}
+		if n.ID != nil {
+			Walk(v, n.ID)
+		}
+		if n.SuperClass != nil {
+			Walk(v, n.SuperClass)
+		}
+		if n.Body != nil {
+			Walk(v, n.Body)
+		}
+	case *MetaProperty:
+		if n.Meta != nil {
+			Walk(v, n.Meta)
+		}
+		if n.Property != nil {
+			Walk(v, n.Property)
+		}
+	case *ImportDeclaration:
+		for _, e := range n.Specifiers {
+			if e != nil {
+				Walk(v, e)
+			}
+		}
+		if n.Source != nil {
+			Walk(v, n.Source)
+		}
+		for _, e := range n.Attributes {
+			if e != nil {
+				Walk(v, e)
+			}
+		}
+	case *ImportSpecifier:
+		if n.Imported != nil {
+			Walk(v, n.Imported)
+		}
+		if n.Local != nil {
+			Walk(v, n.Local)
+		}
+	case *ImportDefaultSpecifier:
+		if n.Local != nil {
+			Walk(v, n.Local)
+		}
+	case *ImportNamespaceSpecifier:
+		if n.Local != nil {
+			Walk(v, n.Local)
+		}
+	case *ImportAttribute:
+		if n.Key != nil {
+			Walk(v, n.Key)
+		}
+		if n.Value != nil {
+			Walk(v, n.Value)
+		}
+	case *ExportNamedDeclaration:
+		if n.Declaration != nil {
+			Walk(v, n.Declaration)
+		}
+		for _, e := range n.Specifiers {
+			if e != nil {
+				Walk(v, e)
+			}
+		}
+		if n.Source != nil {
+			Walk(v, n.Source)
+		}
+		for _, e := range n.Attributes {
+			if e != nil {
+				Walk(v, e)
+			}
+		}
+	case *ExportSpecifier:
+		if n.Local != nil {
+			Walk(v, n.Local)
+		}
+		if n.Exported != nil {
+			Walk(v, n.Exported)
+		}
+	case *ExportDefaultDeclaration:
+		if n.Declaration != nil {
+			Walk(v, n.Declaration)
+		}
+	case *ExportAllDeclaration:
+		if n.Exported != nil {
+			Walk(v, n.Exported)
+		}
+		if n.Source != nil {
+			Walk(v, n.Source)
+		}
+		for _, e := range n.Attributes {
+			if e != nil {
+				Walk(v, e)
+			}
+		}
+	}
+
+	v.Visit(nil)
+}
+
+type inspector func(Node) bool
+
+func (f inspector) Visit(node Node) Visitor {
+	if f(node) {
+		return f
+	}
+
+	return nil
+}
+
+// Inspect traverses an AST in depth-first order: It starts by calling
+// f(node); node must not be nil. If f returns true, Inspect invokes f
+// recursively for each of the non-nil children of node, followed by a
+// call of f(nil).
+func Inspect(node Node, f func(Node) bool) {
+	Walk(inspector(f), node)
+}
@@ -0,0 +1,131 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"fknsrs.biz/p/jsparser"
+	"fknsrs.biz/p/jsparser/earley"
+)
+
+var (
+	dirFlag     = flag.String("dir", ".", "Directory of JavaScript files to measure")
+	grammarFlag = flag.String("grammar", "./internal/parser_generator/es6.ebnf", "Location of the EBNF grammar")
+	htmlFlag    = flag.String("html", "", "Write an HTML report to this file")
+	jsonFlag    = flag.String("json", "", "Write a JSON report to this file")
+)
+
+// goal picks the goal symbol for a file. .mjs files are modules, and so is
+// anything that only parses as one.
+func goal(g *earley.Grammar, path string, tokens jsparser.TokenSet) (string, *earley.Node, error) {
+	if strings.HasSuffix(path, ".mjs") {
+		n, err := g.Parse(earley.Module, tokens)
+		return earley.Module, n, err
+	}
+
+	n, err := g.Parse(earley.Script, tokens)
+	if err == nil {
+		return earley.Script, n, nil
+	}
+
+	if m, merr := g.Parse(earley.Module, tokens); merr == nil {
+		return earley.Module, m, nil
+	}
+
+	return earley.Script, nil, err
+}
+
+// addDir parses every JavaScript file under dir, and adds up the rules used
+// by the ones that parse.
+func (c *coverage) addDir(dir string) error {
+	return filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if info.IsDir() || !(strings.HasSuffix(path, ".js") || strings.HasSuffix(path, ".mjs")) {
+			return nil
+		}
+
+		b, err := ioutil.ReadFile(path)
+		if err != nil {
+			return err
+		}
+
+		f := fileResult{Path: path}
+
+		tokens, err := jsparser.ParseBytes(b)
+		if err != nil {
+			f.Error = err.Error()
+			c.files = append(c.files, f)
+			return nil
+		}
+
+		f.Tokens = len(tokens)
+
+		goal, n, err := goal(c.g, path, tokens)
+		f.Goal = goal
+		if err != nil {
+			f.Error = err.Error()
+		} else {
+			c.add(n)
+		}
+
+		c.files = append(c.files, f)
+
+		return nil
+	})
+}
+
+func main() {
+	flag.Parse()
+
+	g, err := earley.LoadFile(*grammarFlag)
+	if err != nil {
+		panic(err)
+	}
+
+	c := newCoverage(g)
+
+	if err := c.addDir(*dirFlag); err != nil {
+		panic(err)
+	}
+
+	r := c.report()
+
+	if *jsonFlag != "" {
+		b, err := json.MarshalIndent(r, "", "  ")
+		if err != nil {
+			panic(err)
+		}
+
+		if err := ioutil.WriteFile(*jsonFlag, append(b, '\n'), 0644); err != nil {
+			panic(err)
+		}
+	}
+
+	if *htmlFlag != "" {
+		fd, err := os.Create(*htmlFlag)
+		if err != nil {
+			panic(err)
+		}
+
+		if err := r.HTML(fd); err != nil {
+			panic(err)
+		}
+
+		if err := fd.Close(); err != nil {
+			panic(err)
+		}
+	}
+
+	fmt.Printf("%d files, %d failed\n", len(r.Files), r.Summary.Failed)
+	fmt.Printf("productions:  %d/%d\n", r.Summary.ProductionsHit, r.Summary.Productions)
+	fmt.Printf("alternatives: %d/%d\n", r.Summary.AlternativesHit, r.Summary.Alternatives)
+	fmt.Printf("variants:     %d/%d\n", r.Summary.VariantsHit, r.Summary.Variants)
+}
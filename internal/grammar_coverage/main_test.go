@@ -0,0 +1,127 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"fknsrs.biz/p/jsparser"
+	"fknsrs.biz/p/jsparser/earley"
+)
+
+func loadGrammar(t *testing.T) *earley.Grammar {
+	g, err := earley.LoadFile("../parser_generator/es6.ebnf")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return g
+}
+
+func TestGoal(t *testing.T) {
+	a := assert.New(t)
+
+	g := loadGrammar(t)
+
+	for _, c := range []struct {
+		path, source, goal string
+		ok                 bool
+	}{
+		{"a.js", "a;\n", earley.Script, true},
+		{"a.mjs", "a;\n", earley.Module, true},
+		{"a.js", "import a from \"b\";\n", earley.Module, true},
+		{"a.mjs", "import a from \"b\";\n", earley.Module, true},
+		{"a.js", "var a = ;\n", earley.Script, false},
+		{"a.mjs", "var = 1;\n", earley.Module, false},
+	} {
+		tokens, err := jsparser.ParseString(c.source)
+		if !a.NoError(err, c.source) {
+			continue
+		}
+
+		goal, n, err := goal(g, c.path, tokens)
+		a.Equal(c.goal, goal, "%s: %s", c.path, c.source)
+
+		if c.ok {
+			a.NoError(err, "%s: %s", c.path, c.source)
+			a.NotNil(n, "%s: %s", c.path, c.source)
+		} else {
+			a.Error(err, "%s: %s", c.path, c.source)
+			a.Nil(n, "%s: %s", c.path, c.source)
+		}
+	}
+}
+
+func TestCoverage(t *testing.T) {
+	a := assert.New(t)
+
+	c := newCoverage(loadGrammar(t))
+	if err := c.addDir("testdata"); err != nil {
+		t.Fatal(err)
+	}
+
+	r := c.report()
+
+	var paths, goals []string
+	for _, f := range r.Files {
+		paths = append(paths, f.Path)
+		goals = append(goals, f.Goal)
+	}
+
+	a.Equal([]string{"testdata/broken.js", "testdata/module.js", "testdata/script.js", "testdata/x.mjs"}, paths)
+	a.Equal([]string{earley.Script, earley.Module, earley.Script, earley.Module}, goals)
+	a.NotEmpty(r.Files[0].Error)
+
+	a.Equal(4, r.Summary.Files)
+	a.Equal(1, r.Summary.Failed)
+
+	m := make(map[string]productionCoverage)
+	for _, p := range r.Productions {
+		m[p.Name] = p
+	}
+
+	// script.js uses VariableStatement, outside of a generator.
+	a.Equal(productionCoverage{Name: "VariableStatement", Hits: 1, Variants: []variantCoverage{
+		{Name: "VariableStatement", Hits: 1, Alternatives: []alternativeCoverage{{Rule: `"var" VariableDeclarationList_In ";"`, Hits: 1}}},
+		{Name: "VariableStatement_Yield", Alternatives: []alternativeCoverage{{Rule: `"var" VariableDeclarationList_In_Yield ";"`}}},
+	}}, m["VariableStatement"])
+
+	// module.js imports, and x.mjs and script.js each have a statement in
+	// their top level list, only one of which is a module's.
+	a.Equal(1, m["ImportDeclaration"].Hits)
+	a.Equal(1, m["ImportDeclaration"].Variants[0].Alternatives[0].Hits)
+	a.Equal(0, m["ImportDeclaration"].Variants[0].Alternatives[1].Hits)
+	a.Equal(2, m["ModuleItem"].Hits)
+	a.Equal(1, m["ScriptBody"].Hits)
+	a.Equal(1, m["ExpressionStatement"].Hits)
+	a.Equal(0, m["WithStatement"].Hits)
+
+	var productionsHit, variants, variantsHit, alternatives, alternativesHit int
+	for _, p := range r.Productions {
+		if p.Hits > 0 {
+			productionsHit++
+		}
+
+		for _, v := range p.Variants {
+			variants++
+			if v.Hits > 0 {
+				variantsHit++
+			}
+
+			for _, e := range v.Alternatives {
+				alternatives++
+				if e.Hits > 0 {
+					alternativesHit++
+				}
+			}
+		}
+	}
+
+	a.Equal(len(r.Productions), r.Summary.Productions)
+	a.Equal(productionsHit, r.Summary.ProductionsHit)
+	a.Equal(variants, r.Summary.Variants)
+	a.Equal(variantsHit, r.Summary.VariantsHit)
+	a.Equal(alternatives, r.Summary.Alternatives)
+	a.Equal(alternativesHit, r.Summary.AlternativesHit)
+	a.True(r.Summary.ProductionsHit > 0 && r.Summary.ProductionsHit < r.Summary.Productions)
+}
@@ -0,0 +1,197 @@
+package main
+
+import (
+	"html/template"
+	"io"
+	"strings"
+	"unicode"
+
+	"fknsrs.biz/p/jsparser/earley"
+)
+
+type fileResult struct {
+	Path   string `json:"path"`
+	Goal   string `json:"goal,omitempty"`
+	Tokens int    `json:"tokens,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+type alternativeCoverage struct {
+	Rule string `json:"rule"`
+	Hits int    `json:"hits"`
+}
+
+// variantCoverage is one parameterised variant of a production, like
+// Expression_In_Yield. A production without parameters has a single variant
+// with the same name.
+type variantCoverage struct {
+	Name         string                `json:"name"`
+	Hits         int                   `json:"hits"`
+	Alternatives []alternativeCoverage `json:"alternatives"`
+}
+
+type productionCoverage struct {
+	Name     string            `json:"name"`
+	Hits     int               `json:"hits"`
+	Variants []variantCoverage `json:"variants"`
+}
+
+type summary struct {
+	Files           int `json:"files"`
+	Failed          int `json:"failed"`
+	Productions     int `json:"productions"`
+	ProductionsHit  int `json:"productionsHit"`
+	Variants        int `json:"variants"`
+	VariantsHit     int `json:"variantsHit"`
+	Alternatives    int `json:"alternatives"`
+	AlternativesHit int `json:"alternativesHit"`
+}
+
+type report struct {
+	Summary     summary              `json:"summary"`
+	Files       []fileResult         `json:"files"`
+	Productions []productionCoverage `json:"productions"`
+}
+
+// coverage adds up the rules used to parse each file in the corpus.
+type coverage struct {
+	g     *earley.Grammar
+	files []fileResult
+	uses  map[*earley.Rule]int
+}
+
+func newCoverage(g *earley.Grammar) *coverage {
+	return &coverage{g: g, uses: make(map[*earley.Rule]int)}
+}
+
+func (c *coverage) add(n *earley.Node) {
+	for r, i := range n.Uses() {
+		c.uses[r] += i
+	}
+}
+
+// named reports whether a production comes from the grammar, rather than
+// being a helper for an optional item or a group.
+func named(n string) bool {
+	for _, r := range n {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_' {
+			return false
+		}
+	}
+
+	return n != ""
+}
+
+func (c *coverage) report() *report {
+	r := report{Files: c.files}
+
+	r.Summary.Files = len(c.files)
+	for _, f := range c.files {
+		if f.Error != "" {
+			r.Summary.Failed++
+		}
+	}
+
+	index := make(map[string]int)
+
+	for _, n := range c.g.Syntactic(earley.Script, earley.Module) {
+		if !named(n) {
+			continue
+		}
+
+		b := strings.SplitN(n, "_", 2)[0]
+
+		i, ok := index[b]
+		if !ok {
+			i = len(r.Productions)
+			index[b] = i
+			r.Productions = append(r.Productions, productionCoverage{Name: b})
+		}
+
+		v := variantCoverage{Name: n}
+		for _, rule := range c.g.Rules[n] {
+			a := make([]string, len(rule.Symbols))
+			for j, s := range rule.Symbols {
+				a[j] = s.String()
+			}
+
+			h := c.uses[rule]
+			v.Hits += h
+			v.Alternatives = append(v.Alternatives, alternativeCoverage{Rule: strings.Join(a, " "), Hits: h})
+
+			r.Summary.Alternatives++
+			if h > 0 {
+				r.Summary.AlternativesHit++
+			}
+		}
+
+		r.Summary.Variants++
+		if v.Hits > 0 {
+			r.Summary.VariantsHit++
+		}
+
+		p := &r.Productions[i]
+		p.Hits += v.Hits
+		p.Variants = append(p.Variants, v)
+	}
+
+	r.Summary.Productions = len(r.Productions)
+	for _, p := range r.Productions {
+		if p.Hits > 0 {
+			r.Summary.ProductionsHit++
+		}
+	}
+
+	return &r
+}
+
+var htmlReport = template.Must(template.New("report").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Grammar coverage</title>
+<style>
+body { font-family: sans-serif; }
+table { border-collapse: collapse; }
+td, th { padding: 2px 8px; text-align: left; vertical-align: top; }
+code { white-space: pre-wrap; }
+.hit { background: #dfd; }
+.miss { background: #fdd; }
+h3 { margin-bottom: 4px; }
+</style>
+</head>
+<body>
+<h1>Grammar coverage</h1>
+{{with .Summary}}
+<table>
+<tr><th>Files</th><td>{{.Files}} ({{.Failed}} failed)</td></tr>
+<tr><th>Productions</th><td>{{.ProductionsHit}} / {{.Productions}}</td></tr>
+<tr><th>Variants</th><td>{{.VariantsHit}} / {{.Variants}}</td></tr>
+<tr><th>Alternatives</th><td>{{.AlternativesHit}} / {{.Alternatives}}</td></tr>
+</table>
+{{end}}
+<h2>Productions</h2>
+{{range .Productions}}
+<h3 id="{{.Name}}" class="{{if .Hits}}hit{{else}}miss{{end}}">{{.Name}} ({{.Hits}})</h3>
+<table>
+{{range .Variants}}
+<tr class="{{if .Hits}}hit{{else}}miss{{end}}"><th colspan="2">{{.Name}}</th></tr>
+{{range .Alternatives}}
+<tr class="{{if .Hits}}hit{{else}}miss{{end}}"><td>{{.Hits}}</td><td><code>{{.Rule}}</code></td></tr>
+{{end}}
+{{end}}
+</table>
+{{end}}
+<h2>Files</h2>
+<table>
+{{range .Files}}
+<tr class="{{if .Error}}miss{{else}}hit{{end}}"><td>{{.Path}}</td><td>{{.Goal}}</td><td>{{.Error}}</td></tr>
+{{end}}
+</table>
+</body>
+</html>
+`))
+
+func (r *report) HTML(w io.Writer) error {
+	return htmlReport.Execute(w, r)
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// annotation turns an emu-gann element into a token. These are the bracketed
+// grammar annotations, e.g. `[lookahead ∉ { {, function }]`, `[empty]` or
+// `[no LineTerminator here]`.
+func annotation(s *goquery.Selection) (token, error) {
+	text := strings.Join(strings.Fields(s.Text()), " ")
+
+	switch {
+	case text == "[empty]":
+		return token{empty: true}, nil
+	case text == "[no LineTerminator here]":
+		return token{noLineTerminator: true}, nil
+	case strings.HasPrefix(text, "[lookahead "):
+		t := token{lookahead: true}
+
+		switch op := strings.TrimPrefix(text, "[lookahead "); {
+		case strings.HasPrefix(op, "∉"), strings.HasPrefix(op, "≠"):
+			t.inverse = true
+		case strings.HasPrefix(op, "∈"), strings.HasPrefix(op, "="):
+		default:
+			return token{}, fmt.Errorf("unrecognised lookahead operator in %q", text)
+		}
+
+		t.set = annotationSet(s)
+		if len(t.set) == 0 {
+			return token{}, fmt.Errorf("empty lookahead set in %q", text)
+		}
+
+		return t, nil
+	}
+
+	return token{}, fmt.Errorf("unrecognised grammar annotation %q", text)
+}
+
+// annotationSet reads the comma separated token sequences out of a lookahead
+// annotation. A multi-token sequence like `let [` stays together as one entry.
+func annotationSet(s *goquery.Selection) [][]token {
+	var set [][]token
+	var seq []token
+
+	s.Contents().Each(func(i int, c *goquery.Selection) {
+		switch goquery.NodeName(c) {
+		case "#text":
+			for n := strings.Count(c.Text(), ","); n > 0; n-- {
+				if len(seq) > 0 {
+					set = append(set, seq)
+				}
+
+				seq = nil
+			}
+		case "emu-t", "emu-nt":
+			seq = append(seq, annotationToken(c))
+		}
+	})
+
+	if len(seq) > 0 {
+		set = append(set, seq)
+	}
+
+	return set
+}
+
+// annotationTokens returns every terminal and nonterminal mentioned in an
+// annotation, e.g. the alternatives in `but not one of " or \ or LineTerminator`.
+func annotationTokens(s *goquery.Selection) []token {
+	var a []token
+
+	s.Find("emu-t, emu-nt").Each(func(i int, c *goquery.Selection) {
+		a = append(a, annotationToken(c))
+	})
+
+	return a
+}
+
+func annotationToken(s *goquery.Selection) token {
+	if goquery.NodeName(s) == "emu-t" {
+		return token{terminal: true, value: s.Text()}
+	}
+
+	if a := s.Find("a"); a.Length() > 0 {
+		return token{value: a.Text()}
+	}
+
+	return token{value: s.Text()}
+}
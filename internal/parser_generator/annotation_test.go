@@ -0,0 +1,85 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/stretchr/testify/assert"
+)
+
+func selection(t *testing.T, html, selector string) *goquery.Selection {
+	d, err := goquery.NewDocumentFromReader(strings.NewReader(html))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return d.Find(selector)
+}
+
+func TestAnnotation(t *testing.T) {
+	a := assert.New(t)
+
+	for _, c := range []struct {
+		html, out, err string
+	}{
+		{`<emu-gann>[empty]</emu-gann>`, "[empty]", ""},
+		{`<emu-gann>[no <emu-nt><a>LineTerminator</a></emu-nt> here]</emu-gann>`, "[no LineTerminator here]", ""},
+		{`<emu-gann>[lookahead ≠ <emu-t>let</emu-t>]</emu-gann>`, `[lookahead ≠ "let"]`, ""},
+		{`<emu-gann>[lookahead = <emu-t>in</emu-t>]</emu-gann>`, `[lookahead = "in"]`, ""},
+		{`<emu-gann>[lookahead ∉ <emu-nt><a>DecimalDigit</a></emu-nt>]</emu-gann>`, `[lookahead ∉ DecimalDigit]`, ""},
+		{`<emu-gann>[lookahead ∈ { <emu-t>0</emu-t>, <emu-t>1</emu-t> }]</emu-gann>`, `[lookahead ∈ { "0", "1" }]`, ""},
+		{
+			`<emu-gann>[lookahead ∉ { <emu-t>{</emu-t>, <emu-t>function</emu-t>, <emu-t>let</emu-t> <emu-t>[</emu-t> }]</emu-gann>`,
+			`[lookahead ∉ { "{", "function", "let" "[" }]`, "",
+		},
+		{`<emu-gann>[lookahead ∉ { }]</emu-gann>`, "", `empty lookahead set in "[lookahead ∉ { }]"`},
+		{`<emu-gann>[lookahead ⊂ <emu-t>x</emu-t>]</emu-gann>`, "", `unrecognised lookahead operator in "[lookahead ⊂ x]"`},
+		{`<emu-gann>[whatever]</emu-gann>`, "", `unrecognised grammar annotation "[whatever]"`},
+	} {
+		tk, err := annotation(selection(t, c.html, "emu-gann"))
+		if c.err != "" {
+			a.EqualError(err, c.err, c.html)
+			continue
+		}
+
+		if a.NoError(err, c.html) {
+			a.Equal(c.out, tk.String(), c.html)
+		}
+	}
+}
+
+func TestScrapeRuleButNot(t *testing.T) {
+	a := assert.New(t)
+
+	for _, c := range []struct {
+		html, out, err string
+	}{
+		{
+			`<emu-rhs><emu-nt><a>IdentifierName</a></emu-nt> <emu-gmod>but not <emu-nt><a>ReservedWord</a></emu-nt></emu-gmod></emu-rhs>`,
+			"IdentifierName but not ReservedWord", "",
+		},
+		{
+			`<emu-rhs><emu-nt><a>SourceCharacter</a></emu-nt> <emu-gmod>but not one of <emu-t>"</emu-t> or <emu-t>\</emu-t> or <emu-nt><a>LineTerminator</a></emu-nt></emu-gmod></emu-rhs>`,
+			`SourceCharacter but not "\"" or "\\" or LineTerminator`, "",
+		},
+		{
+			`<emu-rhs><emu-gmod>but not <emu-t>x</emu-t></emu-gmod></emu-rhs>`,
+			"", `Thing: "but not x" doesn't follow a token`,
+		},
+		{
+			`<emu-rhs><emu-nt><a>A</a></emu-nt> <emu-gmod>except <emu-t>x</emu-t></emu-gmod></emu-rhs>`,
+			"", `Thing: unrecognised grammar modifier "except x"`,
+		},
+	} {
+		r, err := scrapeRule("Thing", selection(t, c.html, "emu-rhs"))
+		if c.err != "" {
+			a.EqualError(err, c.err, c.html)
+			continue
+		}
+
+		if a.NoError(err, c.html) {
+			a.Equal(c.out, r.String(), c.html)
+		}
+	}
+}
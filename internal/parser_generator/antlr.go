@@ -0,0 +1,168 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// antlrKeywords can't be used as rule names in a .g4 file.
+var antlrKeywords = map[string]bool{
+	"catch":    true,
+	"finally":  true,
+	"fragment": true,
+	"grammar":  true,
+	"import":   true,
+	"lexer":    true,
+	"locals":   true,
+	"mode":     true,
+	"options":  true,
+	"parser":   true,
+	"returns":  true,
+	"throws":   true,
+	"tokens":   true,
+}
+
+// antlrCodePoints maps the code point names used in the specification's
+// grammar prose to ANTLR literals.
+var antlrCodePoints = map[string]string{
+	"TAB":    `'\t'`,
+	"VT":     `'\u000B'`,
+	"FF":     `'\f'`,
+	"SP":     `' '`,
+	"NBSP":   `'\u00A0'`,
+	"ZWNBSP": `'\uFEFF'`,
+	"LF":     `'\n'`,
+	"CR":     `'\r'`,
+	"LS":     `'\u2028'`,
+	"PS":     `'\u2029'`,
+	"ZWNJ":   `'\u200C'`,
+	"ZWJ":    `'\u200D'`,
+}
+
+// antlrRule turns a production variant name into a parser rule name.
+func antlrRule(s string) string {
+	r := []rune(s)
+	r[0] = unicode.ToLower(r[0])
+	s = string(r)
+
+	if antlrKeywords[s] {
+		s += "_"
+	}
+
+	return s
+}
+
+func antlrLiteral(s string) string {
+	return "'" + strings.NewReplacer("\\", "\\\\", "'", "\\'", "\n", "\\n", "\r", "\\r", "\t", "\\t").Replace(s) + "'"
+}
+
+// antlrProse returns the literal for a code point named in grammar prose, or
+// the name of a token to stand in for anything else.
+func antlrProse(s string, tokens map[string]bool) string {
+	s = strings.Trim(s, "<>")
+
+	if l, ok := antlrCodePoints[s]; ok {
+		return l
+	}
+
+	var b []rune
+	for _, r := range strings.ToUpper(s) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b = append(b, r)
+		} else if len(b) > 0 && b[len(b)-1] != '_' {
+			b = append(b, '_')
+		}
+	}
+
+	n := strings.Trim(string(b), "_")
+	tokens[n] = true
+
+	return n
+}
+
+// ANTLR renders an expanded token, as found in a grammar, for a .g4 file.
+// Any gprose tokens are recorded in tokens so that they can be declared.
+// ANTLR has no way to express lookahead restrictions, [no LineTerminator
+// here] or "but not" without target-specific predicates, so those are kept as
+// comments for whoever wires up the predicates.
+func (t token) ANTLR(tokens map[string]bool) string {
+	var s string
+
+	switch {
+	case t.lookahead:
+		return "/* " + lookaheadString(t.inverse, t.set, func(e token) string { return e.ANTLR(tokens) }) + " */"
+	case t.noLineTerminator:
+		return "/* [no LineTerminator here] */"
+	case t.empty:
+		return "/* [empty] */"
+	case t.prose:
+		s = antlrProse(t.value, tokens)
+	case t.terminal:
+		s = antlrLiteral(t.value)
+	case t.oneof:
+		a := make([]string, len(t.values))
+		for i, e := range t.values {
+			a[i] = antlrLiteral(e)
+		}
+
+		s = "(" + strings.Join(a, " | ") + ")"
+	default:
+		s = antlrRule(t.value)
+	}
+
+	if t.optional {
+		s += "?"
+	}
+
+	if len(t.butNot) > 0 {
+		a := make([]string, len(t.butNot))
+		for i, e := range t.butNot {
+			a[i] = e.ANTLR(tokens)
+		}
+
+		s += " /* but not " + strings.Join(a, " or ") + " */"
+	}
+
+	return s
+}
+
+// antlr renders a whole grammar as an ANTLR4 combined grammar, with every
+// parameterised production expanded into one rule per variant.
+func antlr(name string, g *grammar) string {
+	tokens := make(map[string]bool)
+
+	var rules []string
+	for _, n := range g.names {
+		alts := g.rules[n]
+
+		if len(alts) == 0 {
+			rules = append(rules, fmt.Sprintf("// %s has no alternatives with its parameters\n%s\n  : { false }?\n  ;", n, antlrRule(n)))
+			continue
+		}
+
+		a := make([]string, len(alts))
+		for i, alt := range alts {
+			b := make([]string, len(alt))
+			for j, t := range alt {
+				b[j] = t.ANTLR(tokens)
+			}
+
+			a[i] = strings.Join(b, " ")
+		}
+
+		rules = append(rules, fmt.Sprintf("%s\n  : %s\n  ;", antlrRule(n), strings.Join(a, "\n  | ")))
+	}
+
+	s := fmt.Sprintf("grammar %s;\n\n", name)
+
+	if len(tokens) > 0 {
+		s += "// These stand in for the grammar prose in the specification, and need\n"
+		s += "// lexer rules of their own.\n"
+		s += "tokens { " + strings.Join(keys(tokens), ", ") + " }\n\n"
+	}
+
+	s += strings.Join(rules, "\n\n") + "\n"
+
+	return s
+}
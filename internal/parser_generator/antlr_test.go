@@ -0,0 +1,69 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestANTLRRule(t *testing.T) {
+	for _, c := range []struct{ in, out string }{
+		{"Script", "script"},
+		{"Expression_In_Yield", "expression_In_Yield"},
+		{"Import", "import_"},
+		{"Catch", "catch_"},
+		{"Finally_Yield", "finally_Yield"},
+		{"Tokens", "tokens_"},
+		{"A", "a"},
+	} {
+		assert.Equal(t, c.out, antlrRule(c.in), c.in)
+	}
+}
+
+func TestANTLRProse(t *testing.T) {
+	a := assert.New(t)
+
+	tokens := make(map[string]bool)
+
+	a.Equal(`' '`, antlrProse("<SP>", tokens))
+	a.Equal(`'\u2028'`, antlrProse("<LS>", tokens))
+	a.Equal("ANY_UNICODE_CODE_POINT", antlrProse("<any Unicode code point>", tokens))
+	a.Equal("ANY_CODE_POINT_WITH_PROPERTY_ID_START", antlrProse("<any code point with property “ID_Start”>", tokens))
+	a.Equal(map[string]bool{"ANY_UNICODE_CODE_POINT": true, "ANY_CODE_POINT_WITH_PROPERTY_ID_START": true}, tokens)
+}
+
+func TestANTLR(t *testing.T) {
+	g := expand([]*production{
+		{name: "Catch", params: []string{"Yield"}, rules: []rule{
+			{tokens: []token{tm("catch"), {value: "Block", params: []string{"?Yield"}}}},
+		}},
+		{name: "Block", params: []string{"Yield"}, rules: []rule{
+			{tokens: []token{tm("{"), {noLineTerminator: true}, {value: "Statement", optional: true}, tm("}")}},
+			{constraints: []constraint{{value: "Yield"}}, tokens: []token{{terminal: true, prose: true, value: "<any Unicode code point>"}}},
+		}},
+	})
+
+	assert.Equal(t, `grammar Test;
+
+// These stand in for the grammar prose in the specification, and need
+// lexer rules of their own.
+tokens { ANY_UNICODE_CODE_POINT }
+
+catch_
+  : 'catch' block
+  ;
+
+catch_Yield
+  : 'catch' block_Yield
+  ;
+
+block
+  : '{' /* [no LineTerminator here] */ statement? '}'
+  ;
+
+block_Yield
+  : '{' /* [no LineTerminator here] */ statement? '}'
+  | ANY_UNICODE_CODE_POINT
+  ;
+`, antlr("Test", g))
+}
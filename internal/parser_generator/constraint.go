@@ -15,3 +15,9 @@ func (c constraint) String() string {
 
 	return s
 }
+
+// satisfied reports whether a rule guarded by c applies to the variant of its
+// production with the given parameters set.
+func (c constraint) satisfied(options map[string]bool) bool {
+	return options[c.value] != c.inverse
+}
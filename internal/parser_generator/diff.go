@@ -0,0 +1,210 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// grammarDiff describes the changes between the grammars of two editions of
+// the specification, at the level of productions, alternatives and
+// constraints.
+type grammarDiff struct {
+	Added   []string         `json:"added"`
+	Removed []string         `json:"removed"`
+	Changed []productionDiff `json:"changed"`
+}
+
+type productionDiff struct {
+	Name          string            `json:"name"`
+	ParamsAdded   []string          `json:"paramsAdded,omitempty"`
+	ParamsRemoved []string          `json:"paramsRemoved,omitempty"`
+	Added         []string          `json:"added,omitempty"`
+	Removed       []string          `json:"removed,omitempty"`
+	Constraints   []constraintsDiff `json:"constraints,omitempty"`
+}
+
+// constraintsDiff is an alternative that appears in both editions, but is
+// guarded by different parameter constraints.
+type constraintsDiff struct {
+	Alternative string   `json:"alternative"`
+	Old         []string `json:"old"`
+	New         []string `json:"new"`
+}
+
+func (d productionDiff) empty() bool {
+	return len(d.ParamsAdded) == 0 && len(d.ParamsRemoved) == 0 && len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Constraints) == 0
+}
+
+// diff compares two lists of productions by name. Alternatives are matched by
+// their tokens, so that an alternative whose constraints changed shows up as
+// such rather than as a removal and an addition.
+func diff(old, new []*production) grammarDiff {
+	var d grammarDiff
+
+	om := make(map[string]*production)
+	for _, p := range old {
+		om[p.name] = p
+	}
+
+	nm := make(map[string]*production)
+	for _, p := range new {
+		nm[p.name] = p
+	}
+
+	for _, p := range old {
+		if _, ok := nm[p.name]; !ok {
+			d.Removed = append(d.Removed, p.name)
+		}
+	}
+
+	for _, p := range new {
+		o, ok := om[p.name]
+		if !ok {
+			d.Added = append(d.Added, p.name)
+			continue
+		}
+
+		if c := diffProduction(o, p); !c.empty() {
+			d.Changed = append(d.Changed, c)
+		}
+	}
+
+	sort.Strings(d.Added)
+	sort.Strings(d.Removed)
+	sort.Slice(d.Changed, func(i, j int) bool { return d.Changed[i].Name < d.Changed[j].Name })
+
+	return d
+}
+
+func diffProduction(old, new *production) productionDiff {
+	d := productionDiff{
+		Name:          new.name,
+		ParamsAdded:   missing(new.params, old.params),
+		ParamsRemoved: missing(old.params, new.params),
+	}
+
+	used := make([]bool, len(old.rules))
+
+	for _, r := range new.rules {
+		k := alternative(r)
+
+		found := false
+		for i, o := range old.rules {
+			if used[i] || alternative(o) != k {
+				continue
+			}
+
+			used[i] = true
+			found = true
+
+			oc, nc := constraints(o), constraints(r)
+			if strings.Join(oc, " ") != strings.Join(nc, " ") {
+				d.Constraints = append(d.Constraints, constraintsDiff{Alternative: k, Old: oc, New: nc})
+			}
+
+			break
+		}
+
+		if !found {
+			d.Added = append(d.Added, r.String())
+		}
+	}
+
+	for i, o := range old.rules {
+		if !used[i] {
+			d.Removed = append(d.Removed, o.String())
+		}
+	}
+
+	return d
+}
+
+// alternative renders the tokens of a rule, without its constraints.
+func alternative(r rule) string {
+	a := make([]string, len(r.tokens))
+	for i, t := range r.tokens {
+		a[i] = t.String()
+	}
+
+	return strings.Join(a, " ")
+}
+
+func constraints(r rule) []string {
+	a := make([]string, len(r.constraints))
+	for i, c := range r.constraints {
+		a[i] = c.String()
+	}
+
+	sort.Strings(a)
+
+	return a
+}
+
+// missing returns the elements of a that aren't in b.
+func missing(a, b []string) []string {
+	m := make(map[string]bool)
+	for _, e := range b {
+		m[e] = true
+	}
+
+	var r []string
+	for _, e := range a {
+		if !m[e] {
+			r = append(r, e)
+		}
+	}
+
+	return r
+}
+
+func (d grammarDiff) Text(w io.Writer) error {
+	var err error
+	p := func(format string, a ...interface{}) {
+		if err == nil {
+			_, err = fmt.Fprintf(w, format, a...)
+		}
+	}
+
+	p("Added productions (%d)\n\n", len(d.Added))
+	for _, n := range d.Added {
+		p("  + %s\n", n)
+	}
+	p("\n")
+
+	p("Removed productions (%d)\n\n", len(d.Removed))
+	for _, n := range d.Removed {
+		p("  - %s\n", n)
+	}
+	p("\n")
+
+	p("Changed productions (%d)\n\n", len(d.Changed))
+	for _, c := range d.Changed {
+		p("  ~ %s\n", c.Name)
+
+		if len(c.ParamsAdded) > 0 {
+			p("      + params [%s]\n", strings.Join(c.ParamsAdded, ", "))
+		}
+
+		if len(c.ParamsRemoved) > 0 {
+			p("      - params [%s]\n", strings.Join(c.ParamsRemoved, ", "))
+		}
+
+		for _, s := range c.Removed {
+			p("      - %s\n", s)
+		}
+
+		for _, s := range c.Added {
+			p("      + %s\n", s)
+		}
+
+		for _, e := range c.Constraints {
+			p("      ~ %s: [%s] -> [%s]\n", e.Alternative, strings.Join(e.Old, " "), strings.Join(e.New, " "))
+		}
+
+		p("\n")
+	}
+
+	return err
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiff(t *testing.T) {
+	a := assert.New(t)
+
+	old := []*production{
+		{name: "Gone", rules: []rule{{tokens: []token{tm("x")}}}},
+		{name: "Same", rules: []rule{{tokens: []token{tm("x")}}}},
+		{name: "Statement", params: []string{"Yield", "Return"}, rules: []rule{
+			{tokens: []token{nt("Block")}},
+			{constraints: []constraint{{value: "Return"}}, tokens: []token{nt("ReturnStatement")}},
+			{tokens: []token{tm("with")}},
+		}},
+	}
+
+	new := []*production{
+		{name: "Same", rules: []rule{{tokens: []token{tm("x")}}}},
+		{name: "Statement", params: []string{"Yield", "Await", "Return"}, rules: []rule{
+			{tokens: []token{nt("Block")}},
+			{constraints: []constraint{{value: "Return"}, {inverse: true, value: "Await"}}, tokens: []token{nt("ReturnStatement")}},
+			{tokens: []token{nt("DebuggerStatement")}},
+		}},
+		{name: "New", rules: []rule{{tokens: []token{tm("y")}}}},
+	}
+
+	d := diff(old, new)
+
+	a.Equal(grammarDiff{
+		Added:   []string{"New"},
+		Removed: []string{"Gone"},
+		Changed: []productionDiff{{
+			Name:        "Statement",
+			ParamsAdded: []string{"Await"},
+			Added:       []string{"DebuggerStatement"},
+			Removed:     []string{`"with"`},
+			Constraints: []constraintsDiff{{Alternative: "ReturnStatement", Old: []string{"+Return"}, New: []string{"+Return", "~Await"}}},
+		}},
+	}, d)
+
+	var b bytes.Buffer
+	a.NoError(d.Text(&b))
+	a.Equal(`Added productions (1)
+
+  + New
+
+Removed productions (1)
+
+  - Gone
+
+Changed productions (1)
+
+  ~ Statement
+      + params [Await]
+      - "with"
+      + DebuggerStatement
+      ~ ReturnStatement: [+Return] -> [+Return ~Await]
+
+`, b.String())
+}
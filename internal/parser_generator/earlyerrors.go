@@ -0,0 +1,281 @@
+package main
+
+import (
+	"crypto/sha1"
+	"encoding/json"
+	"fmt"
+	"go/format"
+	"io/ioutil"
+	"sort"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+const earlyErrorsTitle = "Static Semantics: Early Errors"
+
+// earlyError is one item from a "Static Semantics: Early Errors" section. The
+// productions are the ones listed in the emu-grammar above it, which the rule
+// applies to. Status comes from the status file, not the specification.
+type earlyError struct {
+	ID          string                 `json:"id"`
+	Section     string                 `json:"section"`
+	Productions []earlyErrorProduction `json:"productions"`
+	Rule        string                 `json:"rule"`
+	Status      string                 `json:"status,omitempty"`
+}
+
+// earlyErrorID names an early error after the first production it applies to
+// and a hash of its text, so that the ID stays the same when rules are added
+// or removed around it, or the section is renumbered. Rules without any
+// productions are named after their clause instead.
+func earlyErrorID(name, rule string) string {
+	h := sha1.Sum([]byte(rule))
+
+	return fmt.Sprintf("%s-%x", name, h[:4])
+}
+
+// earlyErrorProduction refers to one alternative of a production. Since the
+// early error sections don't bother with parameters, the same right hand side
+// can match more than one alternative, or none if the grammar has changed
+// under it.
+type earlyErrorProduction struct {
+	Name         string `json:"name"`
+	Rule         string `json:"rule"`
+	Alternatives []int  `json:"alternatives"`
+}
+
+func (p earlyErrorProduction) String() string {
+	return p.Name + " : " + p.Rule
+}
+
+// shape is the part of a rule that an early error section repeats: the
+// terminals and nonterminals, without parameters, constraints or
+// annotations.
+func (r rule) shape() string {
+	var a []string
+
+	for _, t := range r.tokens {
+		if t.lookahead || t.noLineTerminator || t.empty {
+			continue
+		}
+
+		s := t.value
+		if t.oneof {
+			s = strings.Join(t.values, " ")
+		}
+
+		if t.optional {
+			s += "?"
+		}
+
+		a = append(a, s)
+	}
+
+	return strings.Join(a, " ")
+}
+
+// scrapeEarlyErrors reads every early error rule in the specification, and
+// attaches them to the alternatives in plist that they apply to.
+func scrapeEarlyErrors(d *goquery.Document, plist []*production) ([]*earlyError, error) {
+	m := make(map[string]*production)
+	for _, p := range plist {
+		m[p.name] = p
+	}
+
+	var a []*earlyError
+	var err error
+
+	seen := make(map[string]int)
+
+	d.Find("emu-clause[id]").Each(func(i int, ctag *goquery.Selection) {
+		if err != nil {
+			return
+		}
+
+		h := ctag.ChildrenFiltered("h1")
+		section := strings.TrimSpace(h.Find(".secnum").Text())
+		if title := strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(h.Text()), section)); title != earlyErrorsTitle {
+			return
+		}
+
+		id := ctag.AttrOr("id", "")
+
+		var productions []earlyErrorProduction
+		ruled := false
+
+		ctag.ChildrenFiltered("emu-grammar, ul").Each(func(i int, etag *goquery.Selection) {
+			if err != nil {
+				return
+			}
+
+			if etag.Is("ul") {
+				etag.ChildrenFiltered("li").Each(func(i int, ltag *goquery.Selection) {
+					rule := strings.Join(strings.Fields(ltag.Text()), " ")
+
+					name := id
+					if len(productions) > 0 {
+						name = productions[0].Name
+					}
+
+					// The same rule can be repeated word for word for the
+					// same production, in which case only the order they
+					// come in can tell them apart.
+					eid := earlyErrorID(name, rule)
+					if n := seen[eid]; n > 0 {
+						seen[eid]++
+						eid = fmt.Sprintf("%s-%d", eid, n+1)
+					} else {
+						seen[eid] = 1
+					}
+
+					a = append(a, &earlyError{
+						ID:          eid,
+						Section:     section,
+						Productions: productions,
+						Rule:        rule,
+					})
+				})
+
+				ruled = true
+
+				return
+			}
+
+			// A grammar after a list of rules starts a new group, and one
+			// straight after another grammar adds to the same group.
+			if ruled {
+				productions = nil
+				ruled = false
+			}
+
+			etag.Find("emu-production").Each(func(i int, ptag *goquery.Selection) {
+				name := ptag.AttrOr("name", "")
+
+				ptag.Find("emu-rhs").Each(func(i int, rtag *goquery.Selection) {
+					if err != nil {
+						return
+					}
+
+					r, e := scrapeRule(name, rtag)
+					if e != nil {
+						err = fmt.Errorf("%s: %s", id, e)
+						return
+					}
+
+					ep := earlyErrorProduction{Name: name, Rule: r.shape()}
+
+					if p, ok := m[name]; ok {
+						for j, pr := range p.rules {
+							if pr.shape() == ep.Rule {
+								ep.Alternatives = append(ep.Alternatives, j)
+							}
+						}
+					}
+
+					productions = append(productions, ep)
+				})
+			})
+		})
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, e := range a {
+		for _, ep := range e.Productions {
+			for _, j := range ep.Alternatives {
+				p := m[ep.Name]
+				p.rules[j].earlyErrors = append(p.rules[j].earlyErrors, e)
+			}
+		}
+	}
+
+	return a, nil
+}
+
+// loadEarlyErrorStatus reads a status file, which maps early error IDs to
+// how far along the parser is with them, e.g. {"Block-5f8a1c2e": "done"}.
+// It's kept by hand rather than generated, so that regenerating the
+// checklist doesn't lose track of anything.
+func loadEarlyErrorStatus(location string) (map[string]string, error) {
+	b, err := ioutil.ReadFile(location)
+	if err != nil {
+		return nil, err
+	}
+
+	var m map[string]string
+	if err := json.Unmarshal(b, &m); err != nil {
+		return nil, fmt.Errorf("%s: %s", location, err)
+	}
+
+	return m, nil
+}
+
+// setEarlyErrorStatus copies the status of each early error out of a status
+// file, and returns the IDs in the status file that don't match any early
+// error any more, sorted.
+func setEarlyErrorStatus(a []*earlyError, status map[string]string) []string {
+	found := make(map[string]bool)
+	for _, e := range a {
+		if s, ok := status[e.ID]; ok {
+			e.Status = s
+			found[e.ID] = true
+		}
+	}
+
+	var stale []string
+	for id := range status {
+		if !found[id] {
+			stale = append(stale, id)
+		}
+	}
+
+	sort.Strings(stale)
+
+	return stale
+}
+
+// earlyErrorsGo generates a table of every early error, for keeping track of
+// which ones the parser enforces.
+func earlyErrorsGo(pkg string, a []*earlyError) ([]byte, error) {
+	s := "// Code generated by parser_generator -format earlyerrors; DO NOT EDIT.\n\n"
+	s += "package " + pkg + "\n\n"
+
+	s += "// earlyError is one of the rules in a \"" + earlyErrorsTitle + "\"\n"
+	s += "// section of the specification.\n"
+	s += "type earlyError struct {\n"
+	s += "ID string\n"
+	s += "Section string\n"
+	s += "Productions []string\n"
+	s += "Rule string\n"
+	s += "Status string\n"
+	s += "}\n\n"
+
+	s += "// earlyErrors is a checklist of every early error in the specification.\n"
+	s += "// Status comes from a status file that's kept by hand, keyed by ID, so\n"
+	s += "// that progress can be tracked across regenerations.\n"
+	s += "var earlyErrors = []earlyError{\n"
+	for _, e := range a {
+		var p []string
+		for _, ep := range e.Productions {
+			p = append(p, fmt.Sprintf("%q", ep.String()))
+		}
+
+		s += "{\n"
+		s += fmt.Sprintf("ID: %q,\n", e.ID)
+		s += fmt.Sprintf("Section: %q,\n", e.Section)
+		if len(p) > 0 {
+			s += fmt.Sprintf("Productions: []string{%s},\n", strings.Join(p, ", "))
+		}
+		s += fmt.Sprintf("Rule: %q,\n", e.Rule)
+		if e.Status != "" {
+			s += fmt.Sprintf("Status: %q,\n", e.Status)
+		}
+		s += "},\n"
+	}
+	s += "}\n"
+
+	return format.Source([]byte(s))
+}
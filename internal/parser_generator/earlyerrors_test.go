@@ -0,0 +1,78 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/stretchr/testify/assert"
+)
+
+const earlyErrorsHTML = `<html><body>
+<emu-production name="Statement" id="prod-Statement">
+ <emu-rhs><emu-t>{</emu-t> <emu-nt optional><a>StatementList</a></emu-nt> <emu-t>}</emu-t></emu-rhs>
+ <emu-rhs><emu-t>;</emu-t></emu-rhs>
+</emu-production>
+<emu-clause id="sec-statement-static-semantics-early-errors"><h1><span class="secnum">13.1.1</span> Static Semantics: Early Errors</h1>
+<emu-grammar><emu-production name="Statement" collapsed><emu-rhs><emu-t>{</emu-t> <emu-nt optional><a>StatementList</a></emu-nt> <emu-t>}</emu-t></emu-rhs></emu-production></emu-grammar>
+<ul>%s<li>It is a Syntax Error if the LexicallyDeclaredNames of <emu-nt>StatementList</emu-nt>
+ contains any duplicate entries.</li><li>Same.</li><li>Same.</li></ul>
+</emu-clause>
+<emu-clause id="sec-other-static-semantics-early-errors"><h1><span class="secnum">13.2.1</span> Static Semantics: Early Errors</h1>
+<ul><li>Same.</li></ul>
+</emu-clause>
+</body></html>`
+
+func scrapeEarlyErrorsHTML(t *testing.T, extra string) []*earlyError {
+	d, err := goquery.NewDocumentFromReader(strings.NewReader(strings.Replace(earlyErrorsHTML, "%s", extra, 1)))
+	assert.NoError(t, err)
+
+	plist, err := scrape(d)
+	assert.NoError(t, err)
+
+	a, err := scrapeEarlyErrors(d, plist)
+	assert.NoError(t, err)
+
+	return a
+}
+
+func TestEarlyErrorIDs(t *testing.T) {
+	a := assert.New(t)
+
+	var ids []string
+	for _, e := range scrapeEarlyErrorsHTML(t, "") {
+		ids = append(ids, e.ID)
+	}
+
+	a.Equal([]string{
+		earlyErrorID("Statement", "It is a Syntax Error if the LexicallyDeclaredNames of StatementList contains any duplicate entries."),
+		earlyErrorID("Statement", "Same."),
+		earlyErrorID("Statement", "Same.") + "-2",
+		earlyErrorID("sec-other-static-semantics-early-errors", "Same."),
+	}, ids)
+	a.Regexp(`^Statement-[0-9a-f]{8}$`, ids[0])
+
+	// Adding a rule doesn't change the IDs of the ones after it.
+	var more []string
+	for _, e := range scrapeEarlyErrorsHTML(t, "<li>A new rule.</li>") {
+		more = append(more, e.ID)
+	}
+
+	a.Equal(ids, more[1:])
+}
+
+func TestSetEarlyErrorStatus(t *testing.T) {
+	a := assert.New(t)
+
+	errs := scrapeEarlyErrorsHTML(t, "")
+
+	stale := setEarlyErrorStatus(errs, map[string]string{
+		errs[0].ID:         "done",
+		"Statement-gone":   "done",
+		"Statement-absent": "todo",
+	})
+
+	a.Equal([]string{"Statement-absent", "Statement-gone"}, stale)
+	a.Equal("done", errs[0].Status)
+	a.Equal("", errs[1].Status)
+}
@@ -0,0 +1,304 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// loadEBNF reads a grammar written by -format ebnf, like es6.ebnf.
+func loadEBNF(location string) ([]*production, error) {
+	fd, err := os.Open(location)
+	if err != nil {
+		return nil, err
+	}
+	defer fd.Close()
+
+	return readEBNF(fd)
+}
+
+// readEBNF reads a grammar in the format written by -format ebnf. That format
+// has already had its parameters expanded, so every variant comes back as a
+// production of its own, without parameters, and refers to the other
+// variants by name. Terminals that look like "<...>" are read as prose.
+func readEBNF(rd io.Reader) ([]*production, error) {
+	var plist []*production
+	var p *production
+
+	s := bufio.NewScanner(rd)
+	s.Buffer(nil, 1<<20)
+
+	for line := 1; s.Scan(); line++ {
+		l := s.Text()
+
+		var alt string
+		switch {
+		case l == "":
+			p = nil
+			continue
+		case p == nil:
+			p = &production{name: l}
+			plist = append(plist, p)
+			continue
+		case strings.HasPrefix(l, "  ::= ") && len(p.rules) == 0:
+			alt = l[6:]
+		case strings.HasPrefix(l, "    | ") && len(p.rules) > 0:
+			alt = l[6:]
+		default:
+			return nil, fmt.Errorf("line %d: unexpected %q", line, l)
+		}
+
+		tokens, err := readEBNFSequence(alt)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %s: %s", line, p.name, err)
+		}
+
+		p.rules = append(p.rules, rule{tokens: tokens})
+	}
+
+	return plist, s.Err()
+}
+
+func readEBNFSequence(s string) ([]token, error) {
+	var a []token
+
+	for s = strings.TrimSpace(s); s != ""; s = strings.TrimSpace(s) {
+		if s[0] == '-' {
+			if len(a) == 0 {
+				return nil, fmt.Errorf("%q doesn't follow a token", s)
+			}
+
+			var err error
+			if a[len(a)-1].butNot, s, err = readEBNFButNot(strings.TrimSpace(s[1:])); err != nil {
+				return nil, err
+			}
+
+			continue
+		}
+
+		t, rest, err := readEBNFToken(s)
+		if err != nil {
+			return nil, err
+		}
+
+		a = append(a, t)
+		s = rest
+	}
+
+	return a, nil
+}
+
+// readEBNFButNot reads what follows the "-" in `X - Y` or `X - ( A | B )`.
+func readEBNFButNot(s string) ([]token, string, error) {
+	if !strings.HasPrefix(s, "( ") {
+		t, rest, err := readEBNFToken(s)
+		return []token{t}, rest, err
+	}
+
+	var a []token
+	for s = s[2:]; ; {
+		t, rest, err := readEBNFToken(s)
+		if err != nil {
+			return nil, "", err
+		}
+
+		a = append(a, t)
+
+		switch rest = strings.TrimSpace(rest); {
+		case strings.HasPrefix(rest, "| "):
+			s = rest[2:]
+		case strings.HasPrefix(rest, ")"):
+			return a, rest[1:], nil
+		default:
+			return nil, "", fmt.Errorf("unterminated group %q", s)
+		}
+	}
+}
+
+// readEBNFToken reads one token from the start of s, and returns it along
+// with the rest of s.
+func readEBNFToken(s string) (token, string, error) {
+	switch r, _ := utf8.DecodeRuneInString(s); {
+	case r == '"' || r == '\'':
+		v, rest, err := readEBNFString(s)
+		if err != nil {
+			return token{}, "", err
+		}
+
+		return token{terminal: true, prose: len(v) > 2 && v[0] == '<' && v[len(v)-1] == '>', value: v}, rest, nil
+	case r == '(':
+		t := token{oneof: true}
+		for s = s[1:]; ; {
+			v, rest, err := readEBNFString(strings.TrimSpace(s))
+			if err != nil {
+				return token{}, "", err
+			}
+
+			t.values = append(t.values, v)
+
+			switch rest = strings.TrimSpace(rest); {
+			case strings.HasPrefix(rest, "|"):
+				s = rest[1:]
+			case strings.HasPrefix(rest, ")"):
+				return t, rest[1:], nil
+			default:
+				return token{}, "", fmt.Errorf("unterminated group %q", s)
+			}
+		}
+	case r == '[':
+		i := ebnfBracketEnd(s)
+		if i < 0 {
+			return token{}, "", fmt.Errorf("unterminated bracket %q", s)
+		}
+
+		t, err := readEBNFBracket(s[1:i])
+
+		return t, s[i+1:], err
+	case r == '_' || unicode.IsLetter(r):
+		i := strings.IndexFunc(s, func(r rune) bool { return r != '_' && !unicode.IsLetter(r) && !unicode.IsDigit(r) })
+		if i < 0 {
+			i = len(s)
+		}
+
+		t := token{value: s[:i]}
+		if strings.HasPrefix(s[i:], "?") {
+			t.optional = true
+			i++
+		}
+
+		return t, s[i:], nil
+	default:
+		return token{}, "", fmt.Errorf("unexpected %q", s)
+	}
+}
+
+// readEBNFString reads a string written by quoted.
+func readEBNFString(s string) (string, string, error) {
+	if s == "" || (s[0] != '"' && s[0] != '\'') {
+		return "", "", fmt.Errorf("expected a string at %q", s)
+	}
+
+	var b []byte
+	for i := 1; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+			if i < len(s) {
+				b = append(b, s[i])
+			}
+		case s[0]:
+			return string(b), s[i+1:], nil
+		default:
+			b = append(b, s[i])
+		}
+	}
+
+	return "", "", fmt.Errorf("unterminated string %q", s)
+}
+
+// ebnfBracketEnd returns the index of the "]" that closes the bracket s
+// starts with, skipping over any in strings, or -1 if there isn't one.
+func ebnfBracketEnd(s string) int {
+	var q byte
+	for i := 1; i < len(s); i++ {
+		switch {
+		case q == 0 && (s[i] == '"' || s[i] == '\''):
+			q = s[i]
+		case q != 0 && s[i] == '\\':
+			i++
+		case q != 0 && s[i] == q:
+			q = 0
+		case q == 0 && s[i] == ']':
+			return i
+		}
+	}
+
+	return -1
+}
+
+// readEBNFBracket reads the annotations and character classes written in
+// square brackets.
+func readEBNFBracket(v string) (token, error) {
+	switch {
+	case v == "empty":
+		return token{empty: true}, nil
+	case v == "no LineTerminator here":
+		return token{noLineTerminator: true}, nil
+	case strings.HasPrefix(v, "lookahead "):
+		v = strings.TrimPrefix(v, "lookahead ")
+
+		t := token{lookahead: true}
+
+		op, n := utf8.DecodeRuneInString(v)
+		switch op {
+		case '∉', '≠':
+			t.inverse = true
+		case '∈', '=':
+		default:
+			return token{}, fmt.Errorf("unrecognised lookahead operator in %q", "["+v+"]")
+		}
+
+		v = strings.TrimSpace(v[n:])
+
+		if !strings.HasPrefix(v, "{") {
+			q, err := readEBNFSequence(v)
+			if err != nil {
+				return token{}, err
+			}
+
+			t.set = [][]token{q}
+
+			return t, nil
+		}
+
+		if !strings.HasSuffix(v, "}") {
+			return token{}, fmt.Errorf("unterminated lookahead set %q", v)
+		}
+
+		for _, e := range splitEBNFSet(v[1 : len(v)-1]) {
+			q, err := readEBNFSequence(e)
+			if err != nil {
+				return token{}, err
+			}
+
+			t.set = append(t.set, q)
+		}
+
+		return t, nil
+	default:
+		t := token{oneof: true}
+		for _, r := range v {
+			t.values = append(t.values, string(r))
+		}
+
+		return t, nil
+	}
+}
+
+// splitEBNFSet splits the inside of a lookahead set at the commas that
+// aren't in strings.
+func splitEBNFSet(s string) []string {
+	var a []string
+
+	var q byte
+	from := 0
+	for i := 0; i < len(s); i++ {
+		switch {
+		case q == 0 && (s[i] == '"' || s[i] == '\''):
+			q = s[i]
+		case q != 0 && s[i] == '\\':
+			i++
+		case q != 0 && s[i] == q:
+			q = 0
+		case q == 0 && s[i] == ',':
+			a = append(a, s[from:i])
+			from = i + 1
+		}
+	}
+
+	return append(a, s[from:])
+}
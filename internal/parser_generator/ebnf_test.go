@@ -0,0 +1,132 @@
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/stretchr/testify/assert"
+)
+
+func ebnfString(plist []*production) string {
+	var s string
+	for _, p := range plist {
+		for _, e := range p.EBNF() {
+			s += e + "\n\n"
+		}
+	}
+
+	return s
+}
+
+// TestRegenerateEBNF checks that es6.ebnf is what -format ebnf writes. The
+// specification isn't kept in the repository, so the full check only runs
+// when ECMASCRIPT_HTML names a copy of it. If it fails, regenerate es6.ebnf
+// with
+//
+//	go run ./internal/parser_generator -html $ECMASCRIPT_HTML -format ebnf > internal/parser_generator/es6.ebnf
+//
+// Without it, es6.ebnf has to at least be in the form -format ebnf writes,
+// and the productions that the scraper used to get wrong have to be the way
+// it reads them now.
+func TestRegenerateEBNF(t *testing.T) {
+	a := assert.New(t)
+
+	expected, err := ioutil.ReadFile("es6.ebnf")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	plist, err := readEBNF(bytes.NewReader(expected))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	a.Equal(string(expected), ebnfString(plist), "es6.ebnf isn't in the form -format ebnf writes")
+
+	d, err := goquery.NewDocumentFromReader(strings.NewReader(scrapeHTML))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if plist, err = scrape(d); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, p := range plist {
+		if p.name != "Block" && p.name != "FormalParameters" {
+			continue
+		}
+
+		for _, s := range p.EBNF() {
+			a.Contains("\n"+string(expected), "\n"+s+"\n\n", "es6.ebnf doesn't have %s the way it's scraped", strings.SplitN(s, "\n", 2)[0])
+		}
+	}
+
+	location := os.Getenv("ECMASCRIPT_HTML")
+	if location == "" {
+		t.Skip("set ECMASCRIPT_HTML to the specification to check es6.ebnf against it")
+	}
+
+	if plist, err = load(location); err != nil {
+		t.Fatal(err)
+	}
+
+	a.True(string(expected) == ebnfString(plist), "es6.ebnf is out of date with %s", location)
+}
+
+func TestReadEBNF(t *testing.T) {
+	a := assert.New(t)
+
+	plist, err := readEBNF(strings.NewReader(`Empty
+
+Thing_Yield
+  ::= [empty]
+    | "{" Body_Yield? '"' "<any Unicode code point>" "<"
+    | ( "a" | "b" ) [xy] [no LineTerminator here] Name - ( "c" | Other )
+    | [lookahead ∉ { "let" "[", "{" }] X - Y [lookahead ∉ DecimalDigit] [lookahead = "]"]
+
+`))
+	if !a.NoError(err) {
+		return
+	}
+
+	a.Equal([]*production{
+		{name: "Empty"},
+		{name: "Thing_Yield", rules: []rule{
+			{tokens: []token{{empty: true}}},
+			{tokens: []token{
+				tm("{"),
+				{value: "Body_Yield", optional: true},
+				tm(`"`),
+				{terminal: true, prose: true, value: "<any Unicode code point>"},
+				tm("<"),
+			}},
+			{tokens: []token{
+				{oneof: true, values: []string{"a", "b"}},
+				{oneof: true, values: []string{"x", "y"}},
+				{noLineTerminator: true},
+				{value: "Name", butNot: []token{tm("c"), nt("Other")}},
+			}},
+			{tokens: []token{
+				{lookahead: true, inverse: true, set: [][]token{{tm("let"), tm("[")}, {tm("{")}}},
+				{value: "X", butNot: []token{nt("Y")}},
+				{lookahead: true, inverse: true, set: [][]token{{nt("DecimalDigit")}}},
+				{lookahead: true, set: [][]token{{tm("]")}}},
+			}},
+		}},
+	}, plist)
+
+	for _, c := range []struct{ in, err string }{
+		{"A\n  | B\n", `line 2: unexpected "  | B"`},
+		{"A\n  ::= - B\n", `line 2: A: "- B" doesn't follow a token`},
+		{"A\n  ::= \"b\n", `line 2: A: unterminated string "\"b"`},
+		{"A\n  ::= [lookahead ⊂ B]\n", `line 2: A: unrecognised lookahead operator in "[⊂ B]"`},
+	} {
+		_, err := readEBNF(strings.NewReader(c.in))
+		a.EqualError(err, c.err, c.in)
+	}
+}
@@ -0,0 +1,128 @@
+package main
+
+import (
+	"strings"
+)
+
+// grammar is a set of productions with their parameters expanded, so that
+// each variant (e.g. Expression_In_Yield) is a plain nonterminal. Nonterminal
+// tokens in the rules name the variant they refer to, and carry no params.
+type grammar struct {
+	names    []string
+	rules    map[string][][]token
+	variants map[string][]string
+}
+
+func expand(plist []*production) *grammar {
+	g := grammar{
+		rules:    make(map[string][][]token),
+		variants: make(map[string][]string),
+	}
+
+	for _, p := range plist {
+		for _, v := range variants(p.name, p.params) {
+			g.add(p, v[1:])
+		}
+	}
+
+	return &g
+}
+
+func (g *grammar) add(p *production, params []string) string {
+	name := strings.Join(append([]string{p.name}, params...), "_")
+
+	m := make(map[string]bool)
+	for _, e := range params {
+		m[e] = true
+	}
+
+	var alts [][]token
+	for _, r := range p.rules {
+		if !r.applies(m) {
+			continue
+		}
+
+		a := make([]token, len(r.tokens))
+		for i, t := range r.tokens {
+			a[i] = t.resolve(m)
+		}
+
+		alts = append(alts, a)
+	}
+
+	g.names = append(g.names, name)
+	g.rules[name] = alts
+	g.variants[p.name] = append(g.variants[p.name], name)
+
+	return name
+}
+
+// complete adds every variant that is referenced from the grammar but isn't
+// one of the combinations produced by variants(), so that all references to
+// known productions resolve.
+func (g *grammar) complete(plist []*production) {
+	m := make(map[string]*production)
+	for _, p := range plist {
+		m[p.name] = p
+	}
+
+	for i := 0; i < len(g.names); i++ {
+		g.references(g.names[i], func(s string) {
+			if _, ok := g.rules[s]; ok {
+				return
+			}
+
+			a := strings.Split(s, "_")
+			if p, ok := m[a[0]]; ok {
+				g.add(p, a[1:])
+			}
+		})
+	}
+}
+
+// references calls f with the name of every nonterminal used by n, including
+// those that only appear in lookahead restrictions and "but not" clauses.
+func (g *grammar) references(n string, f func(string)) {
+	for _, alt := range g.rules[n] {
+		for _, t := range alt {
+			for _, q := range t.set {
+				for _, e := range q {
+					if nonterminal(e) {
+						f(e.value)
+					}
+				}
+			}
+
+			for _, e := range t.butNot {
+				if nonterminal(e) {
+					f(e.value)
+				}
+			}
+
+			if nonterminal(t) {
+				f(t.value)
+			}
+		}
+	}
+}
+
+// nonterminal reports whether t refers to another production.
+func nonterminal(t token) bool {
+	return !t.terminal && !t.oneof && !t.assertion()
+}
+
+// terminals returns the terminals a token can start with, in EBNF notation.
+func terminals(t token) []string {
+	switch {
+	case t.terminal:
+		return []string{quoted(t.value)}
+	case t.oneof:
+		a := make([]string, len(t.values))
+		for i, e := range t.values {
+			a[i] = quoted(e)
+		}
+		return a
+	}
+
+	return nil
+}
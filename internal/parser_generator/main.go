@@ -1,6 +1,7 @@
 package main
 
 import (
+	"encoding/json"
 	"flag"
 	"fmt"
 	"net/http"
@@ -20,6 +21,20 @@ func acceptOne(input string, options []string) (string, bool, error) {
 	return input, false, nil
 }
 
+func lookahead(input string, alternatives ...func(string) (string, bool, error)) (bool, error) {
+	for _, f := range alternatives {
+		if _, ok, err := f(input); err != nil || ok {
+			return ok, err
+		}
+	}
+
+	return false, nil
+}
+
+func lineTerminatorAhead(input string) (bool, error) {
+	return false, nil
+}
+
 `
 
 func variants(p string, in []string) [][]string {
@@ -38,46 +53,92 @@ func variants(p string, in []string) [][]string {
 
 var (
 	htmlFlag   = flag.String("html", "./index.html", "Location of the ECMAScript HTML specification")
-	formatFlag = flag.String("format", "ebnf", "Format of output (ebnf, go)")
+	ebnfFlag   = flag.String("ebnf", "", "Location of a grammar written by -format ebnf, like es6.ebnf, to read instead of the specification")
+	oldFlag    = flag.String("old", "", "Location of an older ECMAScript HTML specification to diff against")
+	formatFlag = flag.String("format", "ebnf", "Format of output ("+strings.Join(formats, ", ")+")")
+	outFlag    = flag.String("out", "./railroad", "Directory to write railroad diagrams to")
+	jsonFlag   = flag.Bool("json", false, "Write reports as JSON rather than text")
+	nameFlag   = flag.String("name", "ECMAScript", "Name of the grammar in antlr output")
+	seedFlag   = flag.Int64("seed", 1, "Random seed for samples")
+	depthFlag  = flag.Int("depth", 40, "Derivation depth limit for samples")
+	countFlag  = flag.Int("count", 10, "Number of samples to generate for each start symbol")
+	pkgFlag    = flag.String("package", "jsparser", "Package name for the earlyerrors checklist")
+	statusFlag = flag.String("status", "", "Location of a hand-kept JSON file of early error IDs and their status, for the earlyerrors checklist")
+	startFlag  = flag.String("start", "", "Goal symbols to analyse the grammar from, or to generate samples from (default "+strings.Join(defaultStart, ",")+" for report, Script for samples)")
 )
 
-func main() {
-	flag.Parse()
+// load reads the productions out of an ECMAScript HTML specification.
+func load(location string) ([]*production, error) {
+	d, err := open(location)
+	if err != nil {
+		return nil, err
+	}
 
+	return scrape(d)
+}
+
+// open reads an ECMAScript HTML specification, either from a local file or
+// over HTTP.
+func open(location string) (*goquery.Document, error) {
 	var d *goquery.Document
 
-	if strings.HasPrefix(*htmlFlag, "http") {
-		r, err := http.Get(*htmlFlag)
+	if strings.HasPrefix(location, "http") {
+		r, err := http.Get(location)
 		if err != nil {
-			panic(err)
+			return nil, err
 		}
 
 		_d, err := goquery.NewDocumentFromResponse(r)
 		if err != nil {
-			panic(err)
+			return nil, err
 		}
 
 		d = _d
 	} else {
-		fd, err := os.Open(*htmlFlag)
+		fd, err := os.Open(location)
 		if err != nil {
-			panic(err)
+			return nil, err
 		}
+		defer fd.Close()
 
 		_d, err := goquery.NewDocumentFromReader(fd)
 		if err != nil {
-			panic(err)
+			return nil, err
 		}
 
 		d = _d
 	}
 
-	productions := make(map[string]*production)
+	return d, nil
+}
+
+// skipClauses are the clauses whose productions are examples or extensions,
+// rather than part of the grammar.
+const skipClauses = "#sec-grammar-notation, #sec-rules-of-automatic-semicolon-insertion, #sec-additional-ecmascript-features-for-web-browsers"
+
+// scrape reads the productions out of the specification. Only the first
+// definition of a production gets an id, and that can be an example, like the
+// Block in the static semantics conventions, so productions without an id are
+// read too and a later definition replaces an earlier one in place. Those
+// inside an emu-grammar only repeat the alternatives that a semantic rule is
+// about, so they're left out unless it's marked as a definition.
+func scrape(d *goquery.Document) ([]*production, error) {
 	var plist []*production
+	var err error
+
+	seen := make(map[string]int)
+
+	d.Find("emu-production").Each(func(i int, ptag *goquery.Selection) {
+		if err != nil {
+			return
+		}
 
-	d.Find("emu-production[id]").Each(func(i int, ptag *goquery.Selection) {
 		id := ptag.AttrOr("id", "")
-		if strings.HasPrefix(id, "prod-grammar-notation-") || strings.HasPrefix(id, "prod-asi-rules-") || strings.HasPrefix(id, "prod-annexB-") || ptag.HasClass("inline") {
+		if id == "" && (ptag.Is("[collapsed]") || ptag.Closest("emu-grammar").Not("[type=definition]").Length() > 0) {
+			return
+		}
+
+		if strings.HasPrefix(id, "prod-grammar-notation-") || strings.HasPrefix(id, "prod-asi-rules-") || strings.HasPrefix(id, "prod-annexB-") || ptag.HasClass("inline") || ptag.Closest(skipClauses).Length() > 0 {
 			return
 		}
 
@@ -105,60 +166,299 @@ func main() {
 			})
 		} else {
 			ptag.Find("emu-rhs").Each(func(i int, rtag *goquery.Selection) {
-				var r rule
-
-				if s, ok := rtag.Attr("constraints"); ok && s != "" {
-					for _, c := range strings.Split(s, ", ") {
-						r.constraints = append(r.constraints, constraint{
-							inverse: c[0] == '~',
-							value:   c[1:],
-						})
-					}
+				if err != nil {
+					return
+				}
+
+				r, e := scrapeRule(p.name, rtag)
+				if e != nil {
+					err = e
+					return
 				}
 
-				rtag.ChildrenFiltered("emu-t, emu-nt, emu-gprose").Each(func(i int, etag *goquery.Selection) {
-					var t token
+				p.rules = append(p.rules, r)
+			})
+		}
 
-					switch etag.Nodes[0].Data {
-					case "emu-t":
-						t.terminal = true
-						t.value = etag.Text()
-					case "emu-nt":
-						t.value = etag.Find("a").Text()
-					case "emu-gprose":
-						t.terminal = true
-						t.value = "<" + etag.Text() + ">"
-					}
+		if j, ok := seen[p.name]; ok {
+			plist[j] = &p
+			return
+		}
 
-					if _, ok := etag.Attr("optional"); ok {
-						t.optional = true
-					}
+		seen[p.name] = len(plist)
+		plist = append(plist, &p)
+	})
 
-					if s, ok := etag.Attr("params"); ok && s != "" {
-						t.params = strings.Split(s, ", ")
-					}
+	return plist, err
+}
 
-					r.tokens = append(r.tokens, t)
-				})
+// scrapeRule reads the right hand side of a production.
+func scrapeRule(name string, rtag *goquery.Selection) (rule, error) {
+	var r rule
+	var err error
 
-				p.rules = append(p.rules, r)
+	if s, ok := rtag.Attr("constraints"); ok && s != "" {
+		for _, c := range strings.Split(s, ", ") {
+			r.constraints = append(r.constraints, constraint{
+				inverse: c[0] == '~',
+				value:   c[1:],
 			})
 		}
+	}
 
-		productions[p.name] = &p
-		plist = append(plist, &p)
+	rtag.ChildrenFiltered("emu-t, emu-nt, emu-gprose, emu-gann, emu-gmod").Each(func(i int, etag *goquery.Selection) {
+		if err != nil {
+			return
+		}
+
+		var t token
+
+		switch etag.Nodes[0].Data {
+		case "emu-t":
+			t.terminal = true
+			t.value = etag.Text()
+		case "emu-nt":
+			t.value = etag.Find("a").Text()
+		case "emu-gprose":
+			t.terminal = true
+			t.prose = true
+			t.value = "<" + etag.Text() + ">"
+		case "emu-gann":
+			a, e := annotation(etag)
+			if e != nil {
+				err = fmt.Errorf("%s: %s", name, e)
+				return
+			}
+
+			t = a
+		case "emu-gmod":
+			if len(r.tokens) == 0 {
+				err = fmt.Errorf("%s: %q doesn't follow a token", name, etag.Text())
+				return
+			}
+
+			if !strings.HasPrefix(strings.TrimSpace(etag.Text()), "but not") {
+				err = fmt.Errorf("%s: unrecognised grammar modifier %q", name, etag.Text())
+				return
+			}
+
+			r.tokens[len(r.tokens)-1].butNot = annotationTokens(etag)
+
+			return
+		}
+
+		if _, ok := etag.Attr("optional"); ok {
+			t.optional = true
+		}
+
+		if s, ok := etag.Attr("params"); ok && s != "" {
+			t.params = strings.Split(s, ", ")
+		}
+
+		r.tokens = append(r.tokens, t)
 	})
 
-	if *formatFlag == "go" {
-		fmt.Printf(goHeader)
+	// Some alternatives are written as a bare "[empty]", with no markup at
+	// all, and an alternative without any symbols can't be anything else.
+	if err == nil && len(r.tokens) == 0 {
+		if s := strings.TrimSpace(rtag.Text()); s != "" && s != "[empty]" {
+			return r, fmt.Errorf("%s: unrecognised right hand side %q", name, s)
+		}
+
+		r.tokens = append(r.tokens, token{empty: true})
 	}
 
-	for _, p := range plist {
-		if *formatFlag == "go" {
-			fmt.Printf("%s\n", p.Go(productions))
+	return r, err
+}
+
+// formats are the values -format accepts.
+var formats = []string{"ebnf", "go", "report", "antlr", "json", "diff", "railroad", "tokens", "samples", "earlyerrors"}
+
+// defaultStarts are the goal symbols for each format that uses them, when
+// -start isn't given.
+var defaultStarts = map[string][]string{
+	"report":  defaultStart,
+	"samples": {"Script"},
+}
+
+func main() {
+	flag.Parse()
+
+	known := false
+	for _, f := range formats {
+		if f == *formatFlag {
+			known = true
 		}
+	}
+
+	if !known {
+		fmt.Fprintf(os.Stderr, "unknown format %q; use one of %s\n", *formatFlag, strings.Join(formats, ", "))
+		os.Exit(2)
+	}
+
+	start := defaultStarts[*formatFlag]
+	if *startFlag != "" {
+		start = strings.Split(*startFlag, ",")
+	}
 
-		if *formatFlag == "ebnf" {
+	var d *goquery.Document
+	var plist []*production
+	var err error
+
+	if *ebnfFlag != "" {
+		// Early errors are only in the specification.
+		if *formatFlag == "earlyerrors" || *formatFlag == "json" {
+			fmt.Fprintf(os.Stderr, "-format %s needs the specification, not -ebnf\n", *formatFlag)
+			os.Exit(2)
+		}
+
+		if plist, err = loadEBNF(*ebnfFlag); err != nil {
+			panic(err)
+		}
+	} else {
+		if d, err = open(*htmlFlag); err != nil {
+			panic(err)
+		}
+
+		if plist, err = scrape(d); err != nil {
+			panic(err)
+		}
+	}
+
+	switch *formatFlag {
+	case "diff":
+		old, err := load(*oldFlag)
+		if err != nil {
+			panic(err)
+		}
+
+		d := diff(old, plist)
+
+		if *jsonFlag {
+			e := json.NewEncoder(os.Stdout)
+			e.SetIndent("", "  ")
+			if err := e.Encode(d); err != nil {
+				panic(err)
+			}
+		} else if err := d.Text(os.Stdout); err != nil {
+			panic(err)
+		}
+	case "railroad":
+		if err := os.MkdirAll(*outFlag, 0755); err != nil {
+			panic(err)
+		}
+
+		if err := writeRailroad(*outFlag, plist); err != nil {
+			panic(err)
+		}
+	case "tokens":
+		b, err := tokenKinds(plist)
+		if err != nil {
+			panic(err)
+		}
+
+		os.Stdout.Write(b)
+	case "earlyerrors":
+		earlyErrors, err := scrapeEarlyErrors(d, plist)
+		if err != nil {
+			panic(err)
+		}
+
+		if *statusFlag != "" {
+			status, err := loadEarlyErrorStatus(*statusFlag)
+			if err != nil {
+				panic(err)
+			}
+
+			for _, id := range setEarlyErrorStatus(earlyErrors, status) {
+				fmt.Fprintf(os.Stderr, "%s: %s isn't an early error any more\n", *statusFlag, id)
+			}
+		}
+
+		if *jsonFlag {
+			e := json.NewEncoder(os.Stdout)
+			e.SetIndent("", "  ")
+			if err := e.Encode(earlyErrors); err != nil {
+				panic(err)
+			}
+
+			return
+		}
+
+		b, err := earlyErrorsGo(*pkgFlag, earlyErrors)
+		if err != nil {
+			panic(err)
+		}
+
+		os.Stdout.Write(b)
+	case "samples":
+		g := expand(plist)
+		g.complete(plist)
+
+		s := newSampler(g, *seedFlag, *depthFlag)
+		e := json.NewEncoder(os.Stdout)
+
+		for _, n := range start {
+			for i := 0; i < *countFlag; i++ {
+				r, ok := s.Sample(n)
+				if !ok {
+					fmt.Fprintf(os.Stderr, "couldn't derive a sample from %s\n", n)
+					continue
+				}
+
+				if *jsonFlag {
+					err = e.Encode(r)
+				} else {
+					err = r.Text(os.Stdout)
+				}
+
+				if err != nil {
+					panic(err)
+				}
+			}
+		}
+	case "report":
+		r := analyse(plist, start)
+
+		if *jsonFlag {
+			e := json.NewEncoder(os.Stdout)
+			e.SetIndent("", "  ")
+			if err := e.Encode(r); err != nil {
+				panic(err)
+			}
+		} else if err := r.Text(os.Stdout); err != nil {
+			panic(err)
+		}
+	case "antlr":
+		g := expand(plist)
+		g.complete(plist)
+
+		fmt.Print(antlr(*nameFlag, g))
+	case "json":
+		// The grammar model attaches early errors to the alternatives they
+		// apply to.
+		if _, err := scrapeEarlyErrors(d, plist); err != nil {
+			panic(err)
+		}
+
+		e := json.NewEncoder(os.Stdout)
+		e.SetIndent("", "  ")
+		if err := e.Encode(model(plist)); err != nil {
+			panic(err)
+		}
+	case "go":
+		productions := make(map[string]*production)
+		for _, p := range plist {
+			productions[p.name] = p
+		}
+
+		fmt.Printf(goHeader)
+
+		for _, p := range plist {
+			fmt.Printf("%s\n", p.Go(productions))
+		}
+	case "ebnf":
+		for _, p := range plist {
 			for _, s := range p.EBNF() {
 				fmt.Printf("%s\n\n", s)
 			}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/stretchr/testify/assert"
+)
+
+const scrapeHTML = `<html><body>
+<emu-clause id="sec-static-semantic-rules">
+<emu-production name="Block" id="prod-Block">
+ <emu-nt><a>Block</a></emu-nt>
+ <emu-rhs><emu-t>{</emu-t> <emu-nt><a>StatementList</a></emu-nt> <emu-t>}</emu-t></emu-rhs>
+</emu-production>
+</emu-clause>
+<emu-clause id="sec-grammar-notation">
+<emu-production name="WithoutId">
+ <emu-rhs><emu-t>x</emu-t></emu-rhs>
+</emu-production>
+</emu-clause>
+<emu-clause id="sec-block">
+<emu-production name="Block" params="Yield">
+ <emu-nt><a>Block</a></emu-nt>
+ <emu-rhs><emu-t>{</emu-t> <emu-nt params="?Yield" optional><a>StatementList</a></emu-nt> <emu-t>}</emu-t></emu-rhs>
+</emu-production>
+<emu-clause id="sec-block-static-semantics-early-errors">
+<emu-grammar><emu-production name="Block" collapsed><emu-rhs><emu-t>{</emu-t> <emu-nt><a>StatementList</a></emu-nt> <emu-t>}</emu-t></emu-rhs></emu-production></emu-grammar>
+<ul><li>It is a Syntax Error if ...</li></ul>
+</emu-clause>
+<emu-grammar><emu-production name="Block"><emu-rhs><emu-t>{</emu-t> <emu-t>}</emu-t></emu-rhs></emu-production></emu-grammar>
+<emu-production name="StatementList" params="Yield" id="prod-StatementList">
+ <emu-rhs><emu-t>;</emu-t></emu-rhs>
+</emu-production>
+<emu-production name="FormalParameters" params="Yield" id="prod-FormalParameters">
+ <emu-rhs>[empty]</emu-rhs>
+ <emu-rhs><emu-nt params="?Yield"><a>FormalParameterList</a></emu-nt></emu-rhs>
+</emu-production>
+<emu-production name="Alternative" id="prod-Alternative">
+ <emu-rhs><emu-gann>[empty]</emu-gann></emu-rhs>
+</emu-production>
+</emu-clause>
+</body></html>`
+
+func TestScrape(t *testing.T) {
+	a := assert.New(t)
+
+	d, err := goquery.NewDocumentFromReader(strings.NewReader(scrapeHTML))
+	a.NoError(err)
+
+	plist, err := scrape(d)
+	a.NoError(err)
+
+	var s []string
+	for _, p := range plist {
+		s = append(s, p.EBNF()...)
+	}
+
+	a.Equal([]string{
+		"Block\n  ::= \"{\" StatementList? \"}\"",
+		"Block_Yield\n  ::= \"{\" StatementList_Yield? \"}\"",
+		"StatementList\n  ::= \";\"",
+		"StatementList_Yield\n  ::= \";\"",
+		"FormalParameters\n  ::= [empty]\n    | FormalParameterList",
+		"FormalParameters_Yield\n  ::= [empty]\n    | FormalParameterList_Yield",
+		"Alternative\n  ::= [empty]",
+	}, s)
+}
+
+func TestScrapeRuleUnrecognised(t *testing.T) {
+	a := assert.New(t)
+
+	d, err := goquery.NewDocumentFromReader(strings.NewReader(`<emu-rhs>whatever</emu-rhs>`))
+	a.NoError(err)
+
+	_, err = scrapeRule("Thing", d.Find("emu-rhs"))
+	a.EqualError(err, `Thing: unrecognised right hand side "whatever"`)
+}
@@ -0,0 +1,159 @@
+package main
+
+import (
+	"strings"
+)
+
+// The structured grammar model written by -format json. It mirrors the
+// production, rule and token types, without any of the EBNF-specific
+// rendering, so that other tools can consume the scraped grammar.
+
+type modelGrammar struct {
+	Productions []modelProduction `json:"productions"`
+}
+
+type modelProduction struct {
+	Name         string             `json:"name"`
+	Params       []string           `json:"params,omitempty"`
+	Variants     []string           `json:"variants"`
+	Alternatives []modelAlternative `json:"alternatives"`
+}
+
+type modelAlternative struct {
+	Constraints []modelConstraint `json:"constraints,omitempty"`
+	Items       []modelItem       `json:"items"`
+	EarlyErrors []modelEarlyError `json:"earlyErrors,omitempty"`
+}
+
+// modelEarlyError is an early error rule that applies to an alternative.
+type modelEarlyError struct {
+	ID      string `json:"id"`
+	Section string `json:"section"`
+	Rule    string `json:"rule"`
+}
+
+type modelConstraint struct {
+	Param string `json:"param"`
+	Value bool   `json:"value"`
+}
+
+// modelItem is one element of an alternative. Kind is one of "terminal",
+// "nonterminal", "prose", "oneOf", "lookahead", "noLineTerminator" or
+// "empty".
+type modelItem struct {
+	Kind      string          `json:"kind"`
+	Value     string          `json:"value,omitempty"`
+	Values    []string        `json:"values,omitempty"`
+	Arguments []modelArgument `json:"arguments,omitempty"`
+	Optional  bool            `json:"optional,omitempty"`
+	Negated   bool            `json:"negated,omitempty"`
+	Set       [][]modelItem   `json:"set,omitempty"`
+	ButNot    []modelItem     `json:"butNot,omitempty"`
+}
+
+// modelArgument is a parameter passed to a nonterminal. Mode is "set" for
+// `+In`, "inherit" for `?In` and "clear" for `~In`.
+type modelArgument struct {
+	Param string `json:"param"`
+	Mode  string `json:"mode"`
+}
+
+func model(plist []*production) modelGrammar {
+	var g modelGrammar
+
+	for _, p := range plist {
+		g.Productions = append(g.Productions, p.Model())
+	}
+
+	return g
+}
+
+func (p production) Model() modelProduction {
+	m := modelProduction{
+		Name:         p.name,
+		Params:       p.params,
+		Alternatives: make([]modelAlternative, len(p.rules)),
+	}
+
+	for _, v := range variants(p.name, p.params) {
+		m.Variants = append(m.Variants, strings.Join(v, "_"))
+	}
+
+	for i, r := range p.rules {
+		m.Alternatives[i] = r.Model()
+	}
+
+	return m
+}
+
+func (r rule) Model() modelAlternative {
+	m := modelAlternative{Items: make([]modelItem, len(r.tokens))}
+
+	for _, c := range r.constraints {
+		m.Constraints = append(m.Constraints, modelConstraint{Param: c.value, Value: !c.inverse})
+	}
+
+	for i, t := range r.tokens {
+		m.Items[i] = t.Model()
+	}
+
+	for _, e := range r.earlyErrors {
+		m.EarlyErrors = append(m.EarlyErrors, modelEarlyError{ID: e.ID, Section: e.Section, Rule: e.Rule})
+	}
+
+	return m
+}
+
+func (t token) Model() modelItem {
+	var m modelItem
+
+	switch {
+	case t.lookahead:
+		m.Kind = "lookahead"
+		m.Negated = t.inverse
+		m.Set = make([][]modelItem, len(t.set))
+		for i, q := range t.set {
+			m.Set[i] = make([]modelItem, len(q))
+			for j, e := range q {
+				m.Set[i][j] = e.Model()
+			}
+		}
+	case t.noLineTerminator:
+		m.Kind = "noLineTerminator"
+	case t.empty:
+		m.Kind = "empty"
+	case t.prose:
+		m.Kind = "prose"
+		m.Value = strings.TrimSuffix(strings.TrimPrefix(t.value, "<"), ">")
+	case t.terminal:
+		m.Kind = "terminal"
+		m.Value = t.value
+	case t.oneof:
+		m.Kind = "oneOf"
+		m.Values = t.values
+	default:
+		m.Kind = "nonterminal"
+		m.Value = t.value
+
+		for _, p := range t.params {
+			switch p[0] {
+			case '?':
+				m.Arguments = append(m.Arguments, modelArgument{Param: p[1:], Mode: "inherit"})
+			case '+':
+				m.Arguments = append(m.Arguments, modelArgument{Param: p[1:], Mode: "set"})
+			case '~':
+				m.Arguments = append(m.Arguments, modelArgument{Param: p[1:], Mode: "clear"})
+			default:
+				m.Arguments = append(m.Arguments, modelArgument{Param: p, Mode: "set"})
+			}
+		}
+	}
+
+	m.Optional = t.optional
+
+	for _, e := range t.butNot {
+		m.ButNot = append(m.ButNot, e.Model())
+	}
+
+	return m
+}
@@ -0,0 +1,347 @@
+package main
+
+import (
+	"fmt"
+	"html"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"unicode/utf8"
+)
+
+// Railroad diagrams are laid out as a tree of diagram elements. Every element
+// is entered on the left and left on the right at the same height, its "line".
+// up and down are how far the element extends above and below its line.
+
+const (
+	rrCharWidth = 8
+	rrBoxHeight = 22
+	rrPadding   = 10
+	rrGap       = 10
+	rrArc       = 10
+	rrMargin    = 20
+)
+
+const rrStyle = `
+svg.railroad { background: #fff; }
+svg.railroad path { stroke: #333; stroke-width: 1.5; fill: none; }
+svg.railroad rect { stroke: #333; stroke-width: 1.5; }
+svg.railroad rect.terminal { fill: #dfd; }
+svg.railroad rect.nonterminal { fill: #ddf; }
+svg.railroad rect.prose { fill: #eee; stroke-dasharray: 4 2; }
+svg.railroad text { font: 13px monospace; text-anchor: middle; dominant-baseline: central; }
+svg.railroad text.prose, svg.railroad text.annotation { font-style: italic; }
+svg.railroad text.annotation { fill: #666; }
+svg.railroad a:hover rect { fill: #bbf; }
+`
+
+type rrElement interface {
+	width() int
+	up() int
+	down() int
+	draw(x, y int) string
+}
+
+// rrBox is a terminal, nonterminal or piece of grammar prose. Nonterminals
+// link to the diagram for the production they refer to.
+type rrBox struct {
+	text  string
+	class string
+	href  string
+}
+
+func (b rrBox) width() int { return utf8.RuneCountInString(b.text)*rrCharWidth + rrPadding*2 }
+func (b rrBox) up() int    { return rrBoxHeight / 2 }
+func (b rrBox) down() int  { return rrBoxHeight / 2 }
+
+func (b rrBox) draw(x, y int) string {
+	r := 0
+	if b.class == "terminal" {
+		r = rrBoxHeight / 2
+	}
+
+	s := fmt.Sprintf(`<rect class="%s" x="%d" y="%d" width="%d" height="%d" rx="%d" ry="%d"/>`, b.class, x, y-b.up(), b.width(), rrBoxHeight, r, r)
+	s += fmt.Sprintf(`<text class="%s" x="%d" y="%d">%s</text>`, b.class, x+b.width()/2, y, html.EscapeString(b.text))
+
+	if b.href != "" {
+		s = fmt.Sprintf(`<a xlink:href="%s">%s</a>`, html.EscapeString(b.href), s)
+	}
+
+	return s
+}
+
+// rrComment is an annotation that doesn't consume input, like a lookahead
+// restriction, drawn as text sitting on the line.
+type rrComment struct {
+	text string
+}
+
+func (c rrComment) width() int { return utf8.RuneCountInString(c.text)*rrCharWidth + rrPadding*2 }
+func (c rrComment) up() int    { return rrBoxHeight }
+func (c rrComment) down() int  { return rrBoxHeight / 2 }
+
+func (c rrComment) draw(x, y int) string {
+	s := fmt.Sprintf(`<path d="M%d %dh%d"/>`, x, y, rrPadding/2)
+	s += fmt.Sprintf(`<path d="M%d %dh%d"/>`, x+c.width()-rrPadding/2, y, rrPadding/2)
+	s += fmt.Sprintf(`<text class="annotation" x="%d" y="%d">%s</text>`, x+c.width()/2, y-rrBoxHeight/2-2, html.EscapeString(c.text))
+	s += fmt.Sprintf(`<path d="M%d %dh%d" stroke-dasharray="2 2"/>`, x+rrPadding/2, y, c.width()-rrPadding)
+
+	return s
+}
+
+// rrSkip is an empty path, used for the bypass of an optional element.
+type rrSkip struct{}
+
+func (rrSkip) width() int           { return 0 }
+func (rrSkip) up() int              { return 0 }
+func (rrSkip) down() int            { return 0 }
+func (rrSkip) draw(x, y int) string { return "" }
+
+type rrSequence []rrElement
+
+func (q rrSequence) width() int {
+	w := 0
+	for i, e := range q {
+		if i > 0 {
+			w += rrGap
+		}
+
+		w += e.width()
+	}
+
+	return w
+}
+
+func (q rrSequence) up() int {
+	n := 0
+	for _, e := range q {
+		if e.up() > n {
+			n = e.up()
+		}
+	}
+
+	return n
+}
+
+func (q rrSequence) down() int {
+	n := 0
+	for _, e := range q {
+		if e.down() > n {
+			n = e.down()
+		}
+	}
+
+	return n
+}
+
+func (q rrSequence) draw(x, y int) string {
+	var s string
+
+	for i, e := range q {
+		if i > 0 {
+			s += fmt.Sprintf(`<path d="M%d %dh%d"/>`, x, y, rrGap)
+			x += rrGap
+		}
+
+		s += e.draw(x, y)
+		x += e.width()
+	}
+
+	return s
+}
+
+// rrChoice stacks its alternatives vertically, with the first on the line.
+type rrChoice []rrElement
+
+func (c rrChoice) inner() int {
+	w := 0
+	for _, e := range c {
+		if e.width() > w {
+			w = e.width()
+		}
+	}
+
+	return w
+}
+
+func (c rrChoice) width() int { return c.inner() + rrArc*4 }
+func (c rrChoice) up() int    { return c[0].up() }
+
+func (c rrChoice) down() int {
+	return c.offset(len(c)-1) + c[len(c)-1].down()
+}
+
+// offset returns how far below the line alternative i is drawn.
+func (c rrChoice) offset(i int) int {
+	y := 0
+	for j := 1; j <= i; j++ {
+		y += c[j-1].down() + rrGap + c[j].up()
+		if y < rrArc*2 {
+			y = rrArc * 2
+		}
+	}
+
+	return y
+}
+
+func (c rrChoice) draw(x, y int) string {
+	w := c.width()
+	inner := c.inner()
+
+	var s string
+	for i, e := range c {
+		d := c.offset(i)
+		ex := x + rrArc*2 + (inner-e.width())/2
+
+		if i == 0 {
+			s += fmt.Sprintf(`<path d="M%d %dH%d"/>`, x, y, ex)
+			s += fmt.Sprintf(`<path d="M%d %dH%d"/>`, ex+e.width(), y, x+w)
+		} else {
+			s += fmt.Sprintf(`<path d="M%d %dq%d 0 %d %dv%dq0 %d %d %dH%d"/>`, x, y, rrArc, rrArc, rrArc, d-rrArc*2, rrArc, rrArc, rrArc, ex)
+			s += fmt.Sprintf(`<path d="M%d %dH%dq%d 0 %d %dv%dq0 %d %d %d"/>`, ex+e.width(), y+d, x+w-rrArc*2, rrArc, rrArc, -rrArc, -(d - rrArc*2), -rrArc, rrArc, -rrArc)
+		}
+
+		s += e.draw(ex, y+d)
+	}
+
+	return s
+}
+
+// railroadToken builds the diagram element for a token from an expanded
+// grammar. href returns the link for a nonterminal, or "" if there's nothing
+// to link to.
+func railroadToken(t token, href func(string) string) rrElement {
+	var e rrElement
+
+	switch {
+	case t.lookahead:
+		return rrComment{lookaheadString(t.inverse, t.set, token.String)}
+	case t.noLineTerminator:
+		return rrComment{"[no LineTerminator here]"}
+	case t.empty:
+		return rrComment{"[empty]"}
+	case t.prose:
+		e = rrBox{text: strings.Trim(t.value, "<>"), class: "prose"}
+	case t.terminal:
+		e = rrBox{text: t.value, class: "terminal"}
+	case t.oneof:
+		c := make(rrChoice, len(t.values))
+		for i, v := range t.values {
+			c[i] = rrBox{text: v, class: "terminal"}
+		}
+
+		e = c
+	default:
+		e = rrBox{text: t.value, class: "nonterminal", href: href(t.value)}
+	}
+
+	if len(t.butNot) > 0 {
+		a := make([]string, len(t.butNot))
+		for i, b := range t.butNot {
+			a[i] = b.String()
+		}
+
+		e = rrSequence{e, rrComment{"but not " + strings.Join(a, " or ")}}
+	}
+
+	if t.optional {
+		e = rrChoice{rrSkip{}, e}
+	}
+
+	return e
+}
+
+// railroad renders the alternatives of one production variant as a railroad
+// diagram in a standalone SVG document.
+func railroad(name string, alts [][]token, href func(string) string) string {
+	var d rrElement
+
+	if len(alts) == 0 {
+		d = rrComment{"no alternatives with these parameters"}
+	} else {
+		c := make(rrChoice, len(alts))
+		for i, alt := range alts {
+			q := make(rrSequence, len(alt))
+			for j, t := range alt {
+				q[j] = railroadToken(t, href)
+			}
+
+			c[i] = q
+		}
+
+		if len(c) == 1 {
+			d = c[0]
+		} else {
+			d = c
+		}
+	}
+
+	w := d.width() + rrMargin*2 + rrGap*2
+	h := d.up() + d.down() + rrMargin*2 + rrBoxHeight
+	x, y := rrMargin, rrMargin+rrBoxHeight+d.up()
+
+	s := fmt.Sprintf(`<svg class="railroad" xmlns="http://www.w3.org/2000/svg" xmlns:xlink="http://www.w3.org/1999/xlink" width="%d" height="%d" viewBox="0 0 %d %d">`, w, h, w, h)
+	s += "<title>" + html.EscapeString(name) + "</title>"
+	s += "<style>" + rrStyle + "</style>"
+	s += fmt.Sprintf(`<text class="annotation" x="%d" y="%d" style="text-anchor: start">%s</text>`, rrMargin, rrMargin/2+rrBoxHeight/2, html.EscapeString(name))
+	s += fmt.Sprintf(`<path d="M%d %dv%dM%d %dv%d"/>`, x, y-rrArc, rrArc*2, x+4, y-rrArc, rrArc*2)
+	s += fmt.Sprintf(`<path d="M%d %dh%d"/>`, x, y, rrGap)
+	s += d.draw(x+rrGap, y)
+	s += fmt.Sprintf(`<path d="M%d %dh%d"/>`, x+rrGap+d.width(), y, rrGap)
+	s += fmt.Sprintf(`<path d="M%d %dv%dM%d %dv%d"/>`, w-rrMargin, y-rrArc, rrArc*2, w-rrMargin-4, y-rrArc, rrArc*2)
+	s += "</svg>\n"
+
+	return s
+}
+
+// writeRailroad writes one SVG diagram per production variant into dir,
+// along with an index.html that shows them all, cross-linked.
+func writeRailroad(dir string, plist []*production) error {
+	g := expand(plist)
+	g.complete(plist)
+
+	link := func(prefix, suffix string) func(string) string {
+		return func(n string) string {
+			if _, ok := g.rules[n]; !ok {
+				return ""
+			}
+
+			return prefix + n + suffix
+		}
+	}
+
+	for _, n := range g.names {
+		if err := ioutil.WriteFile(filepath.Join(dir, n+".svg"), []byte(railroad(n, g.rules[n], link("", ".svg"))), 0644); err != nil {
+			return err
+		}
+	}
+
+	s := "<!DOCTYPE html>\n<html>\n<head>\n<meta charset=\"utf-8\">\n<title>ECMAScript grammar</title>\n"
+	s += "<style>body { font-family: sans-serif; } nav a { margin-right: 1em; } section { margin: 2em 0; }</style>\n"
+	s += "</head>\n<body>\n<h1>ECMAScript grammar</h1>\n<nav>\n"
+
+	for _, p := range plist {
+		s += fmt.Sprintf("<a href=\"#%s\">%s</a>\n", html.EscapeString(p.name), html.EscapeString(p.name))
+	}
+
+	s += "</nav>\n"
+
+	for _, p := range plist {
+		h := p.name
+		if len(p.params) > 0 {
+			h += "[" + strings.Join(p.params, ", ") + "]"
+		}
+
+		s += fmt.Sprintf("<section>\n<h2>%s</h2>\n", html.EscapeString(h))
+
+		for _, n := range g.variants[p.name] {
+			s += fmt.Sprintf("<div id=\"%s\">\n%s</div>\n", html.EscapeString(n), railroad(n, g.rules[n], link("#", "")))
+		}
+
+		s += "</section>\n"
+	}
+
+	s += "</body>\n</html>\n"
+
+	return ioutil.WriteFile(filepath.Join(dir, "index.html"), []byte(s), 0644)
+}
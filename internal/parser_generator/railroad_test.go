@@ -0,0 +1,83 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// railroadAlts is
+//
+//	L ::= "a" B? ( "x" | "y" )
+//	    | L "," B
+//
+// which has a sequence, an alternative, an optional, a one-of and, since the
+// grammar writes repetition as recursion, a repetition.
+var railroadAlts = [][]token{
+	{tm("a"), {value: "B", optional: true}, {oneof: true, values: []string{"x", "y"}}},
+	{nt("L"), tm(","), nt("B")},
+}
+
+func railroadHref(n string) string { return n + ".svg" }
+
+func TestRailroadToken(t *testing.T) {
+	a := assert.New(t)
+
+	a.Equal(rrBox{text: "a", class: "terminal"}, railroadToken(railroadAlts[0][0], railroadHref))
+	a.Equal(rrChoice{rrSkip{}, rrBox{text: "B", class: "nonterminal", href: "B.svg"}}, railroadToken(railroadAlts[0][1], railroadHref))
+	a.Equal(rrChoice{rrBox{text: "x", class: "terminal"}, rrBox{text: "y", class: "terminal"}}, railroadToken(railroadAlts[0][2], railroadHref))
+	a.Equal(rrComment{"[empty]"}, railroadToken(token{empty: true}, railroadHref))
+	a.Equal(
+		rrSequence{rrBox{text: "B", class: "nonterminal", href: "B.svg"}, rrComment{"but not \"b\""}},
+		railroadToken(token{value: "B", butNot: []token{tm("b")}}, railroadHref),
+	)
+}
+
+func TestRailroadLayout(t *testing.T) {
+	a := assert.New(t)
+
+	var c rrChoice
+	for _, alt := range railroadAlts {
+		var q rrSequence
+		for _, tk := range alt {
+			q = append(q, railroadToken(tk, railroadHref))
+		}
+
+		c = append(c, q)
+	}
+
+	// Each box is 28 wide and 22 high. The optional and the one-of are both
+	// 28 plus four arcs wide, and hang their second row 32 and 20 below the
+	// line, so the first alternative is 11 above and 43 below.
+	a.Equal(184, c[0].width())
+	a.Equal(11, c[0].up())
+	a.Equal(43, c[0].down())
+	a.Equal(104, c[1].width())
+
+	a.Equal(224, c.width())
+	a.Equal(11, c.up())
+	a.Equal(64, c.offset(1))
+	a.Equal(75, c.down())
+}
+
+func TestRailroad(t *testing.T) {
+	a := assert.New(t)
+
+	s := railroad("L", railroadAlts, railroadHref)
+
+	a.True(strings.HasPrefix(s, `<svg class="railroad" xmlns="http://www.w3.org/2000/svg" xmlns:xlink="http://www.w3.org/1999/xlink" width="284" height="148" viewBox="0 0 284 148">`))
+	a.True(strings.HasSuffix(s, "</svg>\n"))
+	a.Contains(s, "<title>L</title>")
+
+	a.Equal(4, strings.Count(s, `<rect class="terminal"`))
+	a.Equal(3, strings.Count(s, `<rect class="nonterminal"`))
+	a.Equal(2, strings.Count(s, `<a xlink:href="B.svg">`))
+	a.Equal(1, strings.Count(s, `<a xlink:href="L.svg">`), "the repetition links back to its own diagram")
+
+	for _, v := range []string{"a", "x", "y", ","} {
+		a.Contains(s, `>`+v+`</text>`)
+	}
+
+	a.Contains(railroad("L", nil, railroadHref), ">no alternatives with these parameters</text>")
+}
@@ -0,0 +1,504 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// endOfInput stands in for the end of the input in FOLLOW sets.
+const endOfInput = "$"
+
+var defaultStart = []string{
+	"Script",
+	"Module",
+	"InputElementDiv",
+	"InputElementRegExp",
+	"InputElementRegExpOrTemplateTail",
+	"InputElementTemplateTail",
+	"StringNumericLiteral",
+	"Pattern",
+	"Pattern_U",
+}
+
+type report struct {
+	Start         []string          `json:"start"`
+	Productions   []reportVariant   `json:"productions"`
+	LeftRecursion [][]string        `json:"leftRecursion"`
+	Undefined     []reportReference `json:"undefined"`
+	Unreachable   []string          `json:"unreachable"`
+	Conflicts     []reportConflict  `json:"conflicts"`
+	Parameters    []reportParams    `json:"parameters"`
+}
+
+type reportVariant struct {
+	Name     string   `json:"name"`
+	Nullable bool     `json:"nullable"`
+	First    []string `json:"first"`
+	Follow   []string `json:"follow"`
+}
+
+type reportReference struct {
+	Name           string   `json:"name"`
+	ReferencedFrom []string `json:"referencedFrom"`
+}
+
+type reportConflict struct {
+	Name         string   `json:"name"`
+	Alternatives [2]int   `json:"alternatives"`
+	Terminals    []string `json:"terminals"`
+}
+
+type reportParams struct {
+	Production string   `json:"production"`
+	Params     []string `json:"params"`
+	Used       []string `json:"used"`
+	Unused     []string `json:"unused"`
+}
+
+type analysis struct {
+	g        *grammar
+	nullable map[string]bool
+	first    map[string]map[string]bool
+	follow   map[string]map[string]bool
+}
+
+func analyse(plist []*production, start []string) report {
+	// variants() doesn't give every combination of three or more
+	// parameters, so add the ones the grammar refers to, as the antlr and
+	// samples formats do. Otherwise they'd be undefined, and their FIRST
+	// sets empty.
+	g := expand(plist)
+	g.complete(plist)
+
+	a := analysis{
+		g:        g,
+		nullable: make(map[string]bool),
+		first:    make(map[string]map[string]bool),
+		follow:   make(map[string]map[string]bool),
+	}
+
+	for _, n := range a.g.names {
+		a.first[n] = make(map[string]bool)
+		a.follow[n] = make(map[string]bool)
+	}
+
+	a.computeFirst()
+	a.computeFollow(start)
+
+	r := report{Start: start}
+
+	for _, n := range a.g.names {
+		r.Productions = append(r.Productions, reportVariant{
+			Name:     n,
+			Nullable: a.nullable[n],
+			First:    keys(a.first[n]),
+			Follow:   keys(a.follow[n]),
+		})
+	}
+
+	r.LeftRecursion = a.leftRecursion()
+	r.Undefined = a.undefined()
+	r.Unreachable = a.unreachable(start)
+	r.Conflicts = a.conflicts()
+	r.Parameters = a.parameters(plist)
+
+	return r
+}
+
+// sequence computes FIRST and nullability for a run of tokens. A negative
+// lookahead on single terminals removes those terminals from whatever comes
+// next.
+func (a *analysis) sequence(tokens []token) (map[string]bool, bool) {
+	first := make(map[string]bool)
+	exclude := make(map[string]bool)
+
+	for _, t := range tokens {
+		if t.lookahead && t.inverse {
+			for _, q := range t.set {
+				if len(q) == 1 {
+					for _, s := range terminals(q[0]) {
+						exclude[s] = true
+					}
+				}
+			}
+		}
+
+		if t.assertion() {
+			continue
+		}
+
+		var f map[string]bool
+		nullable := t.optional
+
+		if nonterminal(t) {
+			f = a.first[t.value]
+			nullable = nullable || a.nullable[t.value]
+		} else {
+			f = make(map[string]bool)
+			for _, s := range terminals(t) {
+				f[s] = true
+			}
+		}
+
+		for s := range f {
+			if !exclude[s] {
+				first[s] = true
+			}
+		}
+
+		if !nullable {
+			return first, false
+		}
+	}
+
+	return first, true
+}
+
+func (a *analysis) computeFirst() {
+	for changed := true; changed; {
+		changed = false
+
+		for _, n := range a.g.names {
+			for _, alt := range a.g.rules[n] {
+				f, nullable := a.sequence(alt)
+
+				if nullable && !a.nullable[n] {
+					a.nullable[n] = true
+					changed = true
+				}
+
+				for s := range f {
+					if !a.first[n][s] {
+						a.first[n][s] = true
+						changed = true
+					}
+				}
+			}
+		}
+	}
+}
+
+func (a *analysis) computeFollow(start []string) {
+	for _, n := range start {
+		if f, ok := a.follow[n]; ok {
+			f[endOfInput] = true
+		}
+	}
+
+	for changed := true; changed; {
+		changed = false
+
+		for _, n := range a.g.names {
+			for _, alt := range a.g.rules[n] {
+				for i, t := range alt {
+					if !nonterminal(t) || a.follow[t.value] == nil {
+						continue
+					}
+
+					f, nullable := a.sequence(alt[i+1:])
+					if nullable {
+						for s := range a.follow[n] {
+							f[s] = true
+						}
+					}
+
+					for s := range f {
+						if !a.follow[t.value][s] {
+							a.follow[t.value][s] = true
+							changed = true
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+// leftRecursion finds the strongly connected components of the "can begin
+// with" graph, each of which is a set of productions that are left recursive
+// through each other. The members of each set are sorted by name.
+func (a *analysis) leftRecursion() [][]string {
+	edges := make(map[string][]string)
+	for _, n := range a.g.names {
+		for _, alt := range a.g.rules[n] {
+			for _, t := range alt {
+				if t.assertion() {
+					continue
+				}
+
+				if !nonterminal(t) {
+					break
+				}
+
+				if _, ok := a.g.rules[t.value]; ok {
+					edges[n] = append(edges[n], t.value)
+				}
+
+				if !t.optional && !a.nullable[t.value] {
+					break
+				}
+			}
+		}
+	}
+
+	var (
+		index   = make(map[string]int)
+		low     = make(map[string]int)
+		onStack = make(map[string]bool)
+		stack   []string
+		cycles  [][]string
+	)
+
+	var visit func(n string)
+	visit = func(n string) {
+		index[n] = len(index)
+		low[n] = index[n]
+		stack = append(stack, n)
+		onStack[n] = true
+
+		for _, m := range edges[n] {
+			if _, ok := index[m]; !ok {
+				visit(m)
+				if low[m] < low[n] {
+					low[n] = low[m]
+				}
+			} else if onStack[m] && index[m] < low[n] {
+				low[n] = index[m]
+			}
+		}
+
+		if low[n] != index[n] {
+			return
+		}
+
+		var c []string
+		for {
+			m := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			onStack[m] = false
+			c = append(c, m)
+			if m == n {
+				break
+			}
+		}
+
+		self := false
+		for _, m := range edges[n] {
+			if m == n {
+				self = true
+			}
+		}
+
+		if len(c) > 1 || self {
+			sort.Strings(c)
+			cycles = append(cycles, c)
+		}
+	}
+
+	for _, n := range a.g.names {
+		if _, ok := index[n]; !ok {
+			visit(n)
+		}
+	}
+
+	sort.Slice(cycles, func(i, j int) bool { return cycles[i][0] < cycles[j][0] })
+
+	return cycles
+}
+
+func (a *analysis) undefined() []reportReference {
+	m := make(map[string]map[string]bool)
+
+	for _, n := range a.g.names {
+		a.g.references(n, func(s string) {
+			if _, ok := a.g.rules[s]; ok {
+				return
+			}
+
+			if m[s] == nil {
+				m[s] = make(map[string]bool)
+			}
+
+			m[s][n] = true
+		})
+	}
+
+	var r []reportReference
+	for s, from := range m {
+		r = append(r, reportReference{Name: s, ReferencedFrom: keys(from)})
+	}
+
+	sort.Slice(r, func(i, j int) bool { return r[i].Name < r[j].Name })
+
+	return r
+}
+
+func (a *analysis) reachable(start []string) map[string]bool {
+	seen := make(map[string]bool)
+
+	var visit func(n string)
+	visit = func(n string) {
+		if seen[n] {
+			return
+		}
+
+		if _, ok := a.g.rules[n]; !ok {
+			return
+		}
+
+		seen[n] = true
+
+		a.g.references(n, visit)
+	}
+
+	for _, n := range start {
+		visit(n)
+	}
+
+	return seen
+}
+
+func (a *analysis) unreachable(start []string) []string {
+	seen := a.reachable(start)
+
+	var r []string
+	for _, n := range a.g.names {
+		if !seen[n] {
+			r = append(r, n)
+		}
+	}
+
+	return r
+}
+
+func (a *analysis) conflicts() []reportConflict {
+	var r []reportConflict
+
+	for _, n := range a.g.names {
+		alts := a.g.rules[n]
+
+		firsts := make([]map[string]bool, len(alts))
+		for i, alt := range alts {
+			f, nullable := a.sequence(alt)
+			if nullable {
+				for s := range a.follow[n] {
+					f[s] = true
+				}
+			}
+
+			firsts[i] = f
+		}
+
+		for i := range alts {
+			for j := i + 1; j < len(alts); j++ {
+				var c []string
+				for s := range firsts[i] {
+					if firsts[j][s] {
+						c = append(c, s)
+					}
+				}
+
+				if len(c) > 0 {
+					sort.Strings(c)
+					r = append(r, reportConflict{Name: n, Alternatives: [2]int{i + 1, j + 1}, Terminals: c})
+				}
+			}
+		}
+	}
+
+	return r
+}
+
+func (a *analysis) parameters(plist []*production) []reportParams {
+	used := make(map[string]bool)
+	for _, n := range a.g.names {
+		a.g.references(n, func(s string) { used[s] = true })
+	}
+
+	var r []reportParams
+	for _, p := range plist {
+		if len(p.params) == 0 {
+			continue
+		}
+
+		e := reportParams{Production: p.name, Params: p.params}
+		for _, v := range a.g.variants[p.name] {
+			if used[v] {
+				e.Used = append(e.Used, v)
+			} else {
+				e.Unused = append(e.Unused, v)
+			}
+		}
+
+		r = append(r, e)
+	}
+
+	return r
+}
+
+func keys(m map[string]bool) []string {
+	var a []string
+	for k := range m {
+		a = append(a, k)
+	}
+
+	sort.Strings(a)
+
+	return a
+}
+
+func (r report) Text(w io.Writer) error {
+	var err error
+	p := func(format string, a ...interface{}) {
+		if err == nil {
+			_, err = fmt.Fprintf(w, format, a...)
+		}
+	}
+
+	p("Start symbols: %s\n\n", strings.Join(r.Start, ", "))
+
+	p("FIRST/FOLLOW\n\n")
+	for _, v := range r.Productions {
+		n := ""
+		if v.Nullable {
+			n = " (nullable)"
+		}
+
+		p("%s%s\n  FIRST:  %s\n  FOLLOW: %s\n\n", v.Name, n, strings.Join(v.First, " "), strings.Join(v.Follow, " "))
+	}
+
+	// Each entry is the set of productions in a left recursive cycle, in
+	// alphabetical order, not the path the cycle takes through them.
+	p("Left recursive cycle sets (%d)\n\n", len(r.LeftRecursion))
+	for _, c := range r.LeftRecursion {
+		p("  {%s}\n", strings.Join(c, ", "))
+	}
+	p("\n")
+
+	p("Referenced but undefined (%d)\n\n", len(r.Undefined))
+	for _, u := range r.Undefined {
+		p("  %s (from %s)\n", u.Name, strings.Join(u.ReferencedFrom, ", "))
+	}
+	p("\n")
+
+	p("Defined but unreachable (%d)\n\n", len(r.Unreachable))
+	for _, n := range r.Unreachable {
+		p("  %s\n", n)
+	}
+	p("\n")
+
+	p("LL(1) conflicts (%d)\n\n", len(r.Conflicts))
+	for _, c := range r.Conflicts {
+		p("  %s: alternatives %d and %d on %s\n", c.Name, c.Alternatives[0], c.Alternatives[1], strings.Join(c.Terminals, " "))
+	}
+	p("\n")
+
+	p("Parameter combinations\n\n")
+	for _, e := range r.Parameters {
+		p("  %s[%s]\n    used:   %s\n    unused: %s\n", e.Production, strings.Join(e.Params, ", "), strings.Join(e.Used, " "), strings.Join(e.Unused, " "))
+	}
+
+	return err
+}
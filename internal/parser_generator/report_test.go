@@ -0,0 +1,87 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func nt(name string) token  { return token{value: name} }
+func tm(value string) token { return token{terminal: true, value: value} }
+
+// reportGrammar is
+//
+//	S ::= A "x"
+//	A ::= A "a" | B | [empty]
+//	B ::= C "b"
+//	C ::= B? "c"
+//	D[In] ::= [lookahead ≠ "x"] S | [+In] "in"
+var reportGrammar = []*production{
+	{name: "S", rules: []rule{{tokens: []token{nt("A"), tm("x")}}}},
+	{name: "A", rules: []rule{
+		{tokens: []token{nt("A"), tm("a")}},
+		{tokens: []token{nt("B")}},
+		{tokens: []token{{empty: true}}},
+	}},
+	{name: "B", rules: []rule{{tokens: []token{nt("C"), tm("b")}}}},
+	{name: "C", rules: []rule{{tokens: []token{{value: "B", optional: true}, tm("c")}}}},
+	{name: "D", params: []string{"In"}, rules: []rule{
+		{tokens: []token{{lookahead: true, inverse: true, set: [][]token{{tm("x")}}}, nt("S")}},
+		{constraints: []constraint{{value: "In"}}, tokens: []token{tm("in")}},
+	}},
+}
+
+func TestReportFirstFollow(t *testing.T) {
+	a := assert.New(t)
+
+	r := analyse(reportGrammar, []string{"S"})
+
+	a.Equal([]reportVariant{
+		{Name: "S", First: []string{`"a"`, `"c"`, `"x"`}, Follow: []string{"$"}},
+		{Name: "A", Nullable: true, First: []string{`"a"`, `"c"`}, Follow: []string{`"a"`, `"x"`}},
+		{Name: "B", First: []string{`"c"`}, Follow: []string{`"a"`, `"c"`, `"x"`}},
+		{Name: "C", First: []string{`"c"`}, Follow: []string{`"b"`}},
+		{Name: "D", First: []string{`"a"`, `"c"`}},
+		{Name: "D_In", First: []string{`"a"`, `"c"`, `"in"`}},
+	}, r.Productions)
+
+	a.Equal([]string{"D", "D_In"}, r.Unreachable)
+}
+
+func TestReportLeftRecursion(t *testing.T) {
+	a := assert.New(t)
+
+	r := analyse(reportGrammar, []string{"S"})
+
+	a.Equal([][]string{{"A"}, {"B", "C"}}, r.LeftRecursion)
+
+	var b bytes.Buffer
+	a.NoError(r.Text(&b))
+	a.Contains(b.String(), "Left recursive cycle sets (2)\n\n  {A}\n  {B, C}\n\n")
+}
+
+func TestReportCompletesVariants(t *testing.T) {
+	a := assert.New(t)
+
+	// variants() never makes P_In_Yield, only P_In_Yield_Return and the
+	// others, so it has to come from the reference in S.
+	r := analyse([]*production{
+		{name: "S", rules: []rule{{tokens: []token{{value: "P", params: []string{"+In", "+Yield"}}, tm(";")}}}},
+		{name: "P", params: []string{"In", "Yield", "Return"}, rules: []rule{
+			{constraints: []constraint{{value: "Return"}}, tokens: []token{tm("return")}},
+			{constraints: []constraint{{value: "In"}}, tokens: []token{tm("in")}},
+			{tokens: []token{{empty: true}}},
+		}},
+	}, []string{"S"})
+
+	a.Empty(r.Undefined)
+
+	m := make(map[string]reportVariant)
+	for _, v := range r.Productions {
+		m[v.Name] = v
+	}
+
+	a.Equal(reportVariant{Name: "P_In_Yield", Nullable: true, First: []string{`"in"`}, Follow: []string{`";"`}}, m["P_In_Yield"])
+	a.Equal(reportVariant{Name: "S", First: []string{`";"`, `"in"`}, Follow: []string{"$"}}, m["S"])
+}
@@ -8,6 +8,7 @@ import (
 type rule struct {
 	constraints []constraint
 	tokens      []token
+	earlyErrors []*earlyError
 }
 
 func (r rule) String() string {
@@ -27,10 +28,8 @@ func (r rule) String() string {
 func (r rule) EBNF(options map[string]bool) string {
 	s := ""
 
-	for _, c := range r.constraints {
-		if options[c.value] != c.inverse {
-			return ""
-		}
+	if !r.applies(options) {
+		return ""
 	}
 
 	for _, t := range r.tokens {
@@ -40,6 +39,16 @@ func (r rule) EBNF(options map[string]bool) string {
 	return strings.TrimSpace(s)
 }
 
+func (r rule) applies(options map[string]bool) bool {
+	for _, c := range r.constraints {
+		if !c.satisfied(options) {
+			return false
+		}
+	}
+
+	return true
+}
+
 func (r rule) Go(m map[string]*production, p production) string {
 	s := "r := input\n\n"
 
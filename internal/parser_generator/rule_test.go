@@ -0,0 +1,112 @@
+package main
+
+import (
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProductionConstraints(t *testing.T) {
+	a := assert.New(t)
+
+	p := production{
+		name:   "ClassElement",
+		params: []string{"Yield"},
+		rules: []rule{
+			{
+				constraints: []constraint{{value: "Yield"}},
+				tokens:      []token{{terminal: true, value: "yield"}},
+			},
+			{
+				constraints: []constraint{{inverse: true, value: "Yield"}},
+				tokens:      []token{{value: "Identifier"}},
+			},
+			{
+				tokens: []token{{terminal: true, value: ";"}},
+			},
+		},
+	}
+
+	a.Equal([]string{
+		"ClassElement\n  ::= Identifier\n    | \";\"",
+		"ClassElement_Yield\n  ::= \"yield\"\n    | \";\"",
+	}, p.EBNF())
+}
+
+func TestRuleApplies(t *testing.T) {
+	for _, c := range []struct {
+		constraints []constraint
+		options     map[string]bool
+		applies     bool
+	}{
+		{nil, nil, true},
+		{[]constraint{{value: "Yield"}}, map[string]bool{"Yield": true}, true},
+		{[]constraint{{value: "Yield"}}, map[string]bool{}, false},
+		{[]constraint{{inverse: true, value: "Yield"}}, map[string]bool{"Yield": true}, false},
+		{[]constraint{{inverse: true, value: "Yield"}}, map[string]bool{}, true},
+		{[]constraint{{value: "In"}, {inverse: true, value: "Yield"}}, map[string]bool{"In": true}, true},
+		{[]constraint{{value: "In"}, {inverse: true, value: "Yield"}}, map[string]bool{"In": true, "Yield": true}, false},
+	} {
+		r := rule{constraints: c.constraints}
+		assert.Equal(t, c.applies, r.applies(c.options), "%s with %v", r.constraints, c.options)
+	}
+}
+
+// TestConstraintsInGrammar pins the alternatives that survive in each variant
+// of two productions from the specification, one with a [+In] alternative
+// and one with a [~Yield] one, and checks es6.ebnf has them the same way.
+func TestConstraintsInGrammar(t *testing.T) {
+	a := assert.New(t)
+
+	grammar, err := ioutil.ReadFile("es6.ebnf")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	shift := token{value: "ShiftExpression", params: []string{"?Yield"}}
+	relational := func(op string, params ...string) rule {
+		return rule{tokens: []token{{value: "RelationalExpression", params: params}, tm(op), shift}}
+	}
+
+	for _, c := range []struct {
+		p        production
+		variants []string
+	}{
+		{
+			production{name: "RelationalExpression", params: []string{"In", "Yield"}, rules: []rule{
+				{tokens: []token{shift}},
+				relational("<", "?In", "?Yield"),
+				relational(">", "?In", "?Yield"),
+				relational("<=", "?In", "?Yield"),
+				relational(">=", "?In", "?Yield"),
+				relational("instanceof", "?In", "?Yield"),
+				{constraints: []constraint{{value: "In"}}, tokens: relational("in", "In", "?Yield").tokens},
+			}},
+			[]string{
+				"RelationalExpression\n  ::= ShiftExpression\n    | RelationalExpression \"<\" ShiftExpression\n    | RelationalExpression \">\" ShiftExpression\n    | RelationalExpression \"<=\" ShiftExpression\n    | RelationalExpression \">=\" ShiftExpression\n    | RelationalExpression \"instanceof\" ShiftExpression",
+				"RelationalExpression_In\n  ::= ShiftExpression\n    | RelationalExpression_In \"<\" ShiftExpression\n    | RelationalExpression_In \">\" ShiftExpression\n    | RelationalExpression_In \"<=\" ShiftExpression\n    | RelationalExpression_In \">=\" ShiftExpression\n    | RelationalExpression_In \"instanceof\" ShiftExpression\n    | RelationalExpression_In \"in\" ShiftExpression",
+				"RelationalExpression_In_Yield\n  ::= ShiftExpression_Yield\n    | RelationalExpression_In_Yield \"<\" ShiftExpression_Yield\n    | RelationalExpression_In_Yield \">\" ShiftExpression_Yield\n    | RelationalExpression_In_Yield \"<=\" ShiftExpression_Yield\n    | RelationalExpression_In_Yield \">=\" ShiftExpression_Yield\n    | RelationalExpression_In_Yield \"instanceof\" ShiftExpression_Yield\n    | RelationalExpression_In_Yield \"in\" ShiftExpression_Yield",
+				"RelationalExpression_Yield\n  ::= ShiftExpression_Yield\n    | RelationalExpression_Yield \"<\" ShiftExpression_Yield\n    | RelationalExpression_Yield \">\" ShiftExpression_Yield\n    | RelationalExpression_Yield \"<=\" ShiftExpression_Yield\n    | RelationalExpression_Yield \">=\" ShiftExpression_Yield\n    | RelationalExpression_Yield \"instanceof\" ShiftExpression_Yield",
+			},
+		},
+		{
+			production{name: "IdentifierReference", params: []string{"Yield"}, rules: []rule{
+				{tokens: []token{nt("Identifier")}},
+				{constraints: []constraint{{inverse: true, value: "Yield"}}, tokens: []token{tm("yield")}},
+			}},
+			[]string{
+				"IdentifierReference\n  ::= Identifier\n    | \"yield\"",
+				"IdentifierReference_Yield\n  ::= Identifier",
+			},
+		},
+	} {
+		v := c.p.EBNF()
+		a.Equal(c.variants, v)
+
+		for _, s := range v {
+			a.Contains(string(grammar), "\n"+s+"\n\n", "es6.ebnf doesn't have %s like this", strings.SplitN(s, "\n", 2)[0])
+		}
+	}
+}
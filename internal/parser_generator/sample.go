@@ -0,0 +1,541 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"math/rand"
+	"strings"
+	"unicode"
+)
+
+// sampleKinds maps the lexical productions that make up a single token of the
+// syntactic grammar to the name of the TokenKind the tokeniser reads them as.
+// Punctuators get their kind from their text.
+var sampleKinds = map[string]string{
+	"IdentifierName":           "identifier",
+	"NumericLiteral":           "number",
+	"StringLiteral":            "string",
+	"RegularExpressionLiteral": "regexp",
+	"NoSubstitutionTemplate":   "templateNoSubstitution",
+	"TemplateHead":             "templateHead",
+	"TemplateMiddle":           "templateMiddle",
+	"TemplateTail":             "templateTail",
+	"Punctuator":               "",
+	"DivPunctuator":            "",
+	"RightBracePunctuator":     "",
+}
+
+// sampleCodePoints are the code points named in grammar prose.
+var sampleCodePoints = map[string]string{
+	"TAB":    "\t",
+	"VT":     "\v",
+	"FF":     "\f",
+	"SP":     " ",
+	"NBSP":   "\u00a0",
+	"ZWNBSP": "\ufeff",
+	"LF":     "\n",
+	"CR":     "\r",
+	"LS":     "\u2028",
+	"PS":     "\u2029",
+	"ZWNJ":   "\u200c",
+	"ZWJ":    "\u200d",
+}
+
+const (
+	sampleLetters  = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ"
+	sampleDigits   = "0123456789"
+	sampleAttempts = 20
+	sampleFinite   = 512
+)
+
+type sample struct {
+	Start  string        `json:"start"`
+	Source string        `json:"source"`
+	Tokens []sampleToken `json:"tokens,omitempty"`
+}
+
+// sampleToken is a token the tokeniser is expected to read from a sample.
+// Kind is the TokenKind's String(), or "" if it can't be worked out. Words are
+// always "identifier", since it's the parser that picks out keywords.
+type sampleToken struct {
+	Kind  string `json:"kind"`
+	Value string `json:"value"`
+}
+
+// piece is a token when deriving the syntactic grammar, or a run of
+// characters when deriving the lexical grammar.
+type piece struct {
+	text string
+	kind string
+}
+
+// sampler derives random sentences from a grammar. Every alternative has a
+// cost, which is the depth of its shallowest derivation. An alternative is
+// only picked if its cost fits in what's left of the depth limit, so that
+// derivations always finish.
+//
+// The samples match the grammar, including lookahead restrictions and "but
+// not" clauses where they can be checked, but static semantics and early
+// errors aren't taken into account.
+type sampler struct {
+	g         *grammar
+	rnd       *rand.Rand
+	depth     int
+	height    map[string]int
+	syntactic map[string]bool
+	finite    map[string][]string
+}
+
+func newSampler(g *grammar, seed int64, depth int) *sampler {
+	s := sampler{
+		g:         g,
+		rnd:       rand.New(rand.NewSource(seed)),
+		depth:     depth,
+		height:    make(map[string]int),
+		syntactic: make(map[string]bool),
+		finite:    make(map[string][]string),
+	}
+
+	s.computeHeight()
+
+	// The syntactic grammar is everything that can be reached from the goal
+	// symbols without going through a token of the lexical grammar.
+	var visit func(n string)
+	visit = func(n string) {
+		if s.syntactic[n] || s.kind(n) {
+			return
+		}
+
+		if _, ok := g.rules[n]; !ok {
+			return
+		}
+
+		s.syntactic[n] = true
+
+		g.references(n, visit)
+	}
+
+	visit("Script")
+	visit("Module")
+
+	return &s
+}
+
+// kind reports whether a production variant is a single token of the
+// syntactic grammar.
+func (s *sampler) kind(n string) bool {
+	_, ok := sampleKinds[strings.SplitN(n, "_", 2)[0]]
+	return ok
+}
+
+const sampleInfinite = 1 << 30
+
+func (s *sampler) cost(alt []token) int {
+	c := 0
+
+	for _, t := range alt {
+		if !nonterminal(t) || t.optional {
+			continue
+		}
+
+		h, ok := s.height[t.value]
+		if !ok {
+			return sampleInfinite
+		}
+
+		if h > c {
+			c = h
+		}
+	}
+
+	if c == sampleInfinite {
+		return c
+	}
+
+	return c + 1
+}
+
+func (s *sampler) computeHeight() {
+	for _, n := range s.g.names {
+		s.height[n] = sampleInfinite
+	}
+
+	for changed := true; changed; {
+		changed = false
+
+		for _, n := range s.g.names {
+			for _, alt := range s.g.rules[n] {
+				if c := s.cost(alt); c < s.height[n] {
+					s.height[n] = c
+					changed = true
+				}
+			}
+		}
+	}
+}
+
+// Sample derives one sentence from start. It returns false if it couldn't,
+// which happens when restrictions keep rejecting what it comes up with.
+func (s *sampler) Sample(start string) (sample, bool) {
+	if _, ok := s.g.rules[start]; !ok {
+		return sample{}, false
+	}
+
+	syntactic := s.syntactic[start] || s.kind(start)
+
+	for i := 0; i < sampleAttempts; i++ {
+		p, ok := s.derive(start, s.depth, syntactic)
+		if !ok {
+			continue
+		}
+
+		r := sample{Start: start}
+
+		if !syntactic {
+			r.Source = join(p, "")
+			return r, true
+		}
+
+		a := make([]string, len(p))
+		for i, e := range p {
+			a[i] = e.text
+			r.Tokens = append(r.Tokens, sampleToken{Kind: e.kind, Value: e.text})
+		}
+
+		r.Source = strings.Join(a, " ")
+
+		return r, true
+	}
+
+	return sample{}, false
+}
+
+func join(p []piece, sep string) string {
+	a := make([]string, len(p))
+	for i, e := range p {
+		a[i] = e.text
+	}
+
+	return strings.Join(a, sep)
+}
+
+func (s *sampler) derive(n string, budget int, syntactic bool) ([]piece, bool) {
+	if syntactic && s.kind(n) {
+		p, ok := s.derive(n, budget, false)
+		if !ok {
+			return nil, false
+		}
+
+		text := join(p, "")
+
+		kind := sampleKinds[strings.SplitN(n, "_", 2)[0]]
+		if kind == "" {
+			kind = terminalKind(text)
+		}
+
+		return []piece{{text: text, kind: kind}}, true
+	}
+
+	var alts [][]token
+	least := sampleInfinite
+	for _, alt := range s.g.rules[n] {
+		c := s.cost(alt)
+
+		if c <= budget {
+			alts = append(alts, alt)
+		}
+
+		if c < least {
+			least = c
+		}
+	}
+
+	if len(alts) == 0 {
+		for _, alt := range s.g.rules[n] {
+			if least != sampleInfinite && s.cost(alt) == least {
+				alts = append(alts, alt)
+			}
+		}
+	}
+
+	if len(alts) == 0 {
+		return nil, false
+	}
+
+	for i := 0; i < sampleAttempts; i++ {
+		if p, ok := s.sequence(alts[s.rnd.Intn(len(alts))], budget-1, syntactic); ok {
+			return p, true
+		}
+	}
+
+	return nil, false
+}
+
+func (s *sampler) sequence(alt []token, budget int, syntactic bool) ([]piece, bool) {
+	var out []piece
+
+	type check struct {
+		at int
+		t  token
+	}
+
+	var checks []check
+
+	for _, t := range alt {
+		if t.lookahead {
+			checks = append(checks, check{len(out), t})
+			continue
+		}
+
+		if t.assertion() {
+			continue
+		}
+
+		if t.optional && (s.rnd.Intn(2) == 0 || nonterminal(t) && s.height[t.value] > budget) {
+			continue
+		}
+
+		p, ok := s.item(t, budget, syntactic)
+		if !ok {
+			return nil, false
+		}
+
+		for _, b := range t.butNot {
+			if s.matches(join(p, ""), b) {
+				return nil, false
+			}
+		}
+
+		out = append(out, p...)
+	}
+
+	for _, c := range checks {
+		if !s.lookahead(out[c.at:], c.t, syntactic) {
+			return nil, false
+		}
+	}
+
+	return out, true
+}
+
+func (s *sampler) item(t token, budget int, syntactic bool) ([]piece, bool) {
+	switch {
+	case t.prose:
+		return []piece{{text: s.prose(t.value)}}, true
+	case t.terminal:
+		if syntactic {
+			return []piece{{text: t.value, kind: terminalKind(t.value)}}, true
+		}
+
+		return []piece{{text: t.value}}, true
+	case t.oneof:
+		v := t.values[s.rnd.Intn(len(t.values))]
+		if syntactic {
+			return []piece{{text: v, kind: terminalKind(v)}}, true
+		}
+
+		return []piece{{text: v}}, true
+	}
+
+	return s.derive(t.value, budget, syntactic)
+}
+
+// prose picks a code point to stand in for a piece of grammar prose, like
+// "any Unicode code point".
+func (s *sampler) prose(v string) string {
+	v = strings.Trim(v, "<>")
+
+	if c, ok := sampleCodePoints[v]; ok {
+		return c
+	}
+
+	chars := sampleLetters
+	if strings.Contains(v, "ID_Continue") {
+		chars += sampleDigits
+	}
+
+	return string(chars[s.rnd.Intn(len(chars))])
+}
+
+// matches reports whether text is one of the strings that t can produce. If
+// that can't be worked out, it assumes it isn't.
+func (s *sampler) matches(text string, t token) bool {
+	for _, e := range s.language(t) {
+		if e == text {
+			return true
+		}
+	}
+
+	return false
+}
+
+// lookahead checks a lookahead restriction against what was derived after
+// it. Restrictions at the end of an alternative are about whatever follows
+// the production, which isn't known, so they're let through.
+func (s *sampler) lookahead(rest []piece, t token, syntactic bool) bool {
+	if len(rest) == 0 {
+		return true
+	}
+
+	found := false
+
+	for _, q := range t.set {
+		if syntactic {
+			if len(q) > len(rest) {
+				continue
+			}
+
+			ok := true
+			for i, e := range q {
+				if !s.matches(rest[i].text, e) {
+					ok = false
+				}
+			}
+
+			found = found || ok
+
+			continue
+		}
+
+		text := join(rest, "")
+
+		prefixes := []string{""}
+		for _, e := range q {
+			var next []string
+			for _, p := range prefixes {
+				for _, l := range s.language(e) {
+					next = append(next, p+l)
+				}
+			}
+
+			prefixes = next
+		}
+
+		for _, p := range prefixes {
+			if strings.HasPrefix(text, p) {
+				found = true
+			}
+		}
+	}
+
+	return found != t.inverse
+}
+
+// language returns every string a token can produce, if there are few enough
+// of them to list.
+func (s *sampler) language(t token) []string {
+	switch {
+	case t.prose:
+		return nil
+	case t.terminal:
+		return []string{t.value}
+	case t.oneof:
+		return t.values
+	}
+
+	return s.finiteLanguage(t.value, make(map[string]bool))
+}
+
+func (s *sampler) finiteLanguage(n string, visiting map[string]bool) []string {
+	if l, ok := s.finite[n]; ok {
+		return l
+	}
+
+	if visiting[n] {
+		return nil
+	}
+
+	visiting[n] = true
+	defer delete(visiting, n)
+
+	var l []string
+	for _, alt := range s.g.rules[n] {
+		a := []string{""}
+
+		for _, t := range alt {
+			if t.assertion() {
+				continue
+			}
+
+			var e []string
+			if nonterminal(t) {
+				e = s.finiteLanguage(t.value, visiting)
+			} else {
+				e = s.language(t)
+			}
+
+			if e == nil || len(t.butNot) > 0 {
+				s.finite[n] = nil
+				return nil
+			}
+
+			if t.optional {
+				e = append([]string{""}, e...)
+			}
+
+			var next []string
+			for _, p := range a {
+				for _, x := range e {
+					next = append(next, p+x)
+				}
+			}
+
+			if len(next) > sampleFinite {
+				s.finite[n] = nil
+				return nil
+			}
+
+			a = next
+		}
+
+		l = append(l, a...)
+	}
+
+	if len(l) > sampleFinite {
+		l = nil
+	}
+
+	s.finite[n] = l
+
+	return l
+}
+
+// terminalKind works out the TokenKind of a terminal of the syntactic grammar.
+func terminalKind(s string) string {
+	if s == "" {
+		return ""
+	}
+
+	for _, r := range s {
+		if !unicode.IsLetter(r) && r != '$' && r != '_' {
+			if _, ok := punctuatorNames[s]; ok {
+				return lowerFirst(punctuatorName(s))
+			}
+
+			return ""
+		}
+	}
+
+	return "identifier"
+}
+
+func (r sample) Text(w io.Writer) error {
+	var a []string
+	for _, t := range r.Tokens {
+		k := t.Kind
+		if k == "" {
+			k = "?"
+		}
+
+		a = append(a, k)
+	}
+
+	s := fmt.Sprintf("// %s\n%s\n", r.Start, r.Source)
+	if len(a) > 0 {
+		s += "// tokens: " + strings.Join(a, " ") + "\n"
+	}
+
+	_, err := fmt.Fprintf(w, "%s\n", s)
+
+	return err
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"fknsrs.biz/p/jsparser"
+	"fknsrs.biz/p/jsparser/earley"
+)
+
+// sampleTokenSet turns the tokens of a sample into the input of the earley
+// recogniser. It's built from the sample's own tokens, rather than by running
+// the tokeniser over the source, because the tokeniser doesn't read all of
+// the lexical grammar yet (escapes and ZWNJ in identifiers, for one), and
+// finding where it falls short is what the samples are for.
+func sampleTokenSet(t *testing.T, r sample) jsparser.TokenSet {
+	kinds := make(map[string]jsparser.TokenKind)
+	for k := jsparser.TokenKind(0); k.String() != "unknown"; k++ {
+		kinds[k.String()] = k
+	}
+
+	var a jsparser.TokenSet
+	var text []string
+	for i, e := range r.Tokens {
+		k, ok := kinds[e.Kind]
+		if !ok {
+			t.Errorf("%s: token %d %q has kind %q, which isn't a TokenKind", r.Source, i, e.Value, e.Kind)
+		}
+
+		if i > 0 {
+			a = append(a, jsparser.Token{Kind: jsparser.TokenKindWhitespace, Raw: " "})
+		}
+
+		a = append(a, jsparser.Token{Kind: k, Value: e.Value, Raw: e.Value})
+		text = append(text, e.Value)
+	}
+
+	assert.Equal(t, r.Source, strings.Join(text, " "))
+
+	return a
+}
+
+func TestSamplesAreAccepted(t *testing.T) {
+	a := assert.New(t)
+
+	plist, err := loadEBNF("es6.ebnf")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	eg, err := earley.LoadFile("es6.ebnf")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s := newSampler(expand(plist), 1, 30)
+
+	for _, start := range []string{earley.Script, earley.Module} {
+		for i := 0; i < 20; i++ {
+			r, ok := s.Sample(start)
+			if a.True(ok, "%s sample %d", start, i) {
+				a.NoError(eg.Recognise(start, sampleTokenSet(t, r)), r.Source)
+			}
+		}
+	}
+}
+
+func TestSampleRecursion(t *testing.T) {
+	a := assert.New(t)
+
+	// A has no way out, so it can't be derived at all. L can, but only by
+	// stopping the recursion within the depth limit.
+	s := newSampler(expand([]*production{
+		{name: "A", rules: []rule{{tokens: []token{nt("A"), tm("x")}}}},
+		{name: "L", rules: []rule{
+			{tokens: []token{nt("L"), tm(","), tm("a")}},
+			{tokens: []token{tm("a")}},
+		}},
+	}), 1, 5)
+
+	_, ok := s.Sample("A")
+	a.False(ok)
+
+	for i := 0; i < 20; i++ {
+		r, ok := s.Sample("L")
+		if a.True(ok) {
+			a.Regexp(`^a(,a){0,4}$`, r.Source)
+		}
+	}
+}
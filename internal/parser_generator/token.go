@@ -19,11 +19,68 @@ type token struct {
 	params   []string
 	optional bool
 	terminal bool
+	prose    bool
 	oneof    bool
 	values   []string
+
+	// lookahead restrictions are zero-width, and match (or, when inverse is
+	// set, reject) any of the token sequences in set
+	lookahead bool
+	inverse   bool
+	set       [][]token
+
+	// [no LineTerminator here]
+	noLineTerminator bool
+
+	// [empty]
+	empty bool
+
+	// "but not" clauses, which rule out input matched by any of these tokens
+	butNot []token
+}
+
+// lookaheadString renders a lookahead restriction in the notation used by the
+// specification, using f to render each token in the set.
+func lookaheadString(inverse bool, set [][]token, f func(token) string) string {
+	a := make([]string, len(set))
+	for i, q := range set {
+		b := make([]string, len(q))
+		for j, e := range q {
+			b[j] = f(e)
+		}
+		a[i] = strings.Join(b, " ")
+	}
+
+	if len(set) == 1 && len(set[0]) == 1 {
+		switch {
+		case set[0][0].terminal && inverse:
+			return fmt.Sprintf("[lookahead ≠ %s]", a[0])
+		case set[0][0].terminal:
+			return fmt.Sprintf("[lookahead = %s]", a[0])
+		case inverse:
+			return fmt.Sprintf("[lookahead ∉ %s]", a[0])
+		default:
+			return fmt.Sprintf("[lookahead ∈ %s]", a[0])
+		}
+	}
+
+	if inverse {
+		return fmt.Sprintf("[lookahead ∉ { %s }]", strings.Join(a, ", "))
+	}
+
+	return fmt.Sprintf("[lookahead ∈ { %s }]", strings.Join(a, ", "))
 }
 
 func (t token) String() string {
+	switch {
+	case t.lookahead:
+		return lookaheadString(t.inverse, t.set, token.String)
+	case t.noLineTerminator:
+		return "[no LineTerminator here]"
+	case t.empty:
+		return "[empty]"
+	}
+
 	s := t.value
 	if t.terminal {
 		s = fmt.Sprintf("%q", t.value)
@@ -40,12 +97,27 @@ func (t token) String() string {
 		s += "?"
 	}
 
+	if len(t.butNot) > 0 {
+		a := make([]string, len(t.butNot))
+		for i, e := range t.butNot {
+			a[i] = e.String()
+		}
+
+		s += " but not " + strings.Join(a, " or ")
+	}
+
 	return s
 }
 
 func (t token) EBNF(options map[string]bool) string {
 	s := t.value
 	switch {
+	case t.lookahead:
+		return lookaheadString(t.inverse, t.set, func(e token) string { return e.EBNF(options) })
+	case t.noLineTerminator:
+		return "[no LineTerminator here]"
+	case t.empty:
+		return "[empty]"
 	case t.terminal:
 		s = fmt.Sprintf("%s", quoted(t.value))
 	case t.oneof:
@@ -67,31 +139,135 @@ func (t token) EBNF(options map[string]bool) string {
 			s = fmt.Sprintf("( %s )", strings.Join(a, " | "))
 		}
 	default:
-		for _, p := range t.params {
-			maybe := false
-			if p[0] == '?' {
-				maybe = true
-				p = p[1:]
+		s = t.ref(options)
+
+		if t.optional {
+			s = s + "?"
+		}
+	}
+
+	if len(t.butNot) > 0 {
+		a := make([]string, len(t.butNot))
+		for i, e := range t.butNot {
+			a[i] = e.EBNF(options)
+		}
+
+		if len(a) == 1 {
+			s = fmt.Sprintf("%s - %s", s, a[0])
+		} else {
+			s = fmt.Sprintf("%s - ( %s )", s, strings.Join(a, " | "))
+		}
+	}
+
+	return s
+}
+
+// ref returns the name of the production variant that a nonterminal refers
+// to, given the parameters set on the variant it appears in.
+func (t token) ref(options map[string]bool) string {
+	s := t.value
+
+	for _, p := range t.params {
+		switch p[0] {
+		case '?':
+			if options[p[1:]] {
+				s = s + "_" + p[1:]
 			}
+		case '+':
+			s = s + "_" + p[1:]
+		case '~':
+		default:
+			s = s + "_" + p
+		}
+	}
 
-			if maybe {
-				if options[p] {
-					s = s + "_" + p
-				}
-			} else {
-				s = s + "_" + p
+	return s
+}
+
+// resolve returns a copy of t with every nonterminal in it replaced by a
+// reference to the production variant it names.
+func (t token) resolve(options map[string]bool) token {
+	if !t.terminal && !t.oneof && !t.assertion() {
+		t.value = t.ref(options)
+		t.params = nil
+	}
+
+	if len(t.set) > 0 {
+		set := make([][]token, len(t.set))
+		for i, q := range t.set {
+			set[i] = make([]token, len(q))
+			for j, e := range q {
+				set[i][j] = e.resolve(options)
 			}
 		}
+		t.set = set
+	}
 
-		if t.optional {
-			s = s + "?"
+	if len(t.butNot) > 0 {
+		a := make([]token, len(t.butNot))
+		for i, e := range t.butNot {
+			a[i] = e.resolve(options)
 		}
+		t.butNot = a
 	}
 
-	return s
+	return t
+}
+
+// assertion reports whether t is a zero-width annotation, rather than
+// something that consumes input.
+func (t token) assertion() bool {
+	return t.lookahead || t.noLineTerminator || t.empty
 }
 
 func (t token) Go(m map[string]*production, p production, inner string) string {
+	switch {
+	case t.lookahead:
+		a := make([]string, len(t.set))
+		for i, q := range t.set {
+			b := "return r, true, nil"
+			for j := len(q) - 1; j >= 0; j-- {
+				b = q[j].Go(m, p, b)
+			}
+
+			a[i] = fmt.Sprintf("func(r string) (string, bool, error) { %s\nreturn r, false, nil }", b)
+		}
+
+		cond := "ok"
+		if t.inverse {
+			cond = "!ok"
+		}
+
+		return fmt.Sprintf(
+			"if ok, err := lookahead(r, %s); err != nil { return input, false, err } else if %s { %s }\n",
+			strings.Join(a, ", "),
+			cond,
+			inner,
+		)
+	case t.noLineTerminator:
+		return fmt.Sprintf(
+			"if ok, err := lineTerminatorAhead(r); err != nil { return input, false, err } else if !ok { %s }\n",
+			inner,
+		)
+	case t.empty:
+		return inner
+	}
+
+	if len(t.butNot) > 0 {
+		for i := len(t.butNot) - 1; i >= 0; i-- {
+			inner = fmt.Sprintf(
+				"if x, ok, err := %s; err != nil { return input, false, err } else if !ok || len(x) != len(r) { %s }\n",
+				t.butNot[i].call(m, p, "s"),
+				inner,
+			)
+		}
+
+		b := t
+		b.butNot = nil
+
+		return fmt.Sprintf("{ s := r\n%s }\n", b.Go(m, p, inner))
+	}
+
 	ok := "ok"
 	ifok := "else if ok"
 	if t.optional {
@@ -99,26 +275,26 @@ func (t token) Go(m map[string]*production, p production, inner string) string {
 		ifok = "else"
 	}
 
+	return fmt.Sprintf(
+		"if r, %s, err := %s; err != nil { return input, false, err } %s { %s }\n",
+		ok,
+		t.call(m, p, "r"),
+		ifok,
+		inner,
+	)
+}
+
+// call returns an expression that tries to match t against the input held in
+// the variable named by in.
+func (t token) call(m map[string]*production, p production, in string) string {
 	switch {
 	case t.terminal:
-		return fmt.Sprintf(
-			"if r, %s, err := acceptString(r, %q); err != nil { return input, false, err } %s { %s }\n",
-			ok,
-			t.value,
-			ifok,
-			inner,
-		)
+		return fmt.Sprintf("acceptString(%s, %q)", in, t.value)
 	case t.oneof:
-		return fmt.Sprintf(
-			"if r, %s, err := acceptOne(r, %#v); err != nil { return input, false, err } %s { %s }\n",
-			ok,
-			t.values,
-			ifok,
-			inner,
-		)
+		return fmt.Sprintf("acceptOne(%s, %#v)", in, t.values)
 	default:
 		args := make([]string, len(m[t.value].params)+1)
-		args[0] = "r"
+		args[0] = in
 		for i, v := range m[t.value].params {
 			passThrough := false
 			for _, s := range p.params {
@@ -143,13 +319,6 @@ func (t token) Go(m map[string]*production, p production, inner string) string {
 			}
 		}
 
-		return fmt.Sprintf(
-			"if r, %s, err := parse%s(%s); err != nil { return input, false, err } %s { %s }\n",
-			ok,
-			t.value,
-			strings.Join(args, ", "),
-			ifok,
-			inner,
-		)
+		return fmt.Sprintf("parse%s(%s)", t.value, strings.Join(args, ", "))
 	}
 }
@@ -0,0 +1,458 @@
+package main
+
+import (
+	"fmt"
+	"go/format"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// punctuatorStart are the productions of the lexical grammar that make up the
+// punctuators, as opposed to names, literals, comments and whitespace.
+var punctuatorStart = []string{"Punctuator", "DivPunctuator", "RightBracePunctuator"}
+
+// punctuatorNames keeps the TokenKind names the tokeniser has always used.
+// Any punctuator that isn't listed here gets a name made up of the names of
+// its characters, which is ugly but stable, and easy to add to this list.
+var punctuatorNames = map[string]string{
+	"{":    "PuncLeftBrace",
+	"}":    "PuncRightBrace",
+	"(":    "PuncLeftParen",
+	")":    "PuncRightParen",
+	"[":    "PuncLeftBracket",
+	"]":    "PuncRightBracket",
+	".":    "PuncPeriod",
+	"...":  "PuncSpread",
+	";":    "PuncSemicolon",
+	",":    "PuncComma",
+	":":    "PuncColon",
+	"?":    "PuncQuestion",
+	"?.":   "PuncOptionalChain",
+	"=>":   "PuncFatArrow",
+	"@":    "PuncAt",
+	"<":    "BinaryLess",
+	">":    "BinaryGreater",
+	"<=":   "BinaryLessOrEqual",
+	">=":   "BinaryGreaterOrEqual",
+	"==":   "BinaryEquals",
+	"!=":   "BinaryNotEquals",
+	"===":  "BinaryStrictEquals",
+	"!==":  "BinaryStrictNotEquals",
+	"+":    "BinaryPlus",
+	"-":    "BinaryMinus",
+	"*":    "BinaryStar",
+	"/":    "BinaryDivide",
+	"%":    "BinaryModulo",
+	"**":   "BinaryExponent",
+	"<<":   "BinaryShiftLeft",
+	">>":   "BinaryShiftRight",
+	">>>":  "BinaryShiftRightUnsigned",
+	"&":    "BinaryBitwiseAnd",
+	"|":    "BinaryBitwiseOr",
+	"^":    "BinaryBitwiseXor",
+	"&&":   "BinaryLogicalAnd",
+	"||":   "BinaryLogicalOr",
+	"??":   "BinaryNullishCoalescing",
+	"=":    "BinaryAssignment",
+	"+=":   "BinaryPlusAssignment",
+	"-=":   "BinaryMinusAssignment",
+	"*=":   "BinaryStarAssignment",
+	"/=":   "BinaryDivideEquals",
+	"%=":   "BinaryModuloAssignment",
+	"**=":  "BinaryExponentAssignment",
+	"<<=":  "BinaryShiftLeftAssignment",
+	">>=":  "BinaryShiftRightAssignment",
+	">>>=": "BinaryShiftRightUnsignedAssignment",
+	"&=":   "BinaryBitwiseAndAssignment",
+	"|=":   "BinaryBitwiseOrAssignment",
+	"^=":   "BinaryBitwiseXorAssignment",
+	"&&=":  "BinaryLogicalAndAssignment",
+	"||=":  "BinaryLogicalOrAssignment",
+	"??=":  "BinaryNullishCoalescingAssignment",
+	"!":    "UnaryBang",
+	"~":    "UnaryTilde",
+	"++":   "UnaryIncrement",
+	"--":   "UnaryDecrement",
+}
+
+var punctuatorCharNames = map[rune]string{
+	'{': "LeftBrace", '}': "RightBrace", '(': "LeftParen", ')': "RightParen",
+	'[': "LeftBracket", ']': "RightBracket", '.': "Period", ';': "Semicolon",
+	',': "Comma", ':': "Colon", '?': "Question", '@': "At", '#': "Hash",
+	'<': "Less", '>': "Greater", '=': "Equals", '!': "Bang", '+': "Plus",
+	'-': "Minus", '*': "Star", '/': "Slash", '%': "Percent", '&': "Ampersand",
+	'|': "Pipe", '^': "Caret", '~': "Tilde",
+}
+
+// extraPunctuators aren't in the grammar that es6.ebnf is scraped from. "@"
+// is used by decorators, and the tokeniser has always read it. The others come
+// from later editions: optional chaining and nullish coalescing from ES2020,
+// and the logical assignment operators from ES2021. "?." can't be followed by
+// a digit, so that `a?.5:b` is still a conditional.
+var extraPunctuators = []punctuator{
+	{value: "@"},
+	{value: "?.", exclude: "0123456789"},
+	{value: "??"},
+	{value: "??="},
+	{value: "&&="},
+	{value: "||="},
+}
+
+// otherTokenKinds are the kinds of token that aren't punctuators, and so
+// can't be read from the grammar.
+var otherTokenKinds = []string{
+	"Whitespace",
+	"Identifier",
+	"Keyword",
+	"MetaShebangLine",
+	"MultipleLineComment",
+	"Number",
+	"PuncBacktick",
+	"Regexp",
+	"SingleLineComment",
+	"String",
+	"TemplateHead",
+	"TemplateMiddle",
+	"TemplateNoSubstitution",
+	"TemplateTail",
+}
+
+// tokenKindOrder fixes the values of the TokenKind constants, so that
+// regenerating them doesn't renumber the ones that are already there.
+// Whitespace comes first so that it's the zero value. Kinds that aren't
+// listed come after these in alphabetical order, and belong at the end of
+// the list once they've been generated.
+var tokenKindOrder = []string{
+	"Whitespace",
+	"BinaryAssignment",
+	"BinaryBitwiseAnd",
+	"BinaryBitwiseAndAssignment",
+	"BinaryBitwiseOr",
+	"BinaryBitwiseOrAssignment",
+	"BinaryBitwiseXor",
+	"BinaryBitwiseXorAssignment",
+	"BinaryDivide",
+	"BinaryDivideEquals",
+	"BinaryEquals",
+	"BinaryExponent",
+	"BinaryExponentAssignment",
+	"BinaryGreater",
+	"BinaryGreaterOrEqual",
+	"BinaryLess",
+	"BinaryLessOrEqual",
+	"BinaryLogicalAnd",
+	"BinaryLogicalOr",
+	"BinaryMinus",
+	"BinaryMinusAssignment",
+	"BinaryModulo",
+	"BinaryModuloAssignment",
+	"BinaryNotEquals",
+	"BinaryPlus",
+	"BinaryPlusAssignment",
+	"BinaryShiftLeft",
+	"BinaryShiftLeftAssignment",
+	"BinaryShiftRight",
+	"BinaryShiftRightAssignment",
+	"BinaryShiftRightUnsigned",
+	"BinaryShiftRightUnsignedAssignment",
+	"BinaryStar",
+	"BinaryStarAssignment",
+	"BinaryStrictEquals",
+	"BinaryStrictNotEquals",
+	"Identifier",
+	"Keyword",
+	"MetaShebangLine",
+	"MultipleLineComment",
+	"Number",
+	"PuncAt",
+	"PuncBacktick",
+	"PuncColon",
+	"PuncComma",
+	"PuncFatArrow",
+	"PuncLeftBrace",
+	"PuncLeftBracket",
+	"PuncLeftParen",
+	"PuncPeriod",
+	"PuncQuestion",
+	"PuncRightBrace",
+	"PuncRightBracket",
+	"PuncRightParen",
+	"PuncSemicolon",
+	"PuncSpread",
+	"Regexp",
+	"SingleLineComment",
+	"String",
+	"TemplateHead",
+	"TemplateMiddle",
+	"TemplateNoSubstitution",
+	"TemplateTail",
+	"UnaryBang",
+	"UnaryDecrement",
+	"UnaryIncrement",
+	"UnaryTilde",
+	// Added with the generated tokeniser.
+	"BinaryLogicalAndAssignment",
+	"BinaryLogicalOrAssignment",
+	"BinaryNullishCoalescing",
+	"BinaryNullishCoalescingAssignment",
+	"PuncOptionalChain",
+}
+
+type punctuator struct {
+	value string
+	name  string
+	// exclude is the characters that can't directly follow the punctuator,
+	// from a lookahead restriction like the one on `?.`.
+	exclude string
+}
+
+func punctuatorName(s string) string {
+	if n, ok := punctuatorNames[s]; ok {
+		return n
+	}
+
+	n := "Punc"
+	for _, r := range s {
+		if c, ok := punctuatorCharNames[r]; ok {
+			n += c
+		} else {
+			n += fmt.Sprintf("U%04X", r)
+		}
+	}
+
+	return n
+}
+
+// punctuators collects every punctuator that can be produced from the
+// punctuatorStart productions.
+func punctuators(plist []*production) ([]punctuator, error) {
+	m := make(map[string]*production)
+	for _, p := range plist {
+		m[p.name] = p
+	}
+
+	seen := make(map[string]bool)
+	var a []punctuator
+
+	add := func(value, exclude string) {
+		if !seen[value] {
+			seen[value] = true
+			a = append(a, punctuator{value: value, name: punctuatorName(value), exclude: exclude})
+		}
+	}
+
+	var collect func(name string) error
+	collect = func(name string) error {
+		p, ok := m[name]
+		if !ok {
+			return fmt.Errorf("production %s isn't defined", name)
+		}
+
+		for _, r := range p.rules {
+			tokens := r.tokens
+
+			var exclude string
+			if n := len(tokens); n > 0 && tokens[n-1].lookahead {
+				s, err := lookaheadExclude(m, tokens[n-1])
+				if err != nil {
+					return fmt.Errorf("%s: %s", name, err)
+				}
+
+				exclude = s
+				tokens = tokens[:n-1]
+			}
+
+			switch {
+			case len(tokens) == 1 && tokens[0].oneof:
+				for _, v := range tokens[0].values {
+					add(v, exclude)
+				}
+			case len(tokens) == 1 && nonterminal(tokens[0]) && !tokens[0].prose:
+				if err := collect(tokens[0].value); err != nil {
+					return err
+				}
+			default:
+				var s string
+				for _, t := range tokens {
+					if !t.terminal || t.prose || t.optional || len(t.butNot) > 0 {
+						return fmt.Errorf("%s: can't make a punctuator out of %s", name, r.String())
+					}
+
+					s += t.value
+				}
+
+				add(s, exclude)
+			}
+		}
+
+		return nil
+	}
+
+	for _, n := range punctuatorStart {
+		if err := collect(n); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, e := range extraPunctuators {
+		add(e.value, e.exclude)
+	}
+
+	return a, nil
+}
+
+// lookaheadExclude turns a negative lookahead made up of single characters
+// into the string of characters it excludes.
+func lookaheadExclude(m map[string]*production, t token) (string, error) {
+	if !t.inverse {
+		return "", fmt.Errorf("can't use %s in a punctuator", t.String())
+	}
+
+	var s string
+	for _, q := range t.set {
+		if len(q) != 1 {
+			return "", fmt.Errorf("can't use %s in a punctuator", t.String())
+		}
+
+		switch e := q[0]; {
+		case e.terminal && !e.prose:
+			s += e.value
+		case nonterminal(e):
+			p, ok := m[e.value]
+			if !ok || len(p.rules) != 1 || len(p.rules[0].tokens) != 1 || !p.rules[0].tokens[0].oneof {
+				return "", fmt.Errorf("can't use %s in a punctuator, %s isn't a set of characters", t.String(), e.value)
+			}
+
+			s += strings.Join(p.rules[0].tokens[0].values, "")
+		default:
+			return "", fmt.Errorf("can't use %s in a punctuator", t.String())
+		}
+	}
+
+	return s, nil
+}
+
+type trieNode struct {
+	p    *punctuator
+	next map[rune]*trieNode
+}
+
+func (n *trieNode) Go(indent string) string {
+	var a []string
+
+	if n.p != nil {
+		a = append(a, fmt.Sprintf("kind: TokenKind%s", n.p.name), "terminal: true")
+		if n.p.exclude != "" {
+			a = append(a, fmt.Sprintf("exclude: %q", n.p.exclude))
+		}
+	}
+
+	if len(n.next) > 0 {
+		var keys []rune
+		for r := range n.next {
+			keys = append(keys, r)
+		}
+
+		sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+
+		s := "next: map[rune]*punctuatorTrie{\n"
+		for _, r := range keys {
+			s += fmt.Sprintf("%s\t%q: %s,\n", indent, r, n.next[r].Go(indent+"\t"))
+		}
+		s += indent + "}"
+
+		a = append(a, s)
+	}
+
+	return "{" + strings.Join(a, ", ") + "}"
+}
+
+func lowerFirst(s string) string {
+	r := []rune(s)
+	r[0] = unicode.ToLower(r[0])
+	return string(r)
+}
+
+// orderTokenKinds puts the names of token kinds into the order of
+// tokenKindOrder, followed by any that aren't in it.
+func orderTokenKinds(names []string) []string {
+	rest := make(map[string]bool)
+	for _, n := range names {
+		rest[n] = true
+	}
+
+	var a []string
+	for _, n := range tokenKindOrder {
+		if rest[n] {
+			a = append(a, n)
+			delete(rest, n)
+		}
+	}
+
+	var b []string
+	for n := range rest {
+		b = append(b, n)
+	}
+
+	sort.Strings(b)
+
+	return append(a, b...)
+}
+
+// tokenKinds generates the TokenKind enum, its String method and the trie
+// used by the tokeniser to read punctuators.
+func tokenKinds(plist []*production) ([]byte, error) {
+	a, err := punctuators(plist)
+	if err != nil {
+		return nil, err
+	}
+
+	names := append([]string(nil), otherTokenKinds...)
+	root := &trieNode{next: make(map[rune]*trieNode)}
+
+	for i := range a {
+		names = append(names, a[i].name)
+
+		n := root
+		for _, r := range a[i].value {
+			if n.next[r] == nil {
+				n.next[r] = &trieNode{next: make(map[rune]*trieNode)}
+			}
+
+			n = n.next[r]
+		}
+
+		n.p = &a[i]
+	}
+
+	names = orderTokenKinds(names)
+
+	s := "// Code generated by parser_generator -format tokens; DO NOT EDIT.\n\n"
+	s += "package jsparser\n\n"
+
+	s += "type TokenKind int\n\n"
+	s += "const (\n"
+	for i, n := range names {
+		if i == 0 {
+			s += fmt.Sprintf("TokenKind%s TokenKind = iota\n", n)
+		} else {
+			s += fmt.Sprintf("TokenKind%s\n", n)
+		}
+	}
+	s += ")\n\n"
+
+	s += "func (t TokenKind) String() string {\n"
+	s += "switch t {\n"
+	for _, n := range names {
+		s += fmt.Sprintf("case TokenKind%s:\nreturn %q\n", n, lowerFirst(n))
+	}
+	s += "default:\nreturn \"unknown\"\n"
+	s += "}\n}\n\n"
+
+	s += "// punctuators is the root of a trie of every punctuator in the lexical\n"
+	s += "// grammar, which the tokeniser walks to find the longest match.\n"
+	s += "var punctuators = &punctuatorTrie" + root.Go("") + "\n"
+
+	return format.Source([]byte(s))
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"io/ioutil"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOrderTokenKinds(t *testing.T) {
+	a := assert.New(t)
+
+	names := orderTokenKinds([]string{"PuncZ", "PuncOptionalChain", "Number", "PuncA", "Whitespace", "BinaryAssignment"})
+
+	a.Equal([]string{"Whitespace", "BinaryAssignment", "Number", "PuncOptionalChain", "PuncA", "PuncZ"}, names)
+}
+
+func TestTokenKindOrder(t *testing.T) {
+	a := assert.New(t)
+
+	seen := make(map[string]bool)
+	for _, n := range tokenKindOrder {
+		a.False(seen[n], "%s is listed twice", n)
+		seen[n] = true
+	}
+
+	a.Equal("Whitespace", tokenKindOrder[0])
+}
+
+// TestTokenKindsGolden checks that tokenkind.go is what -format tokens makes
+// from es6.ebnf. If it fails, regenerate it with
+//
+//	go run ./internal/parser_generator -ebnf internal/parser_generator/es6.ebnf -format tokens > tokenkind.go
+func TestTokenKindsGolden(t *testing.T) {
+	plist, err := loadEBNF("es6.ebnf")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := tokenKinds(plist)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected, err := ioutil.ReadFile("../../tokenkind.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, string(expected), string(b), "tokenkind.go is out of date with es6.ebnf")
+}
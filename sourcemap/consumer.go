@@ -0,0 +1,313 @@
+package sourcemap
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+
+	"fknsrs.biz/p/jsparser"
+)
+
+// Consumer looks up original positions in a parsed source map.
+type Consumer struct {
+	File           string
+	Sources        []string
+	SourcesContent []*string
+	Names          []string
+
+	mappings []Mapping // sorted by generated position
+}
+
+// section is one part of an index map.
+type section struct {
+	Offset struct {
+		Line   int `json:"line"`
+		Column int `json:"column"`
+	} `json:"offset"`
+	URL string          `json:"url"`
+	Map json.RawMessage `json:"map"`
+}
+
+// rawMap is a source map or an index map, as it's read from JSON.
+type rawMap struct {
+	Map
+	Sections []section `json:"sections"`
+}
+
+// Parse reads a source map, or an index map made of sections of source maps.
+// Sources are resolved against the map's sourceRoot.
+func Parse(b []byte) (*Consumer, error) {
+	var m rawMap
+	if err := json.Unmarshal(b, &m); err != nil {
+		return nil, fmt.Errorf("sourcemap: %s", err)
+	}
+
+	if m.Version != 3 {
+		return nil, fmt.Errorf("sourcemap: unsupported version %d", m.Version)
+	}
+
+	c := &Consumer{File: m.File}
+
+	if m.Sections == nil {
+		if err := c.add(&m.Map, Position{Line: 1}); err != nil {
+			return nil, err
+		}
+	}
+
+	for i, s := range m.Sections {
+		if s.URL != "" {
+			return nil, fmt.Errorf("sourcemap: section %d: sections with a url aren't supported", i)
+		}
+
+		var sm rawMap
+		if err := json.Unmarshal(s.Map, &sm); err != nil {
+			return nil, fmt.Errorf("sourcemap: section %d: %s", i, err)
+		}
+
+		if sm.Sections != nil {
+			return nil, fmt.Errorf("sourcemap: section %d: index maps can't be nested", i)
+		}
+
+		if err := c.add(&sm.Map, Position{Line: s.Offset.Line + 1, Column: s.Offset.Column}); err != nil {
+			return nil, fmt.Errorf("sourcemap: section %d: %s", i, strings.TrimPrefix(err.Error(), "sourcemap: "))
+		}
+	}
+
+	sort.SliceStable(c.mappings, func(i, j int) bool { return before(c.mappings[i].Generated, c.mappings[j].Generated) })
+
+	return c, nil
+}
+
+func before(a, b Position) bool {
+	return a.Line < b.Line || a.Line == b.Line && a.Column < b.Column
+}
+
+// add decodes the mappings of m, with generated positions moved by offset,
+// and adds its sources and names to c.
+func (c *Consumer) add(m *Map, offset Position) error {
+	sources := make([]string, len(m.Sources))
+	for i, s := range m.Sources {
+		if m.SourceRoot != "" && !strings.Contains(s, "://") && !strings.HasPrefix(s, "/") {
+			s = strings.TrimSuffix(m.SourceRoot, "/") + "/" + s
+		}
+
+		sources[i] = s
+
+		c.Sources = append(c.Sources, s)
+		if i < len(m.SourcesContent) {
+			c.SourcesContent = append(c.SourcesContent, m.SourcesContent[i])
+		} else {
+			c.SourcesContent = append(c.SourcesContent, nil)
+		}
+	}
+
+	c.Names = append(c.Names, m.Names...)
+
+	var prev struct{ source, line, column, name int }
+
+	for l, line := range strings.Split(m.Mappings, ";") {
+		column := 0
+
+		for _, segment := range strings.Split(line, ",") {
+			if segment == "" {
+				continue
+			}
+
+			v, err := readVLQs(segment)
+			if err != nil {
+				return err
+			}
+
+			switch len(v) {
+			case 1, 4, 5:
+			default:
+				return fmt.Errorf("sourcemap: segment %q has %d fields", segment, len(v))
+			}
+
+			column += v[0]
+
+			mp := Mapping{Generated: Position{Line: l + offset.Line, Column: column}}
+			if l == 0 {
+				mp.Generated.Column += offset.Column
+			}
+
+			if len(v) > 1 {
+				prev.source += v[1]
+				prev.line += v[2]
+				prev.column += v[3]
+
+				if prev.source < 0 || prev.source >= len(sources) {
+					return fmt.Errorf("sourcemap: segment %q refers to source %d", segment, prev.source)
+				}
+
+				mp.Source = sources[prev.source]
+				mp.Original = Position{Line: prev.line + 1, Column: prev.column}
+			}
+
+			if len(v) > 4 {
+				prev.name += v[4]
+
+				if prev.name < 0 || prev.name >= len(m.Names) {
+					return fmt.Errorf("sourcemap: segment %q refers to name %d", segment, prev.name)
+				}
+
+				mp.Name = m.Names[prev.name]
+			}
+
+			c.mappings = append(c.mappings, mp)
+		}
+	}
+
+	return nil
+}
+
+// Mappings returns every mapping in the map, in order of generated position.
+func (c *Consumer) Mappings() []Mapping {
+	return append([]Mapping(nil), c.mappings...)
+}
+
+// Original returns the mapping for the segment of generated code that pos is
+// in: the last one on the same line that starts at or before it. It returns
+// false if there isn't one, or if the segment doesn't come from a source.
+func (c *Consumer) Original(pos Position) (Mapping, bool) {
+	i := sort.Search(len(c.mappings), func(i int) bool { return before(pos, c.mappings[i].Generated) }) - 1
+	if i < 0 {
+		return Mapping{}, false
+	}
+
+	m := c.mappings[i]
+	if m.Generated.Line != pos.Line || m.Source == "" {
+		return Mapping{}, false
+	}
+
+	return m, true
+}
+
+// Compose chains two maps together, when the code that first maps was
+// generated for has been transformed again to make the code that second
+// maps. The result maps the final code straight to the original sources.
+// Segments of second that don't map back through first are left out.
+func Compose(first, second *Consumer) *Map {
+	g := NewGenerator(second.File)
+
+	for i, s := range first.Sources {
+		if c := first.SourcesContent[i]; c != nil {
+			g.AddSource(s, *c)
+		}
+	}
+
+	for _, m := range second.mappings {
+		if m.Source == "" {
+			continue
+		}
+
+		o, ok := first.Original(m.Original)
+		if !ok {
+			continue
+		}
+
+		if o.Name == "" {
+			o.Name = m.Name
+		}
+
+		o.Generated = m.Generated
+
+		g.Add(o)
+	}
+
+	return g.Map()
+}
+
+// readVLQs reads the base 64 variable length quantities in a segment.
+func readVLQs(s string) ([]int, error) {
+	var a []int
+
+	v, shift := 0, uint(0)
+
+	for i := 0; i < len(s); i++ {
+		d := strings.IndexByte(base64Digits, s[i])
+		if d < 0 {
+			return nil, fmt.Errorf("sourcemap: invalid character %q in segment %q", s[i], s)
+		}
+
+		v |= (d & 31) << shift
+		shift += 5
+
+		if d&32 != 0 {
+			continue
+		}
+
+		if v&1 != 0 {
+			a = append(a, -(v >> 1))
+		} else {
+			a = append(a, v>>1)
+		}
+
+		v, shift = 0, 0
+	}
+
+	if shift != 0 {
+		return nil, fmt.Errorf("sourcemap: segment %q ends in the middle of a number", s)
+	}
+
+	return a, nil
+}
+
+// URL returns the sourceMappingURL given in the last comment that has one,
+// or "" if there isn't one.
+func URL(tokens jsparser.TokenSet) string {
+	for i := len(tokens) - 1; i >= 0; i-- {
+		var s string
+
+		switch t := tokens[i]; t.Kind {
+		case jsparser.TokenKindSingleLineComment:
+			s = strings.TrimPrefix(t.Raw, "//")
+		case jsparser.TokenKindMultipleLineComment:
+			s = strings.TrimSuffix(strings.TrimPrefix(t.Raw, "/*"), "*/")
+		default:
+			continue
+		}
+
+		if len(s) == 0 || s[0] != '#' && s[0] != '@' {
+			continue
+		}
+
+		s = strings.TrimLeft(s[1:], " \t")
+		if !strings.HasPrefix(s, "sourceMappingURL=") {
+			continue
+		}
+
+		if f := strings.Fields(strings.TrimPrefix(s, "sourceMappingURL=")); len(f) > 0 {
+			return f[0]
+		}
+	}
+
+	return ""
+}
+
+// ReadDataURL returns the content of a data: URL, like the ones that inline
+// source maps are given in.
+func ReadDataURL(u string) ([]byte, error) {
+	if !strings.HasPrefix(u, "data:") {
+		return nil, fmt.Errorf("sourcemap: %q isn't a data URL", u)
+	}
+
+	i := strings.IndexByte(u, ',')
+	if i < 0 {
+		return nil, fmt.Errorf("sourcemap: data URL has no data")
+	}
+
+	if strings.HasSuffix(u[:i], ";base64") {
+		return base64.StdEncoding.DecodeString(u[i+1:])
+	}
+
+	s, err := url.PathUnescape(u[i+1:])
+	if err != nil {
+		return nil, fmt.Errorf("sourcemap: %s", err)
+	}
+
+	return []byte(s), nil
+}
@@ -0,0 +1,216 @@
+package sourcemap
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"fknsrs.biz/p/jsparser"
+)
+
+func TestParse(t *testing.T) {
+	a := assert.New(t)
+
+	g := NewGenerator("out.js")
+	g.AddSource("a.js", "var foo;")
+	g.Add(Mapping{Generated: Position{1, 0}, Source: "a.js", Original: Position{1, 0}, Name: "foo"})
+	g.Add(Mapping{Generated: Position{1, 4}, Source: "a.js", Original: Position{1, 6}})
+	g.Add(Mapping{Generated: Position{2, 0}, Source: "b.js", Original: Position{3, 2}})
+	g.Add(Mapping{Generated: Position{4, 1}})
+
+	b, err := json.Marshal(g.Map())
+	a.NoError(err)
+
+	c, err := Parse(b)
+	if !a.NoError(err) {
+		return
+	}
+
+	a.Equal("out.js", c.File)
+	a.Equal([]string{"a.js", "b.js"}, c.Sources)
+	a.Equal([]string{"foo"}, c.Names)
+	a.Equal([]Mapping{
+		{Generated: Position{1, 0}, Source: "a.js", Original: Position{1, 0}, Name: "foo"},
+		{Generated: Position{1, 4}, Source: "a.js", Original: Position{1, 6}},
+		{Generated: Position{2, 0}, Source: "b.js", Original: Position{3, 2}},
+		{Generated: Position{4, 1}},
+	}, c.Mappings())
+
+	m, ok := c.Original(Position{1, 3})
+	a.True(ok)
+	a.Equal(Position{1, 0}, m.Original)
+	a.Equal("foo", m.Name)
+
+	m, ok = c.Original(Position{1, 100})
+	a.True(ok)
+	a.Equal(Position{1, 6}, m.Original)
+
+	_, ok = c.Original(Position{3, 0})
+	a.False(ok)
+	_, ok = c.Original(Position{4, 5})
+	a.False(ok)
+
+	_, err = Parse([]byte(`{"version":3,"sources":[],"names":[],"mappings":"AAAA"}`))
+	a.Error(err)
+	_, err = Parse([]byte(`{"version":3,"sources":["a"],"names":[],"mappings":"AA!A"}`))
+	a.Error(err)
+	_, err = Parse([]byte(`{"version":2,"sources":[],"names":[],"mappings":""}`))
+	a.Error(err)
+}
+
+func TestParseSourceRoot(t *testing.T) {
+	a := assert.New(t)
+
+	c, err := Parse([]byte(`{"version":3,"sourceRoot":"src/","sources":["a.js","/b.js"],"names":[],"mappings":"AAAA"}`))
+	if a.NoError(err) {
+		a.Equal([]string{"src/a.js", "/b.js"}, c.Sources)
+	}
+}
+
+func TestParseSections(t *testing.T) {
+	a := assert.New(t)
+
+	c, err := Parse([]byte(`{
+		"version": 3,
+		"file": "out.js",
+		"sections": [
+			{"offset": {"line": 0, "column": 0}, "map": {"version": 3, "sources": ["a.js"], "names": ["x"], "mappings": "AAAAA;CACA"}},
+			{"offset": {"line": 1, "column": 10}, "map": {"version": 3, "sources": ["b.js"], "names": [], "mappings": "EAAE;AACA"}}
+		]
+	}`))
+	if !a.NoError(err) {
+		return
+	}
+
+	a.Equal([]string{"a.js", "b.js"}, c.Sources)
+	a.Equal([]Mapping{
+		{Generated: Position{1, 0}, Source: "a.js", Original: Position{1, 0}, Name: "x"},
+		{Generated: Position{2, 1}, Source: "a.js", Original: Position{2, 0}},
+		{Generated: Position{2, 12}, Source: "b.js", Original: Position{1, 2}},
+		{Generated: Position{3, 0}, Source: "b.js", Original: Position{2, 2}},
+	}, c.Mappings())
+
+	_, err = Parse([]byte(`{"version":3,"sections":[{"offset":{"line":0,"column":0},"url":"a.map"}]}`))
+	a.Error(err)
+}
+
+func TestCompose(t *testing.T) {
+	a := assert.New(t)
+
+	// a.js -> b.js
+	g1 := NewGenerator("b.js")
+	g1.AddSource("a.js", "let value = 1;")
+	g1.Add(Mapping{Generated: Position{1, 0}, Source: "a.js", Original: Position{1, 0}})
+	g1.Add(Mapping{Generated: Position{1, 4}, Source: "a.js", Original: Position{1, 4}, Name: "value"})
+
+	// b.js -> c.js
+	g2 := NewGenerator("c.js")
+	g2.Add(Mapping{Generated: Position{1, 0}, Source: "b.js", Original: Position{1, 0}})
+	g2.Add(Mapping{Generated: Position{1, 4}, Source: "b.js", Original: Position{1, 4}, Name: "a"})
+	g2.Add(Mapping{Generated: Position{2, 0}, Source: "b.js", Original: Position{5, 0}})
+
+	first, err := Parse(marshal(t, g1.Map()))
+	a.NoError(err)
+	second, err := Parse(marshal(t, g2.Map()))
+	a.NoError(err)
+
+	m := Compose(first, second)
+	a.Equal("c.js", m.File)
+	a.Equal([]string{"a.js"}, m.Sources)
+	if a.Len(m.SourcesContent, 1) {
+		a.Equal("let value = 1;", *m.SourcesContent[0])
+	}
+
+	c, err := Parse(marshal(t, m))
+	if a.NoError(err) {
+		a.Equal([]Mapping{
+			{Generated: Position{1, 0}, Source: "a.js", Original: Position{1, 0}},
+			{Generated: Position{1, 4}, Source: "a.js", Original: Position{1, 4}, Name: "value"},
+		}, c.Mappings())
+	}
+}
+
+func marshal(t *testing.T, m *Map) []byte {
+	b, err := json.Marshal(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return b
+}
+
+func TestURL(t *testing.T) {
+	a := assert.New(t)
+
+	for s, u := range map[string]string{
+		"a();\n//# sourceMappingURL=a.js.map":                          "a.js.map",
+		"a();\n//@ sourceMappingURL=a.js.map\n":                        "a.js.map",
+		"a();\n/*# sourceMappingURL=a.js.map */":                       "a.js.map",
+		"//# sourceMappingURL=a.map\na();\n//# sourceMappingURL=b.map": "b.map",
+		"a(\"//# sourceMappingURL=a.map\");":                           "",
+		"a();\n// sourceMappingURL=a.map":                              "",
+	} {
+		tokens, err := jsparser.ParseString(s)
+		if a.NoError(err, s) {
+			a.Equal(u, URL(tokens), s)
+		}
+	}
+}
+
+func TestReadDataURL(t *testing.T) {
+	a := assert.New(t)
+
+	b, err := ReadDataURL("data:application/json;charset=utf-8;base64,eyJ2ZXJzaW9uIjozfQ==")
+	a.NoError(err)
+	a.Equal(`{"version":3}`, string(b))
+
+	b, err = ReadDataURL("data:application/json,%7B%22version%22:3%7D")
+	a.NoError(err)
+	a.Equal(`{"version":3}`, string(b))
+
+	_, err = ReadDataURL("a.js.map")
+	a.Error(err)
+}
+
+func TestRemapStack(t *testing.T) {
+	a := assert.New(t)
+
+	g := NewGenerator("bundle.js")
+	g.Add(Mapping{Generated: Position{1, 0}, Source: "src/a.js", Original: Position{10, 2}})
+	g.Add(Mapping{Generated: Position{1, 20}, Source: "src/b.js", Original: Position{3, 4}})
+
+	c, err := Parse(marshal(t, g.Map()))
+	if !a.NoError(err) {
+		return
+	}
+
+	lookup := func(file string) *Consumer {
+		if file == "http://example.com/bundle.js" {
+			return c
+		}
+
+		return nil
+	}
+
+	a.Equal(
+		"TypeError: x is undefined\n"+
+			"    at f (src/a.js:10:3)\n"+
+			"    at src/b.js:3:5\n"+
+			"    at g (http://example.com/other.js:1:1)\n"+
+			"    at new C (src/a.js:10:3)",
+		RemapStack(
+			"TypeError: x is undefined\n"+
+				"    at f (http://example.com/bundle.js:1:5)\n"+
+				"    at http://example.com/bundle.js:1:21\n"+
+				"    at g (http://example.com/other.js:1:1)\n"+
+				"    at new C (http://example.com/bundle.js:1:1)",
+			lookup,
+		),
+	)
+
+	a.Equal(
+		"f@src/a.js:10:3\n@src/b.js:3:5\n",
+		RemapStack("f@http://example.com/bundle.js:1:5\n@http://example.com/bundle.js:1:22\n", lookup),
+	)
+}
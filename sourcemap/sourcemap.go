@@ -0,0 +1,288 @@
+// Package sourcemap generates source maps, in version 3 of the format, for
+// code that has been printed from a syntax tree or rewritten token by token.
+// It also reads them, to find where generated code came from.
+package sourcemap
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Position is a place in a file. Like ast.Position, lines start at 1 and
+// columns start at 0. Columns count UTF-16 code units, as JavaScript does.
+type Position struct {
+	Line   int
+	Column int
+}
+
+// Mapping says that the text at Generated came from Original in Source. A
+// mapping with no Source marks generated text that didn't come from anywhere.
+// Name is the original name of an identifier, if there is one.
+type Mapping struct {
+	Generated Position
+	Source    string
+	Original  Position
+	Name      string
+}
+
+// Map is a source map, as it's written to JSON.
+type Map struct {
+	Version        int       `json:"version"`
+	File           string    `json:"file,omitempty"`
+	SourceRoot     string    `json:"sourceRoot,omitempty"`
+	Sources        []string  `json:"sources"`
+	SourcesContent []*string `json:"sourcesContent,omitempty"`
+	Names          []string  `json:"names"`
+	Mappings       string    `json:"mappings"`
+}
+
+// DataURL returns the map as a data: URL.
+func (m *Map) DataURL() (string, error) {
+	b, err := json.Marshal(m)
+	if err != nil {
+		return "", err
+	}
+
+	return "data:application/json;charset=utf-8;base64," + base64.StdEncoding.EncodeToString(b), nil
+}
+
+// Comment returns a sourceMappingURL comment with the map inlined in it, to go
+// at the end of the generated file.
+func (m *Map) Comment() (string, error) {
+	u, err := m.DataURL()
+	if err != nil {
+		return "", err
+	}
+
+	return "//# sourceMappingURL=" + u, nil
+}
+
+// Generator collects mappings for a generated file, and builds a Map from
+// them.
+type Generator struct {
+	File       string
+	SourceRoot string
+
+	sources  []string
+	contents map[string]string
+	lines    map[string][]int
+	mappings []Mapping
+}
+
+// NewGenerator returns a Generator for the named generated file.
+func NewGenerator(file string) *Generator {
+	return &Generator{
+		File:     file,
+		contents: make(map[string]string),
+		lines:    make(map[string][]int),
+	}
+}
+
+// AddSource records the content of a source file, which is included in the
+// map and lets AddOffset find positions in it.
+func (g *Generator) AddSource(name, content string) {
+	g.source(name)
+	g.contents[name] = content
+
+	lines := []int{0}
+	for i := 0; i < len(content); i++ {
+		switch content[i] {
+		case '\r':
+			if i+1 < len(content) && content[i+1] == '\n' {
+				i++
+			}
+			lines = append(lines, i+1)
+		case '\n':
+			lines = append(lines, i+1)
+		case 0xe2:
+			// U+2028 and U+2029 end lines too.
+			if strings.HasPrefix(content[i:], "\u2028") || strings.HasPrefix(content[i:], "\u2029") {
+				i += 2
+				lines = append(lines, i+1)
+			}
+		}
+	}
+
+	g.lines[name] = lines
+}
+
+func (g *Generator) source(name string) {
+	for _, s := range g.sources {
+		if s == name {
+			return
+		}
+	}
+
+	g.sources = append(g.sources, name)
+}
+
+// Add records a mapping.
+func (g *Generator) Add(m Mapping) {
+	if m.Source != "" {
+		g.source(m.Source)
+	}
+
+	g.mappings = append(g.mappings, m)
+}
+
+// AddOffset records a mapping to a byte offset in a source file, like the
+// Offset of a jsparser.Token. The content of the source has to have been
+// given to AddSource first.
+func (g *Generator) AddOffset(generated Position, source string, offset int, name string) error {
+	lines, ok := g.lines[source]
+	if !ok {
+		return fmt.Errorf("sourcemap: no content for source %q", source)
+	}
+
+	content := g.contents[source]
+	if offset < 0 || offset > len(content) {
+		return fmt.Errorf("sourcemap: offset %d is outside of source %q", offset, source)
+	}
+
+	l := sort.Search(len(lines), func(i int) bool { return lines[i] > offset }) - 1
+
+	g.Add(Mapping{
+		Generated: generated,
+		Source:    source,
+		Original:  Position{Line: l + 1, Column: Columns(content[lines[l]:offset])},
+		Name:      name,
+	})
+
+	return nil
+}
+
+// Columns returns the number of columns that some text on a single line
+// takes up, counting UTF-16 code units.
+func Columns(s string) int {
+	n := 0
+	for _, r := range s {
+		if r >= 0x10000 {
+			n += 2
+		} else {
+			n++
+		}
+	}
+
+	return n
+}
+
+// Map builds the source map.
+func (g *Generator) Map() *Map {
+	m := &Map{
+		Version:    3,
+		File:       g.File,
+		SourceRoot: g.SourceRoot,
+		Sources:    append([]string{}, g.sources...),
+		Names:      []string{},
+	}
+
+	if len(g.contents) > 0 {
+		for _, s := range g.sources {
+			if c, ok := g.contents[s]; ok {
+				m.SourcesContent = append(m.SourcesContent, &c)
+			} else {
+				m.SourcesContent = append(m.SourcesContent, nil)
+			}
+		}
+	}
+
+	sources := make(map[string]int)
+	for i, s := range g.sources {
+		sources[s] = i
+	}
+
+	names := make(map[string]int)
+
+	a := append([]Mapping(nil), g.mappings...)
+	sort.SliceStable(a, func(i, j int) bool {
+		if a[i].Generated.Line != a[j].Generated.Line {
+			return a[i].Generated.Line < a[j].Generated.Line
+		}
+
+		return a[i].Generated.Column < a[j].Generated.Column
+	})
+
+	var b strings.Builder
+	var prev struct{ column, source, line, originalColumn, name int }
+
+	line := 1
+	first := true
+
+	for _, mp := range a {
+		for line < mp.Generated.Line {
+			b.WriteByte(';')
+			line++
+			prev.column = 0
+			first = true
+		}
+
+		if !first {
+			b.WriteByte(',')
+		}
+		first = false
+
+		writeVLQ(&b, mp.Generated.Column-prev.column)
+		prev.column = mp.Generated.Column
+
+		if mp.Source == "" {
+			continue
+		}
+
+		s := sources[mp.Source]
+		writeVLQ(&b, s-prev.source)
+		prev.source = s
+
+		writeVLQ(&b, mp.Original.Line-1-prev.line)
+		prev.line = mp.Original.Line - 1
+
+		writeVLQ(&b, mp.Original.Column-prev.originalColumn)
+		prev.originalColumn = mp.Original.Column
+
+		if mp.Name == "" {
+			continue
+		}
+
+		n, ok := names[mp.Name]
+		if !ok {
+			n = len(m.Names)
+			names[mp.Name] = n
+			m.Names = append(m.Names, mp.Name)
+		}
+
+		writeVLQ(&b, n-prev.name)
+		prev.name = n
+	}
+
+	m.Mappings = b.String()
+
+	return m
+}
+
+const base64Digits = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789+/"
+
+// writeVLQ writes a number as a base 64 variable length quantity, with its
+// sign in the lowest bit.
+func writeVLQ(b *strings.Builder, v int) {
+	u := v << 1
+	if v < 0 {
+		u = -v<<1 | 1
+	}
+
+	for {
+		d := u & 31
+		u >>= 5
+
+		if u > 0 {
+			d |= 32
+		}
+
+		b.WriteByte(base64Digits[d])
+
+		if u == 0 {
+			return
+		}
+	}
+}
@@ -0,0 +1,91 @@
+package sourcemap
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVLQ(t *testing.T) {
+	a := assert.New(t)
+
+	for v, s := range map[int]string{
+		0:    "A",
+		1:    "C",
+		-1:   "D",
+		15:   "e",
+		16:   "gB",
+		-16:  "hB",
+		123:  "2H",
+		1000: "w+B",
+	} {
+		var b strings.Builder
+		writeVLQ(&b, v)
+		a.Equal(s, b.String(), v)
+	}
+}
+
+func TestMap(t *testing.T) {
+	a := assert.New(t)
+
+	g := NewGenerator("out.js")
+	g.Add(Mapping{Generated: Position{2, 0}, Source: "a.js", Original: Position{3, 2}})
+	g.Add(Mapping{Generated: Position{1, 0}, Source: "a.js", Original: Position{1, 0}, Name: "foo"})
+	g.Add(Mapping{Generated: Position{1, 4}, Source: "a.js", Original: Position{1, 6}})
+	g.Add(Mapping{Generated: Position{4, 1}})
+
+	m := g.Map()
+	a.Equal(3, m.Version)
+	a.Equal("out.js", m.File)
+	a.Equal([]string{"a.js"}, m.Sources)
+	a.Nil(m.SourcesContent)
+	a.Equal([]string{"foo"}, m.Names)
+	a.Equal("AAAAA,IAAM;AAEJ;;C", m.Mappings)
+}
+
+func TestAddOffset(t *testing.T) {
+	a := assert.New(t)
+
+	g := NewGenerator("")
+	g.AddSource("a.js", "var a;\r\nvar \U0001f600b;\nc")
+
+	a.NoError(g.AddOffset(Position{1, 0}, "a.js", 0, ""))
+	a.NoError(g.AddOffset(Position{1, 1}, "a.js", 16, "b"))
+	a.NoError(g.AddOffset(Position{1, 2}, "a.js", 19, ""))
+	a.Error(g.AddOffset(Position{1, 3}, "a.js", 21, ""))
+	a.Error(g.AddOffset(Position{1, 3}, "b.js", 0, ""))
+
+	a.Equal([]Mapping{
+		{Generated: Position{1, 0}, Source: "a.js", Original: Position{1, 0}},
+		{Generated: Position{1, 1}, Source: "a.js", Original: Position{2, 6}, Name: "b"},
+		{Generated: Position{1, 2}, Source: "a.js", Original: Position{3, 0}},
+	}, g.mappings)
+
+	m := g.Map()
+	if a.Len(m.SourcesContent, 1) {
+		a.Equal("var a;\r\nvar \U0001f600b;\nc", *m.SourcesContent[0])
+	}
+}
+
+func TestComment(t *testing.T) {
+	a := assert.New(t)
+
+	g := NewGenerator("out.js")
+	g.Add(Mapping{Generated: Position{1, 0}, Source: "a.js", Original: Position{1, 0}})
+
+	c, err := g.Map().Comment()
+	a.NoError(err)
+
+	const prefix = "//# sourceMappingURL=data:application/json;charset=utf-8;base64,"
+	if a.True(strings.HasPrefix(c, prefix)) {
+		b, err := base64.StdEncoding.DecodeString(c[len(prefix):])
+		a.NoError(err)
+
+		var m Map
+		a.NoError(json.Unmarshal(b, &m))
+		a.Equal(g.Map(), &m)
+	}
+}
@@ -0,0 +1,58 @@
+package sourcemap
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	// v8Frame matches a frame like "    at f (file.js:1:2)" or "    at
+	// file.js:1:2".
+	v8Frame = regexp.MustCompile(`^(\s*at .*?\(?)([^()\s]+):(\d+):(\d+)(\)*)$`)
+	// spiderMonkeyFrame matches a frame like "f@file.js:1:2".
+	spiderMonkeyFrame = regexp.MustCompile(`^(.*@)(.+):(\d+):(\d+)$`)
+)
+
+// RemapStack rewrites the locations in a stack trace from V8 or SpiderMonkey
+// to point at the original sources. The consumer for the map of each file is
+// given by lookup, which returns nil for files without one. Lines with
+// locations that can't be mapped are left as they are.
+//
+// Stack traces count lines and columns from 1, and so do the locations that
+// replace them.
+func RemapStack(stack string, lookup func(file string) *Consumer) string {
+	lines := strings.Split(stack, "\n")
+
+	for i, line := range lines {
+		m := v8Frame.FindStringSubmatch(line)
+		if m == nil {
+			m = spiderMonkeyFrame.FindStringSubmatch(line)
+		}
+		if m == nil {
+			continue
+		}
+
+		c := lookup(m[2])
+		if c == nil {
+			continue
+		}
+
+		l, _ := strconv.Atoi(m[3])
+		col, _ := strconv.Atoi(m[4])
+
+		o, ok := c.Original(Position{Line: l, Column: col - 1})
+		if !ok {
+			continue
+		}
+
+		var suffix string
+		if len(m) > 5 {
+			suffix = m[5]
+		}
+
+		lines[i] = m[1] + o.Source + ":" + strconv.Itoa(o.Original.Line) + ":" + strconv.Itoa(o.Original.Column+1) + suffix
+	}
+
+	return strings.Join(lines, "\n")
+}
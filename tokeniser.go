@@ -4,6 +4,7 @@ import (
 	"bufio"
 	"fmt"
 	"io"
+	"strings"
 	"unicode"
 	"unicode/utf8"
 )
@@ -18,219 +19,6 @@ func (t TokeniserError) Error() string {
 	return fmt.Sprintf("TokeniserError (state %s at offset %d): %s", t.Mode, t.Offset, t.Message)
 }
 
-type TokenKind int
-
-const (
-	TokenKindWhitespace TokenKind = iota
-	TokenKindBinaryAssignment
-	TokenKindBinaryBitwiseAnd
-	TokenKindBinaryBitwiseAndAssignment
-	TokenKindBinaryBitwiseOr
-	TokenKindBinaryBitwiseOrAssignment
-	TokenKindBinaryBitwiseXor
-	TokenKindBinaryBitwiseXorAssignment
-	TokenKindBinaryDivide
-	TokenKindBinaryDivideEquals
-	TokenKindBinaryEquals
-	TokenKindBinaryExponent
-	TokenKindBinaryExponentAssignment
-	TokenKindBinaryGreater
-	TokenKindBinaryGreaterOrEqual
-	TokenKindBinaryLess
-	TokenKindBinaryLessOrEqual
-	TokenKindBinaryLogicalAnd
-	TokenKindBinaryLogicalOr
-	TokenKindBinaryMinus
-	TokenKindBinaryMinusAssignment
-	TokenKindBinaryModulo
-	TokenKindBinaryModuloAssignment
-	TokenKindBinaryNotEquals
-	TokenKindBinaryPlus
-	TokenKindBinaryPlusAssignment
-	TokenKindBinaryShiftLeft
-	TokenKindBinaryShiftLeftAssignment
-	TokenKindBinaryShiftRight
-	TokenKindBinaryShiftRightAssignment
-	TokenKindBinaryShiftRightUnsigned
-	TokenKindBinaryShiftRightUnsignedAssignment
-	TokenKindBinaryStar
-	TokenKindBinaryStarAssignment
-	TokenKindBinaryStrictEquals
-	TokenKindBinaryStrictNotEquals
-	TokenKindIdentifier
-	TokenKindKeyword
-	TokenKindMetaShebangLine
-	TokenKindMultipleLineComment
-	TokenKindNumber
-	TokenKindPuncAt
-	TokenKindPuncBacktick
-	TokenKindPuncColon
-	TokenKindPuncComma
-	TokenKindPuncFatArrow
-	TokenKindPuncLeftBrace
-	TokenKindPuncLeftBracket
-	TokenKindPuncLeftParen
-	TokenKindPuncPeriod
-	TokenKindPuncQuestion
-	TokenKindPuncRightBrace
-	TokenKindPuncRightBracket
-	TokenKindPuncRightParen
-	TokenKindPuncSemicolon
-	TokenKindPuncSpread
-	TokenKindRegexp
-	TokenKindSingleLineComment
-	TokenKindString
-	TokenKindTemplateHead
-	TokenKindTemplateMiddle
-	TokenKindTemplateNoSubstitution
-	TokenKindTemplateTail
-	TokenKindUnaryBang
-	TokenKindUnaryDecrement
-	TokenKindUnaryIncrement
-	TokenKindUnaryTilde
-)
-
-func (t TokenKind) String() string {
-	switch t {
-	case TokenKindBinaryAssignment:
-		return "binaryAssignment"
-	case TokenKindBinaryBitwiseAnd:
-		return "binaryBitwiseAnd"
-	case TokenKindBinaryBitwiseAndAssignment:
-		return "binaryBitwiseAndAssignment"
-	case TokenKindBinaryBitwiseOr:
-		return "binaryBitwiseOr"
-	case TokenKindBinaryBitwiseOrAssignment:
-		return "binaryBitwiseOrAssignment"
-	case TokenKindBinaryBitwiseXor:
-		return "binaryBitwiseXor"
-	case TokenKindBinaryBitwiseXorAssignment:
-		return "binaryBitwiseXorAssignment"
-	case TokenKindBinaryDivide:
-		return "binaryDivide"
-	case TokenKindBinaryDivideEquals:
-		return "binaryDivideEquals"
-	case TokenKindBinaryEquals:
-		return "binaryEquals"
-	case TokenKindBinaryExponent:
-		return "binaryExponent"
-	case TokenKindBinaryExponentAssignment:
-		return "binaryExponentAssignment"
-	case TokenKindBinaryGreater:
-		return "binaryGreater"
-	case TokenKindBinaryGreaterOrEqual:
-		return "binaryGreaterOrEqual"
-	case TokenKindBinaryLess:
-		return "binaryLess"
-	case TokenKindBinaryLessOrEqual:
-		return "binaryLessOrEqual"
-	case TokenKindBinaryLogicalAnd:
-		return "binaryLogicalAnd"
-	case TokenKindBinaryLogicalOr:
-		return "binaryLogicalOr"
-	case TokenKindBinaryMinus:
-		return "binaryMinus"
-	case TokenKindBinaryMinusAssignment:
-		return "binaryMinusAssignment"
-	case TokenKindBinaryModulo:
-		return "binaryModulo"
-	case TokenKindBinaryModuloAssignment:
-		return "binaryModuloAssignment"
-	case TokenKindBinaryNotEquals:
-		return "binaryNotEquals"
-	case TokenKindBinaryPlus:
-		return "binaryPlus"
-	case TokenKindBinaryPlusAssignment:
-		return "binaryPlusAssignment"
-	case TokenKindBinaryShiftLeft:
-		return "binaryShiftLeft"
-	case TokenKindBinaryShiftLeftAssignment:
-		return "binaryShiftLeftAssignment"
-	case TokenKindBinaryShiftRight:
-		return "binaryShiftRight"
-	case TokenKindBinaryShiftRightAssignment:
-		return "binaryShiftRightAssignment"
-	case TokenKindBinaryShiftRightUnsigned:
-		return "binaryShiftRightUnsigned"
-	case TokenKindBinaryShiftRightUnsignedAssignment:
-		return "binaryShiftRightUnsignedAssignment"
-	case TokenKindBinaryStar:
-		return "binaryStar"
-	case TokenKindBinaryStarAssignment:
-		return "binaryStarAssignment"
-	case TokenKindBinaryStrictEquals:
-		return "binaryStrictEquals"
-	case TokenKindBinaryStrictNotEquals:
-		return "binaryStrictNotEquals"
-	case TokenKindIdentifier:
-		return "identifier"
-	case TokenKindKeyword:
-		return "keyword"
-	case TokenKindMetaShebangLine:
-		return "metaShebangLine"
-	case TokenKindMultipleLineComment:
-		return "multipleLineComment"
-	case TokenKindNumber:
-		return "number"
-	case TokenKindPuncAt:
-		return "puncAt"
-	case TokenKindPuncBacktick:
-		return "puncBacktick"
-	case TokenKindPuncColon:
-		return "puncColon"
-	case TokenKindPuncComma:
-		return "puncComma"
-	case TokenKindPuncFatArrow:
-		return "puncFatArrow"
-	case TokenKindPuncLeftBrace:
-		return "puncLeftBrace"
-	case TokenKindPuncLeftBracket:
-		return "puncLeftBracket"
-	case TokenKindPuncLeftParen:
-		return "puncLeftParen"
-	case TokenKindPuncPeriod:
-		return "puncPeriod"
-	case TokenKindPuncQuestion:
-		return "puncQuestion"
-	case TokenKindPuncRightBrace:
-		return "puncRightBrace"
-	case TokenKindPuncRightBracket:
-		return "puncRightBracket"
-	case TokenKindPuncRightParen:
-		return "puncRightParen"
-	case TokenKindPuncSemicolon:
-		return "puncSemicolon"
-	case TokenKindPuncSpread:
-		return "puncSpread"
-	case TokenKindRegexp:
-		return "regexp"
-	case TokenKindSingleLineComment:
-		return "singleLineComment"
-	case TokenKindString:
-		return "string"
-	case TokenKindTemplateHead:
-		return "templateHead"
-	case TokenKindTemplateMiddle:
-		return "templateMiddle"
-	case TokenKindTemplateNoSubstitution:
-		return "templateNoSubstitution"
-	case TokenKindTemplateTail:
-		return "templateTail"
-	case TokenKindUnaryBang:
-		return "unaryBang"
-	case TokenKindUnaryDecrement:
-		return "unaryDecrement"
-	case TokenKindUnaryIncrement:
-		return "unaryIncrement"
-	case TokenKindUnaryTilde:
-		return "unaryTilde"
-	case TokenKindWhitespace:
-		return "whitespace"
-	default:
-		return "unknown"
-	}
-}
-
 type Token struct {
 	Kind   TokenKind
 	Value  string
@@ -375,159 +163,12 @@ func (t *Tokeniser) Read() (*Token, error) {
 		return &Token{Kind: TokenKindWhitespace, Raw: string(ws), Offset: t.save()}, nil
 	}
 
-	// try to read a static token
 	r0, err := t.readRune()
 	if err != nil {
 		return nil, err
 	}
 
 	switch r0 {
-	case '!':
-		r1, err := t.readRune()
-		if err != nil {
-			return nil, err
-		}
-
-		switch r1 {
-		case '=':
-			r2, err := t.readRune()
-			if err != nil {
-				return nil, err
-			}
-
-			switch r2 {
-			case '=':
-				return &Token{Kind: TokenKindBinaryStrictNotEquals, Raw: "!==", Offset: t.save()}, nil
-			}
-
-			t.unreadRune(r2)
-
-			return &Token{Kind: TokenKindBinaryNotEquals, Raw: "!=", Offset: t.save()}, nil
-		}
-
-		t.unreadRune(r1)
-
-		return &Token{Kind: TokenKindUnaryBang, Raw: "!", Offset: t.save()}, nil
-	case '%':
-		r1, err := t.readRune()
-		if err != nil {
-			return nil, err
-		}
-
-		switch r1 {
-		case '=':
-			return &Token{Kind: TokenKindBinaryModuloAssignment, Raw: "%=", Offset: t.save()}, nil
-		}
-
-		t.unreadRune(r1)
-
-		return &Token{Kind: TokenKindBinaryModulo, Raw: "%", Offset: t.save()}, nil
-	case '&':
-		r1, err := t.readRune()
-		if err != nil {
-			return nil, err
-		}
-
-		switch r1 {
-		case '&':
-			return &Token{Kind: TokenKindBinaryLogicalAnd, Raw: "&&", Offset: t.save()}, nil
-		case '=':
-			return &Token{Kind: TokenKindBinaryBitwiseAndAssignment, Raw: "&=", Offset: t.save()}, nil
-		}
-
-		t.unreadRune(r1)
-
-		return &Token{Kind: TokenKindBinaryBitwiseAnd, Raw: "&", Offset: t.save()}, nil
-	case '(':
-		return &Token{Kind: TokenKindPuncLeftParen, Raw: "(", Offset: t.save()}, nil
-	case ')':
-		return &Token{Kind: TokenKindPuncRightParen, Raw: ")", Offset: t.save()}, nil
-	case '*':
-		r1, err := t.readRune()
-		if err != nil {
-			return nil, err
-		}
-
-		switch r1 {
-		case '*':
-			r2, err := t.readRune()
-			if err != nil {
-				return nil, err
-			}
-
-			switch r2 {
-			case '=':
-				return &Token{Kind: TokenKindBinaryExponentAssignment, Raw: "**=", Offset: t.save()}, nil
-			}
-
-			t.unreadRune(r2)
-
-			return &Token{Kind: TokenKindBinaryExponent, Raw: "**", Offset: t.save()}, nil
-		case '=':
-			return &Token{Kind: TokenKindBinaryStarAssignment, Raw: "*=", Offset: t.save()}, nil
-		}
-
-		t.unreadRune(r1)
-
-		return &Token{Kind: TokenKindBinaryStar, Raw: "*", Offset: t.save()}, nil
-	case '+':
-		r1, err := t.readRune()
-		if err != nil {
-			return nil, err
-		}
-
-		switch r1 {
-		case '+':
-			return &Token{Kind: TokenKindUnaryIncrement, Raw: "++", Offset: t.save()}, nil
-		case '=':
-			return &Token{Kind: TokenKindBinaryPlusAssignment, Raw: "+=", Offset: t.save()}, nil
-		}
-
-		t.unreadRune(r1)
-
-		return &Token{Kind: TokenKindBinaryPlus, Raw: "+", Offset: t.save()}, nil
-	case ',':
-		return &Token{Kind: TokenKindPuncComma, Raw: ",", Offset: t.save()}, nil
-	case '-':
-		r1, err := t.readRune()
-		if err != nil {
-			return nil, err
-		}
-
-		switch r1 {
-		case '-':
-			return &Token{Kind: TokenKindUnaryDecrement, Raw: "--", Offset: t.save()}, nil
-		case '=':
-			return &Token{Kind: TokenKindBinaryMinusAssignment, Raw: "-=", Offset: t.save()}, nil
-		}
-
-		t.unreadRune(r1)
-
-		return &Token{Kind: TokenKindBinaryMinus, Raw: "-", Offset: t.save()}, nil
-	case '.':
-		r1, err := t.readRune()
-		if err != nil {
-			return nil, err
-		}
-
-		switch r1 {
-		case '.':
-			r2, err := t.readRune()
-			if err != nil {
-				return nil, err
-			}
-
-			switch r2 {
-			case '.':
-				return &Token{Kind: TokenKindPuncSpread, Raw: "...", Offset: t.save()}, nil
-			}
-
-			t.unreadRune(r2)
-		}
-
-		t.unreadRune(r1)
-
-		return &Token{Kind: TokenKindPuncPeriod, Raw: ".", Offset: t.save()}, nil
 	case '/':
 		r1, err := t.readRune()
 		if err != nil {
@@ -544,172 +185,44 @@ func (t *Tokeniser) Read() (*Token, error) {
 		case t.state == InputElementRegExp || t.state == InputElementRegExpOrTemplateTail:
 			t.unreadRune(r1, r0)
 			return t.lexRegexp()
-		case r1 == '=':
-			return &Token{Kind: TokenKindBinaryDivideEquals, Raw: "/=", Offset: t.save()}, nil
-		}
-
-		t.unreadRune(r1)
-
-		return &Token{Kind: TokenKindBinaryDivide, Raw: "/", Offset: t.save()}, nil
-	case ':':
-		return &Token{Kind: TokenKindPuncColon, Raw: ":", Offset: t.save()}, nil
-	case ';':
-		return &Token{Kind: TokenKindPuncSemicolon, Raw: ";", Offset: t.save()}, nil
-	case '<':
-		r1, err := t.readRune()
-		if err != nil {
-			return nil, err
-		}
-
-		switch r1 {
-		case '<':
-			r2, err := t.readRune()
-			if err != nil {
-				return nil, err
-			}
-
-			switch r2 {
-			case '=':
-				return &Token{Kind: TokenKindBinaryShiftLeftAssignment, Raw: "<<=", Offset: t.save()}, nil
-			}
-
-			t.unreadRune(r2)
-
-			return &Token{Kind: TokenKindBinaryShiftLeft, Raw: "<<", Offset: t.save()}, nil
-		case '=':
-			return &Token{Kind: TokenKindBinaryLessOrEqual, Raw: "<=", Offset: t.save()}, nil
 		}
 
 		t.unreadRune(r1)
-
-		return &Token{Kind: TokenKindBinaryLess, Raw: "<", Offset: t.save()}, nil
-	case '=':
+	case '.':
+		// A number can start with a period, as in `.5`, and that has to be
+		// caught before the period is read as a punctuator. This is also
+		// what splits `a?.5:b` into `?` and `.5`.
 		r1, err := t.readRune()
-		if err != nil {
+		if err == io.EOF {
+			break
+		} else if err != nil {
 			return nil, err
 		}
 
-		switch r1 {
-		case '=':
-			r2, err := t.readRune()
-			if err != nil {
-				return nil, err
-			}
-
-			switch r2 {
-			case '=':
-				return &Token{Kind: TokenKindBinaryStrictEquals, Raw: "===", Offset: t.save()}, nil
-			}
-
-			t.unreadRune(r2)
-
-			return &Token{Kind: TokenKindBinaryEquals, Raw: "==", Offset: t.save()}, nil
-		case '>':
-			return &Token{Kind: TokenKindPuncFatArrow, Raw: "=>", Offset: t.save()}, nil
-		}
-
 		t.unreadRune(r1)
 
-		return &Token{Kind: TokenKindBinaryAssignment, Raw: "=", Offset: t.save()}, nil
-	case '>':
-		r1, err := t.readRune()
-		if err != nil {
-			return nil, err
-		}
-
-		switch r1 {
-		case '=':
-			return &Token{Kind: TokenKindBinaryGreaterOrEqual, Raw: ">=", Offset: t.save()}, nil
-		case '>':
-			r2, err := t.readRune()
-			if err != nil {
-				return nil, err
-			}
-
-			switch r2 {
-			case '=':
-				return &Token{Kind: TokenKindBinaryShiftRightAssignment, Raw: ">>=", Offset: t.save()}, nil
-			case '>':
-				r3, err := t.readRune()
-				if err != nil {
-					return nil, err
-				}
-
-				switch r3 {
-				case '=':
-					return &Token{Kind: TokenKindBinaryShiftRightUnsignedAssignment, Raw: ">>>=", Offset: t.save()}, nil
-				}
-
-				t.unreadRune(r3)
-
-				return &Token{Kind: TokenKindBinaryShiftRightUnsigned, Raw: ">>>", Offset: t.save()}, nil
-			}
-
-			t.unreadRune(r2)
-
-			return &Token{Kind: TokenKindBinaryShiftRight, Raw: ">>", Offset: t.save()}, nil
+		if r1 >= '0' && r1 <= '9' {
+			t.unreadRune(r0)
+			return t.lexNumber()
 		}
-
-		t.unreadRune(r1)
-
-		return &Token{Kind: TokenKindBinaryGreater, Raw: ">", Offset: t.save()}, nil
-	case '?':
-		return &Token{Kind: TokenKindPuncQuestion, Raw: "?", Offset: t.save()}, nil
-	case '@':
-		return &Token{Kind: TokenKindPuncAt, Raw: "@", Offset: t.save()}, nil
-	case '[':
-		return &Token{Kind: TokenKindPuncLeftBracket, Raw: "[", Offset: t.save()}, nil
-	case ']':
-		return &Token{Kind: TokenKindPuncRightBracket, Raw: "]", Offset: t.save()}, nil
 	case '`':
 		t.unreadRune(r0)
 		return t.lexTemplateHead()
-	case '^':
-		r1, err := t.readRune()
-		if err != nil {
-			return nil, err
-		}
-
-		switch r1 {
-		case '=':
-			return &Token{Kind: TokenKindBinaryBitwiseXorAssignment, Raw: "^=", Offset: t.save()}, nil
-		}
-
-		t.unreadRune(r1)
-
-		return &Token{Kind: TokenKindBinaryBitwiseXor, Raw: "^", Offset: t.save()}, nil
-	case '{':
-		return &Token{Kind: TokenKindPuncLeftBrace, Raw: "{", Offset: t.save()}, nil
-	case '|':
-		r1, err := t.readRune()
-		if err != nil {
-			return nil, err
-		}
-
-		switch r1 {
-		case '=':
-			return &Token{Kind: TokenKindBinaryBitwiseOrAssignment, Raw: "|=", Offset: t.save()}, nil
-		case '|':
-			return &Token{Kind: TokenKindBinaryLogicalOr, Raw: "||", Offset: t.save()}, nil
-		}
-
-		t.unreadRune(r1)
-
-		return &Token{Kind: TokenKindBinaryBitwiseOr, Raw: "|", Offset: t.save()}, nil
 	case '}':
 		switch t.state {
 		case InputElementTemplateTail, InputElementRegExpOrTemplateTail:
 			t.unreadRune(r0)
 			return t.lexTemplateTail()
-		default:
-			return &Token{Kind: TokenKindPuncRightBrace, Raw: "}", Offset: t.save()}, nil
 		}
-	case '~':
-		return &Token{Kind: TokenKindUnaryTilde, Raw: "~", Offset: t.save()}, nil
 	}
 
 	t.unreadRune(r0)
 
+	// try to read a static token
+	if tk, err := t.lexPunctuator(); tk != nil || err != nil {
+		return tk, err
+	}
+
 	for {
 		r, err := t.readRune()
 		if err != nil {
@@ -735,6 +248,50 @@ func (t *Tokeniser) Read() (*Token, error) {
 	}
 }
 
+// punctuatorTrie is a node in the trie of punctuators in tokenkind.go. A
+// terminal node ends a punctuator, unless the next character is in exclude.
+type punctuatorTrie struct {
+	kind     TokenKind
+	terminal bool
+	exclude  string
+	next     map[rune]*punctuatorTrie
+}
+
+// lexPunctuator reads the longest punctuator at the current position. If
+// there isn't one, it returns a nil token and leaves the input untouched.
+func (t *Tokeniser) lexPunctuator() (*Token, error) {
+	var b []rune
+	var match *punctuatorTrie
+	var n int
+
+	for p := punctuators; p != nil; p = p.next[b[len(b)-1]] {
+		r, err := t.readRune()
+		if err != nil && err != io.EOF {
+			return nil, err
+		}
+
+		if p.terminal && (err == io.EOF || !strings.ContainsRune(p.exclude, r)) {
+			match, n = p, len(b)
+		}
+
+		if err == io.EOF {
+			break
+		}
+
+		b = append(b, r)
+	}
+
+	for i := len(b) - 1; i >= n; i-- {
+		t.unreadRune(b[i])
+	}
+
+	if match == nil {
+		return nil, nil
+	}
+
+	return &Token{Kind: match.kind, Raw: string(b[:n]), Offset: t.save()}, nil
+}
+
 func (t *Tokeniser) lexTemplateHead() (*Token, error) {
 	r0, err := t.readRune()
 	if err != nil {
@@ -755,6 +312,18 @@ func (t *Tokeniser) lexTemplateHead() (*Token, error) {
 		}
 
 		switch r1 {
+		case '\\':
+			// An escape can't end the template, so the character after
+			// it is read along with it.
+			r2, err := t.readRune()
+			if err != nil {
+				return nil, err
+			}
+
+			b = append(b, r1, r2)
+			v = v + string(r1) + string(r2)
+
+			continue
 		case '`':
 			b = append(b, r1)
 
@@ -810,6 +379,18 @@ func (t *Tokeniser) lexTemplateTail() (*Token, error) {
 		}
 
 		switch r1 {
+		case '\\':
+			// An escape can't end the template, so the character after
+			// it is read along with it.
+			r2, err := t.readRune()
+			if err != nil {
+				return nil, err
+			}
+
+			b = append(b, r1, r2)
+			v = v + string(r1) + string(r2)
+
+			continue
 		case '`':
 			b = append(b, r1)
 
@@ -838,10 +419,10 @@ func (t *Tokeniser) lexTemplateTail() (*Token, error) {
 			}
 
 			t.unreadRune(r2)
-
-			b = append(b, r1)
-			v = v + string(r1)
 		}
+
+		b = append(b, r1)
+		v = v + string(r1)
 	}
 }
 
@@ -1060,6 +641,9 @@ func (t *Tokeniser) lexSingleLineComment() (*Token, error) {
 	buf := []rune{r0, r1}
 	for {
 		r, err := t.readRune()
+		if err == io.EOF {
+			break
+		}
 		if err != nil {
 			return nil, err
 		}
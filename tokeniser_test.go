@@ -0,0 +1,59 @@
+package jsparser
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTokeniserPunctuators(t *testing.T) {
+	a := assert.New(t)
+
+	r, err := NewTokeniser(strings.NewReader("a??=b?.c?.5:d>>>=e;")).ReadAll()
+	a.NoError(err)
+	a.Equal(TokenSet{
+		Token{Kind: TokenKindIdentifier, Value: "a", Raw: "a", Offset: 0},
+		Token{Kind: TokenKindBinaryNullishCoalescingAssignment, Raw: "??=", Offset: 1},
+		Token{Kind: TokenKindIdentifier, Value: "b", Raw: "b", Offset: 4},
+		Token{Kind: TokenKindPuncOptionalChain, Raw: "?.", Offset: 5},
+		Token{Kind: TokenKindIdentifier, Value: "c", Raw: "c", Offset: 7},
+		Token{Kind: TokenKindPuncQuestion, Raw: "?", Offset: 8},
+		Token{Kind: TokenKindNumber, Value: ".5", Raw: ".5", Offset: 9},
+		Token{Kind: TokenKindPuncColon, Raw: ":", Offset: 11},
+		Token{Kind: TokenKindIdentifier, Value: "d", Raw: "d", Offset: 12},
+		Token{Kind: TokenKindBinaryShiftRightUnsignedAssignment, Raw: ">>>=", Offset: 13},
+		Token{Kind: TokenKindIdentifier, Value: "e", Raw: "e", Offset: 17},
+		Token{Kind: TokenKindPuncSemicolon, Raw: ";", Offset: 18},
+	}, r)
+}
+
+func TestTokeniserCommentAtEOF(t *testing.T) {
+	a := assert.New(t)
+
+	r, err := NewTokeniser(strings.NewReader("a;//# b")).ReadAll()
+	a.NoError(err)
+	a.Equal(TokenSet{
+		Token{Kind: TokenKindIdentifier, Value: "a", Raw: "a", Offset: 0},
+		Token{Kind: TokenKindPuncSemicolon, Raw: ";", Offset: 1},
+		Token{Kind: TokenKindSingleLineComment, Value: "//# b", Raw: "//# b", Offset: 2},
+	}, r)
+}
+
+func TestTokeniserTemplates(t *testing.T) {
+	a := assert.New(t)
+
+	// Escaped backticks and "${" don't end a template, and neither do the
+	// characters between a "}" and the next "${" or backtick.
+	r, err := ParseString("`a\\``+`b${c}d\\${e}\\`${f}g`")
+	a.NoError(err)
+	a.Equal(TokenSet{
+		Token{Kind: TokenKindTemplateNoSubstitution, Value: "a\\`", Raw: "`a\\``", Offset: 0},
+		Token{Kind: TokenKindBinaryPlus, Raw: "+", Offset: 5},
+		Token{Kind: TokenKindTemplateHead, Value: "b", Raw: "`b${", Offset: 6},
+		Token{Kind: TokenKindIdentifier, Value: "c", Raw: "c", Offset: 10},
+		Token{Kind: TokenKindTemplateMiddle, Value: "d\\${e}\\`", Raw: "}d\\${e}\\`${", Offset: 11},
+		Token{Kind: TokenKindIdentifier, Value: "f", Raw: "f", Offset: 22},
+		Token{Kind: TokenKindTemplateTail, Value: "g", Raw: "}g`", Offset: 23},
+	}, r)
+}
@@ -0,0 +1,319 @@
+// Code generated by parser_generator -format tokens; DO NOT EDIT.
+
+package jsparser
+
+type TokenKind int
+
+const (
+	TokenKindWhitespace TokenKind = iota
+	TokenKindBinaryAssignment
+	TokenKindBinaryBitwiseAnd
+	TokenKindBinaryBitwiseAndAssignment
+	TokenKindBinaryBitwiseOr
+	TokenKindBinaryBitwiseOrAssignment
+	TokenKindBinaryBitwiseXor
+	TokenKindBinaryBitwiseXorAssignment
+	TokenKindBinaryDivide
+	TokenKindBinaryDivideEquals
+	TokenKindBinaryEquals
+	TokenKindBinaryExponent
+	TokenKindBinaryExponentAssignment
+	TokenKindBinaryGreater
+	TokenKindBinaryGreaterOrEqual
+	TokenKindBinaryLess
+	TokenKindBinaryLessOrEqual
+	TokenKindBinaryLogicalAnd
+	TokenKindBinaryLogicalOr
+	TokenKindBinaryMinus
+	TokenKindBinaryMinusAssignment
+	TokenKindBinaryModulo
+	TokenKindBinaryModuloAssignment
+	TokenKindBinaryNotEquals
+	TokenKindBinaryPlus
+	TokenKindBinaryPlusAssignment
+	TokenKindBinaryShiftLeft
+	TokenKindBinaryShiftLeftAssignment
+	TokenKindBinaryShiftRight
+	TokenKindBinaryShiftRightAssignment
+	TokenKindBinaryShiftRightUnsigned
+	TokenKindBinaryShiftRightUnsignedAssignment
+	TokenKindBinaryStar
+	TokenKindBinaryStarAssignment
+	TokenKindBinaryStrictEquals
+	TokenKindBinaryStrictNotEquals
+	TokenKindIdentifier
+	TokenKindKeyword
+	TokenKindMetaShebangLine
+	TokenKindMultipleLineComment
+	TokenKindNumber
+	TokenKindPuncAt
+	TokenKindPuncBacktick
+	TokenKindPuncColon
+	TokenKindPuncComma
+	TokenKindPuncFatArrow
+	TokenKindPuncLeftBrace
+	TokenKindPuncLeftBracket
+	TokenKindPuncLeftParen
+	TokenKindPuncPeriod
+	TokenKindPuncQuestion
+	TokenKindPuncRightBrace
+	TokenKindPuncRightBracket
+	TokenKindPuncRightParen
+	TokenKindPuncSemicolon
+	TokenKindPuncSpread
+	TokenKindRegexp
+	TokenKindSingleLineComment
+	TokenKindString
+	TokenKindTemplateHead
+	TokenKindTemplateMiddle
+	TokenKindTemplateNoSubstitution
+	TokenKindTemplateTail
+	TokenKindUnaryBang
+	TokenKindUnaryDecrement
+	TokenKindUnaryIncrement
+	TokenKindUnaryTilde
+	TokenKindBinaryLogicalAndAssignment
+	TokenKindBinaryLogicalOrAssignment
+	TokenKindBinaryNullishCoalescing
+	TokenKindBinaryNullishCoalescingAssignment
+	TokenKindPuncOptionalChain
+)
+
+func (t TokenKind) String() string {
+	switch t {
+	case TokenKindWhitespace:
+		return "whitespace"
+	case TokenKindBinaryAssignment:
+		return "binaryAssignment"
+	case TokenKindBinaryBitwiseAnd:
+		return "binaryBitwiseAnd"
+	case TokenKindBinaryBitwiseAndAssignment:
+		return "binaryBitwiseAndAssignment"
+	case TokenKindBinaryBitwiseOr:
+		return "binaryBitwiseOr"
+	case TokenKindBinaryBitwiseOrAssignment:
+		return "binaryBitwiseOrAssignment"
+	case TokenKindBinaryBitwiseXor:
+		return "binaryBitwiseXor"
+	case TokenKindBinaryBitwiseXorAssignment:
+		return "binaryBitwiseXorAssignment"
+	case TokenKindBinaryDivide:
+		return "binaryDivide"
+	case TokenKindBinaryDivideEquals:
+		return "binaryDivideEquals"
+	case TokenKindBinaryEquals:
+		return "binaryEquals"
+	case TokenKindBinaryExponent:
+		return "binaryExponent"
+	case TokenKindBinaryExponentAssignment:
+		return "binaryExponentAssignment"
+	case TokenKindBinaryGreater:
+		return "binaryGreater"
+	case TokenKindBinaryGreaterOrEqual:
+		return "binaryGreaterOrEqual"
+	case TokenKindBinaryLess:
+		return "binaryLess"
+	case TokenKindBinaryLessOrEqual:
+		return "binaryLessOrEqual"
+	case TokenKindBinaryLogicalAnd:
+		return "binaryLogicalAnd"
+	case TokenKindBinaryLogicalOr:
+		return "binaryLogicalOr"
+	case TokenKindBinaryMinus:
+		return "binaryMinus"
+	case TokenKindBinaryMinusAssignment:
+		return "binaryMinusAssignment"
+	case TokenKindBinaryModulo:
+		return "binaryModulo"
+	case TokenKindBinaryModuloAssignment:
+		return "binaryModuloAssignment"
+	case TokenKindBinaryNotEquals:
+		return "binaryNotEquals"
+	case TokenKindBinaryPlus:
+		return "binaryPlus"
+	case TokenKindBinaryPlusAssignment:
+		return "binaryPlusAssignment"
+	case TokenKindBinaryShiftLeft:
+		return "binaryShiftLeft"
+	case TokenKindBinaryShiftLeftAssignment:
+		return "binaryShiftLeftAssignment"
+	case TokenKindBinaryShiftRight:
+		return "binaryShiftRight"
+	case TokenKindBinaryShiftRightAssignment:
+		return "binaryShiftRightAssignment"
+	case TokenKindBinaryShiftRightUnsigned:
+		return "binaryShiftRightUnsigned"
+	case TokenKindBinaryShiftRightUnsignedAssignment:
+		return "binaryShiftRightUnsignedAssignment"
+	case TokenKindBinaryStar:
+		return "binaryStar"
+	case TokenKindBinaryStarAssignment:
+		return "binaryStarAssignment"
+	case TokenKindBinaryStrictEquals:
+		return "binaryStrictEquals"
+	case TokenKindBinaryStrictNotEquals:
+		return "binaryStrictNotEquals"
+	case TokenKindIdentifier:
+		return "identifier"
+	case TokenKindKeyword:
+		return "keyword"
+	case TokenKindMetaShebangLine:
+		return "metaShebangLine"
+	case TokenKindMultipleLineComment:
+		return "multipleLineComment"
+	case TokenKindNumber:
+		return "number"
+	case TokenKindPuncAt:
+		return "puncAt"
+	case TokenKindPuncBacktick:
+		return "puncBacktick"
+	case TokenKindPuncColon:
+		return "puncColon"
+	case TokenKindPuncComma:
+		return "puncComma"
+	case TokenKindPuncFatArrow:
+		return "puncFatArrow"
+	case TokenKindPuncLeftBrace:
+		return "puncLeftBrace"
+	case TokenKindPuncLeftBracket:
+		return "puncLeftBracket"
+	case TokenKindPuncLeftParen:
+		return "puncLeftParen"
+	case TokenKindPuncPeriod:
+		return "puncPeriod"
+	case TokenKindPuncQuestion:
+		return "puncQuestion"
+	case TokenKindPuncRightBrace:
+		return "puncRightBrace"
+	case TokenKindPuncRightBracket:
+		return "puncRightBracket"
+	case TokenKindPuncRightParen:
+		return "puncRightParen"
+	case TokenKindPuncSemicolon:
+		return "puncSemicolon"
+	case TokenKindPuncSpread:
+		return "puncSpread"
+	case TokenKindRegexp:
+		return "regexp"
+	case TokenKindSingleLineComment:
+		return "singleLineComment"
+	case TokenKindString:
+		return "string"
+	case TokenKindTemplateHead:
+		return "templateHead"
+	case TokenKindTemplateMiddle:
+		return "templateMiddle"
+	case TokenKindTemplateNoSubstitution:
+		return "templateNoSubstitution"
+	case TokenKindTemplateTail:
+		return "templateTail"
+	case TokenKindUnaryBang:
+		return "unaryBang"
+	case TokenKindUnaryDecrement:
+		return "unaryDecrement"
+	case TokenKindUnaryIncrement:
+		return "unaryIncrement"
+	case TokenKindUnaryTilde:
+		return "unaryTilde"
+	case TokenKindBinaryLogicalAndAssignment:
+		return "binaryLogicalAndAssignment"
+	case TokenKindBinaryLogicalOrAssignment:
+		return "binaryLogicalOrAssignment"
+	case TokenKindBinaryNullishCoalescing:
+		return "binaryNullishCoalescing"
+	case TokenKindBinaryNullishCoalescingAssignment:
+		return "binaryNullishCoalescingAssignment"
+	case TokenKindPuncOptionalChain:
+		return "puncOptionalChain"
+	default:
+		return "unknown"
+	}
+}
+
+// punctuators is the root of a trie of every punctuator in the lexical
+// grammar, which the tokeniser walks to find the longest match.
+var punctuators = &punctuatorTrie{next: map[rune]*punctuatorTrie{
+	'!': {kind: TokenKindUnaryBang, terminal: true, next: map[rune]*punctuatorTrie{
+		'=': {kind: TokenKindBinaryNotEquals, terminal: true, next: map[rune]*punctuatorTrie{
+			'=': {kind: TokenKindBinaryStrictNotEquals, terminal: true},
+		}},
+	}},
+	'%': {kind: TokenKindBinaryModulo, terminal: true, next: map[rune]*punctuatorTrie{
+		'=': {kind: TokenKindBinaryModuloAssignment, terminal: true},
+	}},
+	'&': {kind: TokenKindBinaryBitwiseAnd, terminal: true, next: map[rune]*punctuatorTrie{
+		'&': {kind: TokenKindBinaryLogicalAnd, terminal: true, next: map[rune]*punctuatorTrie{
+			'=': {kind: TokenKindBinaryLogicalAndAssignment, terminal: true},
+		}},
+		'=': {kind: TokenKindBinaryBitwiseAndAssignment, terminal: true},
+	}},
+	'(': {kind: TokenKindPuncLeftParen, terminal: true},
+	')': {kind: TokenKindPuncRightParen, terminal: true},
+	'*': {kind: TokenKindBinaryStar, terminal: true, next: map[rune]*punctuatorTrie{
+		'*': {kind: TokenKindBinaryExponent, terminal: true, next: map[rune]*punctuatorTrie{
+			'=': {kind: TokenKindBinaryExponentAssignment, terminal: true},
+		}},
+		'=': {kind: TokenKindBinaryStarAssignment, terminal: true},
+	}},
+	'+': {kind: TokenKindBinaryPlus, terminal: true, next: map[rune]*punctuatorTrie{
+		'+': {kind: TokenKindUnaryIncrement, terminal: true},
+		'=': {kind: TokenKindBinaryPlusAssignment, terminal: true},
+	}},
+	',': {kind: TokenKindPuncComma, terminal: true},
+	'-': {kind: TokenKindBinaryMinus, terminal: true, next: map[rune]*punctuatorTrie{
+		'-': {kind: TokenKindUnaryDecrement, terminal: true},
+		'=': {kind: TokenKindBinaryMinusAssignment, terminal: true},
+	}},
+	'.': {kind: TokenKindPuncPeriod, terminal: true, next: map[rune]*punctuatorTrie{
+		'.': {next: map[rune]*punctuatorTrie{
+			'.': {kind: TokenKindPuncSpread, terminal: true},
+		}},
+	}},
+	'/': {kind: TokenKindBinaryDivide, terminal: true, next: map[rune]*punctuatorTrie{
+		'=': {kind: TokenKindBinaryDivideEquals, terminal: true},
+	}},
+	':': {kind: TokenKindPuncColon, terminal: true},
+	';': {kind: TokenKindPuncSemicolon, terminal: true},
+	'<': {kind: TokenKindBinaryLess, terminal: true, next: map[rune]*punctuatorTrie{
+		'<': {kind: TokenKindBinaryShiftLeft, terminal: true, next: map[rune]*punctuatorTrie{
+			'=': {kind: TokenKindBinaryShiftLeftAssignment, terminal: true},
+		}},
+		'=': {kind: TokenKindBinaryLessOrEqual, terminal: true},
+	}},
+	'=': {kind: TokenKindBinaryAssignment, terminal: true, next: map[rune]*punctuatorTrie{
+		'=': {kind: TokenKindBinaryEquals, terminal: true, next: map[rune]*punctuatorTrie{
+			'=': {kind: TokenKindBinaryStrictEquals, terminal: true},
+		}},
+		'>': {kind: TokenKindPuncFatArrow, terminal: true},
+	}},
+	'>': {kind: TokenKindBinaryGreater, terminal: true, next: map[rune]*punctuatorTrie{
+		'=': {kind: TokenKindBinaryGreaterOrEqual, terminal: true},
+		'>': {kind: TokenKindBinaryShiftRight, terminal: true, next: map[rune]*punctuatorTrie{
+			'=': {kind: TokenKindBinaryShiftRightAssignment, terminal: true},
+			'>': {kind: TokenKindBinaryShiftRightUnsigned, terminal: true, next: map[rune]*punctuatorTrie{
+				'=': {kind: TokenKindBinaryShiftRightUnsignedAssignment, terminal: true},
+			}},
+		}},
+	}},
+	'?': {kind: TokenKindPuncQuestion, terminal: true, next: map[rune]*punctuatorTrie{
+		'.': {kind: TokenKindPuncOptionalChain, terminal: true, exclude: "0123456789"},
+		'?': {kind: TokenKindBinaryNullishCoalescing, terminal: true, next: map[rune]*punctuatorTrie{
+			'=': {kind: TokenKindBinaryNullishCoalescingAssignment, terminal: true},
+		}},
+	}},
+	'@': {kind: TokenKindPuncAt, terminal: true},
+	'[': {kind: TokenKindPuncLeftBracket, terminal: true},
+	']': {kind: TokenKindPuncRightBracket, terminal: true},
+	'^': {kind: TokenKindBinaryBitwiseXor, terminal: true, next: map[rune]*punctuatorTrie{
+		'=': {kind: TokenKindBinaryBitwiseXorAssignment, terminal: true},
+	}},
+	'{': {kind: TokenKindPuncLeftBrace, terminal: true},
+	'|': {kind: TokenKindBinaryBitwiseOr, terminal: true, next: map[rune]*punctuatorTrie{
+		'=': {kind: TokenKindBinaryBitwiseOrAssignment, terminal: true},
+		'|': {kind: TokenKindBinaryLogicalOr, terminal: true, next: map[rune]*punctuatorTrie{
+			'=': {kind: TokenKindBinaryLogicalOrAssignment, terminal: true},
+		}},
+	}},
+	'}': {kind: TokenKindPuncRightBrace, terminal: true},
+	'~': {kind: TokenKindUnaryTilde, terminal: true},
+}}